@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/config"
+)
+
+// balanceRefreshDue decides whether a poll tick refreshes the balance.
+// While the WebSocket is up the wallet subscription already pushes every
+// change, so polling only backstops it every wsInterval; when it is down
+// (or wsInterval is 0) every tick polls.
+func balanceRefreshDue(wsUp bool, sinceLast, wsInterval time.Duration) bool {
+	return !wsUp || wsInterval <= 0 || sinceLast >= wsInterval
+}
+
+// refreshBalances polls the wallet balance every balance_refresh_seconds,
+// backing off to balance_refresh_ws_seconds while wsConnected reports true
+func refreshBalances(ctx context.Context, cfg *config.Manager, tracker *blockchain.BalanceTracker, wsConnected func() bool) {
+	ticker := time.NewTicker(cfg.GetBalanceRefresh())
+	defer ticker.Stop()
+
+	var last time.Time
+	wasUp := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		wsUp := wsConnected()
+		if wsUp != wasUp {
+			log.Debug().Bool("ws", wsUp).Msg("balance polling switched interval")
+			wasUp = wsUp
+		}
+		if !balanceRefreshDue(wsUp, time.Since(last), cfg.GetBalanceRefreshWS()) {
+			continue
+		}
+		if err := tracker.Refresh(ctx); err != nil {
+			log.Debug().Err(err).Msg("balance refresh failed")
+			continue
+		}
+		last = time.Now()
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBalanceRefreshDue(t *testing.T) {
+	tests := []struct {
+		name       string
+		wsUp       bool
+		sinceLast  time.Duration
+		wsInterval time.Duration
+		want       bool
+	}{
+		{"ws down polls every tick", false, time.Second, time.Minute, true},
+		{"ws up backs off", true, 5 * time.Second, time.Minute, false},
+		{"ws up polls once the slow interval elapses", true, time.Minute, time.Minute, true},
+		{"backoff disabled", true, time.Second, 0, true},
+	}
+	for _, tt := range tests {
+		if got := balanceRefreshDue(tt.wsUp, tt.sinceLast, tt.wsInterval); got != tt.want {
+			t.Errorf("%s: due = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
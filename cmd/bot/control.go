@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/trading"
+)
+
+// handleControlSignals serves operator signals until ctx ends: reloadSignal
+// (SIGHUP) re-reads the config file, dumpSignal (SIGUSR1) logs a state
+// snapshot. Neither exists on Windows, where this returns at once.
+func handleControlSignals(ctx context.Context, cfg *config.Manager, dump func()) {
+	if len(controlSignals) == 0 {
+		return
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, controlSignals...)
+	defer signal.Stop(ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-ch:
+			switch sig {
+			case reloadSignal:
+				log.Info().Msg("🔄 SIGHUP: reloading config")
+				if err := cfg.Reload(); err == nil {
+					log.Info().Msg("config reloaded")
+				}
+			case dumpSignal:
+				dump()
+			}
+		}
+	}
+}
+
+// dumpState logs open positions, wallet balance and trade metrics (SIGUSR1)
+func dumpState(executor *trading.ExecutorFast, balanceTracker *blockchain.BalanceTracker) {
+	if balanceTracker != nil {
+		log.Info().Float64("sol", balanceTracker.BalanceSOL()).Msg("📸 STATE: wallet")
+	}
+	if executor == nil {
+		return
+	}
+	total, success, failed, rate := executor.GetMetrics().Stats()
+	m := executor.GetMetrics()
+	log.Info().
+		Int64("trades", total).
+		Int64("success", success).
+		Int64("failed", failed).
+		Float64("successRate", rate).
+		Int64("p50Ms", m.P50()).
+		Int64("p95Ms", m.P95()).
+		Int64("p99Ms", m.P99()).
+		Msg("📸 STATE: metrics")
+
+	positions := executor.GetOpenPositions()
+	log.Info().Int("count", len(positions)).Msg("📸 STATE: open positions")
+	for _, p := range positions {
+		log.Info().
+			Str("token", p.TokenName).
+			Str("mint", p.Mint).
+			Float64("sizeSol", p.Size).
+			Float64("pnlSol", p.PnLSol).
+			Float64("pnlPct", p.PnLPercent).
+			Float64("peak", p.PeakMultiple).
+			Bool("partial", p.PartialSold).
+			Dur("age", time.Since(p.EntryTime).Round(time.Second)).
+			Msg("📸 STATE: position")
+	}
+}
@@ -0,0 +1,15 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+const (
+	reloadSignal = syscall.SIGHUP
+	dumpSignal   = syscall.SIGUSR1
+)
+
+var controlSignals = []os.Signal{reloadSignal, dumpSignal}
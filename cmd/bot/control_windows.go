@@ -0,0 +1,16 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// No SIGHUP/SIGUSR1 on Windows: never delivered, nothing is subscribed
+const (
+	reloadSignal = syscall.Signal(-1)
+	dumpSignal   = syscall.Signal(-2)
+)
+
+var controlSignals []os.Signal
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"solana-pump-bot/internal/config"
+)
+
+// cliOptions holds command-line overrides for scripted / CI runs.
+// Only flags that were explicitly passed are applied on top of the YAML.
+type cliOptions struct {
+	configPath string
+
+	alloc        float64
+	takeProfit   float64
+	minEntry     float64
+	maxPositions int
+	sim          bool
+	autoTrade    bool
+
+	set map[string]bool // flag names explicitly passed
+}
+
+// cli is parsed once in main before any component starts
+var cli = cliOptions{configPath: "config/config.yaml"}
+
+// parseFlags parses os.Args into cli
+func parseFlags(args []string) error {
+	fs := flag.NewFlagSet("bot", flag.ContinueOnError)
+	fs.StringVar(&cli.configPath, "config", cli.configPath, "path to config YAML")
+	fs.Float64Var(&cli.alloc, "alloc", 0, "override trading.max_alloc_percent")
+	fs.Float64Var(&cli.takeProfit, "take-profit", 0, "override trading.take_profit_multiple")
+	fs.Float64Var(&cli.minEntry, "min-entry", 0, "override trading.min_entry_percent")
+	fs.IntVar(&cli.maxPositions, "max-positions", 0, "override trading.max_open_positions")
+	fs.BoolVar(&cli.sim, "sim", false, "override trading.simulation_mode")
+	fs.BoolVar(&cli.autoTrade, "auto", false, "override trading.auto_trading_enabled")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: bot [flags]   (flags override config file values)")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cli.set = make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { cli.set[f.Name] = true })
+	return nil
+}
+
+// applyOverrides mutates cfg with every explicitly passed flag
+func (o cliOptions) applyOverrides(c *config.Config) {
+	if o.set["alloc"] {
+		c.Trading.MaxAllocPercent = o.alloc
+	}
+	if o.set["take-profit"] {
+		c.Trading.TakeProfitMultiple = o.takeProfit
+	}
+	if o.set["min-entry"] {
+		c.Trading.MinEntryPercent = o.minEntry
+	}
+	if o.set["max-positions"] {
+		c.Trading.MaxOpenPositions = o.maxPositions
+	}
+	if o.set["sim"] {
+		c.Trading.SimulationMode = o.sim
+	}
+	if o.set["auto"] {
+		c.Trading.AutoTradingEnabled = o.autoTrade
+	}
+}
+
+// overridden lists the flags that were applied, for the startup log
+func (o cliOptions) overridden() string {
+	names := make([]string, 0, len(o.set))
+	for _, name := range []string{"alloc", "take-profit", "min-entry", "max-positions", "sim", "auto"} {
+		if o.set[name] {
+			names = append(names, "--"+name)
+		}
+	}
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// logTailPollInterval is how often the tailer checks for new data at EOF
+const logTailPollInterval = 100 * time.Millisecond
+
+// tailLogFile follows path like `tail -F`: it starts at the end, delivers each
+// complete line to onLine, and reopens the file when it is rotated (different
+// inode at the same path) or truncated (size drops below our read offset).
+// It never returns; run it in a goroutine.
+func tailLogFile(path string, onLine func(string)) {
+	var (
+		file    *os.File
+		info    os.FileInfo
+		reader  *bufio.Reader
+		offset  int64
+		pending string
+	)
+
+	open := func(seekEnd bool) bool {
+		f, err := os.Open(path)
+		if err != nil {
+			return false
+		}
+		fi, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return false
+		}
+		offset = 0
+		if seekEnd {
+			// Seek to end initially to avoid spamming old logs
+			if offset, err = f.Seek(0, io.SeekEnd); err != nil {
+				offset = 0
+			}
+		}
+		if file != nil {
+			file.Close()
+		}
+		file, info, reader, pending = f, fi, bufio.NewReader(f), ""
+		return true
+	}
+
+	for !open(true) {
+		time.Sleep(time.Second) // File may not exist yet
+	}
+
+	for {
+		chunk, err := reader.ReadString('\n')
+		offset += int64(len(chunk))
+		if err == nil {
+			if line := strings.TrimSpace(pending + chunk); line != "" {
+				onLine(line)
+			}
+			pending = ""
+			continue
+		}
+		// EOF: keep any partial line until its newline arrives
+		pending += chunk
+
+		time.Sleep(logTailPollInterval) // Wait for new data
+
+		current, statErr := os.Stat(path)
+		switch {
+		case statErr != nil:
+			// Path missing mid-rotation; keep reading the old handle
+		case !os.SameFile(info, current):
+			// Drain whatever was appended to the old file before it was moved
+			for {
+				chunk, err := reader.ReadString('\n')
+				if err != nil {
+					break
+				}
+				if line := strings.TrimSpace(pending + chunk); line != "" {
+					onLine(line)
+				}
+				pending = ""
+			}
+			log.Debug().Str("path", path).Msg("log file rotated, reopening")
+			open(false)
+		case current.Size() < offset:
+			log.Debug().Str("path", path).Msg("log file truncated, rereading from start")
+			if _, err := file.Seek(0, io.SeekStart); err == nil {
+				offset, pending = 0, ""
+				reader.Reset(file)
+			}
+		}
+	}
+}
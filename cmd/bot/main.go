@@ -1,8 +1,9 @@
 package main
 
 import (
-	"bufio"
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
@@ -16,7 +17,12 @@ import (
 
 	"solana-pump-bot/internal/blockchain"
 	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/events"
+	"solana-pump-bot/internal/health"
 	"solana-pump-bot/internal/jupiter"
+	"solana-pump-bot/internal/lockfile"
+	"solana-pump-bot/internal/netutil"
+	"solana-pump-bot/internal/notify"
 	"solana-pump-bot/internal/analytics"
 	signalPkg "solana-pump-bot/internal/signal"
 	"solana-pump-bot/internal/storage"
@@ -26,6 +32,13 @@ import (
 )
 
 func main() {
+	if err := parseFlags(os.Args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+
 	// Check for TUI mode (default) or headless mode
 	headless := os.Getenv("HEADLESS") == "1"
 
@@ -41,13 +54,18 @@ func runHeadless() {
 	log.Info().Msg("🚀 Solana Pump Bot starting (headless mode)...")
 
 	// Initialize all components
-	cfg, tokenResolver, signalChan, server, executor, balanceTracker, blockhashCache := initComponents()
+	cfg, tokenResolver, signalChan, server, executor, balanceTracker, blockhashCache, _ := initComponents()
 	
 	// Setup WebSocket for real-time updates
 	if err := executor.SetupWebSocket(); err != nil {
 		log.Warn().Err(err).Msg("WebSocket setup failed (will use polling)")
 	}
 	
+	executor.AdoptOrphans(context.Background())
+	if tokenResolver != nil {
+		executor.StartWarmStandby(context.Background(), tokenResolver.Resolve)
+	}
+
 	// Start monitor
 	executor.StartMonitoring(context.Background())
 
@@ -62,7 +80,7 @@ func runHeadless() {
 				}
 			}
 			if executor != nil {
-				executor.ProcessSignalFast(context.Background(), sig)
+				executor.EnqueueSignal(context.Background(), sig)
 			}
 		}
 	}()
@@ -79,27 +97,29 @@ func runHeadless() {
 		Int("port", cfg.Get().Telegram.ListenPort).
 		Msg("signal server started")
 
-	// Balance refresh loop
+	// Balance refresh loop: slow while the WebSocket pushes balance changes
 	if balanceTracker != nil {
-		go func() {
-			ticker := time.NewTicker(cfg.GetBalanceRefresh())
-			defer ticker.Stop()
-			for range ticker.C {
-				balanceTracker.Refresh(context.Background())
-			}
-		}()
+		go refreshBalances(context.Background(), cfg, balanceTracker, executor.WSConnected)
 	}
 
+	// SIGHUP reloads the config, SIGUSR1 logs a state snapshot
+	go handleControlSignals(context.Background(), cfg, func() { dumpState(executor, balanceTracker) })
+
 	// Wait for shutdown signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Info().Msg("shutting down...")
-	server.Shutdown()
-	if blockhashCache != nil {
-		blockhashCache.Stop()
+	var seller positionSeller
+	if executor != nil {
+		seller = executor
 	}
+	shutdownSequence(cfg.Get().Shutdown, seller, server.Shutdown, func() {
+		if blockhashCache != nil {
+			blockhashCache.Stop()
+		}
+	}, releaseInstanceLock)
 	log.Info().Msg("goodbye 👋")
 }
 
@@ -120,7 +140,7 @@ func runWithTUI() {
 	}
 
 	// Initialize components
-	cfg, tokenResolver, signalChan, server, executor, balanceTracker, blockhashCache := initComponents()
+	cfg, tokenResolver, signalChan, server, executor, balanceTracker, blockhashCache, rpc := initComponents()
 
 	// Setup WebSocket for real-time updates
 	if err := executor.SetupWebSocket(); err != nil {
@@ -131,7 +151,10 @@ func runWithTUI() {
 	model := tui.NewModel(cfg)
 
 	// Set callbacks
-	db, _ := storage.NewDB("data/trades.db") // For export
+	db, _ := storage.NewDB("data/trades.db") // For export and the trades screen
+	if db != nil {
+		model.SetTradesDB(db)
+	}
 	model.SetCallbacks(
 		func() {
 			// Toggle pause
@@ -148,16 +171,34 @@ func runWithTUI() {
 		func() {
 			// Clear stats and positions (F9) -> PANIC SELL ALL
 			if executor != nil {
-				executor.SellAllPositions(context.Background())
+				// Sells are awaited in the background; the TUI stays responsive
+				go func() { logSellResults(executor.SellAllPositions(context.Background())) }()
 				// executor.ResetStats() // Optional: maybe don't reset stats on selling? User said clear positions.
 				// Let's reset stats too as per "Clear" semantics usually implying reset.
 				executor.ResetStats()
 			}
 		},
 		func() {
-			// Export trades to CSV (E key)
+			// Export trades (E key): CSV, or JSON with EXPORT_FORMAT=json,
+			// plus the decision audit log as CSV
 			if db != nil {
-				path := fmt.Sprintf("trades_%s.csv", time.Now().Format("20060102_150405"))
+				stamp := time.Now().Format("20060102_150405")
+				decisionsPath := fmt.Sprintf("decisions_%s.csv", stamp)
+				if err := analytics.ExportDecisionsToCSV(db, decisionsPath); err != nil {
+					log.Error().Err(err).Msg("decision export failed")
+				} else {
+					log.Info().Str("path", decisionsPath).Msg("Trade decisions exported to CSV")
+				}
+				if strings.EqualFold(os.Getenv("EXPORT_FORMAT"), "json") {
+					path := fmt.Sprintf("trades_%s.json", stamp)
+					if err := analytics.ExportTradesToJSON(db, path); err != nil {
+						log.Error().Err(err).Msg("JSON export failed")
+					} else {
+						log.Info().Str("path", path).Msg("Trades exported to JSON")
+					}
+					return
+				}
+				path := fmt.Sprintf("trades_%s.csv", stamp)
 				if err := analytics.ExportTradesToCSV(db, path); err != nil {
 					log.Error().Err(err).Msg("CSV export failed")
 				} else {
@@ -167,9 +208,36 @@ func runWithTUI() {
 		},
 	)
 
+	var resolve func(string) (string, error)
+	if tokenResolver != nil {
+		resolve = tokenResolver.Resolve
+	}
+	model.SetManualBuy(resolve, func(mint string, amountSol float64) {
+		// Manual buy (B key)
+		if executor != nil {
+			if err := executor.ManualBuy(context.Background(), mint, amountSol); err != nil {
+				log.Error().Err(err).Msg("manual buy failed")
+			}
+		}
+	})
+
 	// Create TUI program
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
+	// Event bus: the executor publishes, the TUI subscribes (replay covers
+	// anything published before the subscriber starts)
+	bus := events.NewBus(256)
+	if executor != nil {
+		executor.SetEventBus(bus)
+	}
+	go forwardEventsToTUI(p, bus, executor)
+	if executor != nil {
+		go executor.AdoptOrphans(context.Background()) // RPC + quotes; don't delay the TUI
+		if tokenResolver != nil {
+			executor.StartWarmStandby(context.Background(), tokenResolver.Resolve)
+		}
+	}
+
 	// Start HTTP server in background
 	go func() {
 		if err := server.Start(); err != nil {
@@ -188,48 +256,23 @@ func runWithTUI() {
 				}
 			}
 			
-			// Send to TUI
-			tui.SendSignal(p, sig)
+			bus.Publish(events.SignalReceived{Signal: sig})
 			
 			// Execute trade (FAST - no blocking checks)
 			if executor != nil {
-				// FIX: Run async to not block signal ingestion
-				go func(s *signalPkg.Signal) {
-					executor.ProcessSignalFast(context.Background(), s)
-					// Update positions in TUI
-					tui.SendPositions(p, executor.GetOpenPositions())
-				}(sig)
+				// Queued, not blocking ingestion: at most trading.max_concurrent_trades in flight
+				executor.EnqueueSignal(context.Background(), sig)
 			}
 		}
 	}()
 
-	// TUI Log Tailing (Fix for missing logs)
-	go func() {
-		file, err := os.Open("data/afnex.log")
-		if err != nil {
-			return
-		}
-		defer file.Close()
+	// TUI Log Tailing (Fix for missing logs) - survives rotation/truncation
+	go tailLogFile("data/afnex.log", func(line string) {
+		tui.SendLogs(p, []string{line})
+	})
 
-		// Seek to end initially to avoid spamming old logs
-		file.Seek(0, 2)
-		
-		reader := bufio.NewReader(file)
-		for {
-			line, err := reader.ReadString('\n')
-			if err != nil {
-				time.Sleep(100 * time.Millisecond) // Wait for new data
-				continue
-			}
-			// Clean and send
-			line = strings.TrimSpace(line)
-			if line != "" {
-				tui.SendLogs(p, []string{line})
-			}
-		}
-	}()
-
-	// Balance, latency, and stats refresh loop
+	// Balance refresh plus health that has no event (latency, degraded mode,
+	// blockhash cache, component probes); positions, trades and issues arrive via the bus
 	go func() {
 		ticker := time.NewTicker(5 * time.Second)
 		defer ticker.Stop()
@@ -238,18 +281,28 @@ func runWithTUI() {
 				start := time.Now()
 				balanceTracker.Refresh(context.Background())
 				latencyMs := time.Since(start).Milliseconds()
-				tui.SendBalance(p, balanceTracker.BalanceSOL())
+				bus.Publish(events.BalanceChanged{SOL: balanceTracker.BalanceSOL()})
 				tui.SendLatency(p, latencyMs)
 			}
 			if executor != nil {
-				tui.SendPositions(p, executor.GetOpenPositions())
-				// Send stats to TUI
-				totalEntry, reached2X := executor.GetStats()
-				tui.SendStats(p, totalEntry, reached2X)
+				tui.SendDegraded(p, executor.DegradedMode(), executor.WSDownFor())
+				tui.SendWSStatus(p, executor.WSConnected(), executor.WSLastError())
+				queued, inFlight := executor.QueueDepth()
+				tui.SendQueue(p, queued, inFlight)
+			}
+			if blockhashCache != nil {
+				tui.SendBlockhashStats(p, blockhashCache.Stats())
 			}
+			if rpc != nil {
+				tui.SendRPCEndpoint(p, rpc.BestEndpoint(), rpc.EndpointStats())
+			}
+			tui.SendHealth(p, healthChecker.Last())
 		}
 	}()
 
+	// SIGHUP reloads the config, SIGUSR1 logs a state snapshot (to the log file)
+	go handleControlSignals(context.Background(), cfg, func() { dumpState(executor, balanceTracker) })
+
 	// Run TUI (blocking)
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
@@ -257,15 +310,78 @@ func runWithTUI() {
 	}
 
 	// Cleanup
-	server.Shutdown()
+	var seller positionSeller
 	if executor != nil {
-		executor.Shutdown()
+		seller = executor
 	}
-	if blockhashCache != nil {
-		blockhashCache.Stop()
+	shutdownSequence(cfg.Get().Shutdown, seller, server.Shutdown, func() {
+		if executor != nil {
+			executor.Shutdown()
+		}
+		if blockhashCache != nil {
+			blockhashCache.Stop()
+		}
+	}, releaseInstanceLock)
+}
+
+// releaseInstanceLock lets the next bot start (the OS also drops it on exit)
+func releaseInstanceLock() {
+	if err := instanceLock.Release(); err != nil {
+		log.Warn().Err(err).Msg("failed to release the instance lock")
 	}
 }
 
+// forwardEventsToTUI turns bus events into TUI messages until the bus
+// subscription ends. Positions and stats are re-read from the executor so the
+// TUI always gets a consistent snapshot rather than a single changed field.
+func forwardEventsToTUI(p *tea.Program, bus *events.Bus, executor *trading.ExecutorFast) {
+	ch, _ := bus.Subscribe(256, true)
+	for env := range ch {
+		switch ev := env.Event.(type) {
+		case events.SignalReceived:
+			tui.SendSignal(p, ev.Signal)
+		case events.BalanceChanged:
+			// Paper trading spends a paper wallet; the real balance would mislead
+			if sol, ok := executor.PaperBalanceSOL(); ok {
+				tui.SendBalance(p, sol)
+				continue
+			}
+			tui.SendBalance(p, ev.SOL)
+		case events.TradeExecuted, events.PositionUpdated:
+			if executor == nil {
+				continue
+			}
+			tui.SendPositions(p, executor.GetOpenPositions())
+			totalEntry, reached2X := executor.GetStats()
+			tui.SendStats(p, totalEntry, reached2X, executor.GetDailyPnL())
+		case events.Error:
+			if executor == nil {
+				continue
+			}
+			issues := executor.GetIssues()
+			tui.SendIssues(p, issues.Recent(50), issues.CountsSince(time.Minute))
+		case events.SignalSkipped:
+			if executor != nil {
+				tui.SendSkips(p, executor.SkipCounts())
+			}
+		}
+	}
+}
+
+// InstanceLockPath is the single-instance lock: two bots on one wallet
+// double-buy and share (and corrupt) the SQLite WAL
+const InstanceLockPath = "data/bot.lock"
+
+// instanceLock is held from initComponents until shutdown
+var instanceLock *lockfile.Lock
+
+// HealthCheckInterval is how often the component probes run; /healthz
+// serves the latest round unless it is older than this
+const HealthCheckInterval = 15 * time.Second
+
+// healthChecker probes RPC, Jupiter, the DB and the WebSocket (set up in initComponents)
+var healthChecker = health.NewChecker(5 * time.Second)
+
 func initComponents() (
 	*config.Manager,
 	*token.Resolver,
@@ -274,12 +390,29 @@ func initComponents() (
 	*trading.ExecutorFast,
 	*blockchain.BalanceTracker,
 	*blockchain.BlockhashCache,
+	*blockchain.RPCClient,
 ) {
+	// One bot per data directory
+	lock, err := lockfile.Acquire(InstanceLockPath)
+	if err != nil {
+		if errors.Is(err, lockfile.ErrLocked) {
+			log.Fatal().Err(err).Str("lock", InstanceLockPath).Msg("❌ another bot instance is already running; stop it first")
+		}
+		log.Fatal().Err(err).Str("lock", InstanceLockPath).Msg("failed to take the instance lock")
+	}
+	instanceLock = lock
+
 	// Load config
-	cfg, err := config.NewManager("config/config.yaml")
+	cfg, err := config.NewManager(cli.configPath)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to load config")
 	}
+	// Command-line flags take precedence over file values (never written back)
+	if flags := cli.overridden(); flags != "" {
+		cfg.ApplyOverrides(cli.applyOverrides)
+		log.Info().Str("flags", flags).Msg("⚙️ CONFIG OVERRIDES FROM COMMAND LINE")
+	}
+	logStartupSummary(cfg)
 
 	// Load token cache
 	tokenCache, err := token.NewCache("config/tokens_cache.json")
@@ -287,6 +420,32 @@ func initComponents() (
 		log.Fatal().Err(err).Msg("failed to load token cache")
 	}
 	resolver := token.NewResolver(tokenCache)
+	var dexScreener *token.DexScreenerResolver
+	var metaplex *token.MetaplexLookup // reads metadata over RPC, set up below
+	// Cache misses go through tokens.resolver_chain in order
+	tokCfg := cfg.Get().Tokens
+	var lookups token.Lookups
+	if tokCfg.UpstreamLookup {
+		for _, src := range tokCfg.ResolverChain {
+			switch src {
+			case token.SourceDexScreener:
+				dexScreener = token.NewDexScreenerResolver(tokCfg.DexScreenerURL, 3*time.Second)
+				lookups = append(lookups, dexScreener)
+			case token.SourceMetadata:
+				metaplex = token.NewMetaplexLookup(token.NewJupiterLookup(tokCfg.LookupURL, 3*time.Second))
+				lookups = append(lookups, metaplex)
+			case token.SourceJupiter:
+				lookups = append(lookups, token.NewJupiterLookup(tokCfg.LookupURL, 3*time.Second))
+			}
+		}
+	}
+	if len(lookups) > 0 {
+		resolver.SetUpstream(
+			lookups,
+			time.Duration(tokCfg.LookupCooldownSeconds)*time.Second,
+			time.Duration(len(lookups))*3*time.Second,
+		)
+	}
 
 	// Signal channel
 	signalChan := make(chan *signalPkg.Signal, 100)
@@ -296,12 +455,18 @@ func initComponents() (
 		signalChan,
 		func() float64 { return cfg.GetTrading().MinEntryPercent },
 		func() float64 { return cfg.GetTrading().TakeProfitMultiple },
+		func() string { return cfg.GetTrading().ValuelessSignalAction },
 		resolver.Resolve,
 	)
 
 	// Create HTTP server
 	telegramCfg := cfg.Get().Telegram
 	server := signalPkg.NewServer(telegramCfg.ListenHost, telegramCfg.ListenPort, handler)
+	server.SetWebhookSecret(telegramCfg.WebhookSecret)
+	if err := server.SetAllowedIPs(telegramCfg.AllowedIPs); err != nil {
+		log.Fatal().Err(err).Msg("invalid telegram.allowed_ips")
+	}
+	server.SetRateLimit(telegramCfg.RateLimitPerSecond, telegramCfg.RateLimitBurst)
 
 	// Initialize blockchain components (only if wallet key is set)
 	var wallet *blockchain.Wallet
@@ -332,7 +497,27 @@ func initComponents() (
 	if wallet != nil {
 		// Initialize RPC client
 		rpcCfg := cfg.Get().RPC
-		rpc = blockchain.NewRPCClient(rpcCfg.ShyftURL, rpcCfg.FallbackURL, cfg.GetShyftAPIKey())
+		endpoints := []blockchain.RPCEndpoint{
+			{URL: rpcCfg.ShyftURL, APIKey: cfg.GetShyftAPIKey()},
+			{URL: rpcCfg.FallbackURL, APIKey: cfg.GetShyftAPIKey()},
+		}
+		// Extra providers authenticate via their URLs; the Shyft key is not sent to them
+		for _, u := range rpcCfg.FallbackURLs {
+			endpoints = append(endpoints, blockchain.RPCEndpoint{URL: u})
+		}
+		rpc = blockchain.NewRPCClientMulti(endpoints)
+		rpc.SetCircuitBreaker(rpcCfg.CircuitBreaker.FailureThreshold, time.Duration(rpcCfg.CircuitBreaker.ResetSeconds)*time.Second)
+
+		// Shared dialer: optional IPv4-only + in-process DNS cache for RPC and Jupiter
+		var dnsCache *netutil.DNSCache
+		if rpcCfg.DNSCacheTTLSeconds > 0 {
+			dnsCache = netutil.NewDNSCache(time.Duration(rpcCfg.DNSCacheTTLSeconds) * time.Second)
+		}
+		dialer := netutil.NewDialer(rpcCfg.ForceIPv4, dnsCache)
+		rpc.SetDialContext(dialer.DialContext)
+		if metaplex != nil {
+			metaplex.SetAccounts(rpc)
+		}
 
 		// Initialize blockhash cache
 		blockhashCache = blockchain.NewBlockhashCache(
@@ -351,10 +536,16 @@ func initComponents() (
 			jupCfg.SlippageBps,
 			time.Duration(jupCfg.TimeoutSeconds)*time.Second,
 		)
+		jupiterClient.SetDialContext(dialer.DialContext)
+		jupiterClient.SetMaxQuoteAge(time.Duration(jupCfg.MaxQuoteAgeMs) * time.Millisecond)
+		jupiterClient.SetQuoteCacheTTL(time.Duration(jupCfg.QuoteCacheMs) * time.Millisecond)
+		jupiterClient.SetRetry(jupCfg.Retries, time.Duration(jupCfg.RetryBackoffMs)*time.Millisecond)
+		jupiterClient.SetPlatformFee(jupCfg.ReferralAccount, jupCfg.FeeBps)
 
 		// Initialize transaction builder
 		priorityFeeLamports := uint64(cfg.Get().Fees.StaticPriorityFeeSol * 1e9)
 		txBuilder = blockchain.NewTransactionBuilder(wallet, blockhashCache, priorityFeeLamports)
+		txBuilder.SetComputeUnitLimit(cfg.Get().Fees.ComputeUnitLimit)
 
 		// Initialize balance tracker
 		balanceTracker = blockchain.NewBalanceTracker(wallet, rpc)
@@ -393,21 +584,165 @@ func initComponents() (
 		if err != nil {
 			log.Error().Err(err).Msg("failed to initialize database")
 		}
+		if days := cfg.Get().Storage.RetentionDays; db != nil && days > 0 {
+			go runRetention(context.Background(), db, time.Duration(days)*24*time.Hour)
+		}
 
 		// Initialize position tracker
 		positions := trading.NewPositionTracker(db, cfg.GetTrading().MaxOpenPositions)
 
 		// Initialize FAST executor (no balance checks, no preflight, fire-and-forget)
 		executor = trading.NewExecutorFast(cfg, wallet, rpc, jupiterClient, txBuilder, positions, balanceTracker, db)
+		cfg.SetOnChange(executor.ApplyConfig) // hot-reload: position limit, slippage, token filter
+		executor.SetTokenResolver(resolver.Resolve)
+		if dexScreener != nil {
+			executor.SetPoolHint(func(mint string) (string, bool) {
+				pair, ok := dexScreener.PairFor(mint)
+				return pair.Pool, ok && pair.SOLPool()
+			})
+		}
+
+		// Chat notifications: a misconfigured notifier is logged, not fatal
+		if notifier, err := notify.New(cfg.Get().Notify, cfg.GetNotifyBotToken()); err != nil {
+			log.Warn().Err(err).Msg("⚠️ notifications disabled")
+		} else if notifier != nil {
+			executor.SetNotifier(notifier)
+			log.Info().Str("provider", cfg.Get().Notify.Provider).Msg("🔔 trade notifications enabled")
+		}
+
+		// Component health for /healthz and the TUI health screen
+		healthChecker.Add("RPC", health.RPCProbe(rpc))
+		healthChecker.Add("Jupiter", health.JupiterProbe(jupiterClient))
+		if db != nil {
+			healthChecker.Add("Database", health.DBProbe(db))
+		} else {
+			healthChecker.Add("Database", func(context.Context) error { return errors.New("database not open") })
+		}
+		healthChecker.Add("WebSocket", health.WebSocketProbe(executor.WSConnected))
 
 		log.Info().
 			Str("wallet", wallet.Address()).
 			Float64("balance", balanceTracker.BalanceSOL()).
 			Int("tokens", resolver.CacheSize()).
 			Msg("trading engine initialized")
+
+		if grace := cfg.GetTrading().StartupGraceSeconds; grace > 0 {
+			log.Info().Int("seconds", grace).Msg("⏳ STARTUP GRACE ACTIVE: entry signals counted but not traded")
+		}
+
+		// Dynamic priority fee: keep Jupiter's cap at a percentile of recent network fees
+		if dyn := cfg.Get().Fees.Dynamic; dyn.Enabled && dyn.RefreshSeconds > 0 {
+			estimator := blockchain.NewFeeEstimator(rpc, dyn.Accounts, dyn.Percentile, dyn.ComputeUnits, dyn.MinLamports, dyn.MaxLamports)
+			go estimator.Run(context.Background(), time.Duration(dyn.RefreshSeconds)*time.Second, executor.SetPriorityFeeBase)
+		}
+
+		// Latency-aware routing: keep per-endpoint p50s fresh so calls prefer the fastest
+		if secs := cfg.Get().RPC.LatencyProbeSeconds; secs > 0 {
+			go rpc.RunLatencyProbe(context.Background(), time.Duration(secs)*time.Second)
+		}
+	} else {
+		// No trading engine to probe: /healthz must not answer 200 with nothing checked
+		healthChecker.Add("Wallet", func(context.Context) error { return errors.New("no wallet configured, trading engine not started") })
 	}
 
-	return cfg, resolver, signalChan, server, executor, balanceTracker, blockhashCache
+	healthChecker.Start(context.Background(), HealthCheckInterval)
+	server.SetHealthSource(func(ctx context.Context) health.Report {
+		return healthChecker.Current(ctx, HealthCheckInterval)
+	})
+
+	// Prometheus scrape endpoint: GET /metrics on the signal server, or on its own port
+	if mc := cfg.Get().Metrics; mc.Enabled {
+		source := metricsSource(executor, balanceTracker, rpc, blockhashCache)
+		if mc.Port > 0 {
+			addr := fmt.Sprintf("%s:%d", telegramCfg.ListenHost, mc.Port)
+			go func() {
+				if err := signalPkg.ServeMetrics(addr, source); err != nil {
+					log.Error().Err(err).Str("addr", addr).Msg("metrics server stopped")
+				}
+			}()
+		} else {
+			server.SetMetricsSource(source)
+		}
+	}
+
+	return cfg, resolver, signalChan, server, executor, balanceTracker, blockhashCache, rpc
+}
+
+// runRetention prunes signals, trades and decisions older than keep, at startup and
+// then hourly (storage.retention_days). Positions are never pruned.
+func runRetention(ctx context.Context, db *storage.DB, keep time.Duration) {
+	prune := func() {
+		signals, err := db.PruneSignals(keep)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to prune old signals")
+		}
+		trades, err := db.PruneTrades(keep)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to prune old trades")
+		}
+		decisions, err := db.PruneDecisions(keep)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to prune old decisions")
+		}
+		if signals > 0 || trades > 0 || decisions > 0 {
+			log.Info().Int64("signals", signals).Int64("trades", trades).Int64("decisions", decisions).Dur("olderThan", keep).Msg("🧹 pruned old rows")
+		}
+	}
+
+	prune()
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			prune()
+		}
+	}
+}
+
+// metricsSource renders every component's metrics as Prometheus text; nil
+// components (no wallet, no trading engine) are left out
+func metricsSource(
+	executor *trading.ExecutorFast,
+	balanceTracker *blockchain.BalanceTracker,
+	rpc *blockchain.RPCClient,
+	blockhashCache *blockchain.BlockhashCache,
+) func() string {
+	return func() string {
+		var b strings.Builder
+		if executor != nil {
+			executor.GetMetrics().WritePrometheus(&b)
+			fmt.Fprintf(&b, "# HELP pumpbot_open_positions Open positions.\n# TYPE pumpbot_open_positions gauge\npumpbot_open_positions %d\n",
+				len(executor.GetOpenPositions()))
+		}
+		if balanceTracker != nil {
+			fmt.Fprintf(&b, "# HELP pumpbot_wallet_sol Wallet SOL balance.\n# TYPE pumpbot_wallet_sol gauge\npumpbot_wallet_sol %v\n",
+				balanceTracker.BalanceSOL())
+		}
+		if rpc != nil {
+			rpc.WritePrometheus(&b)
+		}
+		if blockhashCache != nil {
+			blockhashCache.Stats().WritePrometheus(&b)
+		}
+		return b.String()
+	}
+}
+
+// logStartupSummary prints the redacted effective config as one block and logs it
+func logStartupSummary(cfg *config.Manager) {
+	c := cfg.Get()
+	fmt.Fprint(os.Stderr, c.SummaryBlock())
+
+	var lines []string
+	for _, l := range c.Summary() {
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	log.Info().Strs("config", lines).Msg("📋 EFFECTIVE CONFIG")
 }
 
 func setupLogger() {
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/trading"
+)
+
+// positionSeller is the part of the executor the exit sequence needs
+type positionSeller interface {
+	SellAllPositions(ctx context.Context) []trading.SellResult
+}
+
+// shutdownSequence stops taking signals, sells every open position when
+// shutdown.sell_on_exit is set (bounded by sell_timeout_seconds, so a dead
+// RPC can't hold the process open), then runs stop in order. The sells
+// still need the executor and blockhash cache, so those belong in stop.
+// seller may be nil (no trading engine).
+func shutdownSequence(cfg config.ShutdownConfig, seller positionSeller, stopSignals func() error, stop ...func()) {
+	if stopSignals != nil {
+		if err := stopSignals(); err != nil {
+			log.Warn().Err(err).Msg("signal server shutdown failed")
+		}
+	}
+	if cfg.SellOnExit && seller != nil {
+		timeout := time.Duration(cfg.SellTimeoutSeconds) * time.Second
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		logSellResults(seller.SellAllPositions(ctx))
+		cancel()
+	}
+	for _, fn := range stop {
+		fn()
+	}
+}
+
+// logSellResults logs each position's outcome from SellAllPositions; a sell
+// still unconfirmed at the shutdown timeout is reported as such, not as sold
+func logSellResults(results []trading.SellResult) {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil && r.Sent && errors.Is(r.Err, context.DeadlineExceeded) {
+			failed++
+			log.Warn().Str("token", r.TokenName).Str("mint", r.Mint).Msg("📤 sell sent, unconfirmed")
+			continue
+		}
+		if r.Err != nil {
+			failed++
+			log.Error().Err(r.Err).Str("token", r.TokenName).Str("mint", r.Mint).Msg("❌ position not sold")
+			continue
+		}
+		log.Info().Str("token", r.TokenName).Str("mint", r.Mint).Msg("✅ position sold")
+	}
+	if len(results) > 0 {
+		log.Info().Int("sold", len(results)-failed).Int("failed", failed).Msg("sell-all finished")
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/trading"
+)
+
+// fakeSeller records the sell-all call; hang makes it wait out the context
+// like a sell stuck on a dead RPC
+type fakeSeller struct {
+	steps *[]string
+	hang  bool
+}
+
+func (f fakeSeller) SellAllPositions(ctx context.Context) []trading.SellResult {
+	*f.steps = append(*f.steps, "sell")
+	if _, ok := ctx.Deadline(); !ok {
+		*f.steps = append(*f.steps, "no deadline")
+	}
+	if f.hang {
+		<-ctx.Done()
+		return []trading.SellResult{{Mint: "Mint111", TokenName: "PEPE", Err: ctx.Err()}}
+	}
+	return []trading.SellResult{{Mint: "Mint111", TokenName: "PEPE"}}
+}
+
+func runShutdown(cfg config.ShutdownConfig, hang bool) []string {
+	var steps []string
+	shutdownSequence(cfg, fakeSeller{steps: &steps, hang: hang},
+		func() error { steps = append(steps, "signals"); return nil },
+		func() { steps = append(steps, "executor") },
+		func() { steps = append(steps, "blockhash") },
+	)
+	return steps
+}
+
+func TestShutdownSequence_SellsBetweenSignalsAndStop(t *testing.T) {
+	got := runShutdown(config.ShutdownConfig{SellOnExit: true, SellTimeoutSeconds: 5}, false)
+	want := []string{"signals", "sell", "executor", "blockhash"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("steps = %v, want %v", got, want)
+	}
+
+	got = runShutdown(config.ShutdownConfig{SellOnExit: false, SellTimeoutSeconds: 5}, false)
+	want = []string{"signals", "executor", "blockhash"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sell_on_exit off: steps = %v, want %v", got, want)
+	}
+}
+
+func TestShutdownSequence_GivesUpOnHangingSells(t *testing.T) {
+	start := time.Now()
+	got := runShutdown(config.ShutdownConfig{SellOnExit: true, SellTimeoutSeconds: 1}, true)
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("shutdown took %v, want about the 1s sell timeout", elapsed)
+	}
+	want := []string{"signals", "sell", "executor", "blockhash"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("steps = %v, want %v", got, want)
+	}
+}
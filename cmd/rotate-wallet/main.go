@@ -0,0 +1,230 @@
+// rotate-wallet replaces the auto-generated cached wallet with a fresh keypair
+// and sweeps everything from the old wallet into it: token balances are
+// swapped to SOL via Jupiter, wrapped SOL accounts are closed into the new
+// address, then the SOL is transferred to it.
+//
+// The old key is backed up next to the cache before anything is sent, so a
+// partially failed sweep can always be finished by hand.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/jupiter"
+)
+
+const confirmTimeout = 60 * time.Second
+
+func main() {
+	configPath := flag.String("config", "config/config.yaml", "path to config file")
+	cacheDir := flag.String("cache-dir", "./data", "directory holding wallet_cache.json")
+	yes := flag.Bool("yes", false, "skip the interactive confirmation")
+	flag.Parse()
+
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: "15:04:05"})
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	cfg, err := config.NewManager(*configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
+	if cfg.GetPrivateKey() != "" {
+		log.Warn().Msg("⚠️  A private key is configured - the bot does not use the cached wallet while it is set")
+	}
+
+	keys := blockchain.NewCachedKeyManager(*cacheDir, 10*time.Minute)
+	oldWallet, err := keys.LoadCached()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to read cached wallet")
+	}
+	if oldWallet == nil {
+		log.Fatal().Str("dir", *cacheDir).Msg("no cached wallet found - nothing to rotate")
+	}
+
+	rpcCfg := cfg.Get().RPC
+	rpc := blockchain.NewRPCClient(rpcCfg.ShyftURL, rpcCfg.FallbackURL, cfg.GetShyftAPIKey())
+	jup := jupiter.NewClient(cfg.Get().Jupiter.QuoteAPIURL, cfg.Get().Jupiter.SlippageBps, 10*time.Second)
+	blockhashCache := blockchain.NewBlockhashCache(rpc, cfg.GetBlockhashRefresh(), 30*time.Second)
+	if err := blockhashCache.Start(); err != nil {
+		log.Fatal().Err(err).Msg("failed to start blockhash cache")
+	}
+	defer blockhashCache.Stop()
+	txBuilder := blockchain.NewTransactionBuilder(oldWallet, blockhashCache, 0)
+
+	ctx := context.Background()
+	solBalance, err := rpc.GetBalance(ctx, oldWallet.Address())
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to get SOL balance")
+	}
+	tokens, err := rpc.GetAllTokenAccounts(ctx, oldWallet.Address())
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to list token accounts")
+	}
+
+	newWallet, err := keys.Generate()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to generate keypair")
+	}
+
+	fmt.Println()
+	fmt.Printf("  Old wallet: %s\n", oldWallet.Address())
+	fmt.Printf("    SOL:      %.9f\n", float64(solBalance)/1e9)
+	for _, t := range tokens {
+		if t.Amount > 0 {
+			fmt.Printf("    Token:    %s  %d (raw)\n", t.Mint, t.Amount)
+		}
+	}
+	fmt.Printf("  New wallet: %s\n", newWallet.Address())
+	fmt.Println()
+	fmt.Println("  The new wallet becomes the cached bot wallet.")
+	fmt.Println("  Tokens are swapped to SOL and wrapped SOL is unwrapped,")
+	fmt.Println("  then all SOL is sent to the new wallet.")
+	fmt.Println()
+
+	if !*yes && !confirm("Type ROTATE to continue: ", "ROTATE") {
+		fmt.Println("Aborted - nothing changed.")
+		return
+	}
+
+	// Back up the old key before it is replaced so funds can never be orphaned
+	backupPath, err := keys.Backup(oldWallet.Address())
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to back up old wallet - aborting")
+	}
+	log.Info().Str("path", backupPath).Msg("💾 old wallet key backed up")
+
+	if err := keys.Save(); err != nil {
+		log.Fatal().Err(err).Msg("failed to save new wallet - old wallet untouched")
+	}
+	log.Info().Str("address", newWallet.Address()).Msg("🔑 new wallet cached")
+
+	// 1. Swap every token balance to SOL (still owned by the old wallet)
+	failed := 0
+	for _, t := range tokens {
+		if t.Amount == 0 || t.Mint == jupiter.SOLMint {
+			continue
+		}
+		if err := swapToSOL(ctx, rpc, jup, txBuilder, oldWallet.Address(), t); err != nil {
+			failed++
+			log.Error().Err(err).Str("mint", t.Mint).Msg("❌ token swap failed - sweep it manually from the backup key")
+			continue
+		}
+		log.Info().Str("mint", t.Mint).Msg("✅ token swapped to SOL")
+	}
+
+	// 2. Close wrapped SOL accounts straight into the new wallet (balance and rent)
+	for _, t := range tokens {
+		if t.Mint != jupiter.SOLMint {
+			continue
+		}
+		blockhash, err := blockhashCache.Get()
+		if err == nil {
+			var tx string
+			if tx, err = blockchain.BuildCloseTokenAccount(oldWallet, t.Address, newWallet.Address(), blockhash); err == nil {
+				_, err = sendAndConfirm(ctx, rpc, tx)
+			}
+		}
+		if err != nil {
+			failed++
+			log.Error().Err(err).Str("account", t.Address).Msg("❌ wrapped SOL unwrap failed - close it manually from the backup key")
+			continue
+		}
+		log.Info().Str("account", t.Address).Float64("sol", float64(t.Amount)/1e9).Msg("✅ wrapped SOL unwrapped into new wallet")
+	}
+
+	// 3. Transfer the remaining SOL
+	solBalance, err = rpc.GetBalance(ctx, oldWallet.Address())
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to refresh SOL balance")
+	}
+	if solBalance <= blockchain.LamportsPerSignature ||
+		solBalance-blockchain.LamportsPerSignature < blockchain.RentExemptMinimumLamports {
+		log.Warn().Uint64("lamports", solBalance).Msg("SOL balance too small to transfer - nothing swept")
+	} else {
+		lamports := solBalance - blockchain.LamportsPerSignature
+		blockhash, err := blockhashCache.Get()
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to get blockhash")
+		}
+		tx, err := blockchain.BuildSOLTransfer(oldWallet, newWallet.Address(), lamports, blockhash)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to build SOL transfer")
+		}
+		sig, err := sendAndConfirm(ctx, rpc, tx)
+		if err != nil {
+			log.Fatal().Err(err).Str("sig", sig).Msg("❌ SOL transfer failed - old key is in the backup file")
+		}
+		log.Info().
+			Str("sig", sig).
+			Float64("sol", float64(lamports)/1e9).
+			Msg("✅ SOL transferred to new wallet")
+	}
+
+	fmt.Println()
+	fmt.Printf("  Old wallet: %s (backup: %s)\n", oldWallet.Address(), backupPath)
+	fmt.Printf("  New wallet: %s\n", newWallet.Address())
+	if failed > 0 {
+		fmt.Printf("  %d token balance(s) could not be swapped or unwrapped and remain in the old wallet.\n", failed)
+	}
+	fmt.Println("  Rent held by empty token accounts stays with the old wallet.")
+}
+
+// confirm reads one line from stdin and compares it to want
+func confirm(prompt, want string) bool {
+	fmt.Print(prompt)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(line) == want
+}
+
+// swapToSOL sells a full token balance for SOL and waits for confirmation
+func swapToSOL(ctx context.Context, rpc *blockchain.RPCClient, jup *jupiter.Client, txBuilder *blockchain.TransactionBuilder, owner string, t blockchain.TokenAccountInfo) error {
+	swapTx, err := jup.GetSwapTransaction(ctx, t.Mint, jupiter.SOLMint, owner, t.Amount)
+	if err != nil {
+		return fmt.Errorf("swap tx: %w", err)
+	}
+	signed, err := txBuilder.SignSerializedTransaction(swapTx)
+	if err != nil {
+		return fmt.Errorf("sign: %w", err)
+	}
+	_, err = sendAndConfirm(ctx, rpc, signed)
+	return err
+}
+
+// sendAndConfirm sends a signed transaction and polls until it lands or fails
+func sendAndConfirm(ctx context.Context, rpc *blockchain.RPCClient, signedTx string) (string, error) {
+	sig, err := rpc.SendTransaction(ctx, signedTx, false)
+	if err != nil {
+		return "", err
+	}
+	deadline := time.Now().Add(confirmTimeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(2 * time.Second)
+		res, err := rpc.CheckTransaction(ctx, sig)
+		if err != nil {
+			continue
+		}
+		switch res.Status {
+		case "SUCCESS":
+			if blockchain.CommitmentReached(res.ConfirmationStatus, "confirmed") {
+				return sig, nil
+			}
+		case "FAILED":
+			return sig, fmt.Errorf("transaction failed: %s", res.Message)
+		}
+	}
+	return sig, fmt.Errorf("not confirmed within %s", confirmTimeout)
+}
@@ -94,6 +94,11 @@ func main() {
 	
 	// Enable Jupiter Sim Mode: Price Multiplier 1.0 (Entry)
 	jup.SetSimulation(true, 1.0)
+	simPrices := map[string]jupiter.SimPrice{}
+	for mint, p := range cfg.Get().Trading.SimulationPrices {
+		simPrices[mint] = jupiter.SimPrice{PriceSOL: p.PriceSol, Decimals: p.Decimals}
+	}
+	jup.SetSimPrices(simPrices)
 	executor.SetSimulationMode(true) // Explicitly enable Sim Bypass
 	
 	executor.StartMonitoring(ctx)
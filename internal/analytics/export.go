@@ -4,6 +4,7 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"solana-pump-bot/internal/storage"
@@ -27,7 +28,7 @@ func ExportTradesToCSV(db *storage.DB, path string) error {
 
 	// Header
 	if err := writer.Write([]string{
-		"ID", "Mint", "Token", "Side", "Amount SOL", "Entry%", "Exit%", "PnL%", "Duration(s)", "Entry TX", "Exit TX", "Timestamp",
+		"ID", "Mint", "Token", "Side", "Amount SOL", "Entry%", "Exit%", "PnL%", "Duration(s)", "Entry TX", "Exit TX", "Timestamp", "Slippage%", "Paper",
 	}); err != nil {
 		return err
 	}
@@ -47,6 +48,8 @@ func ExportTradesToCSV(db *storage.DB, path string) error {
 			t.EntryTxSig,
 			t.ExitTxSig,
 			time.Unix(t.Timestamp, 0).Format(time.RFC3339),
+			fmt.Sprintf("%.2f", t.SlippagePct),
+			strconv.FormatBool(t.Paper),
 		}
 		if err := writer.Write(row); err != nil {
 			return err
@@ -0,0 +1,51 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"time"
+
+	"solana-pump-bot/internal/storage"
+)
+
+// ExportDecisionsToCSV exports the trade decision audit log (why each signal
+// did or didn't trade) to a CSV file, newest first
+func ExportDecisionsToCSV(db *storage.DB, path string) error {
+	decisions, err := db.GetRecentDecisions(100000)
+	if err != nil {
+		return fmt.Errorf("failed to get decisions: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{
+		"ID", "Timestamp", "Mint", "Token", "Signal", "Msg ID", "Action", "Reason", "Detail",
+	}); err != nil {
+		return err
+	}
+	for _, d := range decisions {
+		row := []string{
+			fmt.Sprintf("%d", d.ID),
+			time.Unix(d.Timestamp, 0).Format(time.RFC3339),
+			d.Mint,
+			d.TokenName,
+			d.SignalType,
+			fmt.Sprintf("%d", d.MsgID),
+			d.Action,
+			d.Reason,
+			d.Detail,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package analytics
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"solana-pump-bot/internal/storage"
+)
+
+// TradeJSON is one trade in a JSON export
+type TradeJSON struct {
+	ID             int64   `json:"id"`
+	Mint           string  `json:"mint"`
+	Token          string  `json:"token"`
+	Side           string  `json:"side"`
+	AmountSol      float64 `json:"amount_sol"`
+	EntryValue     float64 `json:"entry_value"`
+	ExitValue      float64 `json:"exit_value"`
+	PnLPercent     float64 `json:"pnl_percent"`
+	RealizedPnLSol float64 `json:"realized_pnl_sol"`
+	SlippagePct    float64 `json:"slippage_pct"` // confirmed fill vs quote, 0 = unknown
+	Paper          bool    `json:"paper"`        // filled at the quote, never sent
+	DurationSec    int64   `json:"duration_seconds"`
+	EntryTx        string  `json:"entry_tx"`
+	ExitTx         string  `json:"exit_tx"`
+	Timestamp      string  `json:"timestamp"` // RFC3339
+}
+
+// ExportTradesToJSON exports all trades to a file as a JSON array, newest
+// first. Trades are read off the database cursor and encoded one at a time
+// straight to the file, so the history is never held in memory.
+func ExportTradesToJSON(db *storage.DB, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+
+	if _, err := w.WriteString("["); err != nil {
+		return err
+	}
+	first := true
+	err = db.EachTrade(func(t *storage.Trade) error {
+		if !first {
+			if _, err := w.WriteString(","); err != nil {
+				return err
+			}
+		}
+		first = false
+		return enc.Encode(TradeJSON{
+			ID:             t.ID,
+			Mint:           t.Mint,
+			Token:          t.TokenName,
+			Side:           t.Side,
+			AmountSol:      t.AmountSol,
+			EntryValue:     t.EntryValue,
+			ExitValue:      t.ExitValue,
+			PnLPercent:     t.PnL,
+			RealizedPnLSol: t.RealizedPnLSol,
+			SlippagePct:    t.SlippagePct,
+			Paper:          t.Paper,
+			DurationSec:    t.Duration,
+			EntryTx:        t.EntryTxSig,
+			ExitTx:         t.ExitTxSig,
+			Timestamp:      time.Unix(t.Timestamp, 0).UTC().Format(time.RFC3339),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export trades: %w", err)
+	}
+	if _, err := w.WriteString("]\n"); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,80 @@
+package analytics
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"solana-pump-bot/internal/storage"
+)
+
+func TestExportTradesToJSON_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	db, err := storage.NewDB(filepath.Join(dir, "trades.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	inserted := []*storage.Trade{
+		{Mint: "MintA", TokenName: "AAA", Side: "BUY", AmountSol: 0.1, EntryValue: 60, EntryTxSig: "buyA", Timestamp: 1700000000},
+		{Mint: "MintA", TokenName: "AAA", Side: "SELL", AmountSol: 0.1, EntryValue: 60, ExitValue: 120, PnL: 100, Duration: 90, EntryTxSig: "buyA", ExitTxSig: "sellA", Timestamp: 1700000090, RealizedPnLSol: 0.095, SlippagePct: 1.25},
+		{Mint: "MintB", TokenName: "BBB", Side: "SELL", AmountSol: 0.2, PnL: -40, ExitTxSig: "PAPER_SELL_BBB", Timestamp: 1700000200, Paper: true},
+	}
+	for _, tr := range inserted {
+		if err := db.InsertTrade(tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(dir, "trades.json")
+	if err := ExportTradesToJSON(db, path); err != nil {
+		t.Fatalf("ExportTradesToJSON: %v", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []TradeJSON
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("export is not a JSON array: %v\n%s", err, raw)
+	}
+
+	if len(got) != len(inserted) {
+		t.Fatalf("exported %d trades, want %d", len(got), len(inserted))
+	}
+	// Newest first
+	if got[0].Token != "BBB" || got[1].ExitTx != "sellA" || got[2].Side != "BUY" {
+		t.Errorf("unexpected order: %+v", got)
+	}
+	if got[1].RealizedPnLSol != 0.095 || got[1].PnLPercent != 100 || got[1].DurationSec != 90 || got[1].SlippagePct != 1.25 {
+		t.Errorf("sell fields = %+v", got[1])
+	}
+	if !got[0].Paper || got[1].Paper {
+		t.Errorf("paper = %v, %v; want only the paper sell flagged", got[0].Paper, got[1].Paper)
+	}
+	if ts, err := time.Parse(time.RFC3339, got[1].Timestamp); err != nil || ts.Unix() != 1700000090 {
+		t.Errorf("timestamp = %q, want RFC3339 of 1700000090", got[1].Timestamp)
+	}
+}
+
+func TestExportTradesToJSON_EmptyIsArray(t *testing.T) {
+	dir := t.TempDir()
+	db, err := storage.NewDB(filepath.Join(dir, "trades.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	path := filepath.Join(dir, "trades.json")
+	if err := ExportTradesToJSON(db, path); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(path)
+	var got []TradeJSON
+	if err := json.Unmarshal(raw, &got); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("empty export = %q, want []", raw)
+	}
+}
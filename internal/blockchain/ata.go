@@ -0,0 +1,132 @@
+package blockchain
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/mr-tron/base58"
+)
+
+// AssociatedTokenProgramID owns every associated token account (ATA)
+const AssociatedTokenProgramID = "ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL"
+
+// TokenAccountRentLamports is the rent-exempt minimum of a 165-byte SPL
+// token account: what a first buy of a token pays to create its ATA
+const TokenAccountRentLamports = 2_039_280
+
+// Program derived address limits (solana_program::pubkey)
+const (
+	maxSeeds   = 16
+	maxSeedLen = 32
+)
+
+// ErrOnCurve is returned by CreateProgramAddress for seeds whose hash is a
+// valid ed25519 public key (a PDA must have no private key); try another bump
+var ErrOnCurve = errors.New("program address is on the ed25519 curve")
+
+// CreateProgramAddress derives the program address of seeds under programID:
+// sha256(seeds || programID || "ProgramDerivedAddress"), refused when on curve
+func CreateProgramAddress(seeds [][]byte, programID string) (string, error) {
+	program, err := base58.Decode(programID)
+	if err != nil || len(program) != 32 {
+		return "", fmt.Errorf("invalid program id %q", programID)
+	}
+	if len(seeds) > maxSeeds {
+		return "", fmt.Errorf("%d seeds: at most %d", len(seeds), maxSeeds)
+	}
+	h := sha256.New()
+	for _, seed := range seeds {
+		if len(seed) > maxSeedLen {
+			return "", fmt.Errorf("seed of %d bytes: at most %d", len(seed), maxSeedLen)
+		}
+		h.Write(seed)
+	}
+	h.Write(program)
+	h.Write([]byte("ProgramDerivedAddress"))
+	addr := h.Sum(nil)
+	if onCurve(addr) {
+		return "", ErrOnCurve
+	}
+	return base58.Encode(addr), nil
+}
+
+// FindProgramAddress returns the first off-curve program address of seeds
+// plus a bump byte, trying bumps from 255 down, and the bump it used
+func FindProgramAddress(seeds [][]byte, programID string) (string, uint8, error) {
+	withBump := append(append([][]byte{}, seeds...), nil)
+	for bump := 255; bump >= 0; bump-- {
+		withBump[len(seeds)] = []byte{byte(bump)}
+		addr, err := CreateProgramAddress(withBump, programID)
+		if err == nil {
+			return addr, uint8(bump), nil
+		}
+		if !errors.Is(err, ErrOnCurve) {
+			return "", 0, err
+		}
+	}
+	return "", 0, fmt.Errorf("no viable bump for program %s", programID)
+}
+
+// DeriveATA returns owner's associated token account for a classic SPL mint
+func DeriveATA(owner, mint string) (string, error) {
+	return DeriveATAForProgram(owner, mint, TokenProgramID)
+}
+
+// DeriveATAForProgram returns owner's associated token account for mint
+// under tokenProgram (TokenProgramID or Token2022ProgramID)
+func DeriveATAForProgram(owner, mint, tokenProgram string) (string, error) {
+	var seeds [][]byte
+	for _, addr := range []string{owner, tokenProgram, mint} {
+		key, err := base58.Decode(addr)
+		if err != nil || len(key) != 32 {
+			return "", fmt.Errorf("invalid address %q", addr)
+		}
+		seeds = append(seeds, key)
+	}
+	ata, _, err := FindProgramAddress(seeds, AssociatedTokenProgramID)
+	return ata, err
+}
+
+// Curve25519 field prime 2^255-19 and the edwards25519 constant d
+var (
+	fieldP    = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	edwardsD  = edwardsConstD()
+	legendreE = new(big.Int).Rsh(new(big.Int).Sub(fieldP, big.NewInt(1)), 1)
+)
+
+// edwardsConstD is -121665/121666 mod p
+func edwardsConstD() *big.Int {
+	inv := new(big.Int).ModInverse(big.NewInt(121666), fieldP)
+	d := new(big.Int).Mul(big.NewInt(-121665), inv)
+	return d.Mod(d, fieldP)
+}
+
+// onCurve reports whether b decompresses to an ed25519 point, as
+// curve25519-dalek's decompress does: y from the low 255 bits (reduced mod p),
+// on curve when x^2 = (y^2-1)/(d*y^2+1) has a square root
+func onCurve(b []byte) bool {
+	be := make([]byte, 32)
+	for i := range be {
+		be[i] = b[31-i]
+	}
+	be[0] &= 0x7f // sign bit of x
+	y := new(big.Int).SetBytes(be)
+	y.Mod(y, fieldP)
+
+	yy := new(big.Int).Mul(y, y)
+	u := new(big.Int).Sub(yy, big.NewInt(1))
+	u.Mod(u, fieldP)
+	v := new(big.Int).Mul(edwardsD, yy)
+	v.Add(v, big.NewInt(1))
+	v.Mod(v, fieldP) // never 0: -1/d is not a square
+
+	xx := new(big.Int).ModInverse(v, fieldP)
+	xx.Mul(xx, u)
+	xx.Mod(xx, fieldP)
+	if xx.Sign() == 0 {
+		return true
+	}
+	return new(big.Int).Exp(xx, legendreE, fieldP).Cmp(big.NewInt(1)) == 0
+}
@@ -0,0 +1,73 @@
+package blockchain
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/mr-tron/base58"
+)
+
+// Vectors from solana_program::pubkey's test_create_program_address
+func TestCreateProgramAddress_SDKVectors(t *testing.T) {
+	const program = "BPFLoaderUpgradeab1e11111111111111111111111"
+	seedKey, err := base58.Decode("SeedPubey1111111111111111111111111111111111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		seeds [][]byte
+		want  string
+	}{
+		{[][]byte{{}, {1}}, "BwqrghZA2htAcqq8dzP1WDAhTXYTYWj7CHxF5j7TDBAe"},
+		{[][]byte{[]byte("☉"), {0}}, "13yWmRpaTR4r5nAktwLqMpRNr28tnVUZw26rTvPSSB19"},
+		{[][]byte{[]byte("Talking"), []byte("Squirrels")}, "2fnQrngrQT4SeLcdToJAD96phoEjNL2man2kfRLCASVk"},
+		{[][]byte{seedKey, {1}}, "976ymqVnfE32QFe6NfGDctSvVa36LWnvYxhU6G2232YL"},
+	}
+	for _, tt := range tests {
+		got, err := CreateProgramAddress(tt.seeds, program)
+		if err != nil || got != tt.want {
+			t.Errorf("CreateProgramAddress(%q) = %s, %v; want %s", tt.seeds, got, err, tt.want)
+		}
+	}
+
+	if _, err := CreateProgramAddress([][]byte{make([]byte, 33)}, program); err == nil {
+		t.Error("seed over 32 bytes accepted")
+	}
+}
+
+func TestDeriveATA(t *testing.T) {
+	const owner = "SeedPubey1111111111111111111111111111111111"
+	const usdc = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+
+	// Bump 252: the first three candidates land on the curve
+	if got, err := DeriveATA(owner, usdc); err != nil || got != "7N6mLQcinNPPkJmQaTNyiFrASrSenpRbkWQXxVcskyza" {
+		t.Errorf("DeriveATA = %s, %v", got, err)
+	}
+	if got, err := DeriveATAForProgram(owner, usdc, Token2022ProgramID); err != nil || got != "AM6YsD2KKdoU4eERbRQ6mBy5enVr6CXXZsSRHrjWzzaj" {
+		t.Errorf("DeriveATAForProgram(Token-2022) = %s, %v", got, err)
+	}
+	if _, err := DeriveATA("not-an-address", usdc); err == nil {
+		t.Error("invalid owner accepted")
+	}
+}
+
+func TestOnCurve_WalletKeys(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		pub, _, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !onCurve(pub) {
+			t.Errorf("ed25519 public key %x reported off curve", pub)
+		}
+	}
+
+	// The bump FindProgramAddress settles on re-derives the same address
+	addr, bump, err := FindProgramAddress([][]byte{[]byte("Talking")}, AssociatedTokenProgramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := CreateProgramAddress([][]byte{[]byte("Talking"), {bump}}, AssociatedTokenProgramID); err != nil || again != addr {
+		t.Errorf("bump %d re-derives %s, %v; want %s", bump, again, err, addr)
+	}
+}
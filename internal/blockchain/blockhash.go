@@ -2,6 +2,8 @@ package blockchain
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -29,8 +31,24 @@ type BlockhashCache struct {
 	wg       sync.WaitGroup
 
 	// Metrics
-	hits   atomic.Int64
-	misses atomic.Int64
+	hits          atomic.Int64 // served from either buffer
+	misses        atomic.Int64 // both buffers past TTL
+	syncRefreshes atomic.Int64 // forced synchronous fetches on the caller's path
+	fetches       atomic.Int64 // successful RPC fetches (prefetch + sync)
+	fetchErrors   atomic.Int64
+	fetchNanos    atomic.Int64 // total latency of successful fetches
+}
+
+// BlockhashStats is a point-in-time snapshot of cache metrics
+type BlockhashStats struct {
+	Hits          int64
+	Misses        int64
+	SyncRefreshes int64
+	Fetches       int64
+	FetchErrors   int64
+	AvgFetchMs    float64
+	HitRate       float64
+	AgeMs         int64 // age of the current blockhash
 }
 
 // NewBlockhashCache creates a new double-buffered blockhash cache
@@ -88,6 +106,7 @@ func (c *BlockhashCache) Get() (string, error) {
 
 	// Both buffers stale - force synchronous refresh (rare)
 	c.misses.Add(1)
+	c.syncRefreshes.Add(1)
 	log.Warn().Msg("blockhash cache miss, forcing sync refresh")
 	
 	if err := c.fetchAndRotate(); err != nil {
@@ -102,14 +121,18 @@ func (c *BlockhashCache) GetWithHeight() (string, uint64, error) {
 	cached := c.current.Load()
 
 	if cached != nil && time.Since(cached.FetchedAt) < c.ttl {
+		c.hits.Add(1)
 		return cached.Hash, cached.LastValidBlockHeight, nil
 	}
 
 	next := c.next.Load()
 	if next != nil && time.Since(next.FetchedAt) < c.ttl {
+		c.hits.Add(1)
 		return next.Hash, next.LastValidBlockHeight, nil
 	}
 
+	c.misses.Add(1)
+	c.syncRefreshes.Add(1)
 	if err := c.fetchAndRotate(); err != nil {
 		return "", 0, err
 	}
@@ -138,6 +161,37 @@ func (c *BlockhashCache) HitRate() float64 {
 	return float64(hits) / float64(total) * 100
 }
 
+// Stats returns a snapshot of the cache metrics (TUI metrics screen, /metrics)
+func (c *BlockhashCache) Stats() BlockhashStats {
+	st := BlockhashStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		SyncRefreshes: c.syncRefreshes.Load(),
+		Fetches:       c.fetches.Load(),
+		FetchErrors:   c.fetchErrors.Load(),
+		HitRate:       c.HitRate(),
+		AgeMs:         c.Age().Milliseconds(),
+	}
+	if st.Fetches > 0 {
+		st.AvgFetchMs = float64(c.fetchNanos.Load()) / float64(st.Fetches) / 1e6
+	}
+	return st
+}
+
+// WritePrometheus writes the stats in Prometheus text exposition format
+func (s BlockhashStats) WritePrometheus(w io.Writer) {
+	metric := func(name, typ, help string, value interface{}) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, typ, name, value)
+	}
+	metric("pumpbot_blockhash_hits_total", "counter", "Blockhash lookups served from cache.", s.Hits)
+	metric("pumpbot_blockhash_misses_total", "counter", "Blockhash lookups with both buffers past TTL.", s.Misses)
+	metric("pumpbot_blockhash_sync_refreshes_total", "counter", "Forced synchronous blockhash fetches on the send path.", s.SyncRefreshes)
+	metric("pumpbot_blockhash_fetches_total", "counter", "Successful blockhash RPC fetches.", s.Fetches)
+	metric("pumpbot_blockhash_fetch_errors_total", "counter", "Failed blockhash RPC fetches.", s.FetchErrors)
+	metric("pumpbot_blockhash_fetch_avg_ms", "gauge", "Average blockhash fetch latency in milliseconds.", s.AvgFetchMs)
+	metric("pumpbot_blockhash_age_ms", "gauge", "Age of the current cached blockhash in milliseconds.", s.AgeMs)
+}
+
 func (c *BlockhashCache) prefetchLoop() {
 	defer c.wg.Done()
 
@@ -160,10 +214,14 @@ func (c *BlockhashCache) fetchAndRotate() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
+	start := time.Now()
 	result, err := c.rpc.GetLatestBlockhash(ctx)
 	if err != nil {
+		c.fetchErrors.Add(1)
 		return err
 	}
+	c.fetches.Add(1)
+	c.fetchNanos.Add(int64(time.Since(start)))
 
 	newHash := &CachedBlockhash{
 		Hash:                 result.Value.Blockhash,
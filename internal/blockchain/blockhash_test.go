@@ -0,0 +1,43 @@
+package blockchain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBlockhashCache_StatsCountSyncRefreshes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"value":{"blockhash":"GHtXQBsoZHVnNFa9YevAzFr17DJjgHXk3ycTKD5xD3Zi","lastValidBlockHeight":100}}}`))
+	}))
+	defer srv.Close()
+
+	// Long prefetch interval so only the explicit calls below fetch
+	cache := NewBlockhashCache(NewRPCClient(srv.URL, srv.URL, ""), time.Hour, 50*time.Millisecond)
+	if err := cache.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer cache.Stop()
+
+	if _, err := cache.Get(); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond) // both buffers now past TTL
+	if _, err := cache.Get(); err != nil {
+		t.Fatalf("Get after TTL: %v", err)
+	}
+
+	st := cache.Stats()
+	if st.Hits != 1 || st.Misses != 1 || st.SyncRefreshes != 1 || st.Fetches != 2 {
+		t.Errorf("stats = %+v, want 1 hit, 1 miss, 1 sync refresh, 2 fetches", st)
+	}
+
+	var b strings.Builder
+	st.WritePrometheus(&b)
+	if !strings.Contains(b.String(), "pumpbot_blockhash_sync_refreshes_total 1\n") {
+		t.Errorf("prometheus output missing sync refresh counter:\n%s", b.String())
+	}
+}
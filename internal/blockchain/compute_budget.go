@@ -0,0 +1,29 @@
+package blockchain
+
+// Compute unit limits: Jupiter swaps set their own (dynamicComputeUnitLimit);
+// these apply to transactions built here
+const (
+	DefaultComputeUnitLimit = 600_000
+	MaxComputeUnitLimit     = 1_400_000 // per-transaction maximum
+)
+
+// MicroLamportsPerCU is the SetComputeUnitPrice that makes a transaction
+// using limit compute units pay priorityFeeLamports in priority fees
+// (rounded down; 0 for a zero limit)
+func MicroLamportsPerCU(priorityFeeLamports uint64, limit uint32) uint64 {
+	if limit == 0 {
+		return 0
+	}
+	return priorityFeeLamports * 1_000_000 / uint64(limit)
+}
+
+// clampComputeUnitLimit maps 0 to the default and caps at the maximum
+func clampComputeUnitLimit(limit uint64) uint32 {
+	switch {
+	case limit == 0:
+		return DefaultComputeUnitLimit
+	case limit > MaxComputeUnitLimit:
+		return MaxComputeUnitLimit
+	}
+	return uint32(limit)
+}
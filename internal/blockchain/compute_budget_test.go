@@ -0,0 +1,43 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestBuildComputeBudgetInstructions_PricePerCU(t *testing.T) {
+	tests := []struct {
+		feeLamports uint64
+		limit       uint32
+		wantLimit   uint32
+		wantMicro   uint64
+	}{
+		{3_750_000, 600_000, 600_000, 6_250_000},               // 0.00375 SOL over the default
+		{3_750_000, 200_000, 200_000, 18_750_000},              // tighter limit, same total fee
+		{100_000, 1_400_000, 1_400_000, 71_428},                // rounds down
+		{1, 1_000_000, 1_000_000, 1},                           // one lamport is a micro-lamport per CU
+		{0, 300_000, 300_000, 0},                               // no priority fee
+		{5_000_000, 0, DefaultComputeUnitLimit, 8_333_333},     // 0 = default limit
+		{5_000_000, 2_000_000, MaxComputeUnitLimit, 3_571_428}, // capped
+	}
+	for _, tt := range tests {
+		b, _ := fixtureBuilder(t)
+		b.priorityFeeLamports = tt.feeLamports
+		b.SetComputeUnitLimit(tt.limit)
+
+		setLimit, setPrice := b.BuildComputeBudgetInstructions()
+		if setLimit[0] != 2 || setPrice[0] != 3 {
+			t.Fatalf("instruction tags = %d, %d; want 2 (limit), 3 (price)", setLimit[0], setPrice[0])
+		}
+		if got := binary.LittleEndian.Uint32(setLimit[1:]); got != tt.wantLimit {
+			t.Errorf("fee %d, limit %d: SetComputeUnitLimit = %d, want %d", tt.feeLamports, tt.limit, got, tt.wantLimit)
+		}
+		if got := binary.LittleEndian.Uint64(setPrice[1:]); got != tt.wantMicro {
+			t.Errorf("fee %d, limit %d: microLamports/CU = %d, want %d", tt.feeLamports, tt.limit, got, tt.wantMicro)
+		}
+		// The price times the limit never exceeds the configured fee
+		if paid := MicroLamportsPerCU(tt.feeLamports, tt.wantLimit) * uint64(tt.wantLimit) / 1_000_000; paid > tt.feeLamports {
+			t.Errorf("fee %d, limit %d: pays %d lamports", tt.feeLamports, tt.limit, paid)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Per-endpoint circuit breaker defaults (rpc.circuit_breaker): an endpoint
+// that fails this many times in a row is skipped until the reset time has
+// passed since its last failure
+const (
+	CircuitFailureThreshold = 5
+	CircuitResetAfter       = 30 * time.Second
+
+	// LatencyWindow is how many probe samples an endpoint's p50 is taken over
+	LatencyWindow = 10
+)
+
+// RPCEndpoint is one RPC provider
+type RPCEndpoint struct {
+	URL    string
+	APIKey string // sent as x-api-key ("" = none)
+}
+
+// endpoint is an RPCEndpoint plus its breaker state (guarded by RPCClient.mu)
+type endpoint struct {
+	RPCEndpoint
+
+	failures      int // consecutive
+	totalFailures int
+	lastFailure   time.Time
+	circuitOpen   bool
+
+	latencies []uint64 // last LatencyWindow probe round-trips (ms), ring
+	nextLat   int
+}
+
+// EndpointStat is an endpoint's health for display (URL is host only)
+type EndpointStat struct {
+	Host          string
+	Failures      int // consecutive
+	TotalFailures int
+	CircuitOpen   bool
+	P50Ms         int64 // rolling median probe latency, -1 = not measured yet
+}
+
+// host returns the endpoint's host, safe to log (paths and queries often carry API keys)
+func (ep *endpoint) host() string {
+	u, err := url.Parse(ep.URL)
+	if err != nil || u.Host == "" {
+		return "(unparseable)"
+	}
+	return u.Host
+}
+
+// open reports whether the endpoint's breaker is open; caller holds c.mu
+func (c *RPCClient) open(ep *endpoint, now time.Time) bool {
+	return ep.circuitOpen && now.Sub(ep.lastFailure) <= c.resetAfter
+}
+
+// SetCircuitBreaker sets how many consecutive failures open an endpoint's
+// breaker and how long it then stays open (values <= 0 keep the current)
+func (c *RPCClient) SetCircuitBreaker(failureThreshold int, resetAfter time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if failureThreshold > 0 {
+		c.failureThreshold = failureThreshold
+	}
+	if resetAfter > 0 {
+		c.resetAfter = resetAfter
+	}
+}
+
+// p50 returns the median of the latency window, -1 if empty; caller holds c.mu
+func (ep *endpoint) p50() int64 {
+	if len(ep.latencies) == 0 {
+		return -1
+	}
+	return int64(Percentile(ep.latencies, 50))
+}
+
+// addLatency records one probe round-trip; caller holds c.mu
+func (ep *endpoint) addLatency(ms uint64) {
+	if len(ep.latencies) < LatencyWindow {
+		ep.latencies = append(ep.latencies, ms)
+		return
+	}
+	ep.latencies[ep.nextLat] = ms
+	ep.nextLat = (ep.nextLat + 1) % LatencyWindow
+}
+
+// candidates returns the endpoints to try, in order: those with a closed
+// breaker first, then the open ones (if everything is failing, still try).
+// While the latency probe runs the closed ones go fastest p50 first
+// (unmeasured ones after); otherwise they keep the configured order.
+func (c *RPCClient) candidates() []*endpoint {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := c.now()
+	out := make([]*endpoint, 0, len(c.endpoints))
+	var skipped []*endpoint
+	for _, ep := range c.endpoints {
+		if c.open(ep, now) {
+			skipped = append(skipped, ep)
+			continue
+		}
+		out = append(out, ep)
+	}
+	if c.probing {
+		sort.SliceStable(out, func(i, j int) bool {
+			pi, pj := out[i].p50(), out[j].p50()
+			if pi < 0 || pj < 0 {
+				return pi >= 0 && pj < 0
+			}
+			return pi < pj
+		})
+	}
+	return append(out, skipped...)
+}
+
+// probe times a getLatestBlockhash against ep, feeding its latency window
+// and breaker; returns the round-trip in ms or -1 on failure
+func (c *RPCClient) probe(ctx context.Context, ep *endpoint) int64 {
+	req := RPCRequest{JSONRPC: "2.0", ID: 1, Method: "getLatestBlockhash"}
+	var result BlockhashResult
+
+	start := time.Now()
+	if err := c.callURL(ctx, ep, req, &result); err != nil {
+		c.recordFailure(ep)
+		log.Debug().Err(err).Str("endpoint", ep.host()).Msg("RPC latency probe failed")
+		return -1
+	}
+	ms := time.Since(start).Milliseconds()
+
+	c.mu.Lock()
+	ep.addLatency(uint64(ms))
+	c.mu.Unlock()
+	c.recordSuccess(ep)
+	return ms
+}
+
+// RunLatencyProbe probes every endpoint each interval until ctx is done, so
+// calls go to the fastest healthy one
+func (c *RPCClient) RunLatencyProbe(ctx context.Context, interval time.Duration) {
+	c.setProbing(true)
+	defer c.setProbing(false)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, ep := range c.endpoints {
+			probeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			c.probe(probeCtx, ep)
+			cancel()
+		}
+		if best := c.BestEndpoint(); best.P50Ms >= 0 {
+			log.Debug().Str("endpoint", best.Host).Int64("p50Ms", best.P50Ms).Msg("RPC latency probed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *RPCClient) setProbing(on bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probing = on
+}
+
+// BestEndpoint returns the endpoint calls currently go to first (zero value if none)
+func (c *RPCClient) BestEndpoint() EndpointStat {
+	eps := c.candidates()
+	if len(eps) == 0 {
+		return EndpointStat{P50Ms: -1}
+	}
+	return c.stat(eps[0], c.now())
+}
+
+func (c *RPCClient) recordFailure(ep *endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ep.failures++
+	ep.totalFailures++
+	ep.lastFailure = c.now()
+
+	if ep.failures >= c.failureThreshold && !ep.circuitOpen {
+		ep.circuitOpen = true
+		log.Warn().Str("endpoint", ep.host()).Int("failures", ep.failures).Msg("RPC circuit breaker opened")
+	}
+}
+
+func (c *RPCClient) recordSuccess(ep *endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ep.failures = 0
+	ep.circuitOpen = false
+}
+
+// EndpointStats returns every endpoint's health, in failover order
+func (c *RPCClient) EndpointStats() []EndpointStat {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := c.now()
+	out := make([]EndpointStat, len(c.endpoints))
+	for i, ep := range c.endpoints {
+		out[i] = c.statLocked(ep, now)
+	}
+	return out
+}
+
+func (c *RPCClient) stat(ep *endpoint, now time.Time) EndpointStat {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.statLocked(ep, now)
+}
+
+// statLocked snapshots ep; caller holds c.mu
+func (c *RPCClient) statLocked(ep *endpoint, now time.Time) EndpointStat {
+	return EndpointStat{
+		Host:          ep.host(),
+		Failures:      ep.failures,
+		TotalFailures: ep.totalFailures,
+		CircuitOpen:   c.open(ep, now),
+		P50Ms:         ep.p50(),
+	}
+}
+
+// WritePrometheus writes per-endpoint latency and breaker state in Prometheus
+// text exposition format, labelled by host. Latency is only reported once
+// probed (rpc.latency_probe_seconds).
+func (c *RPCClient) WritePrometheus(w io.Writer) {
+	stats := c.EndpointStats()
+	family := func(name, typ, help string, value func(EndpointStat) (interface{}, bool)) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+		for _, st := range stats {
+			if v, ok := value(st); ok {
+				fmt.Fprintf(w, "%s{endpoint=%q} %v\n", name, st.Host, v)
+			}
+		}
+	}
+	family("pumpbot_rpc_latency_p50_ms", "gauge", "Median probed RPC latency in milliseconds.",
+		func(st EndpointStat) (interface{}, bool) { return st.P50Ms, st.P50Ms >= 0 })
+	family("pumpbot_rpc_failures_total", "counter", "Failed RPC calls.",
+		func(st EndpointStat) (interface{}, bool) { return st.TotalFailures, true })
+	family("pumpbot_rpc_circuit_open", "gauge", "1 while the endpoint's circuit breaker is open.",
+		func(st EndpointStat) (interface{}, bool) {
+			if st.CircuitOpen {
+				return 1, true
+			}
+			return 0, true
+		})
+}
@@ -1,17 +1,33 @@
 package blockchain
 
 import (
+	"regexp"
 	"strings"
 )
 
 // TxError represents a human-readable transaction error
 type TxError struct {
-	Code    int
-	Raw     string
-	Message string
-	Action  string
+	Code     int
+	Raw      string
+	Category string // Short bucket for aggregation (see Category* constants)
+	Message  string
+	Action   string
 }
 
+// Error categories used to group failures (TUI issues panel, stats)
+const (
+	CategoryBalance    = "balance"
+	CategorySlippage   = "slippage"
+	CategoryBlockhash  = "blockhash"
+	CategoryRateLimit  = "rate_limit"
+	CategoryAccount    = "account"
+	CategoryCompute    = "compute"
+	CategoryProgram    = "program"
+	CategoryNetwork    = "network"
+	CategorySimulation = "simulation"
+	CategoryUnknown    = "unknown"
+)
+
 func (e *TxError) Error() string {
 	return e.Message
 }
@@ -36,83 +52,101 @@ func ParseTxError(err error) *TxError {
 	
 	// Insufficient balance
 	case contains(raw, "no record of a prior credit"):
+		txErr.Category = CategoryBalance
 		txErr.Message = "❌ INSUFFICIENT BALANCE - Wallet has 0 SOL"
 		txErr.Action = "Fund wallet with SOL"
 	
 	case contains(raw, "insufficient funds"):
+		txErr.Category = CategoryBalance
 		txErr.Message = "❌ INSUFFICIENT BALANCE - Not enough SOL for trade + fees"
 		txErr.Action = "Add more SOL to wallet"
 	
 	case contains(raw, "insufficient lamports"):
+		txErr.Category = CategoryBalance
 		txErr.Message = "❌ INSUFFICIENT BALANCE - Not enough lamports"
 		txErr.Action = "Add more SOL to wallet"
 
 	// Slippage / Price errors
 	case contains(raw, "slippage"):
+		txErr.Category = CategorySlippage
 		txErr.Message = "❌ SLIPPAGE TOO HIGH - Price moved too much"
 		txErr.Action = "Increase slippage_bps in config"
 	
 	case contains(raw, "ExceededSlippage"):
+		txErr.Category = CategorySlippage
 		txErr.Message = "❌ SLIPPAGE EXCEEDED - Market moved against you"
 		txErr.Action = "Try again or increase slippage"
 
 	// Blockhash expired
 	case contains(raw, "blockhash not found"):
+		txErr.Category = CategoryBlockhash
 		txErr.Message = "❌ BLOCKHASH EXPIRED - Transaction took too long"
 		txErr.Action = "Retry immediately"
 	
 	case contains(raw, "block height exceeded"):
+		txErr.Category = CategoryBlockhash
 		txErr.Message = "❌ TRANSACTION EXPIRED - Blockhash too old"
 		txErr.Action = "Retry immediately"
 
 	// Rate limiting
 	case contains(raw, "429"):
+		txErr.Category = CategoryRateLimit
 		txErr.Message = "⚠️ RATE LIMITED - Too many requests"
 		txErr.Action = "Wait and retry"
 	
 	case contains(raw, "rate limit"):
+		txErr.Category = CategoryRateLimit
 		txErr.Message = "⚠️ RATE LIMITED - RPC throttled"
 		txErr.Action = "Wait 1-2 seconds and retry"
 
 	// Account errors
 	case contains(raw, "account not found"):
+		txErr.Category = CategoryAccount
 		txErr.Message = "❌ TOKEN ACCOUNT NOT FOUND - You may not own this token"
 		txErr.Action = "Check if you have token balance"
 	
 	case contains(raw, "AccountNotFound"):
+		txErr.Category = CategoryAccount
 		txErr.Message = "❌ ACCOUNT MISSING - Required account doesn't exist"
 		txErr.Action = "Token may need ATA creation"
 
 	// Compute budget
 	case contains(raw, "compute budget exceeded"):
+		txErr.Category = CategoryCompute
 		txErr.Message = "❌ OUT OF COMPUTE - Transaction too complex"
 		txErr.Action = "Increase compute unit limit"
 
 	// Program errors
 	case contains(raw, "custom program error"):
+		txErr.Category = CategoryProgram
 		txErr.Message = "❌ PROGRAM ERROR - DEX rejected the swap"
 		txErr.Action = "Check token liquidity"
 	
 	case contains(raw, "0x1"):
+		txErr.Category = CategoryProgram
 		txErr.Message = "❌ INSUFFICIENT FUNDS IN POOL"
 		txErr.Action = "Token may have low liquidity"
 
 	// Network errors
 	case contains(raw, "connection refused"):
+		txErr.Category = CategoryNetwork
 		txErr.Message = "❌ RPC CONNECTION FAILED"
 		txErr.Action = "Check internet connection"
 	
 	case contains(raw, "timeout"):
+		txErr.Category = CategoryNetwork
 		txErr.Message = "⚠️ RPC TIMEOUT - Network slow"
 		txErr.Action = "Retry"
 
 	// Simulation errors
 	case contains(raw, "simulation failed"):
+		txErr.Category = CategorySimulation
 		txErr.Message = "❌ SIMULATION FAILED - Transaction would fail on-chain"
 		txErr.Action = "Check logs for specific reason"
 
 	// Default
 	default:
+		txErr.Category = CategoryUnknown
 		txErr.Message = "❌ TRANSACTION FAILED"
 		txErr.Action = "Check raw error"
 	}
@@ -138,6 +172,44 @@ func HumanErrorWithAction(err error) string {
 	return txErr.Message + " → " + txErr.Action
 }
 
+// amountErrorPatterns mark failures caused by the amount being sold rather than
+// price or network: the token program refusing to move the full balance
+// (e.g. a Token-2022 transfer fee or rounding leaves the account a few base
+// units short) or Jupiter rejecting the input amount.
+var amountErrorPatterns = []string{
+	"insufficient funds",
+	"InsufficientFunds",
+	"INVALID_AMOUNT",
+	"amount exceeds",
+}
+
+// splInsufficientFunds matches SPL Token error 0x1 (InsufficientFunds) but not
+// longer codes such as 0x1771 (Jupiter slippage)
+var splInsufficientFunds = regexp.MustCompile(`custom program error: 0x1\b`)
+
+// IsAmountError reports whether err looks like the sell amount itself was
+// rejected, as opposed to slippage or network issues
+func IsAmountError(err error) bool {
+	if err == nil {
+		return false
+	}
+	raw := err.Error()
+	for _, p := range amountErrorPatterns {
+		if contains(raw, p) {
+			return true
+		}
+	}
+	return splInsufficientFunds.MatchString(raw)
+}
+
+// ErrorCategory returns the aggregation bucket for an error ("" for nil)
+func ErrorCategory(err error) string {
+	if err == nil {
+		return ""
+	}
+	return ParseTxError(err).Category
+}
+
 func contains(s, substr string) bool {
 	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
@@ -0,0 +1,26 @@
+package blockchain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsAmountError(t *testing.T) {
+	cases := []struct {
+		msg  string
+		want bool
+	}{
+		{"Transaction simulation failed: Error processing Instruction 3: custom program error: 0x1", true},
+		{"Program log: Error: insufficient funds", true},
+		{"custom program error: 0x1771", false}, // Jupiter slippage
+		{"blockhash not found", false},
+	}
+	for _, c := range cases {
+		if got := IsAmountError(errors.New(c.msg)); got != c.want {
+			t.Errorf("IsAmountError(%q) = %v, want %v", c.msg, got, c.want)
+		}
+	}
+	if IsAmountError(nil) {
+		t.Error("IsAmountError(nil) = true")
+	}
+}
@@ -0,0 +1,105 @@
+package blockchain
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FeeEstimator turns recent network prioritization fees into a priority fee
+// budget in lamports: the configured percentile of per-CU fees, times the
+// compute units a swap is assumed to use, clamped to [min, max].
+type FeeEstimator struct {
+	rpc          *RPCClient
+	accounts     []string
+	percentile   float64
+	computeUnits uint32
+	minLamports  uint64
+	maxLamports  uint64
+}
+
+// NewFeeEstimator creates an estimator; accounts narrows the fee sample to
+// transactions writing to them (empty = all)
+func NewFeeEstimator(rpc *RPCClient, accounts []string, percentile float64, computeUnits uint32, minLamports, maxLamports uint64) *FeeEstimator {
+	return &FeeEstimator{
+		rpc:          rpc,
+		accounts:     accounts,
+		percentile:   percentile,
+		computeUnits: computeUnits,
+		minLamports:  minLamports,
+		maxLamports:  maxLamports,
+	}
+}
+
+// Estimate fetches recent fees and returns the priority fee budget in lamports
+func (f *FeeEstimator) Estimate(ctx context.Context) (uint64, error) {
+	fees, err := f.rpc.GetRecentPrioritizationFees(ctx, f.accounts)
+	if err != nil {
+		return 0, err
+	}
+	return f.lamportsFor(fees), nil
+}
+
+// lamportsFor converts per-CU fee samples (micro-lamports) to a clamped budget
+func (f *FeeEstimator) lamportsFor(fees []uint64) uint64 {
+	microPerCU := Percentile(fees, f.percentile)
+	lamports := microPerCU * uint64(f.computeUnits) / 1_000_000
+	if lamports < f.minLamports {
+		lamports = f.minLamports
+	}
+	if f.maxLamports > 0 && lamports > f.maxLamports {
+		lamports = f.maxLamports
+	}
+	return lamports
+}
+
+// Run re-estimates every interval until ctx is done, passing each estimate to
+// apply (e.g. the Jupiter priority fee cap). Failed estimates keep the last value.
+func (f *FeeEstimator) Run(ctx context.Context, interval time.Duration, apply func(lamports uint64)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last uint64
+	for {
+		estimateCtx, cancel := context.WithTimeout(ctx, interval)
+		lamports, err := f.Estimate(estimateCtx)
+		cancel()
+		if err != nil {
+			log.Debug().Err(err).Msg("priority fee estimate failed")
+		} else if lamports != last {
+			last = lamports
+			apply(lamports)
+			log.Debug().
+				Uint64("maxLamports", lamports).
+				Float64("percentile", f.percentile).
+				Msg("priority fee cap updated from recent fees")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Percentile returns the nearest-rank p-th percentile (0-100) of values (0 if empty)
+func Percentile(values []uint64, p float64) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]uint64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,33 @@
+package blockchain
+
+import "testing"
+
+func TestPercentile(t *testing.T) {
+	values := []uint64{50, 10, 40, 20, 30}
+	cases := map[float64]uint64{0: 10, 50: 30, 75: 40, 100: 50}
+	for p, want := range cases {
+		if got := Percentile(values, p); got != want {
+			t.Errorf("p%.0f = %d, want %d", p, got, want)
+		}
+	}
+	if got := Percentile(nil, 75); got != 0 {
+		t.Errorf("empty p75 = %d, want 0", got)
+	}
+}
+
+func TestFeeEstimator_LamportsClamped(t *testing.T) {
+	f := NewFeeEstimator(nil, nil, 75, 600_000, 100_000, 5_000_000)
+
+	// p75 of 1000..4000 micro-lamports/CU = 3000 -> 3000 * 600k / 1e6 = 1800 lamports -> floor
+	if got := f.lamportsFor([]uint64{1000, 2000, 3000, 4000}); got != 100_000 {
+		t.Errorf("quiet network = %d, want floor 100000", got)
+	}
+	// 5M micro-lamports/CU -> 3M lamports
+	if got := f.lamportsFor([]uint64{5_000_000, 5_000_000}); got != 3_000_000 {
+		t.Errorf("busy network = %d, want 3000000", got)
+	}
+	// 50M micro-lamports/CU -> 30M lamports -> ceiling
+	if got := f.lamportsFor([]uint64{50_000_000}); got != 5_000_000 {
+		t.Errorf("congested network = %d, want ceiling 5000000", got)
+	}
+}
@@ -107,6 +107,54 @@ func (m *CachedKeyManager) Refresh() error {
 	return nil
 }
 
+// LoadCached returns the cached wallet regardless of age (nil if no cache file).
+// Used by wallet rotation, which must never silently replace the old key.
+func (m *CachedKeyManager) LoadCached() (*Wallet, error) {
+	data, err := os.ReadFile(m.keyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var cached CachedKeyData
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+	return NewWallet(cached.PrivateKey)
+}
+
+// Backup copies the current cache file to wallet_cache.<address>.bak.json
+// and returns its path
+func (m *CachedKeyManager) Backup(address string) (string, error) {
+	data, err := os.ReadFile(m.keyPath)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(filepath.Dir(m.keyPath), "wallet_cache."+address+".bak.json")
+	return path, os.WriteFile(path, data, 0600)
+}
+
+// Generate creates a fresh key in memory without touching the cache file;
+// call Save to make it the cached wallet
+func (m *CachedKeyManager) Generate() (*Wallet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.generateNewKey(); err != nil {
+		return nil, err
+	}
+	return m.createWallet()
+}
+
+// Save writes the current in-memory key to the cache file
+func (m *CachedKeyManager) Save() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.saveToCache()
+}
+
 func (m *CachedKeyManager) loadFromCache() bool {
 	data, err := os.ReadFile(m.keyPath)
 	if err != nil {
@@ -3,10 +3,14 @@ package blockchain
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,16 +19,18 @@ import (
 
 // RPCClient handles Solana RPC calls
 type RPCClient struct {
-	primaryURL   string
-	fallbackURL  string
-	apiKey       string
-	httpClient   *http.Client
-	
-	// Circuit breaker state
-	mu           sync.RWMutex
-	failures     int
-	lastFailure  time.Time
-	circuitOpen  bool
+	endpoints  []*endpoint // failover order (see endpoints.go)
+	httpClient *http.Client
+
+	// Circuit breaker state of every endpoint
+	mu               sync.RWMutex
+	failureThreshold int
+	resetAfter       time.Duration
+	now              func() time.Time // injectable for tests
+
+	// RunLatencyProbe is running: healthy endpoints are ordered by latency.
+	// Without it the odd display probe would reorder them on one sample.
+	probing bool
 }
 
 // RPCRequest is the JSON-RPC 2.0 request format
@@ -69,8 +75,17 @@ type BalanceResult struct {
 // SendTxResult is the result of sendTransaction
 type SendTxResult string
 
-// NewRPCClient creates a new RPC client
+// NewRPCClient creates a new RPC client with a primary and one fallback endpoint
 func NewRPCClient(primaryURL, fallbackURL, apiKey string) *RPCClient {
+	return NewRPCClientMulti([]RPCEndpoint{
+		{URL: primaryURL, APIKey: apiKey},
+		{URL: fallbackURL, APIKey: apiKey},
+	})
+}
+
+// NewRPCClientMulti creates an RPC client that fails over through endpoints
+// in order (empty URLs are dropped)
+func NewRPCClientMulti(endpoints []RPCEndpoint) *RPCClient {
 	// Configure HTTP transport for keep-alives and connection pooling
 	transport := &http.Transport{
 		MaxIdleConns:        100,
@@ -78,14 +93,29 @@ func NewRPCClient(primaryURL, fallbackURL, apiKey string) *RPCClient {
 		IdleConnTimeout:     90 * time.Second,
 	}
 
-	return &RPCClient{
-		primaryURL:  primaryURL,
-		fallbackURL: fallbackURL,
-		apiKey:      apiKey,
+	c := &RPCClient{
 		httpClient: &http.Client{
 			Timeout:   30 * time.Second,
 			Transport: transport,
 		},
+		failureThreshold: CircuitFailureThreshold,
+		resetAfter:       CircuitResetAfter,
+		now:              time.Now,
+	}
+	for _, ep := range endpoints {
+		if ep.URL != "" {
+			c.endpoints = append(c.endpoints, &endpoint{RPCEndpoint: ep})
+		}
+	}
+	return c
+}
+
+// SetDialContext routes RPC connections through a custom dialer (e.g. IPv4-only, cached DNS).
+// Call before the client is used; existing idle connections are closed.
+func (c *RPCClient) SetDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) {
+	if t, ok := c.httpClient.Transport.(*http.Transport); ok {
+		t.DialContext = dial
+		t.CloseIdleConnections()
 	}
 }
 
@@ -106,6 +136,54 @@ func (c *RPCClient) GetLatestBlockhash(ctx context.Context) (*BlockhashResult, e
 	return &result, nil
 }
 
+// GetBlockHeight fetches the current block height. A transaction whose
+// lastValidBlockHeight is below this can no longer land.
+func (c *RPCClient) GetBlockHeight(ctx context.Context) (uint64, error) {
+	req := RPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "getBlockHeight",
+		Params:  []interface{}{map[string]string{"commitment": "confirmed"}},
+	}
+
+	var height uint64
+	if err := c.call(ctx, req, &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+// GetRecentPrioritizationFees returns the prioritization fees (micro-lamports
+// per compute unit) paid in recent slots by transactions writing to accounts
+// (all transactions if accounts is empty)
+func (c *RPCClient) GetRecentPrioritizationFees(ctx context.Context, accounts []string) ([]uint64, error) {
+	params := []interface{}{}
+	if len(accounts) > 0 {
+		params = append(params, accounts)
+	}
+	req := RPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "getRecentPrioritizationFees",
+		Params:  params,
+	}
+
+	var result []struct {
+		Slot              uint64 `json:"slot"`
+		PrioritizationFee uint64 `json:"prioritizationFee"`
+	}
+	if err := c.call(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	fees := make([]uint64, len(result))
+	for i, r := range result {
+		fees[i] = r.PrioritizationFee
+	}
+	return fees, nil
+}
+
 // GetBalance fetches the SOL balance for a public key
 func (c *RPCClient) GetBalance(ctx context.Context, pubkey string) (uint64, error) {
 	req := RPCRequest{
@@ -148,6 +226,65 @@ func (c *RPCClient) SendTransaction(ctx context.Context, signedTx string, skipPr
 	return string(result), nil
 }
 
+// SimResult is the outcome of simulateTransaction
+type SimResult struct {
+	Err           json.RawMessage // null when the transaction would succeed
+	Logs          []string        // program logs
+	UnitsConsumed uint64
+}
+
+// Failed reports whether the simulated transaction would fail on chain
+func (r *SimResult) Failed() bool {
+	return len(r.Err) > 0 && string(r.Err) != "null"
+}
+
+// Error describes a failed simulation: the error plus the last program log
+// line that mentions one (where e.g. ExceededSlippage shows up)
+func (r *SimResult) Error() string {
+	msg := "Transaction simulation failed: " + string(r.Err)
+	for i := len(r.Logs) - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(r.Logs[i]), "error") {
+			return msg + ": " + r.Logs[i]
+		}
+	}
+	return msg
+}
+
+// SimulateTransaction runs a signed base64 transaction against current state
+// without sending it. A transaction that would fail is not an error here;
+// check SimResult.Failed.
+func (c *RPCClient) SimulateTransaction(ctx context.Context, signedTx string) (*SimResult, error) {
+	req := RPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "simulateTransaction",
+		Params: []interface{}{
+			signedTx,
+			map[string]interface{}{
+				"encoding":   "base64",
+				"commitment": "processed",
+			},
+		},
+	}
+
+	var result struct {
+		Value struct {
+			Err           json.RawMessage `json:"err"`
+			Logs          []string        `json:"logs"`
+			UnitsConsumed uint64          `json:"unitsConsumed"`
+		} `json:"value"`
+	}
+	if err := c.call(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &SimResult{
+		Err:           result.Value.Err,
+		Logs:          result.Value.Logs,
+		UnitsConsumed: result.Value.UnitsConsumed,
+	}, nil
+}
+
 // GetTokenAccountBalance fetches SPL token balance
 func (c *RPCClient) GetTokenAccountBalance(ctx context.Context, tokenAccount string) (uint64, uint8, error) {
 	req := RPCRequest{
@@ -174,38 +311,50 @@ func (c *RPCClient) GetTokenAccountBalance(ctx context.Context, tokenAccount str
 }
 
 func (c *RPCClient) call(ctx context.Context, req RPCRequest, result interface{}) error {
-	// Check circuit breaker
-	if c.isCircuitOpen() {
-		// Try fallback
-		return c.callURL(ctx, c.fallbackURL, req, result)
-	}
-
-	err := c.callURL(ctx, c.primaryURL, req, result)
-	if err != nil {
-		c.recordFailure()
-		// Try fallback
-		log.Warn().Err(err).Msg("primary RPC failed, trying fallback")
-		return c.callURL(ctx, c.fallbackURL, req, result)
-	}
-
-	c.recordSuccess()
-	return nil
+	var lastErr error
+	for i, ep := range c.candidates() {
+		if i > 0 {
+			if ctx.Err() != nil {
+				return lastErr
+			}
+			log.Warn().Err(lastErr).Str("next", ep.host()).Msg("RPC endpoint failed, trying next")
+		}
+
+		err := c.callURL(ctx, ep, req, result)
+		if err == nil {
+			c.recordSuccess(ep)
+			return nil
+		}
+		// JSON-RPC errors come from a healthy node (e.g. a failed simulation),
+		// so replaying them against the next endpoint would only duplicate the call
+		var rpcErr *RPCError
+		if errors.As(err, &rpcErr) {
+			c.recordSuccess(ep)
+			return err
+		}
+		c.recordFailure(ep)
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no RPC endpoints configured")
+	}
+	return lastErr
 }
 
-func (c *RPCClient) callURL(ctx context.Context, url string, rpcReq RPCRequest, result interface{}) error {
+func (c *RPCClient) callURL(ctx context.Context, ep *endpoint, rpcReq RPCRequest, result interface{}) error {
 	body, err := json.Marshal(rpcReq)
 	if err != nil {
 		return fmt.Errorf("marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", ep.URL, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	if c.apiKey != "" {
-		req.Header.Set("x-api-key", c.apiKey)
+	if ep.APIKey != "" {
+		req.Header.Set("x-api-key", ep.APIKey)
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -236,56 +385,17 @@ func (c *RPCClient) callURL(ctx context.Context, url string, rpcReq RPCRequest,
 	return nil
 }
 
-// Circuit breaker methods
-func (c *RPCClient) isCircuitOpen() bool {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	if !c.circuitOpen {
-		return false
-	}
-
-	// Check if circuit should reset (30 seconds)
-	if time.Since(c.lastFailure) > 30*time.Second {
-		return false
-	}
-
-	return true
-}
-
-func (c *RPCClient) recordFailure() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.failures++
-	c.lastFailure = time.Now()
-
-	// Open circuit after 5 consecutive failures
-	if c.failures >= 5 {
-		c.circuitOpen = true
-		log.Warn().Msg("RPC circuit breaker opened")
-	}
-}
-
-func (c *RPCClient) recordSuccess() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.failures = 0
-	c.circuitOpen = false
-}
-
-// LatencyMs returns estimated latency to RPC (for display)
+// LatencyMs returns estimated latency to RPC (for display): one probe of the
+// endpoint calls currently prefer, which also feeds its rolling p50
 func (c *RPCClient) LatencyMs() int64 {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	start := time.Now()
-	_, err := c.GetLatestBlockhash(ctx)
-	if err != nil {
+	eps := c.candidates()
+	if len(eps) == 0 {
 		return -1
 	}
-	return time.Since(start).Milliseconds()
+	return c.probe(ctx, eps[0])
 }
 
 // SignatureStatus represents the status of a transaction signature
@@ -360,6 +470,148 @@ func (c *RPCClient) CheckTransaction(ctx context.Context, signature string) (*Tx
 	return result, nil
 }
 
+// TxDetails is a confirmed transaction's accounting view: fees paid and
+// the SOL and token balances around it
+type TxDetails struct {
+	Slot              uint64
+	Fee               uint64 // lamports, paid by the first account
+	AccountKeys       []string
+	PreBalances       []uint64 // lamports, indexed like AccountKeys
+	PostBalances      []uint64
+	PreTokenBalances  []TokenBalance
+	PostTokenBalances []TokenBalance
+	Err               interface{} // nil = success
+}
+
+// TokenBalance is one token account's balance in a transaction's meta
+type TokenBalance struct {
+	AccountIndex int
+	Mint         string
+	Owner        string
+	Amount       uint64 // raw units
+	Decimals     uint8
+}
+
+// ErrTransactionNotFound is returned by GetTransaction while a transaction
+// has not reached "confirmed" yet (or was never seen)
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// rpcTokenBalance is the jsonParsed shape of pre/postTokenBalances entries
+type rpcTokenBalance struct {
+	AccountIndex  int    `json:"accountIndex"`
+	Mint          string `json:"mint"`
+	Owner         string `json:"owner"`
+	UITokenAmount struct {
+		Amount   string `json:"amount"`
+		Decimals uint8  `json:"decimals"`
+	} `json:"uiTokenAmount"`
+}
+
+// GetTransaction fetches a confirmed transaction with jsonParsed meta
+func (c *RPCClient) GetTransaction(ctx context.Context, signature string) (*TxDetails, error) {
+	req := RPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "getTransaction",
+		Params: []interface{}{
+			signature,
+			map[string]interface{}{
+				"encoding":                       "jsonParsed",
+				"commitment":                     "confirmed",
+				"maxSupportedTransactionVersion": 0,
+			},
+		},
+	}
+
+	var result *struct {
+		Slot        uint64 `json:"slot"`
+		Transaction struct {
+			Message struct {
+				AccountKeys []struct {
+					Pubkey string `json:"pubkey"`
+				} `json:"accountKeys"`
+			} `json:"message"`
+		} `json:"transaction"`
+		Meta *struct {
+			Fee               uint64            `json:"fee"`
+			PreBalances       []uint64          `json:"preBalances"`
+			PostBalances      []uint64          `json:"postBalances"`
+			PreTokenBalances  []rpcTokenBalance `json:"preTokenBalances"`
+			PostTokenBalances []rpcTokenBalance `json:"postTokenBalances"`
+			Err               interface{}       `json:"err"`
+		} `json:"meta"`
+	}
+	if err := c.call(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	if result == nil || result.Meta == nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	details := &TxDetails{
+		Slot:              result.Slot,
+		Fee:               result.Meta.Fee,
+		PreBalances:       result.Meta.PreBalances,
+		PostBalances:      result.Meta.PostBalances,
+		PreTokenBalances:  tokenBalances(result.Meta.PreTokenBalances),
+		PostTokenBalances: tokenBalances(result.Meta.PostTokenBalances),
+		Err:               result.Meta.Err,
+	}
+	for _, key := range result.Transaction.Message.AccountKeys {
+		details.AccountKeys = append(details.AccountKeys, key.Pubkey)
+	}
+	return details, nil
+}
+
+func tokenBalances(raw []rpcTokenBalance) []TokenBalance {
+	out := make([]TokenBalance, 0, len(raw))
+	for _, b := range raw {
+		var amount uint64
+		fmt.Sscanf(b.UITokenAmount.Amount, "%d", &amount)
+		out = append(out, TokenBalance{
+			AccountIndex: b.AccountIndex,
+			Mint:         b.Mint,
+			Owner:        b.Owner,
+			Amount:       amount,
+			Decimals:     b.UITokenAmount.Decimals,
+		})
+	}
+	return out
+}
+
+// SolDelta returns how much owner's SOL balance changed in lamports
+// (negative = spent), fees included for the fee payer
+func (t *TxDetails) SolDelta(owner string) (int64, error) {
+	for i, key := range t.AccountKeys {
+		if key != owner {
+			continue
+		}
+		if i >= len(t.PreBalances) || i >= len(t.PostBalances) {
+			return 0, fmt.Errorf("no balances for account %s", owner)
+		}
+		return int64(t.PostBalances[i]) - int64(t.PreBalances[i]), nil
+	}
+	return 0, fmt.Errorf("account %s not in transaction", owner)
+}
+
+// TokenDelta returns how much of mint owner's token accounts gained (raw
+// units, negative = sent). Accounts opened or closed by the transaction
+// count as zero on the missing side.
+func (t *TxDetails) TokenDelta(owner, mint string) int64 {
+	var delta int64
+	for _, b := range t.PostTokenBalances {
+		if b.Owner == owner && b.Mint == mint {
+			delta += int64(b.Amount)
+		}
+	}
+	for _, b := range t.PreTokenBalances {
+		if b.Owner == owner && b.Mint == mint {
+			delta -= int64(b.Amount)
+		}
+	}
+	return delta
+}
+
 // TxCheckResult is a human-readable transaction check result
 type TxCheckResult struct {
 	Signature          string
@@ -381,6 +633,23 @@ func (r *TxCheckResult) String() string {
 	return fmt.Sprintf("⏳ %s | %s", r.Status, r.Message)
 }
 
+// commitmentRank orders Solana commitment levels from weakest to strongest
+var commitmentRank = map[string]int{
+	"processed": 1,
+	"confirmed": 2,
+	"finalized": 3,
+}
+
+// CommitmentReached reports whether a confirmationStatus satisfies the required level.
+// An unknown required level is treated as "confirmed".
+func CommitmentReached(status, required string) bool {
+	want, ok := commitmentRank[required]
+	if !ok {
+		want = commitmentRank["confirmed"]
+	}
+	return commitmentRank[status] >= want
+}
+
 // TokenAccountInfo holds token account data
 type TokenAccountInfo struct {
 	Address  string
@@ -389,15 +658,215 @@ type TokenAccountInfo struct {
 	Decimals uint8
 }
 
+// SPL token program IDs (classic and Token-2022)
+const (
+	TokenProgramID     = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	Token2022ProgramID = "TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb"
+)
+
+// MemcmpFilter matches program accounts whose data at Offset equals Bytes (base58)
+type MemcmpFilter struct {
+	Offset int
+	Bytes  string
+}
+
+// ProgramAccount is an account returned by getProgramAccounts
+type ProgramAccount struct {
+	Pubkey string
+	Data   []byte
+}
+
+// GetProgramAccounts fetches a program's accounts of dataSize bytes matching every filter
+func (c *RPCClient) GetProgramAccounts(ctx context.Context, programID string, dataSize int, filters []MemcmpFilter) ([]ProgramAccount, error) {
+	rpcFilters := []interface{}{map[string]interface{}{"dataSize": dataSize}}
+	for _, f := range filters {
+		rpcFilters = append(rpcFilters, map[string]interface{}{
+			"memcmp": map[string]interface{}{"offset": f.Offset, "bytes": f.Bytes},
+		})
+	}
+	req := RPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "getProgramAccounts",
+		Params: []interface{}{
+			programID,
+			map[string]interface{}{
+				"encoding":   "base64",
+				"commitment": "confirmed",
+				"filters":    rpcFilters,
+			},
+		},
+	}
+
+	var result []struct {
+		Pubkey  string `json:"pubkey"`
+		Account struct {
+			Data []string `json:"data"` // [base64_data, "base64"]
+		} `json:"account"`
+	}
+	if err := c.call(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]ProgramAccount, 0, len(result))
+	for _, r := range result {
+		if len(r.Account.Data) == 0 {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(r.Account.Data[0])
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", r.Pubkey, err)
+		}
+		accounts = append(accounts, ProgramAccount{Pubkey: r.Pubkey, Data: data})
+	}
+	return accounts, nil
+}
+
+// MaxMultipleAccounts is the most pubkeys one getMultipleAccounts accepts
+const MaxMultipleAccounts = 100
+
+// GetMultipleAccounts fetches token accounts (jsonParsed) in as few calls as
+// the per-request limit allows. The result is index-aligned with pubkeys:
+// nil marks an account that does not exist (e.g. closed after a full sell),
+// and an account that is not an SPL token account comes back with no Mint.
+func (c *RPCClient) GetMultipleAccounts(ctx context.Context, pubkeys []string) ([]*TokenAccountInfo, error) {
+	accounts := make([]*TokenAccountInfo, 0, len(pubkeys))
+	for start := 0; start < len(pubkeys); start += MaxMultipleAccounts {
+		end := start + MaxMultipleAccounts
+		if end > len(pubkeys) {
+			end = len(pubkeys)
+		}
+		batch := pubkeys[start:end]
+		req := RPCRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  "getMultipleAccounts",
+			Params: []interface{}{
+				batch,
+				map[string]string{
+					"encoding":   "jsonParsed",
+					"commitment": "confirmed",
+				},
+			},
+		}
+
+		var result struct {
+			Value []*struct {
+				Data json.RawMessage `json:"data"` // parsed object, or [base64, "base64"] for non-token accounts
+			} `json:"value"`
+		}
+		if err := c.call(ctx, req, &result); err != nil {
+			return nil, err
+		}
+		if len(result.Value) != len(batch) {
+			return nil, fmt.Errorf("getMultipleAccounts: %d accounts for %d pubkeys", len(result.Value), len(batch))
+		}
+
+		for i, v := range result.Value {
+			if v == nil {
+				accounts = append(accounts, nil)
+				continue
+			}
+			info := &TokenAccountInfo{Address: batch[i]}
+			var data struct {
+				Parsed struct {
+					Info struct {
+						Mint        string `json:"mint"`
+						TokenAmount struct {
+							Amount   string `json:"amount"`
+							Decimals uint8  `json:"decimals"`
+						} `json:"tokenAmount"`
+					} `json:"info"`
+				} `json:"parsed"`
+			}
+			if json.Unmarshal(v.Data, &data) == nil {
+				info.Mint = data.Parsed.Info.Mint
+				info.Decimals = data.Parsed.Info.TokenAmount.Decimals
+				fmt.Sscanf(data.Parsed.Info.TokenAmount.Amount, "%d", &info.Amount)
+			}
+			accounts = append(accounts, info)
+		}
+	}
+	return accounts, nil
+}
+
+// GetAccountsData fetches the raw data of accounts (base64) in as few calls as
+// the per-request limit allows, index-aligned with pubkeys: nil marks an
+// account that does not exist
+func (c *RPCClient) GetAccountsData(ctx context.Context, pubkeys []string) ([][]byte, error) {
+	accounts := make([][]byte, 0, len(pubkeys))
+	for start := 0; start < len(pubkeys); start += MaxMultipleAccounts {
+		end := start + MaxMultipleAccounts
+		if end > len(pubkeys) {
+			end = len(pubkeys)
+		}
+		batch := pubkeys[start:end]
+		req := RPCRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  "getMultipleAccounts",
+			Params: []interface{}{
+				batch,
+				map[string]string{
+					"encoding":   "base64",
+					"commitment": "confirmed",
+				},
+			},
+		}
+
+		var result struct {
+			Value []*struct {
+				Data []string `json:"data"` // [base64_data, "base64"]
+			} `json:"value"`
+		}
+		if err := c.call(ctx, req, &result); err != nil {
+			return nil, err
+		}
+		if len(result.Value) != len(batch) {
+			return nil, fmt.Errorf("getMultipleAccounts: %d accounts for %d pubkeys", len(result.Value), len(batch))
+		}
+
+		for i, v := range result.Value {
+			if v == nil || len(v.Data) == 0 {
+				accounts = append(accounts, nil)
+				continue
+			}
+			data, err := base64.StdEncoding.DecodeString(v.Data[0])
+			if err != nil {
+				return nil, fmt.Errorf("account %s: %w", batch[i], err)
+			}
+			accounts = append(accounts, data)
+		}
+	}
+	return accounts, nil
+}
+
 // GetTokenAccountsByOwner fetches all token accounts for an owner and mint
 func (c *RPCClient) GetTokenAccountsByOwner(ctx context.Context, owner, mint string) ([]TokenAccountInfo, error) {
+	return c.getTokenAccounts(ctx, owner, map[string]string{"mint": mint})
+}
+
+// GetAllTokenAccounts fetches every token account an owner holds (both token programs)
+func (c *RPCClient) GetAllTokenAccounts(ctx context.Context, owner string) ([]TokenAccountInfo, error) {
+	var all []TokenAccountInfo
+	for _, program := range []string{TokenProgramID, Token2022ProgramID} {
+		accounts, err := c.getTokenAccounts(ctx, owner, map[string]string{"programId": program})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, accounts...)
+	}
+	return all, nil
+}
+
+func (c *RPCClient) getTokenAccounts(ctx context.Context, owner string, filter map[string]string) ([]TokenAccountInfo, error) {
 	req := RPCRequest{
 		JSONRPC: "2.0",
 		ID:      1,
 		Method:  "getTokenAccountsByOwner",
 		Params: []interface{}{
 			owner,
-			map[string]string{"mint": mint},
+			filter,
 			map[string]string{
 				"encoding": "jsonParsed",
 			},
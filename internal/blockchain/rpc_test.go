@@ -0,0 +1,335 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// cannedGetTransaction is a trimmed jsonParsed getTransaction response for a
+// sell: the owner swaps 1,000,000 tokens for ~0.048 SOL and pays a 5000 fee
+const cannedGetTransaction = `{"jsonrpc":"2.0","id":1,"result":{
+	"slot": 123,
+	"transaction": {"message": {"accountKeys": [
+		{"pubkey": "Owner111", "signer": true, "writable": true, "source": "transaction"},
+		{"pubkey": "OwnerATA", "signer": false, "writable": true, "source": "transaction"},
+		{"pubkey": "PoolVault", "signer": false, "writable": true, "source": "lookupTable"}
+	]}},
+	"meta": {
+		"err": null,
+		"fee": 5000,
+		"preBalances": [1000000000, 2039280, 50000000000],
+		"postBalances": [1048000000, 2039280, 49952005000],
+		"preTokenBalances": [
+			{"accountIndex": 1, "mint": "Mint111", "owner": "Owner111", "uiTokenAmount": {"amount": "1000000", "decimals": 6}}
+		],
+		"postTokenBalances": [
+			{"accountIndex": 1, "mint": "Mint111", "owner": "Owner111", "uiTokenAmount": {"amount": "0", "decimals": 6}}
+		]
+	}
+}}`
+
+func TestGetTransaction_ParsesBalanceDeltas(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(cannedGetTransaction))
+	}))
+	defer srv.Close()
+
+	tx, err := NewRPCClient(srv.URL, srv.URL, "").GetTransaction(context.Background(), "Sig111")
+	if err != nil {
+		t.Fatalf("GetTransaction: %v", err)
+	}
+	if !strings.Contains(body, `"encoding":"jsonParsed"`) || !strings.Contains(body, `"maxSupportedTransactionVersion":0`) {
+		t.Errorf("request missing jsonParsed/v0 options: %s", body)
+	}
+
+	if tx.Slot != 123 || tx.Fee != 5000 {
+		t.Errorf("slot/fee = %d/%d, want 123/5000", tx.Slot, tx.Fee)
+	}
+	if got, err := tx.SolDelta("Owner111"); err != nil || got != 48_000_000 {
+		t.Errorf("SolDelta = %d, %v; want 48000000", got, err)
+	}
+	if _, err := tx.SolDelta("Stranger"); err == nil {
+		t.Error("SolDelta for an account not in the transaction should fail")
+	}
+	if got := tx.TokenDelta("Owner111", "Mint111"); got != -1_000_000 {
+		t.Errorf("TokenDelta = %d, want -1000000", got)
+	}
+	if got := tx.TokenDelta("Owner111", "OtherMint"); got != 0 {
+		t.Errorf("TokenDelta for another mint = %d, want 0", got)
+	}
+}
+
+func TestGetTransaction_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewRPCClient(srv.URL, srv.URL, "").GetTransaction(context.Background(), "Sig111")
+	if !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("err = %v, want ErrTransactionNotFound", err)
+	}
+}
+
+// balanceServer answers getBalance with lamports, or 503 while down is set
+func balanceServer(t *testing.T, lamports uint64, down *bool, hits *int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*hits++
+		if *down {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"value":%d}}`, lamports)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRPCClientMulti_FailsOverInOrderAndSkipsOpenCircuits(t *testing.T) {
+	downA, downB, downC := true, true, false
+	var hitsA, hitsB, hitsC int
+	a := balanceServer(t, 1, &downA, &hitsA)
+	b := balanceServer(t, 2, &downB, &hitsB)
+	c := balanceServer(t, 3, &downC, &hitsC)
+
+	client := NewRPCClientMulti([]RPCEndpoint{{URL: a.URL}, {URL: b.URL}, {URL: ""}, {URL: c.URL}})
+	if got := len(client.EndpointStats()); got != 3 {
+		t.Fatalf("endpoints = %d, want 3 (empty URL dropped)", got)
+	}
+
+	for i := 0; i < CircuitFailureThreshold; i++ {
+		bal, err := client.GetBalance(context.Background(), "Owner111")
+		if err != nil || bal != 3 {
+			t.Fatalf("call %d: balance = %d, %v; want 3 from the third endpoint", i, bal, err)
+		}
+	}
+	if hitsA != CircuitFailureThreshold || hitsB != CircuitFailureThreshold {
+		t.Errorf("hits a/b = %d/%d, want %d each before their circuits open", hitsA, hitsB, CircuitFailureThreshold)
+	}
+
+	stats := client.EndpointStats()
+	if !stats[0].CircuitOpen || !stats[1].CircuitOpen || stats[2].CircuitOpen {
+		t.Errorf("circuits = %v/%v/%v, want open/open/closed", stats[0].CircuitOpen, stats[1].CircuitOpen, stats[2].CircuitOpen)
+	}
+
+	// Flaky endpoints are now skipped outright
+	if _, err := client.GetBalance(context.Background(), "Owner111"); err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if hitsA != CircuitFailureThreshold || hitsB != CircuitFailureThreshold {
+		t.Errorf("open-circuit endpoints were called again: hits a/b = %d/%d", hitsA, hitsB)
+	}
+
+	// With every endpoint down the open ones are still tried, last error wins
+	downC = true
+	if _, err := client.GetBalance(context.Background(), "Owner111"); err == nil {
+		t.Error("GetBalance succeeded with every endpoint down")
+	}
+	if hitsA != CircuitFailureThreshold+1 {
+		t.Errorf("hits a = %d, want open endpoints retried once all are failing", hitsA)
+	}
+}
+
+func TestRPCClientMulti_RPCErrorDoesNotFailOver(t *testing.T) {
+	var hitsB int
+	a := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32002,"message":"Transaction simulation failed"}}`))
+	}))
+	defer a.Close()
+	down := false
+	b := balanceServer(t, 2, &down, &hitsB)
+
+	client := NewRPCClientMulti([]RPCEndpoint{{URL: a.URL}, {URL: b.URL}})
+	var rpcErr *RPCError
+	if _, err := client.GetBalance(context.Background(), "Owner111"); !errors.As(err, &rpcErr) {
+		t.Fatalf("err = %v, want the node's RPCError", err)
+	}
+	if hitsB != 0 {
+		t.Errorf("fallback hit %d times after a JSON-RPC error, want 0", hitsB)
+	}
+}
+
+func TestRPCClientMulti_PrefersLowestLatencyHealthyEndpoint(t *testing.T) {
+	client := NewRPCClientMulti([]RPCEndpoint{
+		{URL: "http://primary.example"},
+		{URL: "http://fast.example"},
+		{URL: "http://medium.example"},
+		{URL: "http://unmeasured.example"},
+	})
+	eps := client.endpoints
+	for _, ms := range []uint64{80, 90, 500} { // p50 90 despite one slow outlier
+		eps[0].addLatency(ms)
+	}
+	eps[1].addLatency(20)
+	eps[2].addLatency(40)
+
+	hosts := func() []string {
+		var out []string
+		for _, ep := range client.candidates() {
+			out = append(out, ep.host())
+		}
+		return out
+	}
+	// Without the latency probe running the configured order stands
+	want := "primary.example fast.example medium.example unmeasured.example"
+	if got := strings.Join(hosts(), " "); got != want {
+		t.Errorf("order without probing = %s, want %s", got, want)
+	}
+
+	client.setProbing(true)
+	want = "fast.example medium.example primary.example unmeasured.example"
+	if got := strings.Join(hosts(), " "); got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+	if best := client.BestEndpoint(); best.Host != "fast.example" || best.P50Ms != 20 {
+		t.Errorf("BestEndpoint = %+v, want fast.example at 20ms", best)
+	}
+
+	// An open circuit demotes the fastest endpoint behind every healthy one
+	for i := 0; i < CircuitFailureThreshold; i++ {
+		client.recordFailure(eps[1])
+	}
+	want = "medium.example primary.example unmeasured.example fast.example"
+	if got := strings.Join(hosts(), " "); got != want {
+		t.Errorf("order with fast.example tripped = %s, want %s", got, want)
+	}
+	if best := client.BestEndpoint(); best.Host != "medium.example" {
+		t.Errorf("BestEndpoint = %s, want medium.example", best.Host)
+	}
+}
+
+func TestEndpointLatencyWindowRolls(t *testing.T) {
+	ep := &endpoint{}
+	for i := 0; i < LatencyWindow; i++ {
+		ep.addLatency(1000)
+	}
+	for i := 0; i < LatencyWindow/2+1; i++ {
+		ep.addLatency(10)
+	}
+	if got := ep.p50(); got != 10 {
+		t.Errorf("p50 = %d, want 10 once recent samples are the majority", got)
+	}
+}
+
+func TestRPCClient_CircuitBreakerThresholdAndReset(t *testing.T) {
+	down := true
+	var hits int
+	a := balanceServer(t, 1, &down, &hits)
+	downB := false
+	var hitsB int
+	b := balanceServer(t, 2, &downB, &hitsB)
+
+	client := NewRPCClientMulti([]RPCEndpoint{{URL: a.URL}, {URL: b.URL}})
+	client.SetCircuitBreaker(3, 10*time.Second)
+	now := time.Unix(1_700_000_000, 0)
+	client.now = func() time.Time { return now }
+
+	for i := 1; i <= 3; i++ {
+		if _, err := client.GetBalance(context.Background(), "Owner111"); err != nil {
+			t.Fatalf("GetBalance: %v", err)
+		}
+		if open := client.EndpointStats()[0].CircuitOpen; open != (i == 3) {
+			t.Fatalf("after %d failures circuit open = %v, want %v", i, open, i == 3)
+		}
+	}
+
+	// Still inside the reset window: the primary is skipped
+	now = now.Add(10 * time.Second)
+	client.GetBalance(context.Background(), "Owner111")
+	if hits != 3 {
+		t.Errorf("primary hits = %d within reset window, want 3", hits)
+	}
+
+	// Past it the primary is tried again and a success closes the breaker
+	now = now.Add(time.Second)
+	down = false
+	if bal, err := client.GetBalance(context.Background(), "Owner111"); err != nil || bal != 1 {
+		t.Fatalf("balance after reset = %d, %v; want 1 from the primary", bal, err)
+	}
+	if st := client.EndpointStats()[0]; st.CircuitOpen || st.Failures != 0 {
+		t.Errorf("primary after recovery = %+v, want closed with 0 failures", st)
+	}
+}
+
+func TestRPCClient_WritePrometheusLabelsByHost(t *testing.T) {
+	client := NewRPCClientMulti([]RPCEndpoint{
+		{URL: "https://rpc.example.com/?api_key=SECRET"},
+		{URL: "https://backup.example.org"},
+	})
+	client.endpoints[0].addLatency(42)
+
+	var b strings.Builder
+	client.WritePrometheus(&b)
+	out := b.String()
+	for _, want := range []string{
+		`pumpbot_rpc_latency_p50_ms{endpoint="rpc.example.com"} 42` + "\n",
+		`pumpbot_rpc_failures_total{endpoint="backup.example.org"} 0` + "\n",
+		`pumpbot_rpc_circuit_open{endpoint="rpc.example.com"} 0` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `latency_p50_ms{endpoint="backup.example.org"}`) {
+		t.Error("unprobed endpoint reported a latency")
+	}
+	if strings.Contains(out, "SECRET") {
+		t.Error("output leaks the endpoint URL")
+	}
+}
+
+func TestGetMultipleAccounts_ParsesBatch(t *testing.T) {
+	var calls int
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"context":{"slot":1},"value":[
+			{"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","data":{"program":"spl-token","parsed":{"type":"account","info":{"mint":"MintA","owner":"Owner111","tokenAmount":{"amount":"1500000","decimals":6}}}}},
+			null,
+			{"lamports":1000000,"owner":"11111111111111111111111111111111","data":["","base64"]},
+			{"lamports":2039280,"owner":"TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb","data":{"program":"spl-token-2022","parsed":{"type":"account","info":{"mint":"MintB","owner":"Owner111","tokenAmount":{"amount":"42","decimals":9}}}}}
+		]}}`))
+	}))
+	defer srv.Close()
+
+	accounts, err := NewRPCClient(srv.URL, srv.URL, "").GetMultipleAccounts(context.Background(), []string{"AccA", "Closed", "Wallet", "AccB"})
+	if err != nil {
+		t.Fatalf("GetMultipleAccounts: %v", err)
+	}
+	if calls != 1 || !strings.Contains(body, `["AccA","Closed","Wallet","AccB"]`) || !strings.Contains(body, `"encoding":"jsonParsed"`) {
+		t.Errorf("%d calls, request %s; want one jsonParsed call with every pubkey", calls, body)
+	}
+	if len(accounts) != 4 {
+		t.Fatalf("got %d accounts, want 4", len(accounts))
+	}
+	if a := accounts[0]; a == nil || a.Address != "AccA" || a.Mint != "MintA" || a.Amount != 1_500_000 || a.Decimals != 6 {
+		t.Errorf("accounts[0] = %+v", a)
+	}
+	if accounts[1] != nil {
+		t.Errorf("closed account = %+v, want nil", accounts[1])
+	}
+	if a := accounts[2]; a == nil || a.Mint != "" || a.Amount != 0 {
+		t.Errorf("non-token account = %+v, want no mint", a)
+	}
+	if a := accounts[3]; a == nil || a.Mint != "MintB" || a.Amount != 42 {
+		t.Errorf("accounts[3] = %+v", a)
+	}
+}
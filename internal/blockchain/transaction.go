@@ -1,8 +1,10 @@
 package blockchain
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/binary"
+	"fmt"
 
 	"github.com/mr-tron/base58"
 )
@@ -24,13 +26,19 @@ func NewTransactionBuilder(wallet *Wallet, blockhashCache *BlockhashCache, prior
 		wallet:              wallet,
 		blockhashCache:      blockhashCache,
 		priorityFeeLamports: priorityFeeLamports,
-		computeUnitLimit:    600000, // Default for Jupiter swaps (bumped for reliability)
+		computeUnitLimit:    DefaultComputeUnitLimit,
 	}
 }
 
-// SetComputeUnitLimit sets the compute unit limit
+// SetComputeUnitLimit sets the compute unit limit (fees.compute_unit_limit);
+// 0 restores the default, values above MaxComputeUnitLimit are capped
 func (b *TransactionBuilder) SetComputeUnitLimit(limit uint32) {
-	b.computeUnitLimit = limit
+	b.computeUnitLimit = clampComputeUnitLimit(uint64(limit))
+}
+
+// ComputeUnitLimit returns the limit BuildComputeBudgetInstructions uses
+func (b *TransactionBuilder) ComputeUnitLimit() uint32 {
+	return b.computeUnitLimit
 }
 
 // BuildComputeBudgetInstructions creates the compute budget instructions
@@ -44,8 +52,8 @@ func (b *TransactionBuilder) BuildComputeBudgetInstructions() (setLimit []byte,
 	// SetComputeUnitPrice instruction (instruction type 3)
 	// Format: [1 byte instruction type] [8 bytes microLamports per CU]
 	// Calculate: priorityFeeLamports / computeUnitLimit = microLamports per CU
-	microLamportsPerCU := (b.priorityFeeLamports * 1_000_000) / uint64(b.computeUnitLimit)
-	
+	microLamportsPerCU := MicroLamportsPerCU(b.priorityFeeLamports, b.computeUnitLimit)
+
 	setPrice = make([]byte, 9)
 	setPrice[0] = 3 // SetComputeUnitPrice
 	binary.LittleEndian.PutUint64(setPrice[1:], microLamportsPerCU)
@@ -60,6 +68,12 @@ func ComputeBudgetProgramIDBytes() []byte {
 }
 
 // SignSerializedTransaction signs a base64-encoded transaction from Jupiter
+//
+// Wire format (legacy and v0): [compact-u16 signature count][64-byte
+// signatures...][message]. The message header lists the required signers as
+// its first account keys, in signature-slot order, so our signature goes in
+// the slot whose key is the wallet (usually 0, the fee payer); other slots
+// may already be filled by co-signers and are left alone.
 func (b *TransactionBuilder) SignSerializedTransaction(serializedTxBase64 string) (string, error) {
 	// Decode the transaction
 	txBytes, err := base64.StdEncoding.DecodeString(serializedTxBase64)
@@ -67,50 +81,110 @@ func (b *TransactionBuilder) SignSerializedTransaction(serializedTxBase64 string
 		return "", err
 	}
 
-	// Solana versioned transaction format:
-	// [signature count] [signatures...] [message]
-	// We need to sign the message and prepend our signature
-
-	// For Jupiter swap transactions, they are typically versioned (v0)
-	// The message starts after the signature section
-	
-	// Find message portion (skip signature count and placeholder signatures)
-	// First byte is signature count in compact-u16 format
-	sigCount := int(txBytes[0])
+	sigCount, n, err := decodeCompactU16(txBytes)
+	if err != nil {
+		return "", fmt.Errorf("signature count: %w", err)
+	}
 	if sigCount == 0 {
-		// Message starts at byte 1
-		message := txBytes[1:]
+		// Unsigned message: build [1][signature][message]
+		message := txBytes[n:]
 		signature := b.wallet.Sign(message)
-		
-		// Build signed transaction: [1 sig count][signature][message]
-		signedTx := make([]byte, 1+64+len(message))
-		signedTx[0] = 1 // 1 signature
-		copy(signedTx[1:65], signature)
-		copy(signedTx[65:], message)
-		
+
+		signedTx := make([]byte, 0, 1+64+len(message))
+		signedTx = append(signedTx, encodeCompactU16(1)...)
+		signedTx = append(signedTx, signature...)
+		signedTx = append(signedTx, message...)
+
 		return base64.StdEncoding.EncodeToString(signedTx), nil
 	}
 
-	// If there are already signatures, we need to fill in ours
-	// Position 0: signature count (1 byte for counts < 128)
-	// Position 1-64: first signature slot (64 bytes)
-	// After that: more signatures and then the message
-
-	sigOffset := 1 // Skip sig count byte
+	sigOffset := n
 	messageOffset := sigOffset + sigCount*64
-
-	// Extract message
+	if messageOffset > len(txBytes) {
+		return "", fmt.Errorf("transaction truncated: %d signatures need %d bytes, have %d", sigCount, messageOffset, len(txBytes))
+	}
 	message := txBytes[messageOffset:]
-	
-	// Sign message
-	signature := b.wallet.Sign(message)
 
-	// Copy signature into first slot
-	copy(txBytes[sigOffset:sigOffset+64], signature)
+	slot, err := signerSlot(message, b.wallet.PublicKey(), sigCount)
+	if err != nil {
+		return "", err
+	}
+
+	// Sign message into our slot
+	signature := b.wallet.Sign(message)
+	start := sigOffset + slot*64
+	copy(txBytes[start:start+64], signature)
 
 	return base64.StdEncoding.EncodeToString(txBytes), nil
 }
 
+// signerSlot returns the signature slot for pubkey: its index among the
+// message's required signers. Messages too short to carry a header and
+// account keys (the simulation placeholder transaction) use slot 0.
+func signerSlot(message, pubkey []byte, sigCount int) (int, error) {
+	pos := 0
+	if len(message) > 0 && message[0]&0x80 != 0 {
+		pos = 1 // Versioned message prefix (0x80 | version)
+	}
+	if len(message) < pos+3 {
+		return 0, nil
+	}
+	numSigners := int(message[pos])
+	if numSigners != sigCount {
+		return 0, fmt.Errorf("message requires %d signatures, transaction has %d slots", numSigners, sigCount)
+	}
+	pos += 3 // numRequiredSignatures, numReadonlySigned, numReadonlyUnsigned
+
+	numKeys, n, err := decodeCompactU16(message[pos:])
+	if err != nil {
+		return 0, nil
+	}
+	pos += n
+	if numKeys < numSigners || len(message) < pos+numSigners*32 {
+		return 0, nil
+	}
+	for i := 0; i < numSigners; i++ {
+		key := message[pos+i*32 : pos+(i+1)*32]
+		if bytes.Equal(key, pubkey) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("wallet %s is not a required signer of this transaction", base58.Encode(pubkey))
+}
+
+// decodeCompactU16 reads Solana's compact-u16 (shortvec) length prefix:
+// 7 bits per byte, low bits first, high bit set on all but the last byte
+// (at most 3 bytes). Returns the value and the number of bytes read.
+func decodeCompactU16(b []byte) (int, int, error) {
+	value := 0
+	for i := 0; i < 3; i++ {
+		if i >= len(b) {
+			return 0, 0, fmt.Errorf("compact-u16 truncated")
+		}
+		value |= int(b[i]&0x7f) << (7 * i)
+		if b[i]&0x80 == 0 {
+			if value > 0xffff {
+				return 0, 0, fmt.Errorf("compact-u16 overflow")
+			}
+			return value, i + 1, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("compact-u16 longer than 3 bytes")
+}
+
+// encodeCompactU16 writes v in compact-u16 (shortvec) form
+func encodeCompactU16(v int) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
 // GetRecentBlockhash returns the current cached blockhash
 func (b *TransactionBuilder) GetRecentBlockhash() (string, error) {
 	return b.blockhashCache.Get()
@@ -1,7 +1,9 @@
 package blockchain
 
 import (
+	"bytes"
 	"crypto/ed25519"
+	"encoding/base64"
 	"testing"
 	"github.com/mr-tron/base58"
 )
@@ -45,3 +47,86 @@ func TestSignSerializedTransaction_SimulationDummy(t *testing.T) {
 	// Ensure it didn't crash and returned something
 	t.Logf("Signed dummy tx: %s", signedTx)
 }
+
+// Unsigned v0 fixtures for the wallet with seed 0x01*32: one with the wallet as
+// sole signer, one where a co-signer (seed 0x02*32) already filled slot 0 and
+// the wallet is the second required signer.
+const (
+	v0OneSignerTx = "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAACAAQABAoqI4910CfGV/VLbLTy6XXLKZwm/HZQSG/N0iAG0D29cAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQAA"
+	v0TwoSignerTx = "ArcGqbH2m63tBBUJ7G6owKog9dQ1cSY/RgU7YMGN+GWzRdhmVFBY/ZV2Kwl2uXlk1e4ZRB3e9R3H5PyVNbyecwMAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAgAIAAQOBOXcOqH0XX1ajVGbDTH7My42KkbTuN6Jd9g9bj8mzlIqI4910CfGV/VLbLTy6XXLKZwm/HZQSG/N0iAG0D29cAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQAA"
+)
+
+func fixtureBuilder(t *testing.T) (*TransactionBuilder, ed25519.PublicKey) {
+	t.Helper()
+	priv := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, 32))
+	wallet, err := NewWallet(base58.Encode(priv))
+	if err != nil {
+		t.Fatalf("wallet: %v", err)
+	}
+	return NewTransactionBuilder(wallet, nil, 0), priv.Public().(ed25519.PublicKey)
+}
+
+func TestSignSerializedTransaction_V0Slots(t *testing.T) {
+	tb, pub := fixtureBuilder(t)
+
+	cases := []struct {
+		name string
+		tx   string
+		slot int
+	}{
+		{"one signer", v0OneSignerTx, 0},
+		{"second of two signers", v0TwoSignerTx, 1},
+	}
+	for _, c := range cases {
+		orig, _ := base64.StdEncoding.DecodeString(c.tx)
+		signedB64, err := tb.SignSerializedTransaction(c.tx)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		signed, _ := base64.StdEncoding.DecodeString(signedB64)
+		if len(signed) != len(orig) {
+			t.Fatalf("%s: length changed %d -> %d", c.name, len(orig), len(signed))
+		}
+
+		sigCount := int(orig[0])
+		message := signed[1+sigCount*64:]
+		sig := signed[1+c.slot*64 : 1+(c.slot+1)*64]
+		if !ed25519.Verify(pub, message, sig) {
+			t.Errorf("%s: slot %d does not hold a valid wallet signature", c.name, c.slot)
+		}
+		for i := 0; i < sigCount; i++ {
+			if i == c.slot {
+				continue
+			}
+			if !bytes.Equal(signed[1+i*64:1+(i+1)*64], orig[1+i*64:1+(i+1)*64]) {
+				t.Errorf("%s: co-signer slot %d was overwritten", c.name, i)
+			}
+		}
+	}
+}
+
+func TestSignSerializedTransaction_RejectsForeignTx(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(nil)
+	wallet, _ := NewWallet(base58.Encode(priv))
+	tb := NewTransactionBuilder(wallet, nil, 0)
+
+	if _, err := tb.SignSerializedTransaction(v0OneSignerTx); err == nil {
+		t.Error("signed a transaction the wallet is not a signer of")
+	}
+}
+
+func TestCompactU16(t *testing.T) {
+	for _, v := range []int{0, 1, 127, 128, 300, 16383, 16384, 0xffff} {
+		enc := encodeCompactU16(v)
+		got, n, err := decodeCompactU16(enc)
+		if err != nil || got != v || n != len(enc) {
+			t.Errorf("round trip %d: got %d (%d bytes, err %v), encoded %x", v, got, n, err, enc)
+		}
+	}
+	if got, n, _ := decodeCompactU16([]byte{0x80, 0x01}); got != 128 || n != 2 {
+		t.Errorf("decode 0x80 0x01 = %d (%d bytes), want 128 (2 bytes)", got, n)
+	}
+	if _, _, err := decodeCompactU16([]byte{0x80}); err == nil {
+		t.Error("truncated compact-u16 decoded without error")
+	}
+}
@@ -0,0 +1,122 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+)
+
+// Solana constants used by hand-built transactions
+const (
+	// LamportsPerSignature is the base fee per signature
+	LamportsPerSignature = 5000
+	// RentExemptMinimumLamports is the rent-exempt minimum for a 0-data system account
+	RentExemptMinimumLamports = 890_880
+
+	systemTransferInstruction    = 2
+	tokenCloseAccountInstruction = 9
+)
+
+// SystemProgramID is the all-zero System Program address
+var SystemProgramID = make([]byte, 32)
+
+// BuildSOLTransfer builds and signs a legacy transaction that moves lamports
+// from the wallet to a base58 address. Returns the base64 wire format
+// accepted by SendTransaction.
+func BuildSOLTransfer(from *Wallet, to string, lamports uint64, recentBlockhash string) (string, error) {
+	toKey, err := base58.Decode(to)
+	if err != nil || len(toKey) != 32 {
+		return "", fmt.Errorf("invalid destination address %q", to)
+	}
+	blockhash, err := base58.Decode(recentBlockhash)
+	if err != nil || len(blockhash) != 32 {
+		return "", fmt.Errorf("invalid blockhash %q", recentBlockhash)
+	}
+
+	// Message: header, account keys, blockhash, instructions
+	msg := []byte{
+		1, // required signatures (from)
+		0, // read-only signed accounts
+		1, // read-only unsigned accounts (system program)
+		3, // account key count
+	}
+	msg = append(msg, from.PublicKey()...)
+	msg = append(msg, toKey...)
+	msg = append(msg, SystemProgramID...)
+	msg = append(msg, blockhash...)
+
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data[0:4], systemTransferInstruction)
+	binary.LittleEndian.PutUint64(data[4:12], lamports)
+
+	msg = append(msg,
+		1,    // instruction count
+		2,    // program id index (system program)
+		2,    // account index count
+		0, 1, // from, to
+		byte(len(data)),
+	)
+	msg = append(msg, data...)
+
+	tx := make([]byte, 0, 1+64+len(msg))
+	tx = append(tx, 1) // signature count
+	tx = append(tx, from.Sign(msg)...)
+	tx = append(tx, msg...)
+	return base64.StdEncoding.EncodeToString(tx), nil
+}
+
+// BuildCloseTokenAccount builds and signs a legacy transaction that closes
+// one of the wallet's token accounts, sending its lamports to destination.
+// For a wrapped SOL account that unwraps the balance and frees its rent; any
+// other account must be empty. Returns the base64 wire format.
+func BuildCloseTokenAccount(owner *Wallet, account, destination, recentBlockhash string) (string, error) {
+	accountKey, err := base58.Decode(account)
+	if err != nil || len(accountKey) != 32 {
+		return "", fmt.Errorf("invalid token account %q", account)
+	}
+	destKey, err := base58.Decode(destination)
+	if err != nil || len(destKey) != 32 {
+		return "", fmt.Errorf("invalid destination address %q", destination)
+	}
+	programKey, _ := base58.Decode(TokenProgramID)
+	blockhash, err := base58.Decode(recentBlockhash)
+	if err != nil || len(blockhash) != 32 {
+		return "", fmt.Errorf("invalid blockhash %q", recentBlockhash)
+	}
+
+	// Account keys: owner (signer, fee payer), token account, destination
+	// unless it is the owner, token program (read-only)
+	keys := [][]byte{owner.PublicKey(), accountKey}
+	destIndex := byte(0)
+	if destination != owner.Address() {
+		keys = append(keys, destKey)
+		destIndex = 2
+	}
+	keys = append(keys, programKey)
+
+	msg := []byte{
+		1, // required signatures (owner)
+		0, // read-only signed accounts
+		1, // read-only unsigned accounts (token program)
+		byte(len(keys)),
+	}
+	for _, k := range keys {
+		msg = append(msg, k...)
+	}
+	msg = append(msg, blockhash...)
+	msg = append(msg,
+		1,                 // instruction count
+		byte(len(keys)-1), // program id index (token program)
+		3,                 // account index count
+		1, destIndex, 0,   // account, destination, owner
+		1, tokenCloseAccountInstruction, // data: length, CloseAccount
+	)
+
+	tx := make([]byte, 0, 1+64+len(msg))
+	tx = append(tx, 1) // signature count
+	tx = append(tx, owner.Sign(msg)...)
+	tx = append(tx, msg...)
+	return base64.StdEncoding.EncodeToString(tx), nil
+}
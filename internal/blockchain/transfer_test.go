@@ -0,0 +1,85 @@
+package blockchain
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/mr-tron/base58"
+)
+
+func TestBuildSOLTransfer_SignedLegacyMessage(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(nil)
+	from, err := NewWallet(base58.Encode(priv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	toPub, _, _ := ed25519.GenerateKey(nil)
+	blockhash := base58.Encode(make([]byte, 32))
+
+	encoded, err := BuildSOLTransfer(from, base58.Encode(toPub), 1_234_567, blockhash)
+	if err != nil {
+		t.Fatalf("BuildSOLTransfer: %v", err)
+	}
+	tx, _ := base64.StdEncoding.DecodeString(encoded)
+
+	if tx[0] != 1 {
+		t.Fatalf("signature count = %d, want 1", tx[0])
+	}
+	sig, msg := tx[1:65], tx[65:]
+	if !ed25519.Verify(from.PublicKey(), msg, sig) {
+		t.Error("signature does not verify against message")
+	}
+	// Lamports are the last 8 bytes of the transfer instruction data
+	if got := binary.LittleEndian.Uint64(msg[len(msg)-8:]); got != 1_234_567 {
+		t.Errorf("lamports = %d, want 1234567", got)
+	}
+
+	if _, err := BuildSOLTransfer(from, "not-an-address", 1, blockhash); err == nil {
+		t.Error("expected error for invalid destination")
+	}
+}
+
+func TestBuildCloseTokenAccount_ClosesToDestination(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(nil)
+	owner, err := NewWallet(base58.Encode(priv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountPub, _, _ := ed25519.GenerateKey(nil)
+	destPub, _, _ := ed25519.GenerateKey(nil)
+	blockhash := base58.Encode(make([]byte, 32))
+
+	encoded, err := BuildCloseTokenAccount(owner, base58.Encode(accountPub), base58.Encode(destPub), blockhash)
+	if err != nil {
+		t.Fatalf("BuildCloseTokenAccount: %v", err)
+	}
+	tx, _ := base64.StdEncoding.DecodeString(encoded)
+	sig, msg := tx[1:65], tx[65:]
+	if !ed25519.Verify(owner.PublicKey(), msg, sig) {
+		t.Error("signature does not verify against message")
+	}
+	if msg[3] != 4 {
+		t.Fatalf("account key count = %d, want 4", msg[3])
+	}
+	if got := base58.Encode(msg[4+3*32 : 4+4*32]); got != TokenProgramID {
+		t.Errorf("last account key = %s, want the token program", got)
+	}
+	// Instruction: program 3, accounts (account, destination, owner), CloseAccount
+	ix := msg[len(msg)-7:]
+	if want := []byte{3, 3, 1, 2, 0, 1, tokenCloseAccountInstruction}; string(ix) != string(want) {
+		t.Errorf("instruction = %v, want %v", ix, want)
+	}
+
+	// Closing to the owner itself reuses its key
+	encoded, err = BuildCloseTokenAccount(owner, base58.Encode(accountPub), owner.Address(), blockhash)
+	if err != nil {
+		t.Fatalf("BuildCloseTokenAccount to owner: %v", err)
+	}
+	tx, _ = base64.StdEncoding.DecodeString(encoded)
+	msg = tx[65:]
+	if ix := msg[len(msg)-7:]; string(ix) != string([]byte{2, 3, 1, 0, 0, 1, tokenCloseAccountInstruction}) {
+		t.Errorf("instruction closing to owner = %v", ix)
+	}
+}
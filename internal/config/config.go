@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
@@ -22,6 +24,10 @@ type Config struct {
 	Storage    StorageConfig    `mapstructure:"storage"`
 	TUI        TUIConfig        `mapstructure:"tui"`
 	WebSocket  WebSocketConfig  `mapstructure:"websocket"`
+	Tokens     TokensConfig     `mapstructure:"tokens"`
+	Notify     NotifyConfig     `mapstructure:"notify"`
+	Metrics    MetricsConfig    `mapstructure:"metrics"`
+	Shutdown   ShutdownConfig   `mapstructure:"shutdown"`
 }
 
 type WalletConfig struct {
@@ -33,62 +39,335 @@ type RPCConfig struct {
 	ShyftURL      string `mapstructure:"shyft_url"`
 	ShyftAPIKeyEnv string `mapstructure:"shyft_api_key_env"`
 	FallbackURL   string `mapstructure:"fallback_url"`
+	FallbackURLs  []string `mapstructure:"fallback_urls"` // more providers, tried in order after fallback_url
+
+	// Probe every endpoint this often and send calls to the fastest healthy
+	// one (rolling p50) instead of always the primary first
+	LatencyProbeSeconds int `mapstructure:"latency_probe_seconds"` // 0 = disabled
+
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+
+	// Connection setup (applies to RPC and Jupiter HTTP transports)
+	ForceIPv4          bool `mapstructure:"force_ipv4"`            // dial tcp4 only
+	DNSCacheTTLSeconds int  `mapstructure:"dns_cache_ttl_seconds"` // 0 = no in-process DNS cache
+}
+
+// CircuitBreakerConfig: an RPC endpoint failing FailureThreshold times in a
+// row is skipped for ResetSeconds
+type CircuitBreakerConfig struct {
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	ResetSeconds     int `mapstructure:"reset_seconds"`
 }
 
 type TradingConfig struct {
 	MinEntryPercent       float64 `mapstructure:"min_entry_percent"`
 	TakeProfitMultiple    float64 `mapstructure:"take_profit_multiple"`
 	MaxAllocPercent       float64 `mapstructure:"max_alloc_percent"`
+	ReserveSol            float64 `mapstructure:"reserve_sol"` // kept back from sizing for fees and ATA rent
 	MaxOpenPositions      int     `mapstructure:"max_open_positions"`
 	AutoTradingEnabled    bool    `mapstructure:"auto_trading_enabled"`
+
+	// How much of the alloc percent a buy uses (fixed or by signal strength)
+	Sizing SizingConfig `mapstructure:"sizing"`
 	
-	// Partial Profit-Taking (sell X% at Y multiple)
+	// Partial Profit-Taking ladder: each level sells its percent of the
+	// original position once, the first time its multiple is reached
+	PartialProfitLevels []ProfitLevel `mapstructure:"partial_profit_levels"`
+
+	// Deprecated: single-level form of partial_profit_levels, used when no levels are set
 	PartialProfitPercent  float64 `mapstructure:"partial_profit_percent"`  // e.g., 50 = sell 50%
 	PartialProfitMultiple float64 `mapstructure:"partial_profit_multiple"` // e.g., 1.5 = at 1.5X
 	
+	// Take-Profit Curve (cumulative fraction sold by multiple); replaces the
+	// all-or-nothing take_profit_multiple sell when set
+	TakeProfitCurve []TakeProfitPoint `mapstructure:"take_profit_curve"`
+
+	// Per-token overrides keyed by mint or symbol (see overrides.go)
+	TokenOverrides map[string]TokenOverride `mapstructure:"token_overrides"`
+
+	// Mints never bought, tracked or sold as positions (stables, WSOL)
+	IgnoredMints []string `mapstructure:"ignored_mints"`
+
+	// Buy filter (mints or symbols; symbols resolved to mints on load).
+	// A blacklisted token is never bought; a non-empty whitelist allows only its tokens.
+	Blacklist []string `mapstructure:"blacklist"`
+	Whitelist []string `mapstructure:"whitelist"`
+
+	// Startup adoption of untracked wallet holdings (see trading/adopt.go)
+	AdoptOrphansOnStartup bool    `mapstructure:"adopt_orphans_on_startup"`
+	MaxAdoptPositions     int     `mapstructure:"max_adopt_positions"`  // most valuable first
+	MinAdoptValueSol      float64 `mapstructure:"min_adopt_value_sol"` // holdings worth less are dust
+
+	// Rug defense from WebSocket pool updates: emergency-sell when the pool's
+	// SOL reserve falls under a floor or drops this much from entry
+	MinPoolLiquiditySol      float64 `mapstructure:"min_pool_liquidity_sol"`      // 0 = disabled
+	MaxLiquidityDropPercent  float64 `mapstructure:"max_liquidity_drop_percent"`  // 0 = disabled
+	LiquidityExitSlippageBps int     `mapstructure:"liquidity_exit_slippage_bps"` // slippage for that sell
+
+	// Global stop-loss: sell all once value falls this far below entry
+	StopLossPercent       float64 `mapstructure:"stop_loss_percent"` // e.g. 40 = sell at 0.6X; 0 = disabled
+
+	// Trailing stop: sell all once value retraces this % from its peak (arms past 1.2X)
+	TrailingStopPercent   float64 `mapstructure:"trailing_stop_percent"` // 0 = disabled
+
+	// After a partial take, raise the position's stop-loss to entry (1.0X)
+	MoveStopToBreakEvenAfterPartial bool `mapstructure:"move_stop_to_break_even_after_partial"`
+
 	// Time-Based Exit (auto-sell after X minutes)
 	MaxHoldMinutes        int     `mapstructure:"max_hold_minutes"` // 0 = disabled
 
+	// Absolute SOL give-back from peak value (peakMultiple * Size), e.g. 0.5
+	MaxGiveBackSol        float64 `mapstructure:"max_give_back_sol"` // 0 = disabled
+
+	// Daily loss cap: new entries are blocked (exits keep running) once
+	// realized losses since UTC midnight reach this many SOL, until midnight
+	MaxDailyLossSol       float64 `mapstructure:"max_daily_loss_sol"` // 0 = disabled
+
+	// Buys whose Jupiter quote moves the price more than this are refused
+	MaxPriceImpactPercent float64 `mapstructure:"max_price_impact_percent"` // e.g. 15; 0 = disabled
+
+	// Re-buy cooldown: entry signals for a mint bought within this window are skipped
+	RebuyCooldownSeconds  int     `mapstructure:"rebuy_cooldown_seconds"` // 0 = disabled
+
+	// Content dedup: a signal with the same token, type, rounded value and
+	// minute as one seen within this window is skipped even under a new msg ID
+	ContentDedupSeconds   int     `mapstructure:"content_dedup_seconds"` // 0 = disabled
+
+	// Signals processed at once; the rest wait in arrival order
+	MaxConcurrentTrades   int     `mapstructure:"max_concurrent_trades"`
+
+	// Monitor: a position whose balance hasn't changed is re-quoted only once
+	// its last quote is this old (price moves between quotes go unseen
+	// unless the WebSocket price feed tracks the pool)
+	RequoteUnchangedSeconds int   `mapstructure:"requote_unchanged_seconds"` // 0 = quote every pass
+
+	// Startup grace: entry signals are counted but not traded for this long after launch
+	StartupGraceSeconds   int     `mapstructure:"startup_grace_seconds"` // 0 = disabled
+
 	// Simulation
 	SimulationMode        bool    `mapstructure:"simulation_mode"`  // Enable for CLI test verification
+	// Seed prices for the simulated market, keyed by mint (unlisted mints
+	// use jupiter.DefaultSimPriceSOL and 6 decimals)
+	SimulationPrices map[string]SimulationPrice `mapstructure:"simulation_prices"`
+
+	// Paper trading: live Jupiter quotes, fills recorded at the quoted
+	// amount (flagged paper in the trades table), nothing ever sent.
+	// Buys are sized from a paper wallet; simulation_mode wins if both are on.
+	PaperTrading    bool    `mapstructure:"paper_trading"`
+	PaperBalanceSol float64 `mapstructure:"paper_balance_sol"` // paper wallet before any paper trade
+
+	// Simulate each signed swap before sending and drop it if it would fail
+	// (saves the fee on doomed sends at the cost of one RPC round-trip)
+	PreflightSimulate     bool    `mapstructure:"preflight_simulate"`
+
+	// Signals that name a token but have no parseable value/unit
+	ValuelessSignalAction string `mapstructure:"valueless_signal_action"` // entry | skip | review
+
+	// Sell Confirmation (position is only removed once the sell reaches this commitment)
+	SellConfirmCommitment     string `mapstructure:"sell_confirm_commitment"`      // processed | confirmed | finalized
+	SellConfirmTimeoutSeconds int    `mapstructure:"sell_confirm_timeout_seconds"` // give up waiting (position stays open)
+
+	// Full-balance sells rejected with an amount error (Token-2022 transfer-fee
+	// reserve, rounding) are retried once with balance * this factor
+	SellRetryAmountFactor float64 `mapstructure:"sell_retry_amount_factor"` // e.g. 0.999; 0 or >= 1 = disabled
+}
+
+// Sizing strategies (trading.sizing.strategy)
+const (
+	SizingFixed  = "fixed"  // every buy uses the alloc percent
+	SizingLinear = "linear" // scaled by the signal's % between min_entry_percent and full_size_percent
+)
+
+// SizingConfig scales buys with the signal: under linear, a call at
+// min_entry_percent buys MinAllocPercent of balance, rising in a straight
+// line to the (per-token) alloc percent at FullSizePercent and above
+type SizingConfig struct {
+	Strategy        string  `mapstructure:"strategy"`          // fixed (or empty) | linear
+	MinAllocPercent float64 `mapstructure:"min_alloc_percent"` // linear: alloc at min_entry_percent
+	FullSizePercent float64 `mapstructure:"full_size_percent"` // linear: signal % that buys the full alloc
+}
+
+// SimulationPrice seeds one mint's simulated price
+type SimulationPrice struct {
+	PriceSol float64 `mapstructure:"price_sol"` // SOL per whole token
+	Decimals int     `mapstructure:"decimals"`  // 0 = 6
+}
+
+// TakeProfitPoint is one point of the take-profit curve, e.g. {multiple: 2, fraction: 0.5}
+type TakeProfitPoint struct {
+	Multiple float64 `mapstructure:"multiple"`
+	Fraction float64 `mapstructure:"fraction"` // 0..1 of the original position
+}
+
+// ProfitLevel is one rung of the partial profit ladder, e.g. {multiple: 1.5, percent: 25}
+type ProfitLevel struct {
+	Multiple float64 `mapstructure:"multiple"`
+	Percent  float64 `mapstructure:"percent"` // of the original position
+}
+
+// ProfitLevels returns the partial profit ladder sorted by multiple, falling
+// back to the legacy partial_profit_percent/multiple pair as a single level
+func (t TradingConfig) ProfitLevels() []ProfitLevel {
+	levels := t.PartialProfitLevels
+	if len(levels) == 0 {
+		if t.PartialProfitPercent > 0 && t.PartialProfitMultiple > 1.0 {
+			return []ProfitLevel{{Multiple: t.PartialProfitMultiple, Percent: t.PartialProfitPercent}}
+		}
+		return nil
+	}
+	sorted := make([]ProfitLevel, len(levels))
+	copy(sorted, levels)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Multiple < sorted[j].Multiple })
+	return sorted
 }
 
 type FeesConfig struct {
 	StaticPriorityFeeSol float64 `mapstructure:"static_priority_fee_sol"`
 	StaticGasFeeSol      float64 `mapstructure:"static_gas_fee_sol"`
+
+	// Compute unit limit for transactions the bot builds itself (Jupiter
+	// swaps size their own); the priority fee is spread over it per CU
+	ComputeUnitLimit uint32 `mapstructure:"compute_unit_limit"` // 0 = 600000
+
+	// Raise Jupiter's priority fee cap after consecutive unlanded sends (see trading/feebump.go)
+	PriorityBumpAfter        int    `mapstructure:"priority_bump_after"`         // unlanded sends before a step up (0 = off)
+	PriorityBumpStepLamports uint64 `mapstructure:"priority_bump_step_lamports"` // added per step
+	PriorityBumpMaxLamports  uint64 `mapstructure:"priority_bump_max_lamports"`  // ceiling for the cap
+	PriorityDecayAfter       int    `mapstructure:"priority_decay_after"`        // landed sends before a step down
+
+	// Track Jupiter's priority fee cap from getRecentPrioritizationFees
+	Dynamic DynamicFeesConfig `mapstructure:"dynamic"`
+}
+
+// DynamicFeesConfig sizes the priority fee cap from recent network fees
+type DynamicFeesConfig struct {
+	Enabled        bool     `mapstructure:"enabled"`
+	Percentile     float64  `mapstructure:"percentile"`      // of recent per-CU fees, e.g. 75
+	RefreshSeconds int      `mapstructure:"refresh_seconds"` // how often to re-estimate
+	ComputeUnits   uint32   `mapstructure:"compute_units"`   // CU assumed per swap (per-CU fee -> lamports)
+	MinLamports    uint64   `mapstructure:"min_lamports"`    // floor for the cap
+	MaxLamports    uint64   `mapstructure:"max_lamports"`    // ceiling for the cap
+	Accounts       []string `mapstructure:"accounts"`        // fee sample filter (empty = global)
 }
 
 type JupiterConfig struct {
 	QuoteAPIURL    string `mapstructure:"quote_api_url"`
 	SlippageBps    int    `mapstructure:"slippage_bps"`
 	TimeoutSeconds int    `mapstructure:"timeout_seconds"`
+	MaxQuoteAgeMs  int    `mapstructure:"max_quote_age_ms"` // re-quote older quotes before building a swap (0 = off)
+	QuoteCacheMs   int    `mapstructure:"quote_cache_ms"`   // reuse position-monitor quotes this long (0 = off; warns at <= MonitorInterval; trades never use it)
+	Retries        int    `mapstructure:"retries"`          // extra attempts on 429/502/503/504 and timeouts
+	RetryBackoffMs int    `mapstructure:"retry_backoff_ms"` // delay before the first retry, doubling after
+
+	// Platform fee on every swap, paid to a referral token account (both empty/0 = off)
+	ReferralAccount string `mapstructure:"referral_account"`
+	FeeBps          int    `mapstructure:"fee_bps"`
+
+	// Adaptive per-mint slippage learned from fill history (see trading/slippage.go)
+	AdaptiveSlippage    bool    `mapstructure:"adaptive_slippage"`
+	AdaptivePadPercent  float64 `mapstructure:"adaptive_pad_percent"`   // padding on top of learned base
+	AdaptiveMinBps      int     `mapstructure:"adaptive_min_bps"`       // floor
+	AdaptiveMaxBps      int     `mapstructure:"adaptive_max_bps"`       // ceiling
+	AdaptiveMaxAgeHours int     `mapstructure:"adaptive_max_age_hours"` // older history is ignored
+
+	// Retry escalation: after a slippage failure the next attempt quotes this
+	// much wider, up to the ceiling (see trading/slippage.go)
+	RetrySlippageStepBps int `mapstructure:"retry_slippage_step_bps"` // 0 = disabled
+	RetrySlippageMaxBps  int `mapstructure:"retry_slippage_max_bps"`
 }
 
 type TelegramConfig struct {
 	ListenPort int    `mapstructure:"listen_port"`
 	ListenHost string `mapstructure:"listen_host"`
+
+	// Require POST /signal to carry X-Signature: hex HMAC-SHA256 of the body
+	WebhookSecret string `mapstructure:"webhook_secret"` // "" = disabled
+
+	// Ingress limits for POST /signal
+	AllowedIPs         []string `mapstructure:"allowed_ips"`           // CIDRs or IPs; empty = any source
+	RateLimitPerSecond float64  `mapstructure:"rate_limit_per_second"` // per source IP; 0 = unlimited
+	RateLimitBurst     int      `mapstructure:"rate_limit_burst"`
 }
 
 type BlockchainConfig struct {
 	BlockhashRefreshMs    int `mapstructure:"blockhash_refresh_ms"`
 	BlockhashTTLSeconds   int `mapstructure:"blockhash_ttl_seconds"`
 	BalanceRefreshSeconds int `mapstructure:"balance_refresh_seconds"`
+	// Poll interval while the WebSocket wallet subscription is up and
+	// already pushing balance changes (0 = always balance_refresh_seconds)
+	BalanceRefreshWSSeconds int `mapstructure:"balance_refresh_ws_seconds"`
 }
 
 type StorageConfig struct {
 	SQLitePath        string `mapstructure:"sqlite_path"`
 	SignalsBufferSize int    `mapstructure:"signals_buffer_size"`
+	RetentionDays     int    `mapstructure:"retention_days"` // prune signals/trades/decisions older than this; 0 = keep forever
 }
 
 type TUIConfig struct {
 	RefreshRateMs int `mapstructure:"refresh_rate_ms"`
 	LogLines      int `mapstructure:"log_lines"`
+
+	// Above this many open positions, lists switch to a dense single-line format
+	CompactPositionsThreshold int `mapstructure:"compact_positions_threshold"`
+
+	// Show slippage/fee-adjusted "exit≈X SOL" next to each position
+	ShowExitEstimate bool `mapstructure:"show_exit_estimate"`
+
+	// Show net PnL (after entry fees and estimated exit slippage/fees) next to gross
+	ShowNetPnL bool `mapstructure:"show_net_pnl"`
+
+	// Theme name saved by the TUI when the theme is cycled ("" = first), and
+	// UI mode 1-4 saved when cycled with M (0 = default; the UI_MODE env var
+	// still wins, unsaved)
+	Theme  string `mapstructure:"theme"`
+	UIMode int    `mapstructure:"ui_mode"`
 }
 
 type WebSocketConfig struct {
 	ShyftURL        string `mapstructure:"shyft_url"`
-	ReconnectDelayMs int   `mapstructure:"reconnect_delay_ms"`
+	ReconnectDelayMs int   `mapstructure:"reconnect_delay_ms"` // base delay, doubled per failed attempt up to 30s
 	PingIntervalMs   int   `mapstructure:"ping_interval_ms"`
+
+	// Outage safety: after this long disconnected, stop entries (or all trading)
+	MaxDowntimeSeconds int    `mapstructure:"max_downtime_seconds"` // 0 = disabled
+	DowntimeAction     string `mapstructure:"downtime_action"`      // sell_only | pause
+	MaxRPCLatencyMs    int    `mapstructure:"max_rpc_latency_ms"`   // only degrade while RPC p50 is at least this slow (0 = downtime alone)
+}
+
+// TokensConfig controls how unknown symbols are resolved on a cache miss
+type TokensConfig struct {
+	UpstreamLookup        bool     `mapstructure:"upstream_lookup"`         // query upstream sources on miss
+	ResolverChain         []string `mapstructure:"resolver_chain"`          // sources in order: dexscreener, metadata, jupiter
+	LookupURL             string   `mapstructure:"lookup_url"`              // metadata, jupiter: Jupiter token search endpoint
+	DexScreenerURL        string   `mapstructure:"dexscreener_url"`         // dexscreener: pair search endpoint
+	LookupCooldownSeconds int      `mapstructure:"lookup_cooldown_seconds"` // per-symbol rate limit
+
+	// Warm standby: symbols/mints kept pre-resolved with a fresh buy quote
+	Watchlist      []string `mapstructure:"watchlist"`
+	WarmQuoteTTLMs int      `mapstructure:"warm_quote_ttl_ms"` // older warm quotes are not used
+}
+
+// NotifyConfig selects where trade notifications (buy sent, sell confirmed,
+// kill switch) are pushed
+type NotifyConfig struct {
+	Provider     string `mapstructure:"provider"`       // "" (off) | telegram | discord | slack
+	BotTokenEnv  string `mapstructure:"bot_token_env"`  // telegram: env var holding the Bot API token
+	ChatID       string `mapstructure:"chat_id"`        // telegram: chat or channel to post to
+	WebhookURL   string `mapstructure:"webhook_url"`    // discord / slack incoming webhook
+	MaxPerMinute int    `mapstructure:"max_per_minute"` // further messages are dropped until the minute is up
+}
+
+// MetricsConfig controls the Prometheus scrape endpoint (GET /metrics)
+type MetricsConfig struct {
+	Enabled bool `mapstructure:"enabled"` // opt-in: /metrics is unauthenticated
+	Port    int  `mapstructure:"port"` // 0 = serve on the signal server's port
+}
+
+// ShutdownConfig controls what happens to open positions on SIGINT/SIGTERM
+type ShutdownConfig struct {
+	SellOnExit         bool `mapstructure:"sell_on_exit"`         // Sell every open position before exiting
+	SellTimeoutSeconds int  `mapstructure:"sell_timeout_seconds"` // Give up on unfinished sells after this
 }
 
 // Manager handles config loading and hot-reload
@@ -97,6 +376,8 @@ type Manager struct {
 	config   *Config
 	viper    *viper.Viper
 	onChange func(*Config)
+
+	overrides func(*Config) // In-memory overrides (e.g. CLI flags), re-applied on reload
 }
 
 // NewManager creates a new config manager
@@ -109,16 +390,107 @@ func NewManager(configPath string) (*Manager, error) {
 	v.SetDefault("blockchain.blockhash_refresh_ms", 100)
 	v.SetDefault("blockchain.blockhash_ttl_seconds", 60)
 	v.SetDefault("blockchain.balance_refresh_seconds", 5)
+	v.SetDefault("blockchain.balance_refresh_ws_seconds", 60)
+	v.SetDefault("trading.min_entry_percent", 50)
+	v.SetDefault("trading.take_profit_multiple", 2.0)
+	v.SetDefault("trading.max_alloc_percent", 20)
+	v.SetDefault("trading.reserve_sol", 0.01)
+	v.SetDefault("trading.max_open_positions", 5)
+	v.SetDefault("trading.sizing.strategy", SizingFixed)
+	v.SetDefault("trading.sizing.min_alloc_percent", 5)
+	v.SetDefault("trading.sizing.full_size_percent", 200)
+	v.SetDefault("trading.valueless_signal_action", "skip")
+	v.SetDefault("trading.startup_grace_seconds", 0)
+	v.SetDefault("trading.stop_loss_percent", 0)
+	v.SetDefault("trading.trailing_stop_percent", 0)
+	v.SetDefault("trading.move_stop_to_break_even_after_partial", false)
+	v.SetDefault("trading.rebuy_cooldown_seconds", 0)
+	v.SetDefault("trading.content_dedup_seconds", 0)
+	v.SetDefault("trading.max_concurrent_trades", 3)
+	v.SetDefault("trading.requote_unchanged_seconds", 0)
+	v.SetDefault("trading.max_daily_loss_sol", 0.0)
+	v.SetDefault("trading.max_price_impact_percent", 0.0)
+	v.SetDefault("trading.ignored_mints", DefaultIgnoredMints)
+	v.SetDefault("trading.blacklist", []string{})
+	v.SetDefault("trading.whitelist", []string{})
+	v.SetDefault("trading.adopt_orphans_on_startup", false)
+	v.SetDefault("trading.min_pool_liquidity_sol", 0)
+	v.SetDefault("trading.max_liquidity_drop_percent", 0)
+	v.SetDefault("trading.liquidity_exit_slippage_bps", 2500)
+	v.SetDefault("trading.max_adopt_positions", 5)
+	v.SetDefault("trading.min_adopt_value_sol", 0.01)
+	v.SetDefault("trading.sell_confirm_commitment", "confirmed")
+	v.SetDefault("trading.sell_confirm_timeout_seconds", 60)
+	v.SetDefault("trading.sell_retry_amount_factor", 0.999)
+	v.SetDefault("trading.preflight_simulate", false)
+	v.SetDefault("trading.paper_trading", false)
+	v.SetDefault("trading.paper_balance_sol", 1.0)
 	v.SetDefault("jupiter.quote_api_url", "https://quote-api.jup.ag/v6/quote")
 	v.SetDefault("jupiter.slippage_bps", 500) // 5%
 	v.SetDefault("jupiter.timeout_seconds", 10)
+	v.SetDefault("jupiter.max_quote_age_ms", 2000)
+	v.SetDefault("jupiter.quote_cache_ms", 0)
+	v.SetDefault("jupiter.retries", 2)
+	v.SetDefault("jupiter.retry_backoff_ms", 150)
+	v.SetDefault("jupiter.referral_account", "")
+	v.SetDefault("jupiter.fee_bps", 0)
+	v.SetDefault("jupiter.adaptive_slippage", false)
+	v.SetDefault("jupiter.adaptive_pad_percent", 20)
+	v.SetDefault("jupiter.adaptive_min_bps", 100)
+	v.SetDefault("jupiter.adaptive_max_bps", 3000)
+	v.SetDefault("jupiter.adaptive_max_age_hours", 72)
+	v.SetDefault("jupiter.retry_slippage_step_bps", 500)
+	v.SetDefault("jupiter.retry_slippage_max_bps", 2000)
+	v.SetDefault("fees.compute_unit_limit", 600_000)
+	v.SetDefault("fees.priority_bump_after", 0)
+	v.SetDefault("fees.priority_bump_step_lamports", 250_000)
+	v.SetDefault("fees.priority_bump_max_lamports", 5_000_000)
+	v.SetDefault("fees.priority_decay_after", 3)
+	v.SetDefault("fees.dynamic.enabled", false)
+	v.SetDefault("fees.dynamic.percentile", 75)
+	v.SetDefault("fees.dynamic.refresh_seconds", 10)
+	v.SetDefault("fees.dynamic.compute_units", 600_000)
+	v.SetDefault("fees.dynamic.min_lamports", 100_000)
+	v.SetDefault("fees.dynamic.max_lamports", 5_000_000)
 	v.SetDefault("rpc.shyft_api_key_env", "SHYFT_API_KEY")
 	v.SetDefault("rpc.fallback_url", "https://api.mainnet-beta.solana.com")
+	v.SetDefault("rpc.fallback_urls", []string{})
+	v.SetDefault("rpc.latency_probe_seconds", 0)
+	v.SetDefault("rpc.circuit_breaker.failure_threshold", 5)
+	v.SetDefault("rpc.circuit_breaker.reset_seconds", 30)
+	v.SetDefault("telegram.webhook_secret", "")
+	v.SetDefault("telegram.allowed_ips", []string{})
+	v.SetDefault("telegram.rate_limit_per_second", 0)
+	v.SetDefault("telegram.rate_limit_burst", 10)
+	v.SetDefault("rpc.force_ipv4", false)
+	v.SetDefault("rpc.dns_cache_ttl_seconds", 60)
 	v.SetDefault("storage.sqlite_path", "./data/bot.db")
 	v.SetDefault("storage.signals_buffer_size", 100)
+	v.SetDefault("storage.retention_days", 0)
 	v.SetDefault("tui.refresh_rate_ms", 100)
 	v.SetDefault("tui.log_lines", 100)
+	v.SetDefault("tui.compact_positions_threshold", 4)
+	v.SetDefault("tui.show_exit_estimate", true)
+	v.SetDefault("tui.show_net_pnl", true)
+	v.SetDefault("tui.theme", "")
+	v.SetDefault("tui.ui_mode", 0)
 	v.SetDefault("wallet.private_key_env", "WALLET_PRIVATE_KEY")
+	v.SetDefault("websocket.max_downtime_seconds", 0)
+	v.SetDefault("tokens.upstream_lookup", false)
+	v.SetDefault("tokens.lookup_url", "https://lite-api.jup.ag/tokens/v2/search")
+	v.SetDefault("tokens.lookup_cooldown_seconds", 300)
+	v.SetDefault("tokens.resolver_chain", []string{"dexscreener", "metadata"})
+	v.SetDefault("tokens.dexscreener_url", "https://api.dexscreener.com/latest/dex/search")
+	v.SetDefault("tokens.warm_quote_ttl_ms", 1500)
+	v.SetDefault("websocket.downtime_action", "sell_only")
+	v.SetDefault("websocket.max_rpc_latency_ms", 1000)
+	v.SetDefault("notify.provider", "")
+	v.SetDefault("notify.bot_token_env", "NOTIFY_BOT_TOKEN")
+	v.SetDefault("notify.max_per_minute", 20)
+	v.SetDefault("metrics.enabled", false)
+	v.SetDefault("metrics.port", 0)
+	v.SetDefault("shutdown.sell_on_exit", false)
+	v.SetDefault("shutdown.sell_timeout_seconds", 30)
 
 	if err := v.ReadInConfig(); err != nil {
 		return nil, err
@@ -132,6 +504,10 @@ func NewManager(configPath string) (*Manager, error) {
 	// Manual fallback if unmarshal leaves zero values (double check)
 	if cfg.Jupiter.QuoteAPIURL == "" { cfg.Jupiter.QuoteAPIURL = "https://quote-api.jup.ag/v6/quote" }
 	if cfg.Storage.SQLitePath == "" { cfg.Storage.SQLitePath = "./data/bot.db" }
+	sanitizeTokenOverrides(&cfg)
+	if err := Validate(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", configPath, err)
+	}
 
 	m := &Manager{
 		config: &cfg,
@@ -169,21 +545,53 @@ func (m *Manager) SetOnChange(fn func(*Config)) {
 	m.onChange = fn
 }
 
-// Update modifies config values and saves to file
+// ApplyOverrides applies in-memory overrides (e.g. command-line flags) that take
+// precedence over the file. They are re-applied after every hot reload and
+// never reach the file: Update only writes the fields its fn changed.
+func (m *Manager) ApplyOverrides(fn func(*Config)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.overrides = fn
+	fn(m.config)
+
+	if m.onChange != nil {
+		m.onChange(m.config)
+	}
+}
+
+// persistedFields are the settings Update can write back to the file
+var persistedFields = []struct {
+	key string
+	get func(*Config) interface{}
+}{
+	{"trading.min_entry_percent", func(c *Config) interface{} { return c.Trading.MinEntryPercent }},
+	{"trading.take_profit_multiple", func(c *Config) interface{} { return c.Trading.TakeProfitMultiple }},
+	{"trading.max_alloc_percent", func(c *Config) interface{} { return c.Trading.MaxAllocPercent }},
+	{"trading.max_open_positions", func(c *Config) interface{} { return c.Trading.MaxOpenPositions }},
+	{"trading.auto_trading_enabled", func(c *Config) interface{} { return c.Trading.AutoTradingEnabled }},
+	{"fees.static_priority_fee_sol", func(c *Config) interface{} { return c.Fees.StaticPriorityFeeSol }},
+	{"tui.theme", func(c *Config) interface{} { return c.TUI.Theme }},
+	{"tui.ui_mode", func(c *Config) interface{} { return c.TUI.UIMode }},
+}
+
+// Update modifies config values and saves to file. Only the fields fn
+// changed are written; the rest keep their file values, so in-memory
+// overrides (ApplyOverrides) and environment-derived values stay out of it.
 func (m *Manager) Update(fn func(*Config)) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// Apply changes
+	before := *m.config
 	fn(m.config)
 
 	// Update viper values
-	m.viper.Set("trading.min_entry_percent", m.config.Trading.MinEntryPercent)
-	m.viper.Set("trading.take_profit_multiple", m.config.Trading.TakeProfitMultiple)
-	m.viper.Set("trading.max_alloc_percent", m.config.Trading.MaxAllocPercent)
-	m.viper.Set("trading.max_open_positions", m.config.Trading.MaxOpenPositions)
-	m.viper.Set("trading.auto_trading_enabled", m.config.Trading.AutoTradingEnabled)
-	m.viper.Set("fees.static_priority_fee_sol", m.config.Fees.StaticPriorityFeeSol)
+	for _, f := range persistedFields {
+		if v := f.get(m.config); v != f.get(&before) {
+			m.viper.Set(f.key, v)
+		}
+	}
 
 	// Write to file
 	if err := m.viper.WriteConfig(); err != nil {
@@ -197,20 +605,43 @@ func (m *Manager) Update(fn func(*Config)) error {
 	return nil
 }
 
-func (m *Manager) reload() {
+// Reload re-reads the config file and applies it as a file-watch change
+// would (SIGHUP: for network filesystems where the watcher misses events).
+// An unreadable or invalid file keeps the current config.
+func (m *Manager) Reload() error {
+	m.mu.Lock()
+	err := m.viper.ReadInConfig()
+	m.mu.Unlock()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to read config on reload, keeping the previous one")
+		return err
+	}
+	return m.reload()
+}
+
+func (m *Manager) reload() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	var cfg Config
 	if err := m.viper.Unmarshal(&cfg); err != nil {
 		log.Error().Err(err).Msg("failed to unmarshal config on reload")
-		return
+		return err
+	}
+	sanitizeTokenOverrides(&cfg)
+	if m.overrides != nil {
+		m.overrides(&cfg)
+	}
+	if err := Validate(&cfg); err != nil {
+		log.Error().Err(err).Msg("invalid config on reload, keeping the previous one")
+		return err
 	}
 
 	m.config = &cfg
 	if m.onChange != nil {
 		m.onChange(&cfg)
 	}
+	return nil
 }
 
 // GetPrivateKey loads private key from environment
@@ -227,6 +658,13 @@ func (m *Manager) GetShyftAPIKey() string {
 	return os.Getenv(m.config.RPC.ShyftAPIKeyEnv)
 }
 
+// GetNotifyBotToken loads the notification bot token from environment
+func (m *Manager) GetNotifyBotToken() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return os.Getenv(m.config.Notify.BotTokenEnv)
+}
+
 // GetBlockhashRefresh returns blockhash refresh interval as duration
 func (m *Manager) GetBlockhashRefresh() time.Duration {
 	m.mu.RLock()
@@ -240,3 +678,10 @@ func (m *Manager) GetBalanceRefresh() time.Duration {
 	defer m.mu.RUnlock()
 	return time.Duration(m.config.Blockchain.BalanceRefreshSeconds) * time.Second
 }
+
+// GetBalanceRefreshWS returns the balance poll interval while the WebSocket is connected
+func (m *Manager) GetBalanceRefreshWS() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return time.Duration(m.config.Blockchain.BalanceRefreshWSSeconds) * time.Second
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TokenOverride replaces global trading settings for one token. Zero fields
+// fall back to the global value.
+type TokenOverride struct {
+	TakeProfit float64 `mapstructure:"take_profit"` // multiple, e.g. 5 = sell at 5X
+	StopLoss   float64 `mapstructure:"stop_loss"`   // multiple, e.g. 0.5 = sell at -50% (beats stop_loss_percent)
+	Alloc      float64 `mapstructure:"alloc"`       // percent of balance, like max_alloc_percent
+}
+
+// OverrideFor returns the override for a token, matched by mint first, then
+// symbol. Keys are compared case-insensitively because viper lowercases map
+// keys when reading YAML. The matched key is returned for logging.
+func (t TradingConfig) OverrideFor(mint, symbol string) (TokenOverride, string, bool) {
+	if len(t.TokenOverrides) == 0 {
+		return TokenOverride{}, "", false
+	}
+	for _, want := range []string{mint, strings.TrimPrefix(symbol, "$")} {
+		if want == "" {
+			continue
+		}
+		for key, o := range t.TokenOverrides {
+			if strings.EqualFold(key, want) {
+				return o, key, true
+			}
+		}
+	}
+	return TokenOverride{}, "", false
+}
+
+// TakeProfitFor returns the take-profit multiple for a token
+func (t TradingConfig) TakeProfitFor(mint, symbol string) float64 {
+	if o, _, ok := t.OverrideFor(mint, symbol); ok && o.TakeProfit > 0 {
+		return o.TakeProfit
+	}
+	return t.TakeProfitMultiple
+}
+
+// StopLossFor returns the stop-loss multiple for a token: the override's
+// stop_loss, else 1 - stop_loss_percent/100. 0 means no stop-loss.
+func (t TradingConfig) StopLossFor(mint, symbol string) float64 {
+	if o, _, ok := t.OverrideFor(mint, symbol); ok && o.StopLoss > 0 {
+		return o.StopLoss
+	}
+	if t.StopLossPercent > 0 && t.StopLossPercent < 100 {
+		return 1 - t.StopLossPercent/100
+	}
+	return 0
+}
+
+// AllocPercentFor returns the per-trade allocation percent for a token
+func (t TradingConfig) AllocPercentFor(mint, symbol string) float64 {
+	if o, _, ok := t.OverrideFor(mint, symbol); ok && o.Alloc > 0 {
+		return o.Alloc
+	}
+	return t.MaxAllocPercent
+}
+
+// DefaultIgnoredMints are reserve assets, not trades: USDC, USDT and wrapped SOL
+var DefaultIgnoredMints = []string{
+	"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", // USDC
+	"Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB", // USDT
+	"So11111111111111111111111111111111111111112",  // WSOL
+}
+
+// IsIgnoredMint reports whether a mint must stay out of position tracking.
+// Every feature that turns wallet holdings or signals into positions checks this.
+func (t TradingConfig) IsIgnoredMint(mint string) bool {
+	for _, m := range t.IgnoredMints {
+		if m == mint {
+			return true
+		}
+	}
+	return false
+}
+
+// validTokenOverride checks values are in range and at least one is set
+func validTokenOverride(o TokenOverride) string {
+	switch {
+	case o.TakeProfit == 0 && o.StopLoss == 0 && o.Alloc == 0:
+		return "no take_profit, stop_loss or alloc set"
+	case o.TakeProfit != 0 && o.TakeProfit <= 1:
+		return "take_profit must be a multiple above 1"
+	case o.StopLoss < 0 || o.StopLoss >= 1:
+		return "stop_loss must be a multiple between 0 and 1"
+	case o.Alloc < 0 || o.Alloc > 100:
+		return "alloc must be a percent between 0 and 100"
+	}
+	return ""
+}
+
+// validOverrideKey accepts a mint address or a token symbol
+func validOverrideKey(key string) bool {
+	key = strings.TrimPrefix(key, "$")
+	if key == "" || len(key) > 44 {
+		return false
+	}
+	for _, r := range key {
+		isAlnum := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isAlnum {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeTokenOverrides drops invalid trading.token_overrides entries with a warning
+func sanitizeTokenOverrides(cfg *Config) {
+	for key, o := range cfg.Trading.TokenOverrides {
+		reason := ""
+		if !validOverrideKey(key) {
+			reason = "key is not a mint address or symbol"
+		} else {
+			reason = validTokenOverride(o)
+		}
+		if reason != "" {
+			log.Warn().Str("key", key).Str("reason", reason).Msg("⚠️ ignoring invalid token override")
+			delete(cfg.Trading.TokenOverrides, key)
+		}
+	}
+}
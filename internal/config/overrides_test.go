@@ -0,0 +1,59 @@
+package config
+
+import "testing"
+
+func TestTradingConfig_OverrideFor(t *testing.T) {
+	cfg := &Config{Trading: TradingConfig{
+		TakeProfitMultiple: 2,
+		MaxAllocPercent:    10,
+		TokenOverrides: map[string]TokenOverride{
+			// viper lowercases keys, so a mint arrives lowercased
+			"dezxaz8z7pnrnrjjz3wxborgixca6xjnb7yab1ppb263": {TakeProfit: 5},
+			"wif":     {TakeProfit: 1.5, Alloc: 25},
+			"bad key": {TakeProfit: 3},
+			"empty":   {},
+		},
+	}}
+	sanitizeTokenOverrides(cfg)
+	tc := cfg.Trading
+
+	if _, ok := tc.TokenOverrides["bad key"]; ok {
+		t.Error("invalid key was not dropped")
+	}
+	if _, ok := tc.TokenOverrides["empty"]; ok {
+		t.Error("override without values was not dropped")
+	}
+
+	if got := tc.TakeProfitFor("DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263", "BONK"); got != 5 {
+		t.Errorf("mint override take-profit = %v, want 5", got)
+	}
+	if got := tc.TakeProfitFor("SomeOtherMint", "$WIF"); got != 1.5 {
+		t.Errorf("symbol override take-profit = %v, want 1.5", got)
+	}
+	if got := tc.AllocPercentFor("SomeOtherMint", "WIF"); got != 25 {
+		t.Errorf("symbol override alloc = %v, want 25", got)
+	}
+	if got := tc.AllocPercentFor("DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263", "BONK"); got != 10 {
+		t.Errorf("unset alloc = %v, want global 10", got)
+	}
+	if got := tc.TakeProfitFor("Unknown", "PEPE"); got != 2 {
+		t.Errorf("no override take-profit = %v, want global 2", got)
+	}
+}
+
+func TestTradingConfig_StopLossFor(t *testing.T) {
+	tc := TradingConfig{
+		StopLossPercent: 40,
+		TokenOverrides:  map[string]TokenOverride{"bonk": {StopLoss: 0.8}},
+	}
+	if got := tc.StopLossFor("SomeMint", "BONK"); got != 0.8 {
+		t.Errorf("override stop-loss = %v, want 0.8", got)
+	}
+	if got := tc.StopLossFor("SomeMint", "PEPE"); got != 0.6 {
+		t.Errorf("global stop-loss = %v, want 0.6", got)
+	}
+	tc.StopLossPercent = 0
+	if got := tc.StopLossFor("SomeMint", "PEPE"); got != 0 {
+		t.Errorf("disabled stop-loss = %v, want 0", got)
+	}
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RedactURL reduces an endpoint URL to its scheme and host so it can be
+// printed or logged safely: providers carry API keys in the path (Helius,
+// QuickNode, Shyft), the query string or userinfo
+func RedactURL(raw string) string {
+	if raw == "" {
+		return "(not set)"
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "(unparseable)"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// redactURLs is RedactURL over a list, comma-separated
+func redactURLs(urls []string) string {
+	out := make([]string, len(urls))
+	for i, u := range urls {
+		out[i] = RedactURL(u)
+	}
+	return strings.Join(out, ", ")
+}
+
+// wsRPCGate describes the RPC latency an outage also needs before degrading
+func wsRPCGate(ms int) string {
+	if ms <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" while RPC p50 >= %dms", ms)
+}
+
+// onOff renders a feature toggle for the summary
+func onOff(enabled bool, detail string) string {
+	if !enabled {
+		return "OFF"
+	}
+	if detail == "" {
+		return "ON"
+	}
+	return "ON  (" + detail + ")"
+}
+
+// curveString renders take-profit points as "50%@2.00x 100%@4.00x"
+func curveString(curve []TakeProfitPoint) string {
+	parts := make([]string, 0, len(curve))
+	for _, p := range curve {
+		parts = append(parts, fmt.Sprintf("%.0f%%@%.2fx", p.Fraction*100, p.Multiple))
+	}
+	return strings.Join(parts, " ")
+}
+
+// sizingString renders the buy size, e.g. "5-20% of balance by signal (200%+ = full)"
+func sizingString(t TradingConfig) string {
+	if s := t.Sizing; s.Strategy == SizingLinear {
+		return fmt.Sprintf("%.0f-%.0f%% of balance by signal (%.0f%%+ = full)", s.MinAllocPercent, t.MaxAllocPercent, s.FullSizePercent)
+	}
+	return fmt.Sprintf("%.0f%% of balance per trade", t.MaxAllocPercent)
+}
+
+// levelsString renders a partial profit ladder, e.g. "25% at 1.50x, 25% at 2.00x"
+func levelsString(levels []ProfitLevel) string {
+	parts := make([]string, len(levels))
+	for i, l := range levels {
+		parts[i] = fmt.Sprintf("%.0f%% at %.2fx", l.Percent, l.Multiple)
+	}
+	return "sell " + strings.Join(parts, ", ")
+}
+
+// overridesString lists token override keys in a stable order
+func overridesString(overrides map[string]TokenOverride) string {
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
+// metricsAddr describes where GET /metrics is served
+func metricsAddr(c *Config) string {
+	if c.Metrics.Port > 0 {
+		return fmt.Sprintf("/metrics on %s:%d", c.Telegram.ListenHost, c.Metrics.Port)
+	}
+	return "/metrics on the signal server"
+}
+
+// Summary returns a redacted, human-readable block of the effective configuration.
+// One entry per line; printed at startup so settings can be verified at a glance.
+func (c *Config) Summary() []string {
+	t := c.Trading
+
+	mode := "LIVE"
+	if t.SimulationMode {
+		mode = "SIMULATION (no transactions sent)"
+	} else if t.PaperTrading {
+		mode = fmt.Sprintf("PAPER (live quotes, no transactions sent, %.2f SOL paper wallet)", t.PaperBalanceSol)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Mode:            %s", mode),
+		fmt.Sprintf("Auto-trading:    %s", onOff(t.AutoTradingEnabled, "")),
+		"",
+		fmt.Sprintf("Entry:           >= %.0f%%  (value-less signals: %s)", t.MinEntryPercent, t.ValuelessSignalAction),
+		fmt.Sprintf("Startup grace:   %s", onOff(t.StartupGraceSeconds > 0, fmt.Sprintf("%ds, entries not traded", t.StartupGraceSeconds))),
+		fmt.Sprintf("Re-buy cooldown: %s", onOff(t.RebuyCooldownSeconds > 0, fmt.Sprintf("%ds per mint", t.RebuyCooldownSeconds))),
+		fmt.Sprintf("Content dedup:   %s", onOff(t.ContentDedupSeconds > 0, fmt.Sprintf("%ds (forwards/edits under new msg IDs)", t.ContentDedupSeconds))),
+		fmt.Sprintf("Sizing:          %s, max %d open", sizingString(t), t.MaxOpenPositions),
+		fmt.Sprintf("Fee reserve:     %s", onOff(t.ReserveSol > 0, fmt.Sprintf("%.3f SOL never sized into buys", t.ReserveSol))),
+		fmt.Sprintf("Trade queue:     %d signals at once, the rest wait in order", t.MaxConcurrentTrades),
+		fmt.Sprintf("Take-profit:     %.2fx", t.TakeProfitMultiple),
+		fmt.Sprintf("Partial profit:  %s", onOff(len(t.ProfitLevels()) > 0, levelsString(t.ProfitLevels()))),
+		fmt.Sprintf("TP curve:        %s", onOff(len(t.TakeProfitCurve) > 0, curveString(t.TakeProfitCurve))),
+		fmt.Sprintf("Ignored mints:   %d (never traded)", len(t.IgnoredMints)),
+		fmt.Sprintf("Token filter:    %s", onOff(len(t.Blacklist) > 0 || len(t.Whitelist) > 0,
+			fmt.Sprintf("%d blacklisted, %d whitelisted", len(t.Blacklist), len(t.Whitelist)))),
+		fmt.Sprintf("Adopt orphans:   %s", onOff(t.AdoptOrphansOnStartup && t.MaxAdoptPositions > 0,
+			fmt.Sprintf("up to %d worth >= %.3f SOL", t.MaxAdoptPositions, t.MinAdoptValueSol))),
+		fmt.Sprintf("Token overrides: %s", onOff(len(t.TokenOverrides) > 0, overridesString(t.TokenOverrides))),
+		fmt.Sprintf("Stop-loss:       %s", onOff(t.StopLossPercent > 0, fmt.Sprintf("-%.0f%% (%.2fx)", t.StopLossPercent, 1-t.StopLossPercent/100))),
+		fmt.Sprintf("Trailing stop:   %s", onOff(t.TrailingStopPercent > 0, fmt.Sprintf("-%.0f%% from peak once past 1.2x", t.TrailingStopPercent))),
+		fmt.Sprintf("Break-even stop: %s", onOff(t.MoveStopToBreakEvenAfterPartial, "stop moves to 1.0x after a partial take")),
+		fmt.Sprintf("Daily loss cap:  %s", onOff(t.MaxDailyLossSol > 0, fmt.Sprintf("%.3f SOL, then auto-trading off", t.MaxDailyLossSol))),
+		fmt.Sprintf("Price impact:    %s", onOff(t.MaxPriceImpactPercent > 0, fmt.Sprintf("refuse buys over %.1f%%", t.MaxPriceImpactPercent))),
+		fmt.Sprintf("Requote:         %s", onOff(t.RequoteUnchangedSeconds > 0, fmt.Sprintf("unchanged balances every %ds", t.RequoteUnchangedSeconds))),
+		fmt.Sprintf("Max hold:        %s", onOff(t.MaxHoldMinutes > 0, fmt.Sprintf("%dm", t.MaxHoldMinutes))),
+		fmt.Sprintf("Liquidity exit:  %s", onOff(t.MinPoolLiquiditySol > 0 || t.MaxLiquidityDropPercent > 0,
+			fmt.Sprintf("floor %.2f SOL, max drop %.0f%%, %d bps", t.MinPoolLiquiditySol, t.MaxLiquidityDropPercent, t.LiquidityExitSlippageBps))),
+		fmt.Sprintf("Max give-back:   %s", onOff(t.MaxGiveBackSol > 0, fmt.Sprintf("%.3f SOL from peak", t.MaxGiveBackSol))),
+		fmt.Sprintf("Sell confirm:    %s (timeout %ds)", t.SellConfirmCommitment, t.SellConfirmTimeoutSeconds),
+		fmt.Sprintf("Preflight sim:   %s", onOff(t.PreflightSimulate, "simulate before send")),
+		fmt.Sprintf("Sell retry amt:  %s", onOff(t.SellRetryAmountFactor > 0 && t.SellRetryAmountFactor < 1,
+			fmt.Sprintf("%.2f%% of balance after an amount error", t.SellRetryAmountFactor*100))),
+		"",
+		fmt.Sprintf("Slippage:        %d bps", c.Jupiter.SlippageBps),
+		fmt.Sprintf("Quote max age:   %s", onOff(c.Jupiter.MaxQuoteAgeMs > 0, fmt.Sprintf("%dms, re-quote if older", c.Jupiter.MaxQuoteAgeMs))),
+		fmt.Sprintf("Jupiter retries: %s", onOff(c.Jupiter.Retries > 0, fmt.Sprintf("%d, backoff %dms doubling", c.Jupiter.Retries, c.Jupiter.RetryBackoffMs))),
+		fmt.Sprintf("Platform fee:    %s", onOff(c.Jupiter.FeeBps > 0, fmt.Sprintf("%d bps to referral account", c.Jupiter.FeeBps))),
+		fmt.Sprintf("Quote cache:     %s", onOff(c.Jupiter.QuoteCacheMs > 0, fmt.Sprintf("%dms for position valuation", c.Jupiter.QuoteCacheMs))),
+		fmt.Sprintf("Adaptive slip:   %s", onOff(c.Jupiter.AdaptiveSlippage,
+			fmt.Sprintf("+%.0f%% pad, %d-%d bps, %dh memory", c.Jupiter.AdaptivePadPercent,
+				c.Jupiter.AdaptiveMinBps, c.Jupiter.AdaptiveMaxBps, c.Jupiter.AdaptiveMaxAgeHours))),
+		fmt.Sprintf("Retry slippage:  %s", onOff(c.Jupiter.RetrySlippageStepBps > 0,
+			fmt.Sprintf("+%d bps per slippage failure, max %d bps", c.Jupiter.RetrySlippageStepBps, c.Jupiter.RetrySlippageMaxBps))),
+		fmt.Sprintf("Priority fee:    %.6f SOL", c.Fees.StaticPriorityFeeSol),
+		fmt.Sprintf("CU limit:        %d (own transactions; swaps size their own)", c.Fees.ComputeUnitLimit),
+		fmt.Sprintf("Dynamic fee:     %s", onOff(c.Fees.Dynamic.Enabled,
+			fmt.Sprintf("p%.0f of recent fees x %d CU, %d-%d lamports, every %ds", c.Fees.Dynamic.Percentile,
+				c.Fees.Dynamic.ComputeUnits, c.Fees.Dynamic.MinLamports, c.Fees.Dynamic.MaxLamports, c.Fees.Dynamic.RefreshSeconds))),
+		fmt.Sprintf("Fee bump:        %s", onOff(c.Fees.PriorityBumpAfter > 0 && c.Fees.PriorityBumpStepLamports > 0,
+			fmt.Sprintf("+%d lamports after %d unlanded, max %d, -1 step per %d landed", c.Fees.PriorityBumpStepLamports,
+				c.Fees.PriorityBumpAfter, c.Fees.PriorityBumpMaxLamports, c.Fees.PriorityDecayAfter))),
+		"",
+		fmt.Sprintf("RPC primary:     %s", RedactURL(c.RPC.ShyftURL)),
+		fmt.Sprintf("RPC fallback:    %s", RedactURL(c.RPC.FallbackURL)),
+		fmt.Sprintf("More fallbacks:  %s", onOff(len(c.RPC.FallbackURLs) > 0, redactURLs(c.RPC.FallbackURLs))),
+		fmt.Sprintf("RPC breaker:     skip an endpoint for %ds after %d straight failures",
+			c.RPC.CircuitBreaker.ResetSeconds, c.RPC.CircuitBreaker.FailureThreshold),
+		fmt.Sprintf("RPC routing:     %s", onOff(c.RPC.LatencyProbeSeconds > 0,
+			fmt.Sprintf("fastest healthy endpoint, probed every %ds", c.RPC.LatencyProbeSeconds))),
+		fmt.Sprintf("WebSocket:       %s", RedactURL(c.WebSocket.ShyftURL)),
+		fmt.Sprintf("WS outage:       %s", onOff(c.WebSocket.MaxDowntimeSeconds > 0,
+			fmt.Sprintf("%s after %ds down%s", c.WebSocket.DowntimeAction, c.WebSocket.MaxDowntimeSeconds, wsRPCGate(c.WebSocket.MaxRPCLatencyMs)))),
+		fmt.Sprintf("Network:         IPv4-only %s, DNS cache %s", onOff(c.RPC.ForceIPv4, ""),
+			onOff(c.RPC.DNSCacheTTLSeconds > 0, fmt.Sprintf("%ds", c.RPC.DNSCacheTTLSeconds))),
+		fmt.Sprintf("Signal server:   %s:%d", c.Telegram.ListenHost, c.Telegram.ListenPort),
+		fmt.Sprintf("Signal auth:     %s", onOff(c.Telegram.WebhookSecret != "", "HMAC-SHA256 X-Signature required")),
+		fmt.Sprintf("Signal sources:  %s", onOff(len(c.Telegram.AllowedIPs) > 0, strings.Join(c.Telegram.AllowedIPs, ", "))),
+		fmt.Sprintf("Signal rate:     %s", onOff(c.Telegram.RateLimitPerSecond > 0,
+			fmt.Sprintf("%.1f/s per source, burst %d", c.Telegram.RateLimitPerSecond, c.Telegram.RateLimitBurst))),
+		fmt.Sprintf("Token lookup:    %s", onOff(c.Tokens.UpstreamLookup && len(c.Tokens.ResolverChain) > 0,
+			fmt.Sprintf("cache -> %s, %ds per-symbol cooldown", strings.Join(c.Tokens.ResolverChain, " -> "), c.Tokens.LookupCooldownSeconds))),
+		fmt.Sprintf("Warm standby:    %s", onOff(len(c.Tokens.Watchlist) > 0 && c.Tokens.WarmQuoteTTLMs > 0,
+			fmt.Sprintf("%d tokens, quotes fresh for %dms", len(c.Tokens.Watchlist), c.Tokens.WarmQuoteTTLMs))),
+		fmt.Sprintf("DB retention:    %s", onOff(c.Storage.RetentionDays > 0,
+			fmt.Sprintf("signals, trades and decisions older than %d days pruned", c.Storage.RetentionDays))),
+		fmt.Sprintf("Metrics:         %s", onOff(c.Metrics.Enabled, metricsAddr(c))),
+		fmt.Sprintf("Notifications:   %s", onOff(c.Notify.Provider != "",
+			fmt.Sprintf("%s, max %d/min", c.Notify.Provider, c.Notify.MaxPerMinute))),
+		fmt.Sprintf("Sell on exit:    %s", onOff(c.Shutdown.SellOnExit,
+			fmt.Sprintf("all positions, up to %ds", c.Shutdown.SellTimeoutSeconds))),
+	}
+	return lines
+}
+
+// SummaryBlock renders Summary as a boxed block for terminal output
+func (c *Config) SummaryBlock() string {
+	lines := c.Summary()
+	width := 0
+	for _, l := range lines {
+		if n := len([]rune(l)); n > width {
+			width = n
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("┌─ EFFECTIVE CONFIG " + strings.Repeat("─", maxInt(width-17, 1)) + "┐\n")
+	for _, l := range lines {
+		b.WriteString("│ " + l + strings.Repeat(" ", width-len([]rune(l))) + " │\n")
+	}
+	b.WriteString("└" + strings.Repeat("─", width+2) + "┘\n")
+	return b.String()
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxFeeBps bounds jupiter.fee_bps (10%), catching percent-for-bps typos
+const MaxFeeBps = 1000
+
+// MonitorInterval is how often the position monitor re-values positions; a
+// valuation quote cached for less is gone by the next pass (a warning, not
+// an error: it still serves repeats within a pass)
+const MonitorInterval = 5 * time.Second
+
+// MaxComputeUnitLimit bounds fees.compute_unit_limit: Solana's per-transaction
+// maximum, the same cap blockchain.MaxComputeUnitLimit applies
+const MaxComputeUnitLimit = 1_400_000
+
+// MaxProfitLevels bounds the partial profit ladder (positions track fired levels in a bitmask)
+const MaxProfitLevels = 32
+
+// Validate checks the settings the bot cannot trade sanely without and
+// returns one error listing every problem (nil if the config is usable)
+func Validate(c *Config) error {
+	var errs []error
+	bad := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	t := c.Trading
+	if t.MinEntryPercent <= 0 || t.MinEntryPercent > 1000 {
+		bad("trading.min_entry_percent = %v: must be in (0, 1000]", t.MinEntryPercent)
+	}
+	if t.TakeProfitMultiple <= 1 {
+		bad("trading.take_profit_multiple = %v: must be greater than 1 (e.g. 2 = sell at 2X)", t.TakeProfitMultiple)
+	}
+	if t.MaxAllocPercent <= 0 || t.MaxAllocPercent > 100 {
+		bad("trading.max_alloc_percent = %v: must be in (0, 100]", t.MaxAllocPercent)
+	}
+	if t.ReserveSol < 0 {
+		bad("trading.reserve_sol = %v: must be 0 (off) or a positive SOL amount", t.ReserveSol)
+	}
+	if t.PaperTrading && t.PaperBalanceSol <= 0 {
+		bad("trading.paper_balance_sol = %v: paper trading needs a positive SOL amount to size buys from", t.PaperBalanceSol)
+	}
+	if t.MaxOpenPositions <= 0 {
+		bad("trading.max_open_positions = %d: must be at least 1", t.MaxOpenPositions)
+	}
+	switch s := t.Sizing; s.Strategy {
+	case "", SizingFixed:
+	case SizingLinear:
+		if s.MinAllocPercent <= 0 || s.MinAllocPercent > t.MaxAllocPercent {
+			bad("trading.sizing.min_alloc_percent = %v: must be in (0, max_alloc_percent]", s.MinAllocPercent)
+		}
+		if s.FullSizePercent <= t.MinEntryPercent {
+			bad("trading.sizing.full_size_percent = %v: must be above min_entry_percent (%v)", s.FullSizePercent, t.MinEntryPercent)
+		}
+	default:
+		bad("trading.sizing.strategy = %q: must be fixed or linear", s.Strategy)
+	}
+	if t.MaxConcurrentTrades <= 0 {
+		bad("trading.max_concurrent_trades = %d: must be at least 1", t.MaxConcurrentTrades)
+	}
+	if t.MaxDailyLossSol < 0 {
+		bad("trading.max_daily_loss_sol = %v: must be 0 (off) or a positive SOL amount", t.MaxDailyLossSol)
+	}
+	if t.MaxPriceImpactPercent < 0 || t.MaxPriceImpactPercent > 100 {
+		bad("trading.max_price_impact_percent = %v: must be in [0, 100] (0 = off)", t.MaxPriceImpactPercent)
+	}
+	if t.RequoteUnchangedSeconds < 0 {
+		bad("trading.requote_unchanged_seconds = %d: must be 0 (every pass) or positive", t.RequoteUnchangedSeconds)
+	}
+	if levels := t.PartialProfitLevels; len(levels) > 0 {
+		total := 0.0
+		for _, l := range levels {
+			if l.Multiple <= 1 || l.Percent <= 0 {
+				bad("trading.partial_profit_levels {multiple: %v, percent: %v}: multiple must be > 1 and percent > 0", l.Multiple, l.Percent)
+			}
+			total += l.Percent
+		}
+		if total > 100 {
+			bad("trading.partial_profit_levels sell %v%% in total: must not exceed 100", total)
+		}
+		if len(levels) > MaxProfitLevels {
+			bad("trading.partial_profit_levels has %d levels: at most %d", len(levels), MaxProfitLevels)
+		}
+	}
+	for mint, p := range t.SimulationPrices {
+		if p.PriceSol <= 0 || p.Decimals < 0 || p.Decimals > 18 {
+			bad("trading.simulation_prices %s {price_sol: %v, decimals: %d}: price must be positive, decimals 0-18", mint, p.PriceSol, p.Decimals)
+		}
+	}
+	if l := c.Fees.ComputeUnitLimit; l > MaxComputeUnitLimit {
+		bad("fees.compute_unit_limit = %d: must be at most %d (0 = default)", l, MaxComputeUnitLimit)
+	}
+	if c.RPC.ShyftURL == "" {
+		bad("rpc.shyft_url is empty: set your primary RPC endpoint")
+	}
+	if cb := c.RPC.CircuitBreaker; cb.FailureThreshold < 1 || cb.ResetSeconds < 1 {
+		bad("rpc.circuit_breaker = %d failures / %ds: both must be at least 1", cb.FailureThreshold, cb.ResetSeconds)
+	}
+	if ms := c.Jupiter.QuoteCacheMs; ms < 0 {
+		bad("jupiter.quote_cache_ms = %d: must be 0 (off) or positive", ms)
+	} else if ms > 0 && ms <= int(MonitorInterval/time.Millisecond) {
+		// Allowed, but only quotes repeated within one pass are reused
+		log.Warn().
+			Int("quoteCacheMs", ms).
+			Dur("monitorInterval", MonitorInterval).
+			Msg("⚠️ jupiter.quote_cache_ms is within one monitor pass: the next pass re-quotes anyway")
+	}
+	if ms := c.WebSocket.MaxRPCLatencyMs; ms < 0 {
+		bad("websocket.max_rpc_latency_ms = %d: must be 0 (downtime alone) or positive", ms)
+	}
+	if j := c.Jupiter; j.Retries < 0 || j.RetryBackoffMs < 0 {
+		bad("jupiter.retries = %d, retry_backoff_ms = %d: must not be negative", j.Retries, j.RetryBackoffMs)
+	}
+	if j := c.Jupiter; j.ReferralAccount != "" || j.FeeBps != 0 {
+		if key, err := base58.Decode(j.ReferralAccount); err != nil || len(key) != 32 {
+			bad("jupiter.referral_account = %q: must be a token account address when fee_bps is set", j.ReferralAccount)
+		}
+		if j.FeeBps < 1 || j.FeeBps > MaxFeeBps {
+			bad("jupiter.fee_bps = %d: must be 1-%d with a referral_account", j.FeeBps, MaxFeeBps)
+		}
+	}
+	for _, e := range c.Telegram.AllowedIPs {
+		if _, _, err := net.ParseCIDR(e); err != nil && net.ParseIP(e) == nil {
+			bad("telegram.allowed_ips entry %q: not an IP or CIDR", e)
+		}
+	}
+	switch n := c.Notify; n.Provider {
+	case "":
+	case "telegram":
+		if n.ChatID == "" {
+			bad("notify.chat_id is empty: set the chat to post telegram notifications to")
+		}
+	case "discord", "slack":
+		if n.WebhookURL == "" {
+			bad("notify.webhook_url is empty: set the %s incoming webhook", n.Provider)
+		}
+	default:
+		bad("notify.provider = %q: must be telegram, discord, slack or empty", n.Provider)
+	}
+	for _, src := range c.Tokens.ResolverChain {
+		if src != "dexscreener" && src != "metadata" && src != "jupiter" {
+			bad("tokens.resolver_chain entry %q: must be dexscreener, metadata or jupiter", src)
+		}
+	}
+	if c.TUI.UIMode < 0 || c.TUI.UIMode > 4 {
+		bad("tui.ui_mode = %d: must be 1-4 (0 = default)", c.TUI.UIMode)
+	}
+	if m := c.Metrics; m.Enabled && m.Port > 0 && m.Port == c.Telegram.ListenPort {
+		bad("metrics.port = %d: already the signal server's port (use 0 to share it)", m.Port)
+	}
+	if s := c.Shutdown; s.SellOnExit && s.SellTimeoutSeconds < 1 {
+		bad("shutdown.sell_timeout_seconds = %d: must be at least 1 with sell_on_exit", s.SellTimeoutSeconds)
+	}
+	if c.RPC.FallbackURL == "" {
+		bad("rpc.fallback_url is empty: set a fallback RPC endpoint")
+	}
+
+	return errors.Join(errs...)
+}
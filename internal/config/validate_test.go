@@ -0,0 +1,146 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-pump-bot/internal/blockchain"
+)
+
+func validConfig() *Config {
+	return &Config{
+		Trading: TradingConfig{
+			MinEntryPercent:     50,
+			TakeProfitMultiple:  2,
+			MaxAllocPercent:     20,
+			MaxOpenPositions:    5,
+			MaxConcurrentTrades: 3,
+		},
+		RPC: RPCConfig{ShyftURL: "https://rpc.example", FallbackURL: "https://fallback.example",
+			CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 5, ResetSeconds: 30}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string // "" = valid
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{"min entry zero", func(c *Config) { c.Trading.MinEntryPercent = 0 }, "trading.min_entry_percent"},
+		{"min entry too high", func(c *Config) { c.Trading.MinEntryPercent = 1001 }, "trading.min_entry_percent"},
+		{"min entry at limit", func(c *Config) { c.Trading.MinEntryPercent = 1000 }, ""},
+		{"take profit at 1x", func(c *Config) { c.Trading.TakeProfitMultiple = 1 }, "trading.take_profit_multiple"},
+		{"alloc zero", func(c *Config) { c.Trading.MaxAllocPercent = 0 }, "trading.max_alloc_percent"},
+		{"alloc over 100", func(c *Config) { c.Trading.MaxAllocPercent = 500 }, "trading.max_alloc_percent"},
+		{"alloc at 100", func(c *Config) { c.Trading.MaxAllocPercent = 100 }, ""},
+		{"negative reserve", func(c *Config) { c.Trading.ReserveSol = -0.01 }, "trading.reserve_sol"},
+		{"empty paper wallet", func(c *Config) { c.Trading.PaperTrading = true }, "trading.paper_balance_sol"},
+		{"no positions", func(c *Config) { c.Trading.MaxOpenPositions = 0 }, "trading.max_open_positions"},
+		{"unknown sizing", func(c *Config) { c.Trading.Sizing.Strategy = "kelly" }, "trading.sizing.strategy"},
+		{"linear without range", func(c *Config) {
+			c.Trading.Sizing = SizingConfig{Strategy: SizingLinear, MinAllocPercent: 5, FullSizePercent: 40}
+		}, "trading.sizing.full_size_percent"},
+		{"no trade slots", func(c *Config) { c.Trading.MaxConcurrentTrades = 0 }, "trading.max_concurrent_trades"},
+		{"negative daily loss cap", func(c *Config) { c.Trading.MaxDailyLossSol = -1 }, "trading.max_daily_loss_sol"},
+		{"price impact over 100", func(c *Config) { c.Trading.MaxPriceImpactPercent = 150 }, "trading.max_price_impact_percent"},
+		{"compute units over max", func(c *Config) { c.Fees.ComputeUnitLimit = 2_000_000 }, "fees.compute_unit_limit"},
+		{"no primary rpc", func(c *Config) { c.RPC.ShyftURL = "" }, "rpc.shyft_url"},
+		{"no fallback rpc", func(c *Config) { c.RPC.FallbackURL = "" }, "rpc.fallback_url"},
+		{"bad allowed ip", func(c *Config) { c.Telegram.AllowedIPs = []string{"10.0.0.0/8", "not-an-ip"} }, "telegram.allowed_ips"},
+		{"breaker threshold zero", func(c *Config) { c.RPC.CircuitBreaker.FailureThreshold = 0 }, "rpc.circuit_breaker"},
+		{"negative quote cache", func(c *Config) { c.Jupiter.QuoteCacheMs = -1 }, "jupiter.quote_cache_ms"},
+		{"quote cache under monitor tick", func(c *Config) { c.Jupiter.QuoteCacheMs = 1000 }, ""},
+		{"quote cache over monitor tick", func(c *Config) { c.Jupiter.QuoteCacheMs = 6000 }, ""},
+		{"negative ws rpc latency", func(c *Config) { c.WebSocket.MaxRPCLatencyMs = -1 }, "websocket.max_rpc_latency_ms"},
+		{"fee without account", func(c *Config) { c.Jupiter.FeeBps = 50 }, "jupiter.referral_account"},
+		{"fee over cap", func(c *Config) {
+			c.Jupiter.ReferralAccount, c.Jupiter.FeeBps = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", 5000
+		}, "jupiter.fee_bps"},
+		{"fee configured", func(c *Config) {
+			c.Jupiter.ReferralAccount, c.Jupiter.FeeBps = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", 50
+		}, ""},
+	}
+	for _, tt := range tests {
+		c := validConfig()
+		tt.mutate(c)
+		err := Validate(c)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want mention of %s", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMaxComputeUnitLimit_MatchesBlockchain(t *testing.T) {
+	if MaxComputeUnitLimit != blockchain.MaxComputeUnitLimit {
+		t.Errorf("config cap %d, blockchain cap %d: keep them equal", MaxComputeUnitLimit, blockchain.MaxComputeUnitLimit)
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	c := validConfig()
+	c.Trading.TakeProfitMultiple = 0
+	c.Trading.MaxAllocPercent = 500
+	err := Validate(c)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"take_profit_multiple", "max_alloc_percent"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestManager_InvalidReloadKeepsPreviousConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(alloc string) {
+		yaml := "trading:\n  max_alloc_percent: " + alloc + "\nrpc:\n  shyft_url: http://127.0.0.1:0\n"
+		if err := os.WriteFile(path, []byte(yaml), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("500")
+	if _, err := NewManager(path); err == nil || !strings.Contains(err.Error(), "max_alloc_percent") {
+		t.Fatalf("NewManager with alloc 500: err = %v, want validation error", err)
+	}
+
+	write("10")
+	m, err := NewManager(path)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	// A valid edit is picked up by the watcher...
+	write("15")
+	waitForAlloc(t, m, 15)
+
+	// ...an invalid one is logged and ignored
+	write("500")
+	time.Sleep(300 * time.Millisecond)
+	if got := m.GetTrading().MaxAllocPercent; got != 15 {
+		t.Errorf("alloc after invalid reload = %v, want previous 15", got)
+	}
+}
+
+func waitForAlloc(t *testing.T, m *Manager, want float64) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if m.GetTrading().MaxAllocPercent == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("alloc = %v, want %v after reload", m.GetTrading().MaxAllocPercent, want)
+}
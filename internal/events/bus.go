@@ -0,0 +1,166 @@
+// Package events is a small in-process publish/subscribe bus. The executor
+// publishes what happened; consumers (the TUI, notifications, metrics)
+// subscribe without the executor knowing about them.
+package events
+
+import (
+	"sync"
+	"time"
+
+	signalPkg "solana-pump-bot/internal/signal"
+)
+
+// Event is anything published on the bus
+type Event interface {
+	EventName() string
+}
+
+// SignalReceived is published for every ingested signal (before trading checks)
+type SignalReceived struct {
+	Signal *signalPkg.Signal
+}
+
+// SignalSkipped is published for every signal the executor decides not to trade
+type SignalSkipped struct {
+	Mint      string
+	TokenName string
+	Reason    string // trading.Skip* constant
+}
+
+// TradeExecuted is published once a buy or sell transaction has been sent
+type TradeExecuted struct {
+	Side      string // "BUY" | "SELL" | "PARTIAL_SELL"
+	Mint      string
+	TokenName string
+	AmountSol float64
+	TxSig     string
+}
+
+// PositionUpdated is published when a position is opened, closed or revalued
+type PositionUpdated struct {
+	Mint   string
+	Closed bool
+}
+
+// BalanceChanged is published when the wallet SOL balance is refreshed
+type BalanceChanged struct {
+	SOL float64
+}
+
+// Error is published for every categorized execution failure
+type Error struct {
+	Stage    string // "buy", "sell", "partial_sell"
+	Category string
+	Message  string
+}
+
+func (SignalReceived) EventName() string  { return "signal_received" }
+func (SignalSkipped) EventName() string   { return "signal_skipped" }
+func (TradeExecuted) EventName() string   { return "trade_executed" }
+func (PositionUpdated) EventName() string { return "position_updated" }
+func (BalanceChanged) EventName() string  { return "balance_changed" }
+func (Error) EventName() string           { return "error" }
+
+// Envelope is an event with its publish time
+type Envelope struct {
+	At    time.Time
+	Event Event
+}
+
+// Bus fans events out to subscribers and keeps the last N for replay
+type Bus struct {
+	mu      sync.Mutex
+	subs    map[int]chan Envelope
+	nextID  int
+	history []Envelope
+	next    int
+	full    bool
+	dropped int
+}
+
+// NewBus creates a bus that keeps historySize events for late subscribers
+func NewBus(historySize int) *Bus {
+	if historySize <= 0 {
+		historySize = 100
+	}
+	return &Bus{
+		subs:    make(map[int]chan Envelope),
+		history: make([]Envelope, historySize),
+	}
+}
+
+// Publish records ev and delivers it to every subscriber. It never blocks:
+// a subscriber whose buffer is full misses the event (counted in Dropped).
+func (b *Bus) Publish(ev Event) {
+	env := Envelope{At: time.Now(), Event: ev}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.history[b.next] = env
+	b.next = (b.next + 1) % len(b.history)
+	if b.next == 0 {
+		b.full = true
+	}
+
+	for _, ch := range b.subs {
+		select {
+		case ch <- env:
+		default:
+			b.dropped++
+		}
+	}
+}
+
+// Subscribe returns a channel of events and a function that ends the
+// subscription. With replay, buffered history is delivered first (oldest
+// first), so a consumer started late still sees recent activity.
+func (b *Bus) Subscribe(buffer int, replay bool) (<-chan Envelope, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var past []Envelope
+	if replay {
+		past = b.historyLocked()
+	}
+	ch := make(chan Envelope, buffer+len(past))
+	for _, env := range past {
+		ch <- env
+	}
+
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[id]; ok {
+			delete(b.subs, id)
+			close(ch)
+		}
+	}
+}
+
+// History returns the buffered events, oldest first
+func (b *Bus) History() []Envelope {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.historyLocked()
+}
+
+// Dropped returns how many deliveries were skipped because a subscriber was full
+func (b *Bus) Dropped() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+func (b *Bus) historyLocked() []Envelope {
+	if !b.full {
+		return append([]Envelope(nil), b.history[:b.next]...)
+	}
+	out := make([]Envelope, 0, len(b.history))
+	out = append(out, b.history[b.next:]...)
+	return append(out, b.history[:b.next]...)
+}
@@ -0,0 +1,44 @@
+package events
+
+import "testing"
+
+func TestBus_DeliversAndReplays(t *testing.T) {
+	b := NewBus(2)
+
+	live, cancel := b.Subscribe(4, false)
+	defer cancel()
+
+	b.Publish(BalanceChanged{SOL: 1})
+	b.Publish(BalanceChanged{SOL: 2})
+	b.Publish(BalanceChanged{SOL: 3})
+
+	for want := 1.0; want <= 3; want++ {
+		env := <-live
+		if got := env.Event.(BalanceChanged).SOL; got != want {
+			t.Fatalf("live event SOL = %v, want %v", got, want)
+		}
+	}
+
+	// A late subscriber replays only what history kept (last 2), oldest first
+	late, cancelLate := b.Subscribe(1, true)
+	defer cancelLate()
+	for _, want := range []float64{2, 3} {
+		env := <-late
+		if got := env.Event.(BalanceChanged).SOL; got != want {
+			t.Fatalf("replayed SOL = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestBus_PublishNeverBlocks(t *testing.T) {
+	b := NewBus(10)
+	_, cancel := b.Subscribe(1, false)
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		b.Publish(PositionUpdated{Mint: "m"})
+	}
+	if got := b.Dropped(); got != 4 {
+		t.Errorf("dropped = %d, want 4", got)
+	}
+}
@@ -2,113 +2,220 @@ package health
 
 import (
 	"context"
-	"net/http"
+	"errors"
+	"fmt"
+	"net/url"
 	"sync"
 	"time"
+
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/jupiter"
 )
 
+// USDCMint is the quote target of the Jupiter probe
+const USDCMint = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+
+// JupiterProbeLamports is the SOL amount the Jupiter probe quotes (0.001 SOL)
+const JupiterProbeLamports = 1_000_000
+
+// DefaultTimeout bounds each probe when NewChecker gets 0
+const DefaultTimeout = 5 * time.Second
+
 // Status represents the health status of a component
 type Status struct {
-	Name    string
-	Healthy bool
-	Latency time.Duration
-	Error   string
+	Name      string        `json:"name"`
+	Healthy   bool          `json:"healthy"`
+	Latency   time.Duration `json:"-"`
+	LatencyMs int64         `json:"latency_ms"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// Report is one round of checks; Healthy only if every component is
+type Report struct {
+	Healthy    bool      `json:"healthy"`
+	Components []Status  `json:"components"`
+	CheckedAt  time.Time `json:"checked_at"`
 }
 
-// Checker periodically checks health of system components
+// Probe checks one component; a nil error means healthy
+type Probe func(ctx context.Context) error
+
+type namedProbe struct {
+	name  string
+	probe Probe
+}
+
+// Checker runs the registered probes and keeps the latest report
 type Checker struct {
-	mu       sync.RWMutex
-	statuses []Status
-	rpcURL   string
-	httpURL  string // Telegram listener endpoint
+	mu      sync.RWMutex
+	probes  []namedProbe
+	timeout time.Duration
+	last    Report
 }
 
-// NewChecker creates a new health checker
-func NewChecker(rpcURL, httpURL string) *Checker {
-	return &Checker{
-		rpcURL:  rpcURL,
-		httpURL: httpURL,
+// NewChecker creates a health checker; timeout bounds each probe
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
 	}
+	return &Checker{timeout: timeout}
 }
 
-// Start begins periodic health checks
-func (c *Checker) Start(ctx context.Context) {
-	go func() {
-		ticker := time.NewTicker(10 * time.Second)
-		defer ticker.Stop()
-		
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				c.check()
-			}
+// Add registers a component; reports list components in the order added
+func (c *Checker) Add(name string, probe Probe) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probes = append(c.probes, namedProbe{name, probe})
+}
+
+// Check runs every probe in parallel and stores the result as the latest report
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	probes := append([]namedProbe(nil), c.probes...)
+	c.mu.RUnlock()
+
+	statuses := make([]Status, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func(i int, p namedProbe) {
+			defer wg.Done()
+			statuses[i] = c.run(ctx, p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	report := Report{Healthy: true, Components: statuses, CheckedAt: time.Now()}
+	for _, s := range statuses {
+		if !s.Healthy {
+			report.Healthy = false
 		}
-	}()
-	
-	// Initial check
-	c.check()
-}
-
-func (c *Checker) check() {
-	var statuses []Status
-	
-	// Check RPC
-	rpcStatus := c.checkRPC()
-	statuses = append(statuses, rpcStatus)
-	
-	// Check Telegram HTTP
-	telegramStatus := c.checkHTTP()
-	statuses = append(statuses, telegramStatus)
-	
+	}
+
 	c.mu.Lock()
-	c.statuses = statuses
+	c.last = report
 	c.mu.Unlock()
+	return report
 }
 
-func (c *Checker) checkRPC() Status {
+func (c *Checker) run(ctx context.Context, p namedProbe) Status {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
 	start := time.Now()
-	
-	client := &http.Client{Timeout: 5 * time.Second}
-	req, _ := http.NewRequest("POST", c.rpcURL, nil)
-	req.Header.Set("Content-Type", "application/json")
-	
-	_, err := client.Do(req)
+	err := p.probe(ctx)
 	latency := time.Since(start)
-	
+
 	status := Status{
-		Name:    "RPC",
-		Latency: latency,
-		Healthy: err == nil,
+		Name:      p.name,
+		Healthy:   err == nil,
+		Latency:   latency,
+		LatencyMs: latency.Milliseconds(),
 	}
 	if err != nil {
-		status.Error = err.Error()
+		status.Error = describe(err)
 	}
 	return status
 }
 
-func (c *Checker) checkHTTP() Status {
-	start := time.Now()
-	
-	client := &http.Client{Timeout: 5 * time.Second}
-	_, err := client.Get(c.httpURL + "/health")
-	latency := time.Since(start)
-	
-	status := Status{
-		Name:    "Telegram",
-		Latency: latency,
-		Healthy: err == nil,
+// describe renders a probe error for /healthz and the TUI. HTTP client
+// errors quote the full endpoint URL, which can carry an API key; only
+// its host is kept.
+func describe(err error) string {
+	var ue *url.Error
+	if !errors.As(err, &ue) {
+		return err.Error()
 	}
-	if err != nil {
-		status.Error = err.Error()
+	host := "endpoint"
+	if u, perr := url.Parse(ue.URL); perr == nil && u.Host != "" {
+		host = u.Host
 	}
-	return status
+	return fmt.Sprintf("%s %s: %v", ue.Op, host, ue.Err)
 }
 
-// GetStatuses returns current health statuses
-func (c *Checker) GetStatuses() []Status {
+// Start checks in the background now and then every interval until ctx is done
+func (c *Checker) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		c.Check(ctx)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.Check(ctx)
+			}
+		}
+	}()
+}
+
+// Last returns the latest report (zero CheckedAt before the first check)
+func (c *Checker) Last() Report {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.statuses
+	return c.last
+}
+
+// Current returns the latest report, checking first if it is older than
+// maxAge, so frequent /healthz scrapes don't each hit RPC and Jupiter
+func (c *Checker) Current(ctx context.Context, maxAge time.Duration) Report {
+	if last := c.Last(); !last.CheckedAt.IsZero() && time.Since(last.CheckedAt) <= maxAge {
+		return last
+	}
+	return c.Check(ctx)
+}
+
+// GetStatuses returns current health statuses
+func (c *Checker) GetStatuses() []Status {
+	return c.Last().Components
+}
+
+// BlockhashSource is the RPC call the RPC probe makes
+type BlockhashSource interface {
+	GetLatestBlockhash(ctx context.Context) (*blockchain.BlockhashResult, error)
+}
+
+// Quoter is the Jupiter call the Jupiter probe makes
+type Quoter interface {
+	GetQuote(ctx context.Context, inputMint, outputMint string, amountLamports uint64) (*jupiter.QuoteResponse, error)
+}
+
+// Pinger is the database round-trip the DB probe makes
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// RPCProbe fetches the latest blockhash
+func RPCProbe(rpc BlockhashSource) Probe {
+	return func(ctx context.Context) error {
+		_, err := rpc.GetLatestBlockhash(ctx)
+		return err
+	}
+}
+
+// JupiterProbe quotes a small SOL->USDC swap
+func JupiterProbe(q Quoter) Probe {
+	return func(ctx context.Context) error {
+		_, err := q.GetQuote(ctx, jupiter.SOLMint, USDCMint, JupiterProbeLamports)
+		return err
+	}
+}
+
+// DBProbe runs SELECT 1
+func DBProbe(db Pinger) Probe {
+	return db.Ping
+}
+
+// ErrWSDisconnected is the WebSocket probe's failure
+var ErrWSDisconnected = errors.New("websocket not connected")
+
+// WebSocketProbe reports the connection state; it makes no network call
+func WebSocketProbe(connected func() bool) Probe {
+	return func(ctx context.Context) error {
+		if !connected() {
+			return ErrWSDisconnected
+		}
+		return nil
+	}
 }
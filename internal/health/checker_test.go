@@ -0,0 +1,130 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/jupiter"
+)
+
+type fakeRPC struct{ err error }
+
+func (f fakeRPC) GetLatestBlockhash(ctx context.Context) (*blockchain.BlockhashResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &blockchain.BlockhashResult{}, nil
+}
+
+type fakeJupiter struct {
+	err       error
+	in, out   string
+	lamports  uint64
+	blockTill bool // wait for the probe deadline, like a hung API
+}
+
+func (f *fakeJupiter) GetQuote(ctx context.Context, inputMint, outputMint string, amountLamports uint64) (*jupiter.QuoteResponse, error) {
+	f.in, f.out, f.lamports = inputMint, outputMint, amountLamports
+	if f.blockTill {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &jupiter.QuoteResponse{}, nil
+}
+
+type fakeDB struct{ err error }
+
+func (f fakeDB) Ping(ctx context.Context) error { return f.err }
+
+func newChecker(rpcErr, dbErr error, jup *fakeJupiter, wsUp bool) *Checker {
+	c := NewChecker(200 * time.Millisecond)
+	c.Add("RPC", RPCProbe(fakeRPC{rpcErr}))
+	c.Add("Jupiter", JupiterProbe(jup))
+	c.Add("Database", DBProbe(fakeDB{dbErr}))
+	c.Add("WebSocket", WebSocketProbe(func() bool { return wsUp }))
+	return c
+}
+
+func TestChecker_AllHealthy(t *testing.T) {
+	jup := &fakeJupiter{}
+	report := newChecker(nil, nil, jup, true).Check(context.Background())
+
+	if !report.Healthy {
+		t.Fatalf("report unhealthy: %+v", report)
+	}
+	var names []string
+	for _, s := range report.Components {
+		names = append(names, s.Name)
+		if !s.Healthy || s.Error != "" {
+			t.Errorf("%s: healthy=%v error=%q", s.Name, s.Healthy, s.Error)
+		}
+	}
+	if got := strings.Join(names, ","); got != "RPC,Jupiter,Database,WebSocket" {
+		t.Errorf("components = %s, want registration order", got)
+	}
+	if jup.in != jupiter.SOLMint || jup.out != USDCMint || jup.lamports != JupiterProbeLamports {
+		t.Errorf("jupiter probe quoted %s->%s %d", jup.in, jup.out, jup.lamports)
+	}
+	if report.CheckedAt.IsZero() {
+		t.Error("CheckedAt not set")
+	}
+}
+
+func TestChecker_Degraded(t *testing.T) {
+	jup := &fakeJupiter{blockTill: true}
+	c := newChecker(errors.New("rpc down"), nil, jup, false)
+
+	start := time.Now()
+	report := c.Check(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("check took %v, want about the 200ms probe timeout", elapsed)
+	}
+	if report.Healthy {
+		t.Fatal("report healthy with RPC, Jupiter and WebSocket down")
+	}
+
+	want := map[string]bool{"RPC": false, "Jupiter": false, "Database": true, "WebSocket": false}
+	for _, s := range report.Components {
+		if s.Healthy != want[s.Name] {
+			t.Errorf("%s healthy = %v, want %v (error %q)", s.Name, s.Healthy, want[s.Name], s.Error)
+		}
+		if !s.Healthy && s.Error == "" {
+			t.Errorf("%s unhealthy without an error", s.Name)
+		}
+	}
+	if got := c.Last(); !got.CheckedAt.Equal(report.CheckedAt) {
+		t.Error("Last() is not the latest report")
+	}
+}
+
+func TestChecker_CurrentReusesFreshReport(t *testing.T) {
+	calls := 0
+	c := NewChecker(time.Second)
+	c.Add("count", func(context.Context) error { calls++; return nil })
+
+	c.Current(context.Background(), time.Minute)
+	c.Current(context.Background(), time.Minute)
+	if calls != 1 {
+		t.Errorf("probe ran %d times, want 1 while the report is fresh", calls)
+	}
+	c.Current(context.Background(), 0)
+	if calls != 2 {
+		t.Errorf("probe ran %d times, want a re-check once the report is stale", calls)
+	}
+}
+
+func TestDescribe_HidesEndpointURL(t *testing.T) {
+	err := &url.Error{Op: "Post", URL: "https://rpc.example.com/v1?api-key=secret", Err: errors.New("connection refused")}
+	got := describe(errors.Join(errors.New("http request"), err))
+	if strings.Contains(got, "secret") || !strings.Contains(got, "rpc.example.com") {
+		t.Errorf("describe = %q, want the host without the key", got)
+	}
+}
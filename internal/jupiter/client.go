@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -21,18 +24,35 @@ import (
 // Metis API endpoint (new, faster)
 const MetisSwapURL = "https://api.jup.ag/swap/v1"
 
+// DefaultKeyCooldown is how long a key that got a 429 sits out of rotation
+const DefaultKeyCooldown = 30 * time.Second
+
+// DefaultMaxPriorityLamports caps Jupiter's veryHigh dynamic priority fee
+const DefaultMaxPriorityLamports = 1_250_000
+
 // Client handles Jupiter Metis API calls with HTTP/2 pooling and API key rotation
 type Client struct {
 	baseURL     string
-	slippageBps int
+	slippageBps atomic.Int32 // Default slippage (hot-reloadable)
 	clientPool  *HTTPClientPool
 	apiKeys     []string
 	keyIdx      atomic.Uint32
-	maxLamports uint64 // Max priority fee cap
+	keyMu       sync.Mutex
+	keyCooldown time.Duration // How long a rate-limited key is skipped
+	coolUntil   []time.Time   // Per key: skipped until then (429)
+	allCooling  bool          // Logged that every key is cooling down
+	retries     int           // Extra attempts on 429/5xx gateway errors and timeouts
+	backoff     time.Duration // Delay before the first retry, doubling after
+	feeAccount  string        // Referral token account collecting the platform fee ("" = none)
+	feeBps      int           // Platform fee charged on swaps (with feeAccount)
+	maxLamports atomic.Uint64 // Max priority fee cap (raised by the executor's fee bump)
+	maxQuoteAge time.Duration // Re-quote before building a swap from an older quote (0 = never)
+	quotes      quoteCache    // Short-lived valuation quotes (GetQuoteCached)
 	
 	// Simulation
 	simMode       bool
 	simMultiplier float64
+	simPrices     map[string]SimPrice // Seed prices by lowercased mint (see sim.go)
 	simMu         sync.RWMutex
 }
 
@@ -82,6 +102,19 @@ func NewHTTPClientPool(size int, timeout time.Duration) *HTTPClientPool {
 	return pool
 }
 
+// SetDialContext replaces the dialer on every pooled transport (e.g. IPv4-only, cached DNS).
+// Call before the pool is used; existing idle connections are closed.
+func (p *HTTPClientPool) SetDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, c := range p.clients {
+		if t, ok := c.Transport.(*http.Transport); ok {
+			t.DialContext = dial
+			t.CloseIdleConnections()
+		}
+	}
+}
+
 func (p *HTTPClientPool) Get() *http.Client {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -106,14 +139,18 @@ func NewClientWithKeys(baseURL string, slippageBps int, timeout time.Duration, a
 		}
 	}
 	
-	return &Client{
+	c := &Client{
 		baseURL:       MetisSwapURL, // Use Metis endpoint
-		slippageBps:   slippageBps,
 		clientPool:    NewHTTPClientPool(4, timeout),
 		apiKeys:       apiKeys,
-		maxLamports:   1_250_000,
+		keyCooldown:   DefaultKeyCooldown,
+		coolUntil:     make([]time.Time, len(apiKeys)),
 		simMultiplier: 1.0,
+		quotes:        quoteCache{entries: make(map[quoteKey]cachedQuote)},
 	}
+	c.slippageBps.Store(int32(slippageBps))
+	c.maxLamports.Store(DefaultMaxPriorityLamports)
+	return c
 }
 
 // SetSimulation configures the simulation mode
@@ -125,25 +162,180 @@ func (c *Client) SetSimulation(enabled bool, multiplier float64) {
 	log.Info().Bool("enabled", enabled).Float64("mult", multiplier).Msg("Jupiter Simulation Mode Configured")
 }
 
-// getAPIKey returns next API key (round-robin)
-func (c *Client) getAPIKey() string {
-	idx := c.keyIdx.Add(1) % uint32(len(c.apiKeys))
-	return c.apiKeys[idx]
+// SetBaseURL overrides the Metis API endpoint (used by tests and self-hosted proxies)
+func (c *Client) SetBaseURL(url string) {
+	c.baseURL = strings.TrimRight(url, "/")
 }
 
+// SetMaxQuoteAge sets how old a quote may be when a swap is built from it
+func (c *Client) SetMaxQuoteAge(d time.Duration) {
+	c.maxQuoteAge = d
+}
+
+// SetRetry sets how many times a request is retried after a retryable
+// failure, and the delay before the first retry (doubling each attempt)
+func (c *Client) SetRetry(retries int, backoff time.Duration) {
+	c.retries = retries
+	c.backoff = backoff
+}
+
+// SetPlatformFee charges feeBps on every swap, paid to the referral
+// account; an empty account or 0 bps disables the fee
+func (c *Client) SetPlatformFee(account string, feeBps int) {
+	if account == "" || feeBps <= 0 {
+		account, feeBps = "", 0
+	}
+	c.feeAccount = account
+	c.feeBps = feeBps
+}
+
+// SetDialContext routes all Jupiter connections through a custom dialer
+func (c *Client) SetDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) {
+	c.clientPool.SetDialContext(dial)
+}
+
+// SetKeyCooldown sets how long a key that got a 429 is skipped
+func (c *Client) SetKeyCooldown(d time.Duration) {
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	c.keyCooldown = d
+}
+
+// getAPIKey returns the next API key (round-robin) and its index, skipping
+// keys cooling down after a 429. With every key cooling down it returns
+// the one whose cooldown ends first.
+func (c *Client) getAPIKey() (int, string) {
+	n := uint32(len(c.apiKeys))
+	start := c.keyIdx.Add(1)
+	now := time.Now()
+
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	soonest := int(start % n)
+	for i := uint32(0); i < n; i++ {
+		idx := int((start + i) % n)
+		if !now.Before(c.coolUntil[idx]) {
+			c.allCooling = false
+			return idx, c.apiKeys[idx]
+		}
+		if c.coolUntil[idx].Before(c.coolUntil[soonest]) {
+			soonest = idx
+		}
+	}
+	if !c.allCooling {
+		c.allCooling = true
+		log.Warn().Int("keys", len(c.apiKeys)).Dur("next_ready", c.coolUntil[soonest].Sub(now)).
+			Msg("⚠️ All Jupiter API keys rate limited, using the first to recover")
+	}
+	return soonest, c.apiKeys[soonest]
+}
+
+// retryableStatus reports whether a response status is worth another
+// attempt: rate limits and gateway errors. Other 4xx (400 bad mint, 422
+// no route) fail the same way every time.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// do sends the request built by newReq with the next API key, retrying
+// retryable statuses and client timeouts on the next pooled client. The
+// final response is returned whatever its status; the caller closes it.
+func (c *Client) do(ctx context.Context, newReq func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			wait := c.backoff << (attempt - 1)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+		last := attempt >= c.retries
+
+		req, err := newReq()
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		keyIdx, apiKey := c.getAPIKey()
+		req.Header.Set("x-api-key", apiKey)
+
+		resp, err := c.clientPool.Get().Do(req)
+		if err != nil {
+			// A client timeout is retryable; the caller's own deadline is not
+			var ne net.Error
+			if last || ctx.Err() != nil || !errors.As(err, &ne) || !ne.Timeout() {
+				return nil, fmt.Errorf("http request: %w", err)
+			}
+			log.Warn().Int("attempt", attempt+1).Msg("⚠️ Jupiter request timed out, retrying")
+			continue
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			c.markRateLimited(keyIdx)
+		}
+		if last || !retryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		log.Warn().Int("attempt", attempt+1).Int("status", resp.StatusCode).Msg("⚠️ Jupiter request failed, retrying")
+	}
+}
+
+// markRateLimited takes a key out of rotation for the cooldown after a 429
+func (c *Client) markRateLimited(idx int) {
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	c.coolUntil[idx] = time.Now().Add(c.keyCooldown)
+	log.Warn().Int("key", idx).Dur("cooldown", c.keyCooldown).Msg("⚠️ Jupiter API key rate limited, rotating")
+}
+
+// Swap modes: which side of the swap the quoted amount fixes
+const (
+	SwapModeExactIn  = "ExactIn"  // spend exactly amount of the input (default)
+	SwapModeExactOut = "ExactOut" // receive exactly amount of the output
+)
+
 // QuoteResponse from Jupiter
+//
+// OtherAmountThreshold is the slippage bound on the side that isn't fixed:
+// for ExactIn the minimum output accepted, for ExactOut the maximum input
+// the swap may spend (InAmount plus slippage) to deliver OutAmount.
 type QuoteResponse struct {
 	InputMint            string          `json:"inputMint"`
 	InAmount             string          `json:"inAmount"`
 	OutputMint           string          `json:"outputMint"`
 	OutAmount            string          `json:"outAmount"`
 	OtherAmountThreshold string          `json:"otherAmountThreshold"`
-	SwapMode             string          `json:"swapMode"`
+	SwapMode             string          `json:"swapMode"` // ExactIn | ExactOut
 	SlippageBps          int             `json:"slippageBps"`
 	PriceImpactPct       string          `json:"priceImpactPct"`
 	RoutePlan            []RoutePlanStep `json:"routePlan"`
 	ContextSlot          uint64          `json:"contextSlot"`
 	TimeTaken            float64         `json:"timeTaken"`
+
+	FetchedAt time.Time `json:"-"` // When the quote was received (local clock)
+}
+
+// Age returns how long ago the quote was received
+func (q *QuoteResponse) Age() time.Duration {
+	if q.FetchedAt.IsZero() {
+		return 0
+	}
+	return time.Since(q.FetchedAt)
+}
+
+// PriceImpactPercent returns the quote's price impact in percent. Jupiter
+// reports priceImpactPct as a fraction ("0.4" = 40%); unparseable is 0.
+func (q *QuoteResponse) PriceImpactPercent() float64 {
+	f, err := strconv.ParseFloat(q.PriceImpactPct, 64)
+	if err != nil {
+		return 0
+	}
+	return math.Abs(f) * 100
 }
 
 type RoutePlanStep struct {
@@ -167,6 +359,8 @@ type SwapResponse struct {
 	SwapTransaction          string `json:"swapTransaction"`
 	LastValidBlockHeight     uint64 `json:"lastValidBlockHeight"`
 	PrioritizationFeeLamports uint64 `json:"prioritizationFeeLamports"`
+
+	Quote *QuoteResponse `json:"-"` // The quote the swap was built from (after any re-quote)
 }
 
 // PriorityLevelWithMaxLamports for dynamic fee estimation
@@ -180,6 +374,36 @@ type PriorityLevelWithMaxLamports struct {
 
 // GetQuote fetches a swap quote from Jupiter
 func (c *Client) GetQuote(ctx context.Context, inputMint, outputMint string, amountLamports uint64) (*QuoteResponse, error) {
+	return c.GetQuoteWithSlippage(ctx, inputMint, outputMint, amountLamports, c.SlippageBps())
+}
+
+// GetQuoteWithSlippage fetches a quote with a per-call slippage (e.g. learned per mint)
+func (c *Client) GetQuoteWithSlippage(ctx context.Context, inputMint, outputMint string, amountLamports uint64, slippageBps int) (*QuoteResponse, error) {
+	return c.getQuote(ctx, inputMint, outputMint, amountLamports, slippageBps, SwapModeExactIn)
+}
+
+// GetQuoteExactOut quotes receiving exactly outAmount of outputMint (e.g. an
+// exact token amount to buy, or an exact SOL amount to sell for). InAmount
+// is the expected cost; OtherAmountThreshold the most the swap may spend.
+func (c *Client) GetQuoteExactOut(ctx context.Context, inputMint, outputMint string, outAmount uint64, slippageBps int) (*QuoteResponse, error) {
+	return c.getQuote(ctx, inputMint, outputMint, outAmount, slippageBps, SwapModeExactOut)
+}
+
+// quoteURL builds the quote request URL; ExactIn is Jupiter's default and left implicit
+func (c *Client) quoteURL(inputMint, outputMint string, amount uint64, slippageBps int, swapMode string) string {
+	url := fmt.Sprintf("%s/quote?inputMint=%s&outputMint=%s&amount=%d&slippageBps=%d",
+		c.baseURL, inputMint, outputMint, amount, slippageBps)
+	if swapMode == SwapModeExactOut {
+		url += "&swapMode=" + SwapModeExactOut
+	}
+	if c.feeBps > 0 {
+		// The fee is priced into the route at quote time; /swap names the account
+		url += fmt.Sprintf("&platformFeeBps=%d", c.feeBps)
+	}
+	return url
+}
+
+func (c *Client) getQuote(ctx context.Context, inputMint, outputMint string, amountLamports uint64, slippageBps int, swapMode string) (*QuoteResponse, error) {
 	// Simulation Interceptor
 	c.simMu.RLock()
 	isSim := c.simMode
@@ -187,59 +411,23 @@ func (c *Client) GetQuote(ctx context.Context, inputMint, outputMint string, amo
 	c.simMu.RUnlock()
 	
 	if isSim {
-		// Mock logic: return amount * multiplier
-		// If input is SOL (SOLMint), we are buying -> return output (Tokens) * multiplier?
-		// Usually price is determined by market.
-		// For our test: 
-		// "Assume random coin reached 50%" calls GetQuote? No, Telegram signal provides price.
-		// Monitoring loop calls GetQuote to check value of HELD TOKENS (Input=Token, Output=SOL).
-		
-		// If Input != SOLMint (Selling/Checking Value):
-		if inputMint != "So11111111111111111111111111111111111111112" {
-			// Calculate Mock Output (SOL)
-			// Assume 1:1 base price * multiplier
-			outAmt := float64(amountLamports) * mult
-			return &QuoteResponse{
-				InputMint: inputMint,
-				InAmount: fmt.Sprintf("%d", amountLamports),
-				OutputMint: outputMint,
-				OutAmount: fmt.Sprintf("%.0f", outAmt),
-				PriceImpactPct: "0.0",
-			}, nil
-		} else {
-			// Buying (SOL -> Token)
-			// Assume 1 SOL = 1 Token (adjusted for decimals temporarily) or just pass through amount
-			// But since signals usually are small amounts, let's say 1 SOL = 100 FakeTokens
-			// Multiplier usually applies to PRICE. If price is 2.5X, buying gives FEWER tokens.
-			// But for simulation simplicity:
-			// Just return OutAmount = InAmount. (1:1)
-			outAmt := amountLamports 
-			return &QuoteResponse{
-				InputMint: inputMint,
-				InAmount: fmt.Sprintf("%d", amountLamports),
-				OutputMint: outputMint,
-				OutAmount: fmt.Sprintf("%d", outAmt),
-				PriceImpactPct: "0.0",
-			}, nil
-		}
+		return c.simQuote(inputMint, outputMint, amountLamports, slippageBps, swapMode, mult), nil
 	}
 
 	start := time.Now()
 
-	url := fmt.Sprintf("%s/quote?inputMint=%s&outputMint=%s&amount=%d&slippageBps=%d",
-		c.baseURL, inputMint, outputMint, amountLamports, c.slippageBps)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("x-api-key", c.getAPIKey())
+	url := c.quoteURL(inputMint, outputMint, amountLamports, slippageBps, swapMode)
 
-	client := c.clientPool.Get()
-	resp, err := client.Do(req)
+	resp, err := c.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -253,6 +441,13 @@ func (c *Client) GetQuote(ctx context.Context, inputMint, outputMint string, amo
 	if err := json.NewDecoder(resp.Body).Decode(&quote); err != nil {
 		return nil, fmt.Errorf("decode quote: %w", err)
 	}
+	quote.FetchedAt = time.Now()
+	if quote.SlippageBps == 0 {
+		quote.SlippageBps = slippageBps
+	}
+	if quote.SwapMode == "" {
+		quote.SwapMode = swapMode
+	}
 
 	log.Debug().
 		Dur("latency", time.Since(start)).
@@ -264,6 +459,22 @@ func (c *Client) GetQuote(ctx context.Context, inputMint, outputMint string, amo
 
 // GetSwapTransaction fetches swap TX using Jupiter Metis API with veryHigh priority
 func (c *Client) GetSwapTransaction(ctx context.Context, inputMint, outputMint, userPubkey string, amountLamports uint64) (string, error) {
+	return c.GetSwapTransactionWithSlippage(ctx, inputMint, outputMint, userPubkey, amountLamports, c.SlippageBps())
+}
+
+// GetSwapTransactionWithSlippage is GetSwapTransaction with a per-call slippage
+func (c *Client) GetSwapTransactionWithSlippage(ctx context.Context, inputMint, outputMint, userPubkey string, amountLamports uint64, slippageBps int) (string, error) {
+	swap, err := c.GetSwapWithSlippage(ctx, inputMint, outputMint, userPubkey, amountLamports, slippageBps)
+	if err != nil {
+		return "", err
+	}
+	return swap.SwapTransaction, nil
+}
+
+// GetSwapWithSlippage returns the full swap response, including the
+// LastValidBlockHeight after which the transaction's blockhash has expired
+// (0 in simulation mode)
+func (c *Client) GetSwapWithSlippage(ctx context.Context, inputMint, outputMint, userPubkey string, amountLamports uint64, slippageBps int) (*SwapResponse, error) {
 	// Simulation Interceptor
 	c.simMu.RLock()
 	isSim := c.simMode
@@ -276,19 +487,94 @@ func (c *Client) GetSwapTransaction(ctx context.Context, inputMint, outputMint,
 		// - Bytes 1-64: 0x00... (Empty Signature Slot)
 		// - Bytes 65-66: 0x00 0x01 (Minimal Dummy Message)
 		// This ensures SignSerializedTransaction can identify the signature slot and message without crashing.
-		return "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABAA==", nil
+		return &SwapResponse{SwapTransaction: simSwapTransaction}, nil
 	}
 
 	start := time.Now()
 
 	// Get quote first
-	quote, err := c.GetQuote(ctx, inputMint, outputMint, amountLamports)
+	quote, err := c.GetQuoteWithSlippage(ctx, inputMint, outputMint, amountLamports, slippageBps)
 	if err != nil {
-		return "", fmt.Errorf("get quote: %w", err)
+		return nil, fmt.Errorf("get quote: %w", err)
 	}
 
 	quoteLatency := time.Since(start)
 
+	swapResp, err := c.GetSwapFromQuote(ctx, quote, userPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	totalLatency := time.Since(start)
+	swapLatency := totalLatency - quoteLatency
+
+	log.Info().
+		Dur("quoteLatency", quoteLatency).
+		Dur("swapLatency", swapLatency).
+		Dur("totalLatency", totalLatency).
+		Uint64("priorityFee", swapResp.PrioritizationFeeLamports).
+		Msg("jupiter swap tx")
+
+	return swapResp, nil
+}
+
+// GetSwapExactOut builds a swap that receives exactly outAmount of
+// outputMint, spending at most the quote's OtherAmountThreshold of the input.
+// The swap mode travels to /swap inside the quote response.
+func (c *Client) GetSwapExactOut(ctx context.Context, inputMint, outputMint, userPubkey string, outAmount uint64, slippageBps int) (*SwapResponse, error) {
+	quote, err := c.GetQuoteExactOut(ctx, inputMint, outputMint, outAmount, slippageBps)
+	if err != nil {
+		return nil, fmt.Errorf("get quote: %w", err)
+	}
+	return c.GetSwapFromQuote(ctx, quote, userPubkey)
+}
+
+// simSwapTransaction is the dummy transaction returned in simulation mode
+const simSwapTransaction = "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABAA=="
+
+// GetSwapFromQuote builds a swap transaction for an existing quote.
+//
+// Quote freshness: a quote older than the configured max age is re-fetched
+// (same mints, amount and slippage) before the swap is built, so a signed
+// swap never prices off a stale route. Where staleness can occur:
+//   - GetSwapWithSlippage quotes and swaps back to back; the quote is only as
+//     old as the quote request itself, so the guard trips on slow responses.
+//   - The position monitor quotes for valuation and exit decisions, but the
+//     resulting sell calls GetSwapWithSlippage and re-quotes, so the decision
+//     may use a price up to one monitor tick old while the signed swap does not.
+//   - Any caller that keeps a quote around and swaps later (this method) is
+//     covered by the age check below.
+func (c *Client) GetSwapFromQuote(ctx context.Context, quote *QuoteResponse, userPubkey string) (*SwapResponse, error) {
+	c.simMu.RLock()
+	isSim := c.simMode
+	c.simMu.RUnlock()
+	if isSim {
+		return &SwapResponse{SwapTransaction: simSwapTransaction}, nil
+	}
+
+	if c.maxQuoteAge > 0 && quote.Age() > c.maxQuoteAge {
+		// Re-quote the fixed side: the input for ExactIn, the output for ExactOut
+		swapMode, fixed := SwapModeExactIn, quote.InAmount
+		if quote.SwapMode == SwapModeExactOut {
+			swapMode, fixed = SwapModeExactOut, quote.OutAmount
+		}
+		var amount uint64
+		fmt.Sscanf(fixed, "%d", &amount)
+		slippageBps := quote.SlippageBps
+		if slippageBps == 0 {
+			slippageBps = c.SlippageBps()
+		}
+		log.Debug().
+			Dur("age", quote.Age()).
+			Dur("maxAge", c.maxQuoteAge).
+			Msg("stale quote, re-quoting before swap")
+		fresh, err := c.getQuote(ctx, quote.InputMint, quote.OutputMint, amount, slippageBps, swapMode)
+		if err != nil {
+			return nil, fmt.Errorf("re-quote: %w", err)
+		}
+		quote = fresh
+	}
+
 	// Build swap request with dynamic priority fee (veryHigh with cap)
 	reqBody := struct {
 		QuoteResponse             *QuoteResponse                `json:"quoteResponse"`
@@ -297,6 +583,8 @@ func (c *Client) GetSwapTransaction(ctx context.Context, inputMint, outputMint,
 		DynamicComputeUnitLimit   bool                          `json:"dynamicComputeUnitLimit"`
 		SkipUserAccountsRpcCalls  bool                          `json:"skipUserAccountsRpcCalls"`
 		PrioritizationFeeLamports *PriorityLevelWithMaxLamports `json:"prioritizationFeeLamports"`
+		FeeAccount                string                        `json:"feeAccount,omitempty"`
+		PlatformFeeBps            int                           `json:"platformFeeBps,omitempty"`
 	}{
 		QuoteResponse:            quote,
 		UserPublicKey:            userPubkey,
@@ -310,60 +598,67 @@ func (c *Client) GetSwapTransaction(ctx context.Context, inputMint, outputMint,
 				Global        bool   `json:"global,omitempty"`
 			}{
 				PriorityLevel: "veryHigh", // Maximum priority
-				MaxLamports:   c.maxLamports,
+				MaxLamports:   c.maxLamports.Load(),
 				Global:        false, // Local fee market (more accurate)
 			},
 		},
+		FeeAccount:     c.feeAccount,
+		PlatformFeeBps: c.feeBps,
 	}
 
 	body, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("marshal request: %w", err)
+		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
 	url := fmt.Sprintf("%s/swap", c.baseURL)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
-	if err != nil {
-		return "", fmt.Errorf("create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("x-api-key", c.getAPIKey())
-
-	client := c.clientPool.Get()
-	resp, err := client.Do(req)
+	resp, err := c.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("http request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("swap failed (%d): %s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("swap failed (%d): %s", resp.StatusCode, string(respBody))
 	}
 
 	var swapResp SwapResponse
 	// Optimized: Use Decoder to stream response
 	if err := json.NewDecoder(resp.Body).Decode(&swapResp); err != nil {
-		return "", fmt.Errorf("decode swap response: %w", err)
+		return nil, fmt.Errorf("decode swap response: %w", err)
 	}
+	swapResp.Quote = quote
 
-	totalLatency := time.Since(start)
-	swapLatency := totalLatency - quoteLatency
+	return &swapResp, nil
+}
 
-	log.Info().
-		Dur("quoteLatency", quoteLatency).
-		Dur("swapLatency", swapLatency).
-		Dur("totalLatency", totalLatency).
-		Uint64("priorityFee", swapResp.PrioritizationFeeLamports).
-		Msg("jupiter swap tx")
+// SlippageBps returns the configured default slippage
+func (c *Client) SlippageBps() int {
+	return int(c.slippageBps.Load())
+}
 
-	return swapResp.SwapTransaction, nil
+// SetSlippageBps changes the default slippage (config hot-reload)
+func (c *Client) SetSlippageBps(bps int) {
+	c.slippageBps.Store(int32(bps))
 }
 
 // SetMaxPriorityFee sets the max priority fee cap in lamports
 func (c *Client) SetMaxPriorityFee(lamports uint64) {
-	c.maxLamports = lamports
+	c.maxLamports.Store(lamports)
+}
+
+// MaxPriorityFee returns the current max priority fee cap in lamports
+func (c *Client) MaxPriorityFee() uint64 {
+	return c.maxLamports.Load()
 }
 
 // SOL mint address constant
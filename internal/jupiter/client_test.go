@@ -2,6 +2,10 @@ package jupiter
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -28,3 +32,330 @@ func TestGetSwapTransaction_SimulationMode(t *testing.T) {
 		t.Errorf("Expected dummy transaction %q, got %q", expected, txStr)
 	}
 }
+
+func TestGetSwapFromQuote_RequotesStaleQuote(t *testing.T) {
+	var quotes, swaps int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/quote"):
+			quotes++
+			json.NewEncoder(w).Encode(QuoteResponse{InAmount: r.URL.Query().Get("amount"), OutAmount: "42"})
+		case strings.HasSuffix(r.URL.Path, "/swap"):
+			swaps++
+			json.NewEncoder(w).Encode(SwapResponse{SwapTransaction: "tx", LastValidBlockHeight: 7})
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("", 50, 5*time.Second)
+	client.SetBaseURL(srv.URL)
+	client.SetMaxQuoteAge(100 * time.Millisecond)
+
+	fresh := &QuoteResponse{InputMint: "A", OutputMint: "B", InAmount: "1000", FetchedAt: time.Now()}
+	if _, err := client.GetSwapFromQuote(context.Background(), fresh, "user"); err != nil {
+		t.Fatalf("GetSwapFromQuote: %v", err)
+	}
+	if quotes != 0 {
+		t.Errorf("fresh quote re-quoted %d times, want 0", quotes)
+	}
+
+	stale := &QuoteResponse{InputMint: "A", OutputMint: "B", InAmount: "1000", FetchedAt: time.Now().Add(-time.Second)}
+	swap, err := client.GetSwapFromQuote(context.Background(), stale, "user")
+	if err != nil {
+		t.Fatalf("GetSwapFromQuote: %v", err)
+	}
+	if quotes != 1 || swaps != 2 {
+		t.Errorf("quotes = %d, swaps = %d, want 1 and 2", quotes, swaps)
+	}
+	if swap.LastValidBlockHeight != 7 {
+		t.Errorf("LastValidBlockHeight = %d, want 7", swap.LastValidBlockHeight)
+	}
+}
+
+func TestGetSwapExactOut_ThreadsSwapMode(t *testing.T) {
+	var queries []map[string]string
+	var swapMode string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/quote"):
+			q := map[string]string{}
+			for k := range r.URL.Query() {
+				q[k] = r.URL.Query().Get(k)
+			}
+			queries = append(queries, q)
+			json.NewEncoder(w).Encode(QuoteResponse{InAmount: "900", OutAmount: q["amount"], SwapMode: q["swapMode"]})
+		case strings.HasSuffix(r.URL.Path, "/swap"):
+			var req struct {
+				QuoteResponse QuoteResponse `json:"quoteResponse"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			swapMode = req.QuoteResponse.SwapMode
+			json.NewEncoder(w).Encode(SwapResponse{SwapTransaction: "tx"})
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("", 50, 5*time.Second)
+	client.SetBaseURL(srv.URL)
+
+	if _, err := client.GetQuoteWithSlippage(context.Background(), "A", "B", 1000, 50); err != nil {
+		t.Fatalf("GetQuoteWithSlippage: %v", err)
+	}
+	if _, err := client.GetSwapExactOut(context.Background(), "A", "B", "user", 5000, 300); err != nil {
+		t.Fatalf("GetSwapExactOut: %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("got %d quote requests, want 2", len(queries))
+	}
+	if _, ok := queries[0]["swapMode"]; ok {
+		t.Errorf("ExactIn quote sent swapMode=%q, want it omitted", queries[0]["swapMode"])
+	}
+	want := map[string]string{"inputMint": "A", "outputMint": "B", "amount": "5000", "slippageBps": "300", "swapMode": SwapModeExactOut}
+	for k, v := range want {
+		if queries[1][k] != v {
+			t.Errorf("ExactOut quote %s = %q, want %q", k, queries[1][k], v)
+		}
+	}
+	if swapMode != SwapModeExactOut {
+		t.Errorf("swap request swapMode = %q, want %q", swapMode, SwapModeExactOut)
+	}
+}
+
+func TestGetSwapFromQuote_RequotesStaleExactOutOnOutput(t *testing.T) {
+	var query map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/quote"):
+			query = r.URL.Query()
+			json.NewEncoder(w).Encode(QuoteResponse{InAmount: "900", OutAmount: r.URL.Query().Get("amount")})
+		case strings.HasSuffix(r.URL.Path, "/swap"):
+			json.NewEncoder(w).Encode(SwapResponse{SwapTransaction: "tx"})
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("", 50, 5*time.Second)
+	client.SetBaseURL(srv.URL)
+	client.SetMaxQuoteAge(100 * time.Millisecond)
+
+	stale := &QuoteResponse{InputMint: "A", OutputMint: "B", InAmount: "900", OutAmount: "5000",
+		SwapMode: SwapModeExactOut, FetchedAt: time.Now().Add(-time.Second)}
+	if _, err := client.GetSwapFromQuote(context.Background(), stale, "user"); err != nil {
+		t.Fatalf("GetSwapFromQuote: %v", err)
+	}
+	if got := query["amount"]; len(got) != 1 || got[0] != "5000" {
+		t.Errorf("re-quote amount = %v, want [5000]", got)
+	}
+	if got := query["swapMode"]; len(got) != 1 || got[0] != SwapModeExactOut {
+		t.Errorf("re-quote swapMode = %v, want [%s]", got, SwapModeExactOut)
+	}
+}
+
+func TestGetQuoteExactOut_SimulationMode(t *testing.T) {
+	client := NewClient("https://api.jup.ag/swap/v1", 50, 10*time.Second)
+	client.SetSimulation(true, 2.0)
+	client.SetSimPrices(map[string]SimPrice{"TOKEN": {PriceSOL: 1, Decimals: 9}}) // 1 raw unit = 1 lamport at 1x
+
+	quote, err := client.GetQuoteExactOut(context.Background(), "TOKEN", SOLMint, 1000, 100)
+	if err != nil {
+		t.Fatalf("GetQuoteExactOut: %v", err)
+	}
+	if quote.SwapMode != SwapModeExactOut || quote.OutAmount != "1000" || quote.InAmount != "500" {
+		t.Errorf("quote = mode %q in %s out %s, want ExactOut in 500 out 1000", quote.SwapMode, quote.InAmount, quote.OutAmount)
+	}
+	if quote.OtherAmountThreshold != "505" {
+		t.Errorf("OtherAmountThreshold = %s, want 505 (max input after 1%% slippage)", quote.OtherAmountThreshold)
+	}
+}
+
+func TestGetQuoteCached_ReusesWithinTTL(t *testing.T) {
+	var quotes int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		quotes++
+		json.NewEncoder(w).Encode(QuoteResponse{InAmount: r.URL.Query().Get("amount"), OutAmount: "42"})
+	}))
+	defer srv.Close()
+
+	client := NewClient("", 50, 5*time.Second)
+	client.SetBaseURL(srv.URL)
+	client.SetQuoteCacheTTL(150 * time.Millisecond)
+	ctx := context.Background()
+
+	if _, err := client.GetQuoteCached(ctx, "A", "B", 1_234_567); err != nil {
+		t.Fatalf("GetQuoteCached: %v", err)
+	}
+	q, err := client.GetQuoteCached(ctx, "A", "B", 1_234_000) // same bucket
+	if err != nil {
+		t.Fatalf("GetQuoteCached: %v", err)
+	}
+	if quotes != 1 || q.OutAmount != "42" {
+		t.Errorf("quotes = %d (out %s), want 1 HTTP call within the TTL", quotes, q.OutAmount)
+	}
+
+	client.GetQuoteCached(ctx, "A", "B", 2_000_000)
+	client.GetQuote(ctx, "A", "B", 1_234_567) // trade path bypasses the cache
+	if quotes != 3 {
+		t.Errorf("quotes = %d, want 3 after a new amount and an uncached call", quotes)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	client.GetQuoteCached(ctx, "A", "B", 1_234_567)
+	if quotes != 4 {
+		t.Errorf("quotes = %d, want a re-fetch once the TTL elapsed", quotes)
+	}
+}
+
+func TestGetQuote_SkipsRateLimitedKey(t *testing.T) {
+	var used []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("x-api-key")
+		used = append(used, key)
+		if key == "throttled" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(QuoteResponse{OutAmount: "42"})
+	}))
+	defer srv.Close()
+
+	client := NewClientWithKeys("", 50, 5*time.Second, []string{"throttled", "ok"})
+	client.SetBaseURL(srv.URL)
+	client.SetKeyCooldown(150 * time.Millisecond)
+	ctx := context.Background()
+
+	limited := 0
+	for i := 0; i < 2; i++ { // round-robin reaches both keys
+		if _, err := client.GetQuote(ctx, "A", "B", 1000); err != nil {
+			limited++
+		}
+	}
+	if limited != 1 {
+		t.Fatalf("%d of 2 quotes failed, want the throttled key's one", limited)
+	}
+
+	used = nil
+	for i := 0; i < 4; i++ {
+		if _, err := client.GetQuote(ctx, "A", "B", 1000); err != nil {
+			t.Fatalf("quote during cooldown: %v", err)
+		}
+	}
+	for _, key := range used {
+		if key == "throttled" {
+			t.Fatalf("keys used during cooldown = %v, want the throttled key skipped", used)
+		}
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	used = nil
+	client.GetQuote(ctx, "A", "B", 1000)
+	client.GetQuote(ctx, "A", "B", 1000)
+	if strings.Join(used, ",") != "ok,throttled" && strings.Join(used, ",") != "throttled,ok" {
+		t.Errorf("keys used after cooldown = %v, want both back in rotation", used)
+	}
+}
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	var quotes, swaps int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/quote"):
+			quotes++
+			if quotes%3 != 0 { // two 503s, then success
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			json.NewEncoder(w).Encode(QuoteResponse{InAmount: "1000", OutAmount: "42"})
+		case strings.HasSuffix(r.URL.Path, "/swap"):
+			swaps++
+			if swaps <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			json.NewEncoder(w).Encode(SwapResponse{SwapTransaction: "tx"})
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("", 50, 5*time.Second)
+	client.SetBaseURL(srv.URL)
+	client.SetRetry(2, time.Millisecond)
+
+	q, err := client.GetQuote(context.Background(), "A", "B", 1000)
+	if err != nil || q.OutAmount != "42" {
+		t.Fatalf("GetQuote = %+v, %v; want success on the third attempt", q, err)
+	}
+	if quotes != 3 {
+		t.Errorf("quote attempts = %d, want 3", quotes)
+	}
+
+	quotes = 0
+	tx, err := client.GetSwapTransaction(context.Background(), "A", "B", "user", 1000)
+	if err != nil || tx != "tx" {
+		t.Fatalf("GetSwapTransaction = %q, %v; want success after retries", tx, err)
+	}
+	if quotes != 3 || swaps != 3 {
+		t.Errorf("quote attempts = %d, swap attempts = %d, want 3 and 3", quotes, swaps)
+	}
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	defer srv.Close()
+
+	client := NewClient("", 50, 5*time.Second)
+	client.SetBaseURL(srv.URL)
+	client.SetRetry(2, time.Millisecond)
+
+	if _, err := client.GetQuote(context.Background(), "A", "B", 1000); err == nil || !strings.Contains(err.Error(), "422") {
+		t.Fatalf("GetQuote error = %v, want the 422", err)
+	}
+	if calls != 1 {
+		t.Errorf("attempts = %d, want 1 (422 is not retryable)", calls)
+	}
+}
+
+func TestGetSwapTransaction_PlatformFee(t *testing.T) {
+	var quoteQuery string
+	var swapBody map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/quote"):
+			quoteQuery = r.URL.RawQuery
+			json.NewEncoder(w).Encode(QuoteResponse{InAmount: "1000", OutAmount: "42"})
+		case strings.HasSuffix(r.URL.Path, "/swap"):
+			swapBody = nil
+			json.NewDecoder(r.Body).Decode(&swapBody)
+			json.NewEncoder(w).Encode(SwapResponse{SwapTransaction: "tx"})
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("", 50, 5*time.Second)
+	client.SetBaseURL(srv.URL)
+	ctx := context.Background()
+
+	if _, err := client.GetSwapTransaction(ctx, "A", "B", "user", 1000); err != nil {
+		t.Fatalf("GetSwapTransaction: %v", err)
+	}
+	_, hasAccount := swapBody["feeAccount"]
+	_, hasBps := swapBody["platformFeeBps"]
+	if hasAccount || hasBps || strings.Contains(quoteQuery, "platformFeeBps") {
+		t.Errorf("fee fields sent without a configured fee: body %v, query %s", swapBody, quoteQuery)
+	}
+
+	client.SetPlatformFee("referral", 25)
+	if _, err := client.GetSwapTransaction(ctx, "A", "B", "user", 1000); err != nil {
+		t.Fatalf("GetSwapTransaction: %v", err)
+	}
+	if swapBody["feeAccount"] != "referral" || swapBody["platformFeeBps"] != float64(25) {
+		t.Errorf("swap body fee fields = %v / %v, want referral / 25", swapBody["feeAccount"], swapBody["platformFeeBps"])
+	}
+	if !strings.Contains(quoteQuery, "platformFeeBps=25") {
+		t.Errorf("quote query %s lacks platformFeeBps", quoteQuery)
+	}
+}
@@ -0,0 +1,100 @@
+package jupiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// quoteCacheMaxEntries triggers a sweep of expired entries on insert
+const quoteCacheMaxEntries = 256
+
+// quoteKey identifies quotes that are interchangeable for valuation
+type quoteKey struct {
+	inputMint, outputMint string
+	amountBucket          uint64
+	slippageBps           int
+}
+
+type cachedQuote struct {
+	quote   QuoteResponse
+	expires time.Time
+}
+
+// quoteCache holds recent ExactIn quotes for GetQuoteCached
+type quoteCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[quoteKey]cachedQuote
+}
+
+// amountBucket keeps the three leading significant digits of amount, so
+// balances that differ by dust (fee rounding, Token-2022 transfer fees)
+// share a quote: within 1% for any amount, within 0.1% above 1000
+func amountBucket(amount uint64) uint64 {
+	scale := uint64(1)
+	for amount/scale >= 1000 {
+		scale *= 10
+	}
+	return amount / scale * scale
+}
+
+func (c *quoteCache) get(key quoteKey) (*QuoteResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	q := e.quote
+	return &q, true
+}
+
+func (c *quoteCache) put(key quoteKey, q *QuoteResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if len(c.entries) >= quoteCacheMaxEntries {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[key] = cachedQuote{quote: *q, expires: now.Add(c.ttl)}
+}
+
+// SetQuoteCacheTTL sets how long GetQuoteCached reuses a quote (0 = off)
+func (c *Client) SetQuoteCacheTTL(ttl time.Duration) {
+	c.quotes.mu.Lock()
+	defer c.quotes.mu.Unlock()
+	c.quotes.ttl = ttl
+	if ttl <= 0 {
+		c.quotes.entries = make(map[quoteKey]cachedQuote)
+	}
+}
+
+// GetQuoteCached is GetQuote for valuation (position monitoring): a quote
+// for the same pair and a near-identical amount fetched within the cache
+// TTL is returned without an HTTP call. Trade paths use GetQuote and its
+// variants, which always fetch.
+func (c *Client) GetQuoteCached(ctx context.Context, inputMint, outputMint string, amountLamports uint64) (*QuoteResponse, error) {
+	c.quotes.mu.Lock()
+	ttl := c.quotes.ttl
+	c.quotes.mu.Unlock()
+	if ttl <= 0 {
+		return c.GetQuote(ctx, inputMint, outputMint, amountLamports)
+	}
+
+	slippage := c.SlippageBps()
+	key := quoteKey{inputMint, outputMint, amountBucket(amountLamports), slippage}
+	if q, ok := c.quotes.get(key); ok {
+		return q, nil
+	}
+	q, err := c.GetQuoteWithSlippage(ctx, inputMint, outputMint, amountLamports, slippage)
+	if err != nil {
+		return nil, err
+	}
+	c.quotes.put(key, q)
+	return q, nil
+}
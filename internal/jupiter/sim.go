@@ -0,0 +1,103 @@
+package jupiter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Simulated market defaults for mints without a seed price
+const (
+	DefaultSimPriceSOL = 0.000001 // SOL per whole token
+	DefaultSimDecimals = 6        // pump.fun style tokens
+)
+
+// SimPrice seeds a mint's simulated price
+type SimPrice struct {
+	PriceSOL float64 // SOL per whole token at multiplier 1
+	Decimals int     // 0 = DefaultSimDecimals
+}
+
+// SetSimPrices replaces the simulated seed prices, keyed by mint
+// (case-insensitive: config keys arrive lowercased)
+func (c *Client) SetSimPrices(prices map[string]SimPrice) {
+	seeded := make(map[string]SimPrice, len(prices))
+	for mint, p := range prices {
+		seeded[strings.ToLower(mint)] = p
+	}
+	c.simMu.Lock()
+	defer c.simMu.Unlock()
+	c.simPrices = seeded
+}
+
+// simPrice returns a mint's seed price and decimals (defaults if unseeded)
+func (c *Client) simPrice(mint string) (float64, int) {
+	c.simMu.RLock()
+	p, ok := c.simPrices[strings.ToLower(mint)]
+	c.simMu.RUnlock()
+	if !ok || p.PriceSOL <= 0 {
+		p.PriceSOL = DefaultSimPriceSOL
+	}
+	if p.Decimals <= 0 {
+		p.Decimals = DefaultSimDecimals
+	}
+	return p.PriceSOL, p.Decimals
+}
+
+// simSOLValue is the simulated SOL value of amount raw units of mint; the
+// multiplier moves every token's price, SOL itself stays at 1
+func (c *Client) simSOLValue(mint string, amount uint64, mult float64) float64 {
+	if mint == SOLMint {
+		return float64(amount) / 1e9
+	}
+	price, decimals := c.simPrice(mint)
+	return float64(amount) / math.Pow10(decimals) * price * mult
+}
+
+// simAmountFor is the raw amount of mint worth sol at the simulated price
+func (c *Client) simAmountFor(mint string, sol, mult float64) uint64 {
+	if mint == SOLMint {
+		return uint64(math.Round(sol * 1e9))
+	}
+	price, decimals := c.simPrice(mint)
+	if price*mult <= 0 {
+		return 0
+	}
+	return uint64(math.Round(sol / (price * mult) * math.Pow10(decimals)))
+}
+
+// SimBuyAmount is the raw token amount lamports of SOL buys at the current
+// simulated price; the executor records it as the simulated holding
+func (c *Client) SimBuyAmount(mint string, lamports uint64) uint64 {
+	c.simMu.RLock()
+	mult := c.simMultiplier
+	c.simMu.RUnlock()
+	return c.simAmountFor(mint, float64(lamports)/1e9, mult)
+}
+
+// simQuote prices a swap on the simulated market: both sides are valued in
+// SOL through each mint's seed price and decimals, so a 2.5x multiplier
+// makes a held position worth 2.5x what it cost
+func (c *Client) simQuote(inputMint, outputMint string, amount uint64, slippageBps int, swapMode string, mult float64) *QuoteResponse {
+	q := &QuoteResponse{
+		InputMint:      inputMint,
+		OutputMint:     outputMint,
+		SwapMode:       swapMode,
+		SlippageBps:    slippageBps,
+		PriceImpactPct: "0.0",
+		FetchedAt:      time.Now(),
+	}
+	if swapMode == SwapModeExactOut {
+		in := c.simAmountFor(inputMint, c.simSOLValue(outputMint, amount, mult), mult)
+		q.InAmount = fmt.Sprintf("%d", in)
+		q.OutAmount = fmt.Sprintf("%d", amount)
+		q.OtherAmountThreshold = fmt.Sprintf("%.0f", float64(in)*(1+float64(slippageBps)/10000))
+		return q
+	}
+	out := c.simAmountFor(outputMint, c.simSOLValue(inputMint, amount, mult), mult)
+	q.InAmount = fmt.Sprintf("%d", amount)
+	q.OutAmount = fmt.Sprintf("%d", out)
+	q.OtherAmountThreshold = fmt.Sprintf("%.0f", float64(out)*(1-float64(slippageBps)/10000))
+	return q
+}
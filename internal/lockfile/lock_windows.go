@@ -0,0 +1,28 @@
+//go:build windows
+
+package lockfile
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Lock one byte far past the PID so the holder's PID stays readable
+const lockOffset = 1 << 30
+
+func lock(f *os.File) error {
+	ol := &windows.Overlapped{Offset: lockOffset}
+	err := windows.LockFileEx(windows.Handle(f.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, ol)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return ErrLocked
+	}
+	return err
+}
+
+func unlock(f *os.File) {
+	ol := &windows.Overlapped{Offset: lockOffset}
+	windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, ol)
+}
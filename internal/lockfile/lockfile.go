@@ -0,0 +1,70 @@
+// Package lockfile keeps a second bot from running against the same data
+// directory (and wallet). The lock is an advisory OS file lock, so it is
+// dropped when the holder exits and a crash never leaves a stale lock.
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrLocked means another process holds the lock
+var ErrLocked = errors.New("already locked by another process")
+
+// Lock is a held lock file
+type Lock struct {
+	f *os.File
+}
+
+// Acquire takes the lock at path (creating it and its directory) without
+// blocking and records the PID in it. A lock held elsewhere returns an
+// error wrapping ErrLocked that names the holder's PID when known.
+func Acquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lock(f); err != nil {
+		pid := holderPID(f)
+		f.Close()
+		if errors.Is(err, ErrLocked) && pid != "" {
+			return nil, fmt.Errorf("%w (pid %s)", ErrLocked, pid)
+		}
+		return nil, err
+	}
+
+	if err := f.Truncate(0); err == nil {
+		if _, err := f.WriteAt([]byte(fmt.Sprintf("%d\n", os.Getpid())), 0); err == nil {
+			f.Sync()
+		}
+	}
+	return &Lock{f: f}, nil
+}
+
+// Release drops the lock; safe to call more than once. The file is left in
+// place: removing it could race a process that just opened it.
+func (l *Lock) Release() error {
+	if l == nil || l.f == nil {
+		return nil
+	}
+	unlock(l.f)
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// holderPID reads the PID the current holder wrote, "" if unreadable
+func holderPID(f *os.File) string {
+	b, err := io.ReadAll(io.LimitReader(f, 32))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
@@ -0,0 +1,36 @@
+package lockfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAcquire_SecondHolderFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "bot.lock")
+
+	first, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("first Acquire: %v", err)
+	}
+	_, err = Acquire(path)
+	if !errors.Is(err, ErrLocked) {
+		t.Fatalf("second Acquire: err = %v, want ErrLocked", err)
+	}
+	if pid := strconv.Itoa(os.Getpid()); !strings.Contains(err.Error(), pid) {
+		t.Errorf("err = %q, want the holder's pid %s", err, pid)
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	again, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire after Release: %v", err)
+	}
+	again.Release()
+	again.Release() // idempotent
+}
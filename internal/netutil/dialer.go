@@ -0,0 +1,129 @@
+package netutil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DialFunc matches http.Transport.DialContext
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// DNSCache is a small in-process cache of host -> IP lookups.
+// Connection setup on the hot path otherwise hits the system resolver every
+// time a pooled connection is replaced.
+type DNSCache struct {
+	ttl      time.Duration
+	resolver *net.Resolver
+
+	mu      sync.RWMutex
+	entries map[string]dnsEntry
+}
+
+type dnsEntry struct {
+	addrs   []net.IPAddr
+	expires time.Time
+}
+
+// NewDNSCache creates a cache; ttl <= 0 disables caching (every lookup resolves)
+func NewDNSCache(ttl time.Duration) *DNSCache {
+	return &DNSCache{
+		ttl:      ttl,
+		resolver: net.DefaultResolver,
+		entries:  make(map[string]dnsEntry),
+	}
+}
+
+// Lookup resolves host, serving from cache while the entry is fresh
+func (c *DNSCache) Lookup(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if c.ttl > 0 {
+		c.mu.RLock()
+		e, ok := c.entries[host]
+		c.mu.RUnlock()
+		if ok && time.Now().Before(e.expires) {
+			return e.addrs, nil
+		}
+	}
+
+	addrs, err := c.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ttl > 0 {
+		c.mu.Lock()
+		c.entries[host] = dnsEntry{addrs: addrs, expires: time.Now().Add(c.ttl)}
+		c.mu.Unlock()
+	}
+	return addrs, nil
+}
+
+// Dialer dials with optional IPv4-only and cached DNS resolution
+type Dialer struct {
+	ForceIPv4 bool
+	Cache     *DNSCache // nil = use the system resolver directly
+	dialer    *net.Dialer
+}
+
+// NewDialer creates a dialer with the same timeouts the HTTP pools used before
+func NewDialer(forceIPv4 bool, cache *DNSCache) *Dialer {
+	return &Dialer{
+		ForceIPv4: forceIPv4,
+		Cache:     cache,
+		dialer: &net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		},
+	}
+}
+
+func (d *Dialer) network(network string) string {
+	if d.ForceIPv4 && (network == "tcp" || network == "tcp6") {
+		return "tcp4"
+	}
+	return network
+}
+
+// DialContext is suitable for http.Transport.DialContext
+func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	network = d.network(network)
+	if d.Cache == nil {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	// Literal IPs need no lookup
+	if net.ParseIP(host) != nil {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+
+	addrs, err := d.Cache.Lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try each candidate in resolver order, skipping IPv6 when forced to IPv4
+	var lastErr error
+	for _, a := range addrs {
+		if d.ForceIPv4 && a.IP.To4() == nil {
+			continue
+		}
+		conn, err := d.dialer.DialContext(ctx, network, net.JoinHostPort(a.IP.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		log.Debug().Err(err).Str("host", host).Str("ip", a.IP.String()).Msg("dial failed, trying next address")
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no usable address for %s (forceIPv4=%v)", host, d.ForceIPv4)
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,73 @@
+package netutil
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// localhostURL rewrites the test server URL to use a hostname so dials resolve
+func localhostURL(srv *httptest.Server) string {
+	return strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+}
+
+func TestDialer_ForceIPv4UsesCachedAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	d := NewDialer(true, NewDNSCache(time.Minute))
+	addr := strings.TrimPrefix(localhostURL(srv), "http://")
+
+	conn, err := d.DialContext(context.Background(), "tcp", addr)
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	defer conn.Close()
+
+	ip := conn.RemoteAddr().(*net.TCPAddr).IP
+	if ip.To4() == nil {
+		t.Errorf("remote addr = %v, want IPv4", ip)
+	}
+	if _, ok := d.Cache.entries["localhost"]; !ok {
+		t.Error("expected localhost lookup to be cached")
+	}
+}
+
+// benchmarkFreshConnections issues requests that each open a new connection,
+// which is where DNS resolution sits on the hot path.
+func benchmarkFreshConnections(b *testing.B, d *Dialer) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext:       d.DialContext,
+		DisableKeepAlives: true,
+	}}
+	url := localhostURL(srv)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := client.Get(url)
+		if err != nil {
+			b.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+}
+
+// go test -bench Dial ./internal/netutil
+func BenchmarkDial_SystemResolver(b *testing.B) {
+	benchmarkFreshConnections(b, NewDialer(false, nil))
+}
+
+func BenchmarkDial_DNSCache(b *testing.B) {
+	benchmarkFreshConnections(b, NewDialer(false, NewDNSCache(time.Minute)))
+}
+
+func BenchmarkDial_DNSCacheIPv4(b *testing.B) {
+	benchmarkFreshConnections(b, NewDialer(true, NewDNSCache(time.Minute)))
+}
@@ -0,0 +1,146 @@
+// Package notify pushes trade notifications (buy sent, sell confirmed, kill
+// switch) to a chat: a Telegram bot, a Discord webhook or a Slack webhook.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"solana-pump-bot/internal/config"
+)
+
+// Kind is what happened
+type Kind string
+
+const (
+	KindBuy        Kind = "BUY"
+	KindSell       Kind = "SELL"
+	KindKillSwitch Kind = "KILL_SWITCH"
+)
+
+// ErrRateLimited is returned when a notification was dropped by the rate limit
+var ErrRateLimited = errors.New("notification rate limit reached")
+
+// Event is one notification
+type Event struct {
+	Kind       Kind
+	TokenName  string
+	Mint       string
+	AmountSol  float64 // buy size
+	TxSig      string
+	PnLSol     float64 // sells: realized (or quote-based) PnL
+	PnLPercent float64
+	Detail     string // free text, e.g. why the kill switch tripped
+	Paper      bool   // booked on paper (trading.paper_trading), no SOL moved
+}
+
+// TxURL links a transaction on the explorer
+func TxURL(sig string) string {
+	return "https://solscan.io/tx/" + sig
+}
+
+// Text renders the event as a plain-text chat message
+func (ev Event) Text() string {
+	var b strings.Builder
+	if ev.Paper {
+		b.WriteString("📝 PAPER ")
+	}
+	switch ev.Kind {
+	case KindBuy:
+		fmt.Fprintf(&b, "🟢 BUY %s: %.4f SOL", ev.TokenName, ev.AmountSol)
+	case KindSell:
+		fmt.Fprintf(&b, "🔴 SELL %s: PnL %+.4f SOL (%+.1f%%)", ev.TokenName, ev.PnLSol, ev.PnLPercent)
+	case KindKillSwitch:
+		b.WriteString("🛑 KILL SWITCH: auto-trading disabled")
+	default:
+		b.WriteString(string(ev.Kind))
+	}
+	if ev.Detail != "" {
+		b.WriteString("\n" + ev.Detail)
+	}
+	if ev.Mint != "" {
+		b.WriteString("\n" + ev.Mint)
+	}
+	if ev.TxSig != "" {
+		b.WriteString("\n" + TxURL(ev.TxSig))
+	}
+	return b.String()
+}
+
+// Notifier delivers a notification
+type Notifier interface {
+	Notify(ctx context.Context, ev Event) error
+}
+
+// New builds the notifier selected by cfg.Provider, rate-limited to
+// cfg.MaxPerMinute. It returns nil (and no error) when notifications are off.
+func New(cfg config.NotifyConfig, botToken string) (Notifier, error) {
+	var n Notifier
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "telegram":
+		if botToken == "" {
+			return nil, fmt.Errorf("telegram notifications need a bot token in $%s", cfg.BotTokenEnv)
+		}
+		n = NewTelegram(botToken, cfg.ChatID)
+	case "discord":
+		n = NewDiscord(cfg.WebhookURL)
+	case "slack":
+		n = NewSlack(cfg.WebhookURL)
+	default:
+		return nil, fmt.Errorf("unknown notify provider %q", cfg.Provider)
+	}
+	return NewLimited(n, cfg.MaxPerMinute), nil
+}
+
+// Limited drops notifications beyond perMinute per rolling minute, so a
+// burst of trades cannot get the bot throttled or banned by the chat API.
+// Kill-switch events always go through.
+type Limited struct {
+	next      Notifier
+	perMinute int
+
+	mu   sync.Mutex
+	sent []time.Time
+	now  func() time.Time
+}
+
+// NewLimited wraps next; perMinute <= 0 disables the limit
+func NewLimited(next Notifier, perMinute int) *Limited {
+	return &Limited{next: next, perMinute: perMinute, now: time.Now}
+}
+
+// Notify forwards ev unless the minute's budget is spent (ErrRateLimited)
+func (l *Limited) Notify(ctx context.Context, ev Event) error {
+	if !l.allow(ev.Kind) {
+		return ErrRateLimited
+	}
+	return l.next.Notify(ctx, ev)
+}
+
+func (l *Limited) allow(kind Kind) bool {
+	if l.perMinute <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	keep := l.sent[:0]
+	for _, t := range l.sent {
+		if now.Sub(t) < time.Minute {
+			keep = append(keep, t)
+		}
+	}
+	l.sent = keep
+	if len(l.sent) >= l.perMinute && kind != KindKillSwitch {
+		return false
+	}
+	l.sent = append(l.sent, now)
+	return true
+}
@@ -0,0 +1,116 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// capture records the path and JSON body of every request
+func capture(t *testing.T, status int) (*httptest.Server, *[]string, *[]map[string]interface{}) {
+	t.Helper()
+	var paths []string
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("body is not JSON: %v", err)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &paths, &bodies
+}
+
+var sell = Event{Kind: KindSell, TokenName: "PEPE", Mint: "Mint111", TxSig: "Sig111", PnLSol: 0.05, PnLPercent: 50}
+
+func TestProviders_PayloadShape(t *testing.T) {
+	cases := []struct {
+		name     string
+		build    func(url string) Notifier
+		wantPath string
+		textKey  string
+		extra    map[string]interface{}
+	}{
+		{"telegram", func(u string) Notifier { return &Telegram{apiURL: u + "/botTOKEN", chatID: "-100123"} },
+			"/botTOKEN/sendMessage", "text", map[string]interface{}{"chat_id": "-100123", "disable_web_page_preview": true}},
+		{"discord", func(u string) Notifier { return NewDiscord(u + "/api/webhooks/1/abc") },
+			"/api/webhooks/1/abc", "content", nil},
+		{"slack", func(u string) Notifier { return NewSlack(u + "/services/T/B/X") },
+			"/services/T/B/X", "text", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			srv, paths, bodies := capture(t, 200)
+			if err := c.build(srv.URL).Notify(context.Background(), sell); err != nil {
+				t.Fatalf("Notify: %v", err)
+			}
+			if len(*bodies) != 1 || (*paths)[0] != c.wantPath {
+				t.Fatalf("requests = %v, want one to %s", *paths, c.wantPath)
+			}
+			body := (*bodies)[0]
+			text, _ := body[c.textKey].(string)
+			for _, want := range []string{"SELL PEPE", "+0.0500 SOL", "+50.0%", "https://solscan.io/tx/Sig111"} {
+				if !strings.Contains(text, want) {
+					t.Errorf("%s = %q, missing %q", c.textKey, text, want)
+				}
+			}
+			for k, v := range c.extra {
+				if body[k] != v {
+					t.Errorf("%s = %v, want %v", k, body[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestPostJSON_ErrorHidesURL(t *testing.T) {
+	srv, _, _ := capture(t, 403)
+	err := (&Telegram{apiURL: srv.URL + "/botSECRET", chatID: "1"}).Notify(context.Background(), sell)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Fatalf("err = %v, want HTTP 403", err)
+	}
+
+	err = NewDiscord("http://127.0.0.1:1/api/webhooks/1/SECRET").Notify(context.Background(), sell)
+	if err == nil || strings.Contains(err.Error(), "SECRET") {
+		t.Errorf("err = %v, want a failure without the webhook URL", err)
+	}
+}
+
+type countNotifier struct{ n int }
+
+func (c *countNotifier) Notify(context.Context, Event) error { c.n++; return nil }
+
+func TestLimited_DropsBeyondRateButNotKillSwitch(t *testing.T) {
+	inner := &countNotifier{}
+	l := NewLimited(inner, 2)
+	now := time.Unix(1_700_000_000, 0)
+	l.now = func() time.Time { return now }
+
+	buy := Event{Kind: KindBuy}
+	for i, want := range []error{nil, nil, ErrRateLimited} {
+		if err := l.Notify(context.Background(), buy); err != want {
+			t.Errorf("notification %d: err = %v, want %v", i+1, err, want)
+		}
+	}
+	if err := l.Notify(context.Background(), Event{Kind: KindKillSwitch}); err != nil {
+		t.Errorf("kill switch: err = %v, want it delivered despite the limit", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := l.Notify(context.Background(), buy); err != nil {
+		t.Errorf("after a minute: err = %v, want delivered", err)
+	}
+	if inner.n != 4 {
+		t.Errorf("delivered %d, want 4", inner.n)
+	}
+}
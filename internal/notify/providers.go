@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestTimeout bounds one delivery attempt
+const RequestTimeout = 10 * time.Second
+
+var httpClient = &http.Client{Timeout: RequestTimeout}
+
+// postJSON posts body as JSON to target. The URL carries the bot token or
+// webhook secret, so it is kept out of returned errors.
+func postJSON(ctx context.Context, provider, target string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", target, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%s: invalid URL", provider)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return fmt.Errorf("%s: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("%s: HTTP %d: %s", provider, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// Telegram posts through the Bot API sendMessage method
+type Telegram struct {
+	apiURL string // https://api.telegram.org/bot<token>
+	chatID string
+}
+
+// NewTelegram creates a Telegram Bot API notifier
+func NewTelegram(botToken, chatID string) *Telegram {
+	return &Telegram{apiURL: "https://api.telegram.org/bot" + botToken, chatID: chatID}
+}
+
+// Notify sends ev as a message to the chat
+func (t *Telegram) Notify(ctx context.Context, ev Event) error {
+	return postJSON(ctx, "telegram", t.apiURL+"/sendMessage", map[string]interface{}{
+		"chat_id":                  t.chatID,
+		"text":                     ev.Text(),
+		"disable_web_page_preview": true,
+	})
+}
+
+// Discord posts to a channel webhook
+type Discord struct {
+	webhookURL string
+}
+
+// NewDiscord creates a Discord webhook notifier
+func NewDiscord(webhookURL string) *Discord {
+	return &Discord{webhookURL: webhookURL}
+}
+
+// Notify sends ev as the webhook message content
+func (d *Discord) Notify(ctx context.Context, ev Event) error {
+	return postJSON(ctx, "discord", d.webhookURL, map[string]interface{}{
+		"content": ev.Text(),
+	})
+}
+
+// Slack posts to an incoming webhook
+type Slack struct {
+	webhookURL string
+}
+
+// NewSlack creates a Slack incoming-webhook notifier
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{webhookURL: webhookURL}
+}
+
+// Notify sends ev as the webhook message text
+func (s *Slack) Notify(ctx context.Context, ev Event) error {
+	return postJSON(ctx, "slack", s.webhookURL, map[string]interface{}{
+		"text": ev.Text(),
+	})
+}
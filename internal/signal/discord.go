@@ -0,0 +1,54 @@
+package signal
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiscordMessage is the subset of a Discord webhook / message JSON body the
+// bot reads. Relays post either the raw message object (with id and
+// timestamp) or an execute-webhook body (content and embeds only).
+type DiscordMessage struct {
+	ID        string         `json:"id"` // snowflake, as a string
+	Content   string         `json:"content"`
+	Timestamp string         `json:"timestamp"` // ISO8601
+	Embeds    []DiscordEmbed `json:"embeds"`
+}
+
+// DiscordEmbed is a rich embed; callers often put the call in the description
+type DiscordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// Text returns the message content, falling back to the first embed's
+// description when the content is empty
+func (m *DiscordMessage) Text() string {
+	if strings.TrimSpace(m.Content) != "" {
+		return m.Content
+	}
+	if len(m.Embeds) > 0 {
+		return m.Embeds[0].Description
+	}
+	return ""
+}
+
+// ToParsedSignal converts the message into the listener payload so it goes
+// through the same parsing path as Telegram. Execute-webhook bodies carry no
+// id: they get a unique one so the executor's msg ID dedup does not drop them.
+func (m *DiscordMessage) ToParsedSignal() ParsedSignal {
+	p := ParsedSignal{Text: m.Text()}
+
+	// Snowflakes fit in int64; Telegram message IDs are far smaller
+	if id, err := strconv.ParseInt(m.ID, 10, 64); err == nil && id > 0 {
+		p.MsgID = id
+	} else {
+		p.MsgID = time.Now().UnixNano()
+	}
+
+	if ts, err := time.Parse(time.RFC3339Nano, m.Timestamp); err == nil {
+		p.Timestamp = ts.Unix()
+	}
+	return p
+}
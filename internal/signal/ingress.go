@@ -0,0 +1,180 @@
+package signal
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// maxBuckets bounds the per-source limiter map; beyond it idle (full)
+// buckets are dropped so a spray of source addresses can't grow it forever
+const maxBuckets = 4096
+
+// ParseAllowlist parses CIDRs and bare IPs ("10.0.0.0/8", "203.0.113.7")
+func ParseAllowlist(entries []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(entries))
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if !strings.Contains(e, "/") {
+			ip := net.ParseIP(e)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP %q", e)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", e, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// ipAllowed reports whether ip is inside any of nets (empty = everything)
+func ipAllowed(nets []*net.IPNet, ip net.IP) bool {
+	if len(nets) == 0 {
+		return true
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP is the request's source: the peer address, or, when the peer is
+// a local reverse proxy (loopback), the hop that proxy saw: the rightmost
+// X-Forwarded-For entry that isn't itself loopback. Entries left of it come
+// from the client and can be forged; a remote peer's header is ignored.
+func clientIP(peer net.IP, forwardedFor string) net.IP {
+	if !peer.IsLoopback() || forwardedFor == "" {
+		return peer
+	}
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			return peer
+		}
+		if !ip.IsLoopback() {
+			return ip
+		}
+	}
+	return peer
+}
+
+// rateLimiter is a token bucket per source IP: each holds up to burst
+// tokens, refilled at rate per second, and a request takes one
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+	now     func() time.Time // injectable for tests
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// allow takes a token from key's bucket, false when it is empty
+func (r *rateLimiter) allow(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	b, ok := r.buckets[key]
+	if !ok {
+		if len(r.buckets) >= maxBuckets {
+			r.prune(now)
+		}
+		b = &bucket{tokens: r.burst, last: now}
+		r.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * r.rate
+	if b.tokens > r.burst {
+		b.tokens = r.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// prune drops buckets that have refilled completely; caller holds r.mu
+func (r *rateLimiter) prune(now time.Time) {
+	for key, b := range r.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*r.rate >= r.burst {
+			delete(r.buckets, key)
+		}
+	}
+}
+
+// SetAllowedIPs restricts POST /signal to sources inside entries (CIDRs or
+// IPs; empty = any source). Call before Start.
+func (s *Server) SetAllowedIPs(entries []string) error {
+	nets, err := ParseAllowlist(entries)
+	if err != nil {
+		return err
+	}
+	s.allowed = nets
+	return nil
+}
+
+// SetRateLimit caps POST /signal at perSecond requests per source IP with
+// bursts of up to burst (perSecond <= 0 = unlimited). Call before Start.
+func (s *Server) SetRateLimit(perSecond float64, burst int) {
+	if perSecond <= 0 {
+		s.limiter = nil
+		return
+	}
+	s.limiter = newRateLimiter(perSecond, burst)
+}
+
+// guardIngress rejects sources outside the allowlist (403) and sources that
+// have used up their rate limit (429) before a signal is even parsed
+func (s *Server) guardIngress(c *fiber.Ctx) error {
+	if len(s.allowed) == 0 && s.limiter == nil {
+		return c.Next()
+	}
+	ip := clientIP(c.Context().RemoteIP(), c.Get(fiber.HeaderXForwardedFor))
+
+	if !ipAllowed(s.allowed, ip) {
+		log.Warn().Str("ip", ip.String()).Msg("🔒 signal rejected: source not in allowed_ips")
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "forbidden"})
+	}
+	if s.limiter != nil && !s.limiter.allow(ip.String()) {
+		log.Warn().Str("ip", ip.String()).Msg("🚦 signal rejected: rate limited")
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "rate limited"})
+	}
+	return c.Next()
+}
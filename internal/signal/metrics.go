@@ -0,0 +1,36 @@
+package signal
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// MetricsContentType is the Prometheus text exposition format
+const MetricsContentType = "text/plain; version=0.0.4"
+
+// MetricsHandler serves source() as Prometheus text on GET
+func MetricsHandler(source func() string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", MetricsContentType)
+		io.WriteString(w, source())
+	})
+}
+
+// ServeMetrics serves GET /metrics on its own port (metrics.port), for
+// scrapers that should not reach the signal endpoint. Blocks like Start.
+func ServeMetrics(addr string, source func() string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", MetricsHandler(source))
+	srv := &http.Server{
+		Addr:         addr,
+		Handler:      mux,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 5 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
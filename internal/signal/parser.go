@@ -13,6 +13,14 @@ const (
 	SignalEntry  SignalType = "ENTRY"
 	SignalExit   SignalType = "EXIT"
 	SignalIgnore SignalType = "IGNORE"
+	SignalReview SignalType = "REVIEW" // Token found but no value - needs a human look
+)
+
+// Actions for signals that name a token but carry no parseable value/unit
+const (
+	ValuelessEntry  = "entry"  // Trade it as an entry anyway
+	ValuelessSkip   = "skip"   // Ignore it
+	ValuelessReview = "review" // Surface it in the TUI review list
 )
 
 // Signal represents a parsed trading signal
@@ -28,10 +36,17 @@ type Signal struct {
 	Reached2X bool       `json:"reached_2x"` // Did this token hit 2X?
 }
 
+// HasValue reports whether the signal carried a parseable value and unit
+func (s *Signal) HasValue() bool {
+	return s.Unit != "" && s.Value > 0
+}
+
 // Parser handles signal parsing from Telegram messages
 type Parser struct {
 	// Pattern: 📈 TOKEN is up VALUE% 📈 or 📈 TOKEN is up VALUEX 📈
 	pattern *regexp.Regexp
+	// Fallback: 📈 TOKEN is up 📈 (token present, value missing/garbled)
+	valuelessPattern *regexp.Regexp
 	// CA pattern: Base58 Solana address (43-44 chars)
 	caPattern *regexp.Regexp
 	// FIX: GeckoTerminal URL pattern for CA extraction
@@ -44,7 +59,8 @@ type Parser struct {
 func NewParser() *Parser {
 	return &Parser{
 		// Match: 📈 TOKEN is up 50% 📈 or 📈 TOKEN is up 2.5X 📈
-		pattern: regexp.MustCompile(`📈\s*([A-Z0-9]+)\s+is\s+up\s+([0-9.]+)\s*(%|X)\s*📈`),
+		pattern:          regexp.MustCompile(`📈\s*([A-Z0-9]+)\s+is\s+up\s+([0-9.]+)\s*(%|X)\s*📈`),
+		valuelessPattern: regexp.MustCompile(`📈\s*([A-Z0-9]+)\s+is\s+up\b`),
 		// Match Solana addresses (Base58, 43-44 chars)
 		caPattern: regexp.MustCompile(`[1-9A-HJ-NP-Za-km-z]{43,44}`),
 		// FIX: Match CA from GeckoTerminal URLs
@@ -68,6 +84,16 @@ func (p *Parser) Parse(text string, msgID int64) (*Signal, error) {
 
 	matches := p.pattern.FindStringSubmatch(cleanText)
 	if len(matches) != 4 {
+		// Token without a usable value - caller decides what to do with it
+		if vm := p.valuelessPattern.FindStringSubmatch(cleanText); len(vm) == 2 {
+			return &Signal{
+				TokenName: strings.ToUpper(vm[1]),
+				MsgID:     msgID,
+				RawText:   text,
+				Type:      SignalIgnore,
+				Mint:      p.extractCA(text),
+			}, nil
+		}
 		return nil, nil // No match, not an error
 	}
 
@@ -109,6 +135,22 @@ func (p *Parser) Classify(signal *Signal, minEntryPercent, takeProfitMultiple fl
 	}
 }
 
+// ClassifyValueless applies the configured action to a signal without value/unit
+func (p *Parser) ClassifyValueless(signal *Signal, action string) {
+	if signal == nil || signal.HasValue() {
+		return
+	}
+
+	switch action {
+	case ValuelessEntry:
+		signal.Type = SignalEntry
+	case ValuelessReview:
+		signal.Type = SignalReview
+	default:
+		signal.Type = SignalIgnore
+	}
+}
+
 // extractCA attempts to extract a Solana contract address from text
 // FIX: Priority order for CA extraction:
 // 1. GeckoTerminal URL (most reliable)
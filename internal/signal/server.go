@@ -1,11 +1,15 @@
 package signal
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/health"
 )
 
 // Handler processes incoming signals from Telegram listener
@@ -14,6 +18,7 @@ type Handler struct {
 	signalChan  chan *Signal
 	minEntry    func() float64
 	takeProfit  func() float64
+	valueless   func() string // Action for signals without value/unit
 	resolveMint func(string) (string, error)
 }
 
@@ -22,6 +27,7 @@ func NewHandler(
 	signalChan chan *Signal,
 	minEntry func() float64,
 	takeProfit func() float64,
+	valueless func() string,
 	resolveMint func(string) (string, error),
 ) *Handler {
 	return &Handler{
@@ -29,6 +35,7 @@ func NewHandler(
 		signalChan:  signalChan,
 		minEntry:    minEntry,
 		takeProfit:  takeProfit,
+		valueless:   valueless,
 		resolveMint: resolveMint,
 	}
 }
@@ -39,6 +46,12 @@ type Server struct {
 	handler *Handler
 	host    string
 	port    int
+
+	metrics func() string                           // Prometheus text exposition for /metrics (optional)
+	healthz func(ctx context.Context) health.Report // component checks for /healthz (optional)
+	secret  string                                  // HMAC key for POST /signal ("" = unsigned accepted), see auth.go
+	allowed []*net.IPNet                            // source allowlist for POST /signal (empty = any), see ingress.go
+	limiter *rateLimiter                            // per-source token bucket (nil = unlimited)
 }
 
 // NewServer creates a new signal server
@@ -69,8 +82,43 @@ func (s *Server) setupRoutes() {
 		})
 	})
 
+	// Component health: 200 when every probe passes, 503 otherwise
+	s.app.Get("/healthz", func(c *fiber.Ctx) error {
+		if s.healthz == nil {
+			return c.Status(404).SendString("health checks not configured\n")
+		}
+		report := s.healthz(c.UserContext())
+		status := fiber.StatusOK
+		if !report.Healthy {
+			status = fiber.StatusServiceUnavailable
+		}
+		return c.Status(status).JSON(report)
+	})
+
 	// Signal endpoint
-	s.app.Post("/signal", s.handleSignal)
+	s.app.Post("/signal", s.guardIngress, s.verifySignature, s.handleSignal)
+
+	// Discord webhook relay, same guards and parsing (see discord.go)
+	s.app.Post("/discord", s.guardIngress, s.verifySignature, s.handleDiscord)
+
+	// Prometheus scrape endpoint
+	s.app.Get("/metrics", func(c *fiber.Ctx) error {
+		if s.metrics == nil {
+			return c.Status(404).SendString("metrics not configured\n")
+		}
+		c.Set(fiber.HeaderContentType, MetricsContentType)
+		return c.SendString(s.metrics())
+	})
+}
+
+// SetMetricsSource registers the Prometheus text served on GET /metrics
+func (s *Server) SetMetricsSource(fn func() string) {
+	s.metrics = fn
+}
+
+// SetHealthSource registers the report served on GET /healthz
+func (s *Server) SetHealthSource(fn func(ctx context.Context) health.Report) {
+	s.healthz = fn
 }
 
 func (s *Server) handleSignal(c *fiber.Ctx) error {
@@ -79,7 +127,21 @@ func (s *Server) handleSignal(c *fiber.Ctx) error {
 		log.Error().Err(err).Msg("failed to parse signal payload")
 		return c.Status(400).JSON(fiber.Map{"error": "invalid payload"})
 	}
+	return s.processPayload(c, payload)
+}
 
+func (s *Server) handleDiscord(c *fiber.Ctx) error {
+	var msg DiscordMessage
+	if err := c.BodyParser(&msg); err != nil {
+		log.Error().Err(err).Msg("failed to parse discord payload")
+		return c.Status(400).JSON(fiber.Map{"error": "invalid payload"})
+	}
+	return s.processPayload(c, msg.ToParsedSignal())
+}
+
+// processPayload parses, classifies and delivers one signal, whatever route
+// it arrived on
+func (s *Server) processPayload(c *fiber.Ctx, payload ParsedSignal) error {
 	// Parse signal
 	signal, err := s.handler.parser.Parse(payload.Text, payload.MsgID)
 	if err != nil {
@@ -100,6 +162,21 @@ func (s *Server) handleSignal(c *fiber.Ctx) error {
 	// Classify signal
 	s.handler.parser.Classify(signal, s.handler.minEntry(), s.handler.takeProfit())
 
+	// Token found but no value/unit - apply configured action explicitly
+	if !signal.HasValue() {
+		action := ValuelessSkip
+		if s.handler.valueless != nil {
+			action = s.handler.valueless()
+		}
+		s.handler.parser.ClassifyValueless(signal, action)
+		log.Warn().
+			Str("token", signal.TokenName).
+			Str("action", action).
+			Str("type", string(signal.Type)).
+			Str("text", payload.Text).
+			Msg("⚠️ AMBIGUOUS SIGNAL: no value/unit")
+	}
+
 	// Resolve mint if not already present
 	if signal.Mint == "" && s.handler.resolveMint != nil {
 		if mint, err := s.handler.resolveMint(signal.TokenName); err == nil {
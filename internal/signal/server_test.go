@@ -0,0 +1,258 @@
+package signal
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-pump-bot/internal/health"
+)
+
+func newTestServer(secret string) (*Server, chan *Signal) {
+	signals := make(chan *Signal, 1)
+	handler := NewHandler(signals,
+		func() float64 { return 50 },
+		func() float64 { return 2 },
+		func() string { return ValuelessSkip },
+		nil,
+	)
+	s := NewServer("127.0.0.1", 0, handler)
+	s.SetWebhookSecret(secret)
+	return s, signals
+}
+
+func postSignal(t *testing.T, s *Server, body []byte, signature string) int {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/signal", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+	resp, err := s.app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func TestServer_WebhookSignature(t *testing.T) {
+	body := []byte(`{"text":"hello","msg_id":1}`)
+	const secret = "s3cret"
+
+	cases := []struct {
+		name      string
+		secret    string
+		signature string
+		want      int
+	}{
+		{"valid signature", secret, Sign(secret, body), 200},
+		{"wrong key", secret, Sign("other", body), 401},
+		{"not hex", secret, "zz", 401},
+		{"missing header", secret, "", 401},
+		{"no secret configured", "", "", 200},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, _ := newTestServer(c.secret)
+			if got := postSignal(t, s, body, c.signature); got != c.want {
+				t.Errorf("status = %d, want %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestServer_WebhookSignatureCoversBody(t *testing.T) {
+	s, _ := newTestServer("s3cret")
+	signed := Sign("s3cret", []byte(`{"text":"hello","msg_id":1}`))
+	if got := postSignal(t, s, []byte(`{"text":"tampered","msg_id":1}`), signed); got != 401 {
+		t.Errorf("status = %d for a tampered body, want 401", got)
+	}
+}
+
+func TestParseAllowlist_CIDRMatching(t *testing.T) {
+	nets, err := ParseAllowlist([]string{"10.0.0.0/8", "203.0.113.7", "2001:db8::/32"})
+	if err != nil {
+		t.Fatalf("ParseAllowlist: %v", err)
+	}
+	cases := map[string]bool{
+		"10.1.2.3":        true,
+		"11.0.0.1":        false,
+		"203.0.113.7":     true,
+		"203.0.113.8":     false,
+		"2001:db8::1":     true,
+		"2001:db9::1":     false,
+		"::ffff:10.0.0.1": true, // IPv4-mapped
+	}
+	for ip, want := range cases {
+		if got := ipAllowed(nets, net.ParseIP(ip)); got != want {
+			t.Errorf("ipAllowed(%s) = %v, want %v", ip, got, want)
+		}
+	}
+	if !ipAllowed(nil, net.ParseIP("8.8.8.8")) {
+		t.Error("empty allowlist should allow everything")
+	}
+	if _, err := ParseAllowlist([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("ParseAllowlist accepted an invalid CIDR")
+	}
+}
+
+func TestClientIP_TrustsForwardedForOnlyFromLoopback(t *testing.T) {
+	if got := clientIP(net.ParseIP("127.0.0.1"), "198.51.100.4"); got.String() != "198.51.100.4" {
+		t.Errorf("behind local proxy = %s, want 198.51.100.4", got)
+	}
+	// The client can prepend anything; only the proxy's own entry counts
+	if got := clientIP(net.ParseIP("127.0.0.1"), "10.0.0.1, 198.51.100.4"); got.String() != "198.51.100.4" {
+		t.Errorf("behind local proxy with a forged hop = %s, want 198.51.100.4", got)
+	}
+	if got := clientIP(net.ParseIP("127.0.0.1"), "198.51.100.4, 127.0.0.1"); got.String() != "198.51.100.4" {
+		t.Errorf("behind two local proxies = %s, want 198.51.100.4", got)
+	}
+	if got := clientIP(net.ParseIP("198.51.100.9"), "10.0.0.1"); got.String() != "198.51.100.9" {
+		t.Errorf("remote peer with spoofed header = %s, want the peer", got)
+	}
+}
+
+func TestRateLimiter_BucketExhaustsAndRefills(t *testing.T) {
+	r := newRateLimiter(2, 3) // 2/s, burst 3
+	now := time.Unix(1_700_000_000, 0)
+	r.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if !r.allow("a") {
+			t.Fatalf("request %d refused within burst", i+1)
+		}
+	}
+	if r.allow("a") {
+		t.Error("4th request allowed with an empty bucket")
+	}
+	if !r.allow("b") {
+		t.Error("another source shares a's bucket")
+	}
+
+	now = now.Add(500 * time.Millisecond) // refills one token
+	if !r.allow("a") {
+		t.Error("request refused after refill")
+	}
+	if r.allow("a") {
+		t.Error("refill gave more than one token")
+	}
+}
+
+func TestServer_IngressGuards(t *testing.T) {
+	body := []byte(`{"text":"hello","msg_id":1}`)
+
+	s, _ := newTestServer("")
+	if err := s.SetAllowedIPs([]string{"10.0.0.0/8"}); err != nil {
+		t.Fatalf("SetAllowedIPs: %v", err)
+	}
+	if got := postSignal(t, s, body, ""); got != 403 {
+		t.Errorf("status from a source outside allowed_ips = %d, want 403", got)
+	}
+
+	s, _ = newTestServer("")
+	s.SetRateLimit(1, 2)
+	for i, want := range []int{200, 200, 429} {
+		if got := postSignal(t, s, body, ""); got != want {
+			t.Errorf("request %d status = %d, want %d", i+1, got, want)
+		}
+	}
+}
+
+func postDiscord(t *testing.T, s *Server, body string) int {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/discord", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func TestServer_DiscordWebhook(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		wantID    int64
+		wantTS    int64
+		wantValue float64
+	}{
+		{
+			name: "message object",
+			body: `{"id":"1234567890123456789","type":0,"channel_id":"1100000000000000000",
+				"content":"📈 PEPE is up 60% 📈","timestamp":"2024-05-01T12:00:00.000000+00:00",
+				"author":{"id":"1","username":"caller","bot":true},"embeds":[],"attachments":[]}`,
+			wantID:    1234567890123456789,
+			wantTS:    1714564800,
+			wantValue: 60,
+		},
+		{
+			name: "execute-webhook embed",
+			body: `{"username":"Pump Alerts","avatar_url":"https://example.com/a.png","content":"",
+				"embeds":[{"title":"New call","description":"📈 PEPE is up 2.5X 📈","color":5814783,
+				"fields":[{"name":"Chart","value":"https://dexscreener.com"}]}]}`,
+			wantValue: 2.5,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, signals := newTestServer("")
+			if got := postDiscord(t, s, c.body); got != 200 {
+				t.Fatalf("status = %d, want 200", got)
+			}
+			var sig *Signal
+			select {
+			case sig = <-signals:
+			default:
+				t.Fatal("no signal delivered")
+			}
+			if sig.TokenName != "PEPE" || sig.Value != c.wantValue {
+				t.Errorf("signal = %s %v, want PEPE %v", sig.TokenName, sig.Value, c.wantValue)
+			}
+			if c.wantID != 0 && sig.MsgID != c.wantID {
+				t.Errorf("MsgID = %d, want the snowflake %d", sig.MsgID, c.wantID)
+			}
+			if c.wantID == 0 && sig.MsgID == 0 {
+				t.Error("MsgID = 0 for a body without id; it would collide in the executor dedup")
+			}
+			if c.wantTS != 0 && sig.Timestamp != c.wantTS {
+				t.Errorf("Timestamp = %d, want %d", sig.Timestamp, c.wantTS)
+			}
+		})
+	}
+}
+
+func TestServer_Healthz(t *testing.T) {
+	s, _ := newTestServer("")
+	get := func() (int, string) {
+		resp, err := s.app.Test(httptest.NewRequest("GET", "/healthz", nil))
+		if err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	if code, _ := get(); code != 404 {
+		t.Errorf("unconfigured /healthz = %d, want 404", code)
+	}
+
+	report := health.Report{Healthy: true, Components: []health.Status{{Name: "RPC", Healthy: true, LatencyMs: 12}}}
+	s.SetHealthSource(func(context.Context) health.Report { return report })
+	code, body := get()
+	if code != 200 || !strings.Contains(body, `"name":"RPC"`) || !strings.Contains(body, `"latency_ms":12`) {
+		t.Errorf("healthy /healthz = %d %s", code, body)
+	}
+
+	report.Healthy = false
+	report.Components[0] = health.Status{Name: "RPC", Error: "rpc down"}
+	code, body = get()
+	if code != 503 || !strings.Contains(body, `"error":"rpc down"`) {
+		t.Errorf("degraded /healthz = %d %s", code, body)
+	}
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"strings"
 	"time"
@@ -24,6 +25,27 @@ type Position struct {
 	EntryTime  int64
 	EntryTxSig string
 	MsgID      int64
+
+	// Live stats, persisted (throttled) by the monitor so a restart does not
+	// show 0% until the first re-quote
+	CurrentValue float64 // in EntryUnit terms
+	PnLPercent   float64
+	Reached2X    bool
+	PeakMultiple float64
+	PoolAddr     string
+
+	// Partial takes so far, written as each one succeeds so a restart
+	// neither re-fires a ladder rung nor forgets the cost already sold
+	ProfitLevels uint32  // bitmask of fired ladder rungs
+	CurveSold    float64 // fraction of the original sold by the take-profit curve
+	LadderSold   float64 // fraction of the original sold by the ladder
+	BreakEven    bool    // stop moved to entry after a partial take
+
+	// Paper positions (trading.paper_trading at entry) exit on paper whatever
+	// the mode is now
+	Paper         bool
+	PaperTokens   uint64  // paper holding left
+	PaperProceeds float64 // SOL booked by paper partial takes
 }
 
 // Trade represents a completed trade
@@ -40,6 +62,19 @@ type Trade struct {
 	EntryTxSig string
 	ExitTxSig  string
 	Timestamp  int64
+
+	// RealizedPnLSol is SOL received by the sell minus SOL spent on the buy,
+	// from on-chain balance changes (0 until the sell is confirmed and fetched)
+	RealizedPnLSol float64
+
+	// SlippagePct is how far the confirmed fill fell short of the quote's
+	// expected output, in percent (negative = better than quoted; 0 until
+	// the transaction is fetched)
+	SlippagePct float64
+
+	// Paper is a simulated fill at the live quote (trading.paper_trading);
+	// nothing was sent and the tx signatures are made up
+	Paper bool
 }
 
 // Signal represents a logged signal
@@ -53,6 +88,28 @@ type Signal struct {
 	Timestamp  int64
 }
 
+// Decision records why a signal did or didn't trade
+type Decision struct {
+	ID         int64
+	Mint       string
+	TokenName  string
+	SignalType string
+	MsgID      int64
+	Action     string // "BUY", "SELL", "SKIP" or "FAIL"
+	Reason     string // skip reason code, or what happened to a trade
+	Detail     string // free text: tx signature, error, threshold
+	Timestamp  int64
+}
+
+// MintSlippage is the learned slippage state for a single mint
+type MintSlippage struct {
+	Mint      string
+	BaseBps   int // Learned base before padding
+	Successes int
+	Failures  int // Slippage failures only
+	UpdatedAt int64
+}
+
 // NewDB creates a new database connection
 func NewDB(path string) (*DB, error) {
 	// Add connection options to path if not present
@@ -70,8 +127,8 @@ func NewDB(path string) (*DB, error) {
 		return nil, err
 	}
 
-	// Create tables
-	if err := createTables(db); err != nil {
+	// Create or upgrade tables
+	if err := runMigrations(db); err != nil {
 		return nil, err
 	}
 
@@ -79,59 +136,38 @@ func NewDB(path string) (*DB, error) {
 	return &DB{db: db}, nil
 }
 
-func createTables(db *sql.DB) error {
-	schema := `
-	CREATE TABLE IF NOT EXISTS positions (
-		mint TEXT PRIMARY KEY,
-		token_name TEXT NOT NULL,
-		size REAL NOT NULL,
-		entry_value REAL NOT NULL,
-		entry_unit TEXT NOT NULL,
-		entry_time INTEGER NOT NULL,
-		entry_tx_sig TEXT NOT NULL,
-		msg_id INTEGER
-	);
-
-	CREATE TABLE IF NOT EXISTS trades (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		mint TEXT NOT NULL,
-		token_name TEXT NOT NULL,
-		side TEXT NOT NULL DEFAULT 'SELL',
-		amount_sol REAL NOT NULL DEFAULT 0,
-		entry_value REAL NOT NULL,
-		exit_value REAL NOT NULL,
-		pnl REAL NOT NULL,
-		duration INTEGER NOT NULL,
-		entry_tx_sig TEXT NOT NULL,
-		exit_tx_sig TEXT NOT NULL,
-		timestamp INTEGER NOT NULL
-	);
-
-	CREATE TABLE IF NOT EXISTS signals (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		token_name TEXT NOT NULL,
-		value REAL NOT NULL,
-		unit TEXT NOT NULL,
-		signal_type TEXT NOT NULL,
-		msg_id INTEGER NOT NULL,
-		timestamp INTEGER NOT NULL
-	);
-
-	CREATE INDEX IF NOT EXISTS idx_trades_timestamp ON trades(timestamp);
-	CREATE INDEX IF NOT EXISTS idx_signals_timestamp ON signals(timestamp);
-	`
-
-	_, err := db.Exec(schema)
-	return err
-}
-
 // InsertPosition inserts or replaces a position
 func (d *DB) InsertPosition(p *Position) error {
 	_, err := d.db.Exec(`
 		INSERT OR REPLACE INTO positions 
-		(mint, token_name, size, entry_value, entry_unit, entry_time, entry_tx_sig, msg_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-		p.Mint, p.TokenName, p.Size, p.EntryValue, p.EntryUnit, p.EntryTime, p.EntryTxSig, p.MsgID)
+		(mint, token_name, size, entry_value, entry_unit, entry_time, entry_tx_sig, msg_id,
+		 current_value, pnl_percent, reached_2x, peak_multiple, pool_addr,
+		 profit_levels, curve_sold, ladder_sold, break_even, paper, paper_tokens, paper_proceeds)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		p.Mint, p.TokenName, p.Size, p.EntryValue, p.EntryUnit, p.EntryTime, p.EntryTxSig, p.MsgID,
+		p.CurrentValue, p.PnLPercent, p.Reached2X, p.PeakMultiple, p.PoolAddr,
+		p.ProfitLevels, p.CurveSold, p.LadderSold, p.BreakEven, p.Paper, p.PaperTokens, p.PaperProceeds)
+	return err
+}
+
+// UpdatePositionStats stores the live stats of an existing position. A
+// position already removed stays removed (no upsert).
+func (d *DB) UpdatePositionStats(p *Position) error {
+	_, err := d.db.Exec(`
+		UPDATE positions SET current_value = ?, pnl_percent = ?, reached_2x = ?, peak_multiple = ?, pool_addr = ?
+		WHERE mint = ?`,
+		p.CurrentValue, p.PnLPercent, p.Reached2X, p.PeakMultiple, p.PoolAddr, p.Mint)
+	return err
+}
+
+// UpdatePositionPartials stores the partial takes of an existing position
+// (fired ladder rungs, the fractions sold, the break-even stop and, on paper,
+// the holding left and the SOL booked). Like UpdatePositionStats it does not upsert.
+func (d *DB) UpdatePositionPartials(p *Position) error {
+	_, err := d.db.Exec(`
+		UPDATE positions SET profit_levels = ?, curve_sold = ?, ladder_sold = ?, break_even = ?, paper_tokens = ?, paper_proceeds = ?
+		WHERE mint = ?`,
+		p.ProfitLevels, p.CurveSold, p.LadderSold, p.BreakEven, p.PaperTokens, p.PaperProceeds, p.Mint)
 	return err
 }
 
@@ -141,13 +177,23 @@ func (d *DB) DeletePosition(mint string) error {
 	return err
 }
 
+// positionColumns are read in the order of Position.scanDest
+const positionColumns = `mint, token_name, size, entry_value, entry_unit, entry_time, entry_tx_sig, msg_id,
+		current_value, pnl_percent, reached_2x, peak_multiple, pool_addr,
+		profit_levels, curve_sold, ladder_sold, break_even, paper, paper_tokens, paper_proceeds`
+
+func (p *Position) scanDest() []interface{} {
+	return []interface{}{&p.Mint, &p.TokenName, &p.Size, &p.EntryValue, &p.EntryUnit, &p.EntryTime, &p.EntryTxSig, &p.MsgID,
+		&p.CurrentValue, &p.PnLPercent, &p.Reached2X, &p.PeakMultiple, &p.PoolAddr,
+		&p.ProfitLevels, &p.CurveSold, &p.LadderSold, &p.BreakEven, &p.Paper, &p.PaperTokens, &p.PaperProceeds}
+}
+
 // GetPosition retrieves a position by mint
 func (d *DB) GetPosition(mint string) (*Position, error) {
 	var p Position
 	err := d.db.QueryRow(`
-		SELECT mint, token_name, size, entry_value, entry_unit, entry_time, entry_tx_sig, msg_id
-		FROM positions WHERE mint = ?`, mint).Scan(
-		&p.Mint, &p.TokenName, &p.Size, &p.EntryValue, &p.EntryUnit, &p.EntryTime, &p.EntryTxSig, &p.MsgID)
+		SELECT `+positionColumns+`
+		FROM positions WHERE mint = ?`, mint).Scan(p.scanDest()...)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -160,7 +206,7 @@ func (d *DB) GetPosition(mint string) (*Position, error) {
 // GetAllPositions retrieves all open positions
 func (d *DB) GetAllPositions() ([]*Position, error) {
 	rows, err := d.db.Query(`
-		SELECT mint, token_name, size, entry_value, entry_unit, entry_time, entry_tx_sig, msg_id
+		SELECT ` + positionColumns + `
 		FROM positions`)
 	if err != nil {
 		return nil, err
@@ -170,7 +216,7 @@ func (d *DB) GetAllPositions() ([]*Position, error) {
 	var positions []*Position
 	for rows.Next() {
 		var p Position
-		if err := rows.Scan(&p.Mint, &p.TokenName, &p.Size, &p.EntryValue, &p.EntryUnit, &p.EntryTime, &p.EntryTxSig, &p.MsgID); err != nil {
+		if err := rows.Scan(p.scanDest()...); err != nil {
 			return nil, err
 		}
 		positions = append(positions, &p)
@@ -182,31 +228,100 @@ func (d *DB) GetAllPositions() ([]*Position, error) {
 func (d *DB) InsertTrade(t *Trade) error {
 	_, err := d.db.Exec(`
 		INSERT INTO trades 
-		(mint, token_name, side, amount_sol, entry_value, exit_value, pnl, duration, entry_tx_sig, exit_tx_sig, timestamp)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		t.Mint, t.TokenName, t.Side, t.AmountSol, t.EntryValue, t.ExitValue, t.PnL, t.Duration, t.EntryTxSig, t.ExitTxSig, t.Timestamp)
+		(mint, token_name, side, amount_sol, entry_value, exit_value, pnl, duration, entry_tx_sig, exit_tx_sig, timestamp, realized_pnl_sol, slippage_pct, paper)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.Mint, t.TokenName, t.Side, t.AmountSol, t.EntryValue, t.ExitValue, t.PnL, t.Duration, t.EntryTxSig, t.ExitTxSig, t.Timestamp, t.RealizedPnLSol, t.SlippagePct, t.Paper)
 	return err
 }
 
+// SetRealizedPnL records the realized SOL PnL on the trade with exitTxSig
+// (sells are logged when sent, before the on-chain result is known)
+func (d *DB) SetRealizedPnL(exitTxSig string, sol float64) error {
+	_, err := d.db.Exec("UPDATE trades SET realized_pnl_sol = ? WHERE exit_tx_sig = ?", sol, exitTxSig)
+	return err
+}
+
+// SetQuotePnL records the quote-based SOL PnL on the trade with exitTxSig,
+// standing in for a realized figure that couldn't be fetched
+func (d *DB) SetQuotePnL(exitTxSig string, sol float64) error {
+	_, err := d.db.Exec("UPDATE trades SET quote_pnl_sol = ? WHERE exit_tx_sig = ?", sol, exitTxSig)
+	return err
+}
+
+// SetTradeSlippage records the realized slippage on the trade sent as txSig
+// (a buy's entry or a sell's exit transaction)
+func (d *DB) SetTradeSlippage(txSig string, pct float64) error {
+	_, err := d.db.Exec(`UPDATE trades SET slippage_pct = ?
+		WHERE (side = 'BUY' AND entry_tx_sig = ?) OR (side != 'BUY' AND exit_tx_sig = ?)`, pct, txSig, txSig)
+	return err
+}
+
+// GetRealizedPnLSince sums the realized SOL PnL of real (not paper) sells
+// logged at or after since, the quote-based PnL standing in where the
+// on-chain figure couldn't be fetched (only one of the two is ever set)
+func (d *DB) GetRealizedPnLSince(since time.Time) (float64, error) {
+	var sol float64
+	err := d.db.QueryRow(`
+		SELECT COALESCE(SUM(realized_pnl_sol + quote_pnl_sol), 0)
+		FROM trades WHERE side = 'SELL' AND paper = 0 AND timestamp >= ?`, since.Unix()).Scan(&sol)
+	return sol, err
+}
+
+// GetPaperRealizedPnL sums the realized SOL PnL of every paper sell: what
+// closed paper positions moved the paper wallet by
+func (d *DB) GetPaperRealizedPnL() (float64, error) {
+	var sol float64
+	err := d.db.QueryRow(`
+		SELECT COALESCE(SUM(realized_pnl_sol), 0)
+		FROM trades WHERE side = 'SELL' AND paper = 1`).Scan(&sol)
+	return sol, err
+}
+
 // GetRecentTrades retrieves the most recent trades
 func (d *DB) GetRecentTrades(limit int) ([]*Trade, error) {
-	rows, err := d.db.Query(`
-		SELECT id, mint, token_name, side, amount_sol, entry_value, exit_value, pnl, duration, entry_tx_sig, exit_tx_sig, timestamp
+	return d.queryTrades(`
+		SELECT `+tradeColumns+`
 		FROM trades ORDER BY timestamp DESC LIMIT ?`, limit)
+}
+
+// tradeColumns are read in the order of queryTrades' Scan
+const tradeColumns = `id, mint, token_name, side, amount_sol, entry_value, exit_value, pnl, duration, entry_tx_sig, exit_tx_sig, timestamp, realized_pnl_sol, slippage_pct, paper`
+
+func (d *DB) queryTrades(query string, args ...interface{}) ([]*Trade, error) {
+	var trades []*Trade
+	err := d.eachTrade(func(t *Trade) error {
+		trades = append(trades, t)
+		return nil
+	}, query, args...)
+	return trades, err
+}
+
+// EachTrade calls fn with every trade, newest first, reading them off the
+// cursor one at a time (the whole history is never held in memory). An
+// error from fn stops the walk and is returned.
+func (d *DB) EachTrade(fn func(*Trade) error) error {
+	return d.eachTrade(fn, `
+		SELECT `+tradeColumns+`
+		FROM trades ORDER BY timestamp DESC`)
+}
+
+func (d *DB) eachTrade(fn func(*Trade) error, query string, args ...interface{}) error {
+	rows, err := d.db.Query(query, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	var trades []*Trade
 	for rows.Next() {
 		var t Trade
-		if err := rows.Scan(&t.ID, &t.Mint, &t.TokenName, &t.Side, &t.AmountSol, &t.EntryValue, &t.ExitValue, &t.PnL, &t.Duration, &t.EntryTxSig, &t.ExitTxSig, &t.Timestamp); err != nil {
-			return nil, err
+		if err := rows.Scan(&t.ID, &t.Mint, &t.TokenName, &t.Side, &t.AmountSol, &t.EntryValue, &t.ExitValue, &t.PnL, &t.Duration, &t.EntryTxSig, &t.ExitTxSig, &t.Timestamp, &t.RealizedPnLSol, &t.SlippagePct, &t.Paper); err != nil {
+			return err
+		}
+		if err := fn(&t); err != nil {
+			return err
 		}
-		trades = append(trades, &t)
 	}
-	return trades, rows.Err()
+	return rows.Err()
 }
 
 // InsertSignal logs a signal
@@ -239,6 +354,63 @@ func (d *DB) GetRecentSignals(limit int) ([]*Signal, error) {
 	return signals, rows.Err()
 }
 
+// InsertDecision appends a trade decision to the audit log
+func (d *DB) InsertDecision(dec *Decision) error {
+	_, err := d.db.Exec(`
+		INSERT INTO decisions (mint, token_name, signal_type, msg_id, action, reason, detail, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		dec.Mint, dec.TokenName, dec.SignalType, dec.MsgID, dec.Action, dec.Reason, dec.Detail, dec.Timestamp)
+	return err
+}
+
+// GetRecentDecisions retrieves the most recent decisions, newest first
+func (d *DB) GetRecentDecisions(limit int) ([]*Decision, error) {
+	rows, err := d.db.Query(`
+		SELECT id, mint, token_name, signal_type, msg_id, action, reason, detail, timestamp
+		FROM decisions ORDER BY timestamp DESC, id DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var decisions []*Decision
+	for rows.Next() {
+		var dec Decision
+		if err := rows.Scan(&dec.ID, &dec.Mint, &dec.TokenName, &dec.SignalType, &dec.MsgID,
+			&dec.Action, &dec.Reason, &dec.Detail, &dec.Timestamp); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, &dec)
+	}
+	return decisions, rows.Err()
+}
+
+// GetMintSlippage retrieves learned slippage for a mint (nil if never traded)
+func (d *DB) GetMintSlippage(mint string) (*MintSlippage, error) {
+	var m MintSlippage
+	err := d.db.QueryRow(`
+		SELECT mint, base_bps, successes, failures, updated_at
+		FROM mint_slippage WHERE mint = ?`, mint).Scan(
+		&m.Mint, &m.BaseBps, &m.Successes, &m.Failures, &m.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// UpsertMintSlippage inserts or replaces learned slippage for a mint
+func (d *DB) UpsertMintSlippage(m *MintSlippage) error {
+	_, err := d.db.Exec(`
+		INSERT OR REPLACE INTO mint_slippage
+		(mint, base_bps, successes, failures, updated_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		m.Mint, m.BaseBps, m.Successes, m.Failures, m.UpdatedAt)
+	return err
+}
+
 // GetTradingStats returns aggregate trading stats
 func (d *DB) GetTradingStats() (totalTrades int, winRate float64, totalPnL float64, err error) {
 	var wins int
@@ -257,6 +429,32 @@ func (d *DB) GetTradingStats() (totalTrades int, winRate float64, totalPnL float
 	return
 }
 
+// PruneSignals deletes logged signals older than olderThan and returns how
+// many were removed
+func (d *DB) PruneSignals(olderThan time.Duration) (int64, error) {
+	return d.pruneBefore("signals", time.Now().Add(-olderThan).Unix())
+}
+
+// PruneTrades deletes trade history older than olderThan and returns how many
+// rows were removed. Open positions live in their own table and are untouched.
+func (d *DB) PruneTrades(olderThan time.Duration) (int64, error) {
+	return d.pruneBefore("trades", time.Now().Add(-olderThan).Unix())
+}
+
+// PruneDecisions deletes audit log rows older than olderThan and returns how
+// many were removed
+func (d *DB) PruneDecisions(olderThan time.Duration) (int64, error) {
+	return d.pruneBefore("decisions", time.Now().Add(-olderThan).Unix())
+}
+
+func (d *DB) pruneBefore(table string, cutoff int64) (int64, error) {
+	res, err := d.db.Exec("DELETE FROM "+table+" WHERE timestamp < ?", cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
 // Close closes the database
 func (d *DB) Close() error {
 	return d.db.Close()
@@ -266,3 +464,9 @@ func (d *DB) Close() error {
 func Now() int64 {
 	return time.Now().Unix()
 }
+
+// Ping runs SELECT 1 (health check)
+func (d *DB) Ping(ctx context.Context) error {
+	var one int
+	return d.db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+}
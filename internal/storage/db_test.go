@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPosition_RoundTripsLiveStats(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+
+	want := Position{
+		Mint:         "Mint111",
+		TokenName:    "PEPE",
+		Size:         0.1,
+		EntryValue:   60,
+		EntryUnit:    "%",
+		EntryTime:    1_700_000_000,
+		EntryTxSig:   "EntrySig",
+		MsgID:        42,
+		CurrentValue: 90,
+		PnLPercent:   50,
+		Reached2X:    true,
+		PeakMultiple: 2.1,
+		PoolAddr:     "Pool111",
+	}
+	if err := db.InsertPosition(&want); err != nil {
+		t.Fatalf("InsertPosition: %v", err)
+	}
+	got, err := db.GetAllPositions()
+	if err != nil || len(got) != 1 || *got[0] != want {
+		t.Fatalf("GetAllPositions = %+v, %v; want [%+v]", got, err, want)
+	}
+
+	want.CurrentValue, want.PnLPercent, want.PeakMultiple = 120, 100, 2.4
+	if err := db.UpdatePositionStats(&want); err != nil {
+		t.Fatalf("UpdatePositionStats: %v", err)
+	}
+	if p, err := db.GetPosition("Mint111"); err != nil || p == nil || *p != want {
+		t.Errorf("GetPosition after update = %+v, %v; want %+v", p, err, want)
+	}
+
+	// Stats written after the position was closed must not resurrect it
+	db.DeletePosition("Mint111")
+	db.UpdatePositionStats(&want)
+	if p, _ := db.GetPosition("Mint111"); p != nil {
+		t.Errorf("closed position came back: %+v", p)
+	}
+}
+
+func TestPrune_RemovesOnlyRowsPastRetention(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	old := time.Now().Add(-10 * 24 * time.Hour).Unix()
+	recent := time.Now().Add(-time.Hour).Unix()
+
+	for i, ts := range []int64{old, recent} {
+		db.InsertSignal(&Signal{TokenName: "PEPE", Value: 60, Unit: "%", SignalType: "ENTRY", MsgID: int64(i), Timestamp: ts})
+		db.InsertTrade(&Trade{Mint: "Mint111", TokenName: "PEPE", Side: "SELL", EntryTxSig: "E", ExitTxSig: "X", Timestamp: ts})
+	}
+	db.InsertPosition(&Position{Mint: "Mint111", TokenName: "PEPE", Size: 0.1, EntryUnit: "%", EntryTime: old, EntryTxSig: "E"})
+
+	keep := 7 * 24 * time.Hour
+	if n, err := db.PruneSignals(keep); err != nil || n != 1 {
+		t.Errorf("PruneSignals = %d, %v; want 1", n, err)
+	}
+	if n, err := db.PruneTrades(keep); err != nil || n != 1 {
+		t.Errorf("PruneTrades = %d, %v; want 1", n, err)
+	}
+
+	if signals, _ := db.GetRecentSignals(10); len(signals) != 1 || signals[0].Timestamp != recent {
+		t.Errorf("signals left = %+v, want only the recent one", signals)
+	}
+	if trades, _ := db.GetRecentTrades(10); len(trades) != 1 || trades[0].Timestamp != recent {
+		t.Errorf("trades left = %+v, want only the recent one", trades)
+	}
+	if p, _ := db.GetPosition("Mint111"); p == nil {
+		t.Error("pruning removed an open position")
+	}
+}
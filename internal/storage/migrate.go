@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// migration upgrades the schema by one version inside a transaction
+type migration func(tx *sql.Tx) error
+
+// migrations are applied in order; migration i brings the database to
+// version i+1. Append new ones, never edit or reorder applied ones.
+var migrations = []migration{
+	migrateV1,
+	migrateV2,
+	migrateV3,
+	migrateV4,
+	migrateV5,
+	migrateV6,
+	migrateV7,
+	migrateV8,
+}
+
+// SchemaVersion is the version a database is at after NewDB
+func SchemaVersion() int {
+	return len(migrations)
+}
+
+// runMigrations applies every migration past the recorded schema_version,
+// each in its own transaction together with its version row. Databases from
+// before versioning have no schema_version and start at 0.
+func runMigrations(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER NOT NULL,
+		applied_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+	)`); err != nil {
+		return err
+	}
+	current, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+
+	for v := current; v < len(migrations); v++ {
+		log.Info().Int("from", v).Int("to", v+1).Msg("migrating database")
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if err := migrations[v](tx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", v+1, err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", v+1); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", v+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %d: %w", v+1, err)
+		}
+	}
+	return nil
+}
+
+// schemaVersion returns the highest applied migration (0 = none)
+func schemaVersion(db *sql.DB) (int, error) {
+	var v sql.NullInt64
+	err := db.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&v)
+	return int(v.Int64), err
+}
+
+// migrateV1 is the schema as of the introduction of versioning. Tables
+// created by older builds already exist, so it also adds the columns those
+// builds lacked.
+func migrateV1(tx *sql.Tx) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS positions (
+		mint TEXT PRIMARY KEY,
+		token_name TEXT NOT NULL,
+		size REAL NOT NULL,
+		entry_value REAL NOT NULL,
+		entry_unit TEXT NOT NULL,
+		entry_time INTEGER NOT NULL,
+		entry_tx_sig TEXT NOT NULL,
+		msg_id INTEGER,
+		current_value REAL NOT NULL DEFAULT 0,
+		pnl_percent REAL NOT NULL DEFAULT 0,
+		reached_2x INTEGER NOT NULL DEFAULT 0,
+		peak_multiple REAL NOT NULL DEFAULT 0,
+		pool_addr TEXT NOT NULL DEFAULT ''
+	);
+
+	CREATE TABLE IF NOT EXISTS trades (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		mint TEXT NOT NULL,
+		token_name TEXT NOT NULL,
+		side TEXT NOT NULL DEFAULT 'SELL',
+		amount_sol REAL NOT NULL DEFAULT 0,
+		entry_value REAL NOT NULL,
+		exit_value REAL NOT NULL,
+		pnl REAL NOT NULL,
+		duration INTEGER NOT NULL,
+		entry_tx_sig TEXT NOT NULL,
+		exit_tx_sig TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		realized_pnl_sol REAL NOT NULL DEFAULT 0
+	);
+
+	CREATE TABLE IF NOT EXISTS signals (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		token_name TEXT NOT NULL,
+		value REAL NOT NULL,
+		unit TEXT NOT NULL,
+		signal_type TEXT NOT NULL,
+		msg_id INTEGER NOT NULL,
+		timestamp INTEGER NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS mint_slippage (
+		mint TEXT PRIMARY KEY,
+		base_bps INTEGER NOT NULL,
+		successes INTEGER NOT NULL DEFAULT 0,
+		failures INTEGER NOT NULL DEFAULT 0,
+		updated_at INTEGER NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_trades_timestamp ON trades(timestamp);
+	CREATE INDEX IF NOT EXISTS idx_signals_timestamp ON signals(timestamp);
+	`
+
+	if _, err := tx.Exec(schema); err != nil {
+		return err
+	}
+	columns := []struct{ table, column, definition string }{
+		{"trades", "realized_pnl_sol", "REAL NOT NULL DEFAULT 0"},
+		{"positions", "current_value", "REAL NOT NULL DEFAULT 0"},
+		{"positions", "pnl_percent", "REAL NOT NULL DEFAULT 0"},
+		{"positions", "reached_2x", "INTEGER NOT NULL DEFAULT 0"},
+		{"positions", "peak_multiple", "REAL NOT NULL DEFAULT 0"},
+		{"positions", "pool_addr", "TEXT NOT NULL DEFAULT ''"},
+	}
+	for _, c := range columns {
+		if err := addColumnIfMissing(tx, c.table, c.column, c.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateV2 adds the slippage realized by each trade
+func migrateV2(tx *sql.Tx) error {
+	return addColumnIfMissing(tx, "trades", "slippage_pct", "REAL NOT NULL DEFAULT 0")
+}
+
+// migrateV3 adds the audit log of trade decisions
+func migrateV3(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS decisions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		mint TEXT NOT NULL,
+		token_name TEXT NOT NULL,
+		signal_type TEXT NOT NULL,
+		msg_id INTEGER NOT NULL,
+		action TEXT NOT NULL,
+		reason TEXT NOT NULL,
+		detail TEXT NOT NULL DEFAULT '',
+		timestamp INTEGER NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_decisions_timestamp ON decisions(timestamp);
+	CREATE INDEX IF NOT EXISTS idx_decisions_mint ON decisions(mint);
+	`)
+	return err
+}
+
+// migrateV4 flags paper trades (trading.paper_trading: filled at the quote,
+// never sent)
+func migrateV4(tx *sql.Tx) error {
+	return addColumnIfMissing(tx, "trades", "paper", "INTEGER NOT NULL DEFAULT 0")
+}
+
+// migrateV5 persists each position's partial takes (ladder rungs fired and
+// the fractions sold by the ladder and the take-profit curve)
+func migrateV5(tx *sql.Tx) error {
+	columns := []struct{ column, definition string }{
+		{"profit_levels", "INTEGER NOT NULL DEFAULT 0"},
+		{"curve_sold", "REAL NOT NULL DEFAULT 0"},
+		{"ladder_sold", "REAL NOT NULL DEFAULT 0"},
+	}
+	for _, c := range columns {
+		if err := addColumnIfMissing(tx, "positions", c.column, c.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateV6 flags paper positions and keeps their paper holding and the SOL
+// their partial takes booked, so exits after a restart stay on paper and
+// keep their cost basis. Positions opened by a paper fill before this are
+// recognised by their entry signature.
+func migrateV6(tx *sql.Tx) error {
+	columns := []struct{ column, definition string }{
+		{"paper", "INTEGER NOT NULL DEFAULT 0"},
+		{"paper_tokens", "INTEGER NOT NULL DEFAULT 0"},
+		{"paper_proceeds", "REAL NOT NULL DEFAULT 0"},
+	}
+	for _, c := range columns {
+		if err := addColumnIfMissing(tx, "positions", c.column, c.definition); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec(`UPDATE positions SET paper = 1 WHERE entry_tx_sig LIKE 'PAPER_%'`)
+	return err
+}
+
+// migrateV7 keeps whether a position's stop has moved to break-even, so a
+// restart doesn't drop it back to the configured stop-loss
+func migrateV7(tx *sql.Tx) error {
+	return addColumnIfMissing(tx, "positions", "break_even", "INTEGER NOT NULL DEFAULT 0")
+}
+
+// migrateV8 keeps the quote-based PnL of sells whose on-chain result
+// couldn't be fetched, so the daily loss window still counts them after a
+// restart
+func migrateV8(tx *sql.Tx) error {
+	return addColumnIfMissing(tx, "trades", "quote_pnl_sol", "REAL NOT NULL DEFAULT 0")
+}
+
+// addColumnIfMissing adds column to table unless it already exists
+func addColumnIfMissing(tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.Query("PRAGMA table_info(" + table + ")")
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		if name == column {
+			found = true
+		}
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil || found {
+		return err
+	}
+
+	log.Info().Str("table", table).Str("column", column).Msg("migrating database: adding column")
+	_, err = tx.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition)
+	return err
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// v0Schema is what builds before versioning (and before realized PnL and
+// position stats) created
+const v0Schema = `
+	CREATE TABLE positions (
+		mint TEXT PRIMARY KEY, token_name TEXT NOT NULL, size REAL NOT NULL, entry_value REAL NOT NULL,
+		entry_unit TEXT NOT NULL, entry_time INTEGER NOT NULL, entry_tx_sig TEXT NOT NULL, msg_id INTEGER);
+	CREATE TABLE trades (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, mint TEXT NOT NULL, token_name TEXT NOT NULL,
+		side TEXT NOT NULL DEFAULT 'SELL', amount_sol REAL NOT NULL DEFAULT 0, entry_value REAL NOT NULL,
+		exit_value REAL NOT NULL, pnl REAL NOT NULL, duration INTEGER NOT NULL,
+		entry_tx_sig TEXT NOT NULL, exit_tx_sig TEXT NOT NULL, timestamp INTEGER NOT NULL);
+	INSERT INTO positions VALUES ('Mint111', 'PEPE', 0.1, 60, '%', 1700000000, 'EntrySig', 42);
+	INSERT INTO trades (mint, token_name, entry_value, exit_value, pnl, duration, entry_tx_sig, exit_tx_sig, timestamp)
+		VALUES ('Mint222', 'WIF', 50, 100, 100, 60, 'E', 'X', 1700000000);`
+
+func TestMigrations_UpgradeV0Database(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	old, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	_, err = old.Exec(v0Schema)
+	old.Close()
+	if err != nil {
+		t.Fatalf("creating v0 schema: %v", err)
+	}
+
+	db, err := NewDB(path)
+	if err != nil {
+		t.Fatalf("NewDB on v0 schema: %v", err)
+	}
+	if v, err := schemaVersion(db.db); err != nil || v != SchemaVersion() {
+		t.Fatalf("schema version = %d, %v; want %d", v, err, SchemaVersion())
+	}
+
+	// Old rows survive with the added columns at their defaults
+	p, err := db.GetPosition("Mint111")
+	if err != nil || p == nil {
+		t.Fatalf("GetPosition after migration = %+v, %v", p, err)
+	}
+	if p.PoolAddr != "" || p.Reached2X || p.PnLPercent != 0 {
+		t.Errorf("migrated position = %+v, want zero stats", p)
+	}
+	if err := db.SetRealizedPnL("X", 0.05); err != nil {
+		t.Errorf("SetRealizedPnL on migrated trades: %v", err)
+	}
+	if _, err := db.db.Exec("INSERT INTO mint_slippage (mint, base_bps, updated_at) VALUES ('M', 500, 0)"); err != nil {
+		t.Errorf("table missing from v0 not created: %v", err)
+	}
+
+	// Reopening applies nothing twice
+	db.Close()
+	if db, err = NewDB(path); err != nil {
+		t.Fatalf("reopening: %v", err)
+	}
+	var applied int
+	db.db.QueryRow("SELECT COUNT(*) FROM schema_version").Scan(&applied)
+	if applied != SchemaVersion() {
+		t.Errorf("schema_version rows = %d after reopening, want %d", applied, SchemaVersion())
+	}
+}
@@ -0,0 +1,79 @@
+package storage
+
+import "database/sql"
+
+// GroupStats aggregates closed trades (SELL rows only, so a round trip counts
+// once; paper trades are left out) for one token or one hour of the day. PnL is the per-trade percent
+// recorded at sell time; RealizedPnLSol sums the on-chain figures.
+type GroupStats struct {
+	Key            string // token name, or hour of day "00".."23" (UTC)
+	Trades         int
+	Wins           int
+	WinRate        float64 // percent
+	TotalPnL       float64 // sum of per-trade PnL percent
+	AvgPnL         float64
+	RealizedPnLSol float64
+}
+
+// GetStatsByToken returns per-token performance, most traded first
+func (d *DB) GetStatsByToken() ([]*GroupStats, error) {
+	return d.groupStats(`
+		SELECT token_name AS k,
+			COUNT(*),
+			SUM(CASE WHEN pnl > 0 THEN 1 ELSE 0 END),
+			COALESCE(SUM(pnl), 0),
+			COALESCE(SUM(realized_pnl_sol), 0)
+		FROM trades WHERE side = 'SELL' AND paper = 0
+		GROUP BY k ORDER BY COUNT(*) DESC, k`)
+}
+
+// GetStatsByHour returns performance by UTC hour of day (sell time); hours
+// without trades are left out
+func (d *DB) GetStatsByHour() ([]*GroupStats, error) {
+	return d.groupStats(`
+		SELECT strftime('%H', timestamp, 'unixepoch') AS k,
+			COUNT(*),
+			SUM(CASE WHEN pnl > 0 THEN 1 ELSE 0 END),
+			COALESCE(SUM(pnl), 0),
+			COALESCE(SUM(realized_pnl_sol), 0)
+		FROM trades WHERE side = 'SELL' AND paper = 0
+		GROUP BY k ORDER BY k`)
+}
+
+// GetBestWorstTrades returns the n highest and n lowest PnL real (not paper) sells
+func (d *DB) GetBestWorstTrades(n int) (best, worst []*Trade, err error) {
+	best, err = d.queryTrades(`
+		SELECT `+tradeColumns+`
+		FROM trades WHERE side = 'SELL' AND paper = 0 ORDER BY pnl DESC, timestamp DESC LIMIT ?`, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	worst, err = d.queryTrades(`
+		SELECT `+tradeColumns+`
+		FROM trades WHERE side = 'SELL' AND paper = 0 ORDER BY pnl ASC, timestamp DESC LIMIT ?`, n)
+	return best, worst, err
+}
+
+func (d *DB) groupStats(query string) ([]*GroupStats, error) {
+	rows, err := d.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*GroupStats
+	for rows.Next() {
+		var g GroupStats
+		var key sql.NullString
+		if err := rows.Scan(&key, &g.Trades, &g.Wins, &g.TotalPnL, &g.RealizedPnLSol); err != nil {
+			return nil, err
+		}
+		g.Key = key.String
+		if g.Trades > 0 {
+			g.WinRate = float64(g.Wins) / float64(g.Trades) * 100
+			g.AvgPnL = g.TotalPnL / float64(g.Trades)
+		}
+		out = append(out, &g)
+	}
+	return out, rows.Err()
+}
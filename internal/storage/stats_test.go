@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStats_AggregateSellsOnly(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	at := func(hour int) int64 {
+		return time.Date(2024, 5, 1, hour, 30, 0, 0, time.UTC).Unix()
+	}
+	seed := []Trade{
+		{TokenName: "PEPE", Side: "BUY", PnL: 0, Timestamp: at(9)},
+		{TokenName: "PEPE", Side: "SELL", PnL: 100, RealizedPnLSol: 0.1, Timestamp: at(9)},
+		{TokenName: "PEPE", Side: "BUY", PnL: 0, Timestamp: at(14)},
+		{TokenName: "PEPE", Side: "SELL", PnL: -40, RealizedPnLSol: -0.04, Timestamp: at(14)},
+		{TokenName: "WIF", Side: "BUY", PnL: 0, Timestamp: at(9)},
+		{TokenName: "WIF", Side: "SELL", PnL: 20, RealizedPnLSol: 0.02, Timestamp: at(9)},
+		// Paper fills never count
+		{TokenName: "PEPE", Side: "SELL", PnL: 500, RealizedPnLSol: 0.5, Timestamp: at(9), Paper: true},
+		{TokenName: "BONK", Side: "SELL", PnL: -90, RealizedPnLSol: -0.09, Timestamp: at(20), Paper: true},
+	}
+	for i := range seed {
+		seed[i].Mint, seed[i].EntryTxSig, seed[i].ExitTxSig = "M", "E", "X"
+		if err := db.InsertTrade(&seed[i]); err != nil {
+			t.Fatalf("InsertTrade: %v", err)
+		}
+	}
+
+	byToken, err := db.GetStatsByToken()
+	if err != nil {
+		t.Fatalf("GetStatsByToken: %v", err)
+	}
+	if len(byToken) != 2 {
+		t.Fatalf("GetStatsByToken = %d groups, want 2", len(byToken))
+	}
+	if g := byToken[0]; g.Key != "PEPE" || g.Trades != 2 || g.Wins != 1 || g.WinRate != 50 || g.TotalPnL != 60 || g.AvgPnL != 30 {
+		t.Errorf("PEPE = %+v, want 2 trades, 1 win, 50%%, total 60, avg 30", g)
+	}
+	if g := byToken[1]; g.Key != "WIF" || g.Trades != 1 || g.WinRate != 100 {
+		t.Errorf("WIF = %+v, want 1 trade at 100%%", g)
+	}
+
+	byHour, err := db.GetStatsByHour()
+	if err != nil {
+		t.Fatalf("GetStatsByHour: %v", err)
+	}
+	if len(byHour) != 2 || byHour[0].Key != "09" || byHour[0].Trades != 2 || byHour[1].Key != "14" || byHour[1].Wins != 0 {
+		t.Errorf("GetStatsByHour = %+v %+v, want 09 with 2 trades and 14 with no wins", byHour[0], byHour[len(byHour)-1])
+	}
+	if got := byHour[0].RealizedPnLSol; got < 0.1199 || got > 0.1201 {
+		t.Errorf("09 realized = %v, want 0.12", got)
+	}
+
+	best, worst, err := db.GetBestWorstTrades(1)
+	if err != nil {
+		t.Fatalf("GetBestWorstTrades: %v", err)
+	}
+	if len(best) != 1 || best[0].PnL != 100 || len(worst) != 1 || worst[0].PnL != -40 {
+		t.Errorf("best/worst = %+v / %+v, want 100 and -40", best, worst)
+	}
+}
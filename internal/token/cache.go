@@ -3,6 +3,7 @@ package token
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/fsnotify/fsnotify"
@@ -14,6 +15,7 @@ type Cache struct {
 	mu     sync.RWMutex
 	tokens map[string]string // TokenName -> Mint
 	path   string
+	saveMu sync.Mutex // serializes writers so an older snapshot can't replace a newer one
 }
 
 // NewCache creates a new token cache from JSON file
@@ -55,17 +57,42 @@ func (c *Cache) Size() int {
 	return len(c.tokens)
 }
 
-// Save writes the cache to file
+// Save writes the cache back to the file it was loaded from
 func (c *Cache) Save() error {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	return c.SaveTo(c.path)
+}
+
+// SaveTo writes the cache to path atomically (temp file + rename) so a crash
+// or concurrent reader never sees a half-written JSON file
+func (c *Cache) SaveTo(path string) error {
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
 
+	c.mu.RLock()
 	data, err := json.MarshalIndent(c.tokens, "", "  ")
+	c.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
 	if err != nil {
 		return err
 	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
 
-	return os.WriteFile(c.path, data, 0644)
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
 func (c *Cache) load() error {
@@ -97,10 +124,13 @@ func (c *Cache) watchFile() {
 	}
 	defer watcher.Close()
 
-	if err := watcher.Add(c.path); err != nil {
+	// Watch the directory: Save replaces the file via rename, which would
+	// drop a watch placed on the file itself
+	if err := watcher.Add(filepath.Dir(c.path)); err != nil {
 		log.Error().Err(err).Msg("failed to watch token cache file")
 		return
 	}
+	target := filepath.Clean(c.path)
 
 	for {
 		select {
@@ -108,7 +138,10 @@ func (c *Cache) watchFile() {
 			if !ok {
 				return
 			}
-			if event.Op&fsnotify.Write == fsnotify.Write {
+			if filepath.Clean(event.Name) != target {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
 				log.Info().Msg("token cache file changed, reloading")
 				if err := c.load(); err != nil {
 					log.Error().Err(err).Msg("failed to reload token cache")
@@ -0,0 +1,67 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mapLookup resolves symbols from a fixed table
+type mapLookup map[string]string
+
+func (m mapLookup) LookupSymbol(ctx context.Context, symbol string) (string, error) {
+	if mint, ok := m[symbol]; ok {
+		return mint, nil
+	}
+	return "", ErrTokenNotFound
+}
+
+func TestCache_ResolvedSymbolsSurviveReload(t *testing.T) {
+	upstream := mapLookup{}
+	for i := 0; i < 20; i++ {
+		upstream[fmt.Sprintf("TOK%d", i)] = fmt.Sprintf("Mint%02d111111111111111111111111111111111111", i)
+	}
+
+	cache := newTempCache(t)
+	r := NewResolver(cache)
+	r.SetUpstream(upstream, time.Minute, time.Second)
+
+	// Concurrent misses each save; the last write must hold every symbol
+	var wg sync.WaitGroup
+	for symbol := range upstream {
+		wg.Add(1)
+		go func(symbol string) {
+			defer wg.Done()
+			if _, err := r.Resolve(symbol); err != nil {
+				t.Errorf("Resolve(%s): %v", symbol, err)
+			}
+		}(symbol)
+	}
+	wg.Wait()
+
+	reloaded, err := NewCache(cache.path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	for symbol, want := range upstream {
+		if got, ok := reloaded.Get(symbol); !ok || got != want {
+			t.Errorf("reloaded %s = %q (%v), want %q", symbol, got, ok, want)
+		}
+	}
+
+	// SaveTo writes a copy elsewhere
+	copyPath := filepath.Join(t.TempDir(), "copy.json")
+	if err := cache.SaveTo(copyPath); err != nil {
+		t.Fatalf("SaveTo: %v", err)
+	}
+	copied, err := NewCache(copyPath)
+	if err != nil {
+		t.Fatalf("load copy: %v", err)
+	}
+	if copied.Size() != len(upstream) {
+		t.Errorf("copy has %d tokens, want %d", copied.Size(), len(upstream))
+	}
+}
@@ -0,0 +1,206 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultDexScreenerURL is DexScreener's pair search (q=<symbol or name>)
+const DefaultDexScreenerURL = "https://api.dexscreener.com/latest/dex/search"
+
+// DexScreenerBackoff is how long a 429 without Retry-After pauses lookups
+const DexScreenerBackoff = time.Minute
+
+// ErrRateLimited is returned while DexScreener asks us to back off; the
+// resolver chain moves on to its next source
+var ErrRateLimited = errors.New("dexscreener rate limited")
+
+// wsolMint is wrapped SOL, the quote of the pools the price feed can price
+const wsolMint = "So11111111111111111111111111111111111111112"
+
+// Pair is the pool a DexScreener lookup settled on
+type Pair struct {
+	Mint         string
+	QuoteMint    string // the pair's other token
+	Pool         string // pair (AMM pool) address
+	DexID        string
+	Labels       []string
+	LiquidityUSD float64
+}
+
+// RaydiumAMM reports whether the pool is a Raydium AMM v4 pair: DexScreener
+// labels the CLMM and CPMM programs, v4 pools carry no label. v4 is the
+// layout the WebSocket price feed decodes.
+func (p Pair) RaydiumAMM() bool {
+	return p.DexID == "raydium" && len(p.Labels) == 0
+}
+
+// SOLPool reports whether the pool can seed the price feed: a Raydium AMM
+// v4 pair quoted in WSOL (a USDC pair's reserves would read as SOL)
+func (p Pair) SOLPool() bool {
+	return p.RaydiumAMM() && p.QuoteMint == wsolMint
+}
+
+// DexScreenerResolver resolves a symbol to the one Solana token carrying it,
+// through that token's most liquid pair. One symbol is often shared by a real
+// token and copycats; when more than one Solana mint matches, the lookup is
+// refused as ambiguous rather than guessing by liquidity. The pool of each
+// resolved mint is remembered for the price feed.
+type DexScreenerResolver struct {
+	url    string
+	client *http.Client
+
+	mu           sync.Mutex
+	pairs        map[string]Pair // mint -> pair it was resolved through
+	backoffUntil time.Time       // set by a 429
+}
+
+// NewDexScreenerResolver creates a resolver against baseURL (DefaultDexScreenerURL if empty)
+func NewDexScreenerResolver(baseURL string, timeout time.Duration) *DexScreenerResolver {
+	if baseURL == "" {
+		baseURL = DefaultDexScreenerURL
+	}
+	return &DexScreenerResolver{
+		url:    baseURL,
+		client: &http.Client{Timeout: timeout},
+		pairs:  make(map[string]Pair),
+	}
+}
+
+// dexPair is the subset of a search result pair we use
+type dexPair struct {
+	ChainID     string   `json:"chainId"`
+	DexID       string   `json:"dexId"`
+	PairAddress string   `json:"pairAddress"`
+	Labels      []string `json:"labels"`
+	BaseToken   struct {
+		Address string `json:"address"`
+		Name    string `json:"name"`
+		Symbol  string `json:"symbol"`
+	} `json:"baseToken"`
+	QuoteToken struct {
+		Address string `json:"address"`
+	} `json:"quoteToken"`
+	Liquidity struct {
+		USD float64 `json:"usd"`
+	} `json:"liquidity"`
+}
+
+// LookupSymbol implements Lookup
+func (d *DexScreenerResolver) LookupSymbol(ctx context.Context, symbol string) (string, error) {
+	pair, err := d.LookupPair(ctx, symbol)
+	if err != nil {
+		return "", err
+	}
+	return pair.Mint, nil
+}
+
+// LookupPair returns the highest-liquidity Solana pair of the only base
+// token whose symbol matches exactly (case-insensitive); ErrAmbiguousSymbol
+// if several mints do
+func (d *DexScreenerResolver) LookupPair(ctx context.Context, symbol string) (Pair, error) {
+	d.mu.Lock()
+	wait := time.Until(d.backoffUntil)
+	d.mu.Unlock()
+	if wait > 0 {
+		return Pair{}, fmt.Errorf("%w for %s", ErrRateLimited, wait.Truncate(time.Second))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", d.url+"?q="+url.QueryEscape(symbol), nil)
+	if err != nil {
+		return Pair{}, err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return Pair{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		wait := retryAfter(resp.Header.Get("Retry-After"))
+		d.mu.Lock()
+		d.backoffUntil = time.Now().Add(wait)
+		d.mu.Unlock()
+		log.Warn().Dur("backoff", wait).Msg("⚠️ DexScreener rate limit hit, pausing lookups")
+		return Pair{}, ErrRateLimited
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Pair{}, fmt.Errorf("dexscreener lookup: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Pairs []dexPair `json:"pairs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Pair{}, fmt.Errorf("dexscreener lookup: decode: %w", err)
+	}
+	pair, mints := bestPair(body.Pairs, symbol)
+	switch {
+	case mints == 0:
+		return Pair{}, ErrTokenNotFound
+	case mints > 1:
+		return Pair{}, fmt.Errorf("%w: %s matches %d Solana tokens", ErrAmbiguousSymbol, symbol, mints)
+	}
+
+	d.mu.Lock()
+	d.pairs[pair.Mint] = pair
+	d.mu.Unlock()
+	return pair, nil
+}
+
+// PairFor returns the pair a mint was resolved through
+func (d *DexScreenerResolver) PairFor(mint string) (Pair, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	pair, ok := d.pairs[mint]
+	return pair, ok
+}
+
+// bestPair picks the matching Solana pair with the most liquidity (ties
+// keep the API's order) and counts the distinct mints that match
+func bestPair(pairs []dexPair, symbol string) (Pair, int) {
+	var best Pair
+	seen := make(map[string]bool)
+	for _, p := range pairs {
+		t := p.BaseToken
+		if p.ChainID != "solana" || t.Address == "" || p.PairAddress == "" {
+			continue
+		}
+		if !strings.EqualFold(t.Symbol, symbol) {
+			continue
+		}
+		found := len(seen) > 0
+		seen[t.Address] = true
+		if found && p.Liquidity.USD <= best.LiquidityUSD {
+			continue
+		}
+		best = Pair{
+			Mint:         t.Address,
+			QuoteMint:    p.QuoteToken.Address,
+			Pool:         p.PairAddress,
+			DexID:        p.DexID,
+			Labels:       p.Labels,
+			LiquidityUSD: p.Liquidity.USD,
+		}
+	}
+	return best, len(seen)
+}
+
+// retryAfter parses a Retry-After header in seconds (the HTTP-date form
+// and missing values fall back to DexScreenerBackoff)
+func retryAfter(header string) time.Duration {
+	if secs, err := strconv.Atoi(strings.TrimSpace(header)); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return DexScreenerBackoff
+}
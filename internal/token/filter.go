@@ -0,0 +1,88 @@
+package token
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Filter decides which tokens may be bought: never a blacklisted one and,
+// when a whitelist is set, only whitelisted ones. Entries are mints or
+// symbols; symbols are resolved to mints up front so a renamed or
+// look-alike ticker can't slip through. A resolved whitelist symbol then
+// only admits its mint (a copycat sharing the ticker stays out); a
+// blacklisted symbol keeps blocking by name too, and an unresolvable entry
+// matches by name only.
+type Filter struct {
+	blackMints, blackSymbols map[string]bool
+	whiteMints, whiteSymbols map[string]bool
+}
+
+// Filter verdicts (the skip reason when a buy is refused)
+const (
+	FilterBlacklisted    = "blacklisted"
+	FilterNotWhitelisted = "not_whitelisted"
+)
+
+// NewFilter builds a filter; resolve (e.g. Resolver.Resolve) may be nil,
+// in which case entries are matched as given
+func NewFilter(blacklist, whitelist []string, resolve func(string) (string, error)) *Filter {
+	f := &Filter{
+		blackMints:   make(map[string]bool),
+		blackSymbols: make(map[string]bool),
+		whiteMints:   make(map[string]bool),
+		whiteSymbols: make(map[string]bool),
+	}
+	f.add(blacklist, f.blackMints, f.blackSymbols, resolve, true)
+	f.add(whitelist, f.whiteMints, f.whiteSymbols, resolve, false)
+	return f
+}
+
+// add records entries; keepNames keeps matching a resolved symbol by name
+// as well as by its mint
+func (f *Filter) add(entries []string, mints, symbols map[string]bool, resolve func(string) (string, error), keepNames bool) {
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		mints[entry] = true
+		if resolve == nil {
+			symbols[normalizeSymbol(entry)] = true
+			continue
+		}
+		if mint, err := resolve(strings.TrimPrefix(entry, "$")); err == nil {
+			mints[mint] = true
+			if keepNames {
+				symbols[normalizeSymbol(entry)] = true
+			}
+		} else {
+			symbols[normalizeSymbol(entry)] = true
+			log.Warn().Str("token", entry).Msg("token filter: cannot resolve, matching by name only")
+		}
+	}
+}
+
+// Allow reports whether a token may be bought, with the verdict when not
+func (f *Filter) Allow(mint, symbol string) (bool, string) {
+	if f == nil {
+		return true, ""
+	}
+	sym := normalizeSymbol(symbol)
+	if f.blackMints[mint] || (sym != "" && f.blackSymbols[sym]) {
+		return false, FilterBlacklisted
+	}
+	if len(f.whiteMints) > 0 && !f.whiteMints[mint] && (sym == "" || !f.whiteSymbols[sym]) {
+		return false, FilterNotWhitelisted
+	}
+	return true, ""
+}
+
+// Empty reports whether the filter allows everything
+func (f *Filter) Empty() bool {
+	return f == nil || (len(f.blackMints) == 0 && len(f.whiteMints) == 0)
+}
+
+func normalizeSymbol(s string) string {
+	return strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(s), "$"))
+}
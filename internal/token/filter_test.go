@@ -0,0 +1,50 @@
+package token
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	bonkMint = "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263"
+	wifMint  = "EKpQGSJtjMFqKZ9KQanSqYXRcF8fBopzLHYxdM65zcjm"
+	scamMint = "Scam1111111111111111111111111111111111111111"
+)
+
+func testResolve(name string) (string, error) {
+	switch strings.ToUpper(name) {
+	case "BONK":
+		return bonkMint, nil
+	case "WIF":
+		return wifMint, nil
+	}
+	return "", ErrTokenNotFound
+}
+
+func TestFilter_Allow(t *testing.T) {
+	tests := []struct {
+		name         string
+		black, white []string
+		mint, symbol string
+		wantOK       bool
+		wantVerdict  string
+	}{
+		{"empty lists allow all", nil, nil, scamMint, "SCAM", true, ""},
+		{"blacklisted mint", []string{scamMint}, nil, scamMint, "SCAM", false, FilterBlacklisted},
+		{"blacklisted symbol resolves to mint", []string{"BONK"}, nil, bonkMint, "", false, FilterBlacklisted},
+		{"blacklist only allows others", []string{scamMint}, nil, bonkMint, "BONK", true, ""},
+		{"whitelisted symbol resolves to mint", nil, []string{"$wif"}, wifMint, "", true, ""},
+		{"whitelist only refuses others", nil, []string{"WIF"}, bonkMint, "BONK", false, FilterNotWhitelisted},
+		{"resolved whitelist symbol refuses a copycat", nil, []string{"WIF"}, scamMint, "WIF", false, FilterNotWhitelisted},
+		{"resolved blacklist symbol still blocks by name", []string{"BONK"}, nil, scamMint, "BONK", false, FilterBlacklisted},
+		{"unresolvable whitelist entry matches by name", nil, []string{"NEWCOIN"}, scamMint, "$newcoin", true, ""},
+		{"blacklist beats whitelist", []string{"WIF"}, []string{"WIF"}, wifMint, "WIF", false, FilterBlacklisted},
+	}
+	for _, tt := range tests {
+		f := NewFilter(tt.black, tt.white, testResolve)
+		ok, verdict := f.Allow(tt.mint, tt.symbol)
+		if ok != tt.wantOK || verdict != tt.wantVerdict {
+			t.Errorf("%s: Allow = %v, %q; want %v, %q", tt.name, ok, verdict, tt.wantOK, tt.wantVerdict)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package token
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mr-tron/base58"
+
+	"solana-pump-bot/internal/blockchain"
+)
+
+// MetaplexProgramID owns the token metadata accounts (one PDA per mint)
+const MetaplexProgramID = "metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s"
+
+// metadataKeyV1 is the account key byte of a Metaplex MetadataV1 account
+const metadataKeyV1 = 4
+
+// AccountsReader fetches raw account data index-aligned with pubkeys, nil
+// for accounts that do not exist (blockchain.RPCClient)
+type AccountsReader interface {
+	GetAccountsData(ctx context.Context, pubkeys []string) ([][]byte, error)
+}
+
+// Candidate is a mint a search returned for a symbol or name
+type Candidate struct {
+	Mint         string
+	LiquidityUSD float64
+}
+
+// CandidateSource lists mints that may carry a symbol; their names are not
+// trusted, only used to narrow what is read on-chain
+type CandidateSource interface {
+	Candidates(ctx context.Context, query string) ([]Candidate, error)
+}
+
+// Metadata is the name and symbol a mint's Metaplex metadata account holds
+type Metadata struct {
+	Mint   string
+	Name   string
+	Symbol string
+}
+
+// MetadataPDA returns the Metaplex metadata account of mint
+// (seeds: "metadata", program id, mint)
+func MetadataPDA(mint string) (string, error) {
+	program, err := base58.Decode(MetaplexProgramID)
+	if err != nil {
+		return "", err
+	}
+	mintKey, err := base58.Decode(mint)
+	if err != nil || len(mintKey) != 32 {
+		return "", fmt.Errorf("invalid mint %q", mint)
+	}
+	addr, _, err := blockchain.FindProgramAddress([][]byte{[]byte("metadata"), program, mintKey}, MetaplexProgramID)
+	return addr, err
+}
+
+// ParseMetadata decodes the head of a MetadataV1 account: key (1), update
+// authority (32), mint (32), then name and symbol as borsh strings, which
+// Metaplex pads with NULs
+func ParseMetadata(data []byte) (Metadata, error) {
+	if len(data) < 65 || data[0] != metadataKeyV1 {
+		return Metadata{}, errors.New("not a metadata account")
+	}
+	md := Metadata{Mint: base58.Encode(data[33:65])}
+	rest := data[65:]
+	for _, field := range []*string{&md.Name, &md.Symbol} {
+		if len(rest) < 4 {
+			return Metadata{}, errors.New("metadata account truncated")
+		}
+		n := binary.LittleEndian.Uint32(rest)
+		if uint64(n) > uint64(len(rest)-4) {
+			return Metadata{}, errors.New("metadata account truncated")
+		}
+		*field = strings.TrimSpace(strings.TrimRight(string(rest[4:4+n]), "\x00"))
+		rest = rest[4+n:]
+	}
+	return md, nil
+}
+
+// MetaplexLookup resolves a symbol through on-chain token metadata: a
+// search supplies candidate mints, their metadata PDAs are read in one call,
+// and the one mint whose on-chain symbol matches exactly (case-insensitive)
+// is returned. A copycat reusing the symbol on-chain makes the lookup
+// ambiguous; tokens whose metadata says otherwise are never picked.
+type MetaplexLookup struct {
+	candidates CandidateSource
+	accounts   AccountsReader
+}
+
+// NewMetaplexLookup creates a metadata lookup over candidates; it needs an
+// AccountsReader (SetAccounts) before it can resolve anything
+func NewMetaplexLookup(candidates CandidateSource) *MetaplexLookup {
+	return &MetaplexLookup{candidates: candidates}
+}
+
+// SetAccounts sets where metadata accounts are read from
+func (m *MetaplexLookup) SetAccounts(accounts AccountsReader) {
+	m.accounts = accounts
+}
+
+// LookupSymbol implements Lookup
+func (m *MetaplexLookup) LookupSymbol(ctx context.Context, symbol string) (string, error) {
+	if m.accounts == nil {
+		return "", errors.New("metadata lookup: no RPC")
+	}
+	candidates, err := m.candidates.Candidates(ctx, symbol)
+	if err != nil {
+		return "", err
+	}
+	var mints, pdas []string
+	for _, c := range candidates {
+		pda, err := MetadataPDA(c.Mint)
+		if err != nil {
+			continue
+		}
+		mints = append(mints, c.Mint)
+		pdas = append(pdas, pda)
+	}
+	if len(pdas) == 0 {
+		return "", ErrTokenNotFound
+	}
+	accounts, err := m.accounts.GetAccountsData(ctx, pdas)
+	if err != nil {
+		return "", fmt.Errorf("metadata lookup: %w", err)
+	}
+
+	var matches []string
+	seen := make(map[string]bool)
+	for i, data := range accounts {
+		if data == nil {
+			continue
+		}
+		md, err := ParseMetadata(data)
+		if err != nil || md.Mint != mints[i] {
+			continue
+		}
+		if strings.EqualFold(md.Symbol, symbol) && !seen[md.Mint] {
+			seen[md.Mint] = true
+			matches = append(matches, md.Mint)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return "", ErrTokenNotFound
+	case len(matches) > 1:
+		return "", fmt.Errorf("%w: %s is the on-chain symbol of %d tokens", ErrAmbiguousSymbol, symbol, len(matches))
+	}
+	return matches[0], nil
+}
@@ -0,0 +1,131 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mr-tron/base58"
+)
+
+// fakeAccounts serves metadata accounts by PDA
+type fakeAccounts map[string][]byte
+
+func (f fakeAccounts) GetAccountsData(_ context.Context, pubkeys []string) ([][]byte, error) {
+	out := make([][]byte, len(pubkeys))
+	for i, pk := range pubkeys {
+		out[i] = f[pk]
+	}
+	return out, nil
+}
+
+// metadataAccount encodes a MetadataV1 head the way Metaplex stores it
+// (name padded to 32 bytes, symbol to 10)
+func metadataAccount(mint, name, symbol string) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(metadataKeyV1)
+	buf.Write(make([]byte, 32)) // update authority
+	key, _ := base58.Decode(mint)
+	buf.Write(key)
+	for _, f := range []struct {
+		s   string
+		pad int
+	}{{name, 32}, {symbol, 10}} {
+		binary.Write(&buf, binary.LittleEndian, uint32(f.pad))
+		buf.WriteString(f.s)
+		buf.Write(make([]byte, f.pad-len(f.s)))
+	}
+	return buf.Bytes()
+}
+
+func testMintN(n byte) string { return base58.Encode(bytes.Repeat([]byte{n}, 32)) }
+
+func TestMetaplexLookup_RequiresOneOnChainMatch(t *testing.T) {
+	real, copycat, impostor, bare := testMintN(1), testMintN(2), testMintN(3), testMintN(4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The list's symbols are not trusted: only on-chain metadata decides
+		json.NewEncoder(w).Encode([]jupiterToken{
+			{ID: impostor, Symbol: "PEPE", Liquidity: 900_000},
+			{ID: bare, Symbol: "PEPE", Liquidity: 800_000},
+			{ID: copycat, Symbol: "PEPE", Liquidity: 1_000},
+			{ID: real, Symbol: "Pepe", Liquidity: 500_000},
+		})
+	}))
+	defer srv.Close()
+
+	accounts := fakeAccounts{}
+	for mint, md := range map[string][2]string{
+		real:     {"Pepe", "PEPE"},
+		copycat:  {"Pepe Copy", "PEPE"},
+		impostor: {"Pepe Two", "PEPE2"},
+	} {
+		pda, err := MetadataPDA(mint)
+		if err != nil {
+			t.Fatalf("MetadataPDA: %v", err)
+		}
+		accounts[pda] = metadataAccount(mint, md[0], md[1])
+	}
+
+	path := filepath.Join(t.TempDir(), "tokens_cache.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewCache(path)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	lookup := NewMetaplexLookup(NewJupiterLookup(srv.URL, time.Second))
+	r := NewResolver(cache)
+	r.SetUpstream(lookup, time.Minute, time.Second)
+
+	if _, err := r.Resolve("PEPE"); err == nil {
+		t.Fatal("resolved without an RPC to read metadata from")
+	}
+	lookup.SetAccounts(accounts)
+	r = NewResolver(cache)
+	r.SetUpstream(lookup, time.Minute, time.Second)
+
+	// The copycat carries PEPE on-chain too: ambiguous, nothing cached
+	if mint, err := r.Resolve("PEPE"); err == nil {
+		t.Fatalf("Resolve(PEPE) = %q with two on-chain matches, want an error", mint)
+	}
+	if _, ok := cache.Get("PEPE"); ok {
+		t.Fatal("ambiguous symbol PEPE was cached")
+	}
+
+	// Copycat gone: the one on-chain match resolves
+	copyPDA, _ := MetadataPDA(copycat)
+	delete(accounts, copyPDA)
+	r = NewResolver(cache)
+	r.SetUpstream(lookup, time.Minute, time.Second)
+	mint, err := r.Resolve("PEPE")
+	if err != nil || mint != real {
+		t.Fatalf("Resolve(PEPE) = %q, %v; want the one on-chain match %s", mint, err, real)
+	}
+	data, _ := os.ReadFile(path)
+	var saved map[string]string
+	if err := json.Unmarshal(data, &saved); err != nil || saved["PEPE"] != real {
+		t.Errorf("cache file = %s, want PEPE written back", data)
+	}
+}
+
+func TestParseMetadata_RejectsOtherAccounts(t *testing.T) {
+	md, err := ParseMetadata(metadataAccount(testMintN(1), "Pepe", "PEPE"))
+	if err != nil || md.Name != "Pepe" || md.Symbol != "PEPE" || md.Mint != testMintN(1) {
+		t.Errorf("ParseMetadata = %+v, %v; want Pepe/PEPE", md, err)
+	}
+	truncated := metadataAccount(testMintN(1), "Pepe", "PEPE")[:80]
+	if _, err := ParseMetadata(truncated); err == nil {
+		t.Error("truncated account parsed")
+	}
+	if _, err := ParseMetadata(make([]byte, 200)); err == nil {
+		t.Error("account with the wrong key parsed")
+	}
+}
@@ -1,7 +1,10 @@
 package token
 
 import (
+	"context"
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
@@ -22,20 +25,35 @@ var base58Set = func() [256]bool {
 // Resolver handles token name to mint address resolution
 type Resolver struct {
 	cache *Cache
+
+	// On-miss upstream refresh (optional)
+	upstream      Lookup
+	cooldown      time.Duration        // min time between upstream lookups per symbol
+	lookupTimeout time.Duration        // per lookup
+	lastLookup    map[string]time.Time // symbol -> last upstream attempt
+	lookupMu      sync.Mutex
 }
 
 // NewResolver creates a new token resolver
 func NewResolver(cache *Cache) *Resolver {
 	return &Resolver{
-		cache: cache,
+		cache:      cache,
+		lastLookup: make(map[string]time.Time),
 	}
 }
 
+// SetUpstream enables on-miss lookups; each symbol is queried at most once per cooldown
+func (r *Resolver) SetUpstream(lookup Lookup, cooldown, timeout time.Duration) {
+	r.upstream = lookup
+	r.cooldown = cooldown
+	r.lookupTimeout = timeout
+}
+
 // Resolve returns the mint address for a token name
 // Priority:
 // 1. CA already provided (passthrough)
 // 2. Cache lookup
-// 3. (Future) RPC lookup
+// 3. Upstream lookup (tokens.resolver_chain): one unambiguous mint, written back to the cache file
 func (r *Resolver) Resolve(tokenNameOrCA string) (string, error) {
 	// Check if it's already a CA (Base58, 43-44 chars)
 	if len(tokenNameOrCA) >= 43 && len(tokenNameOrCA) <= 44 {
@@ -54,12 +72,54 @@ func (r *Resolver) Resolve(tokenNameOrCA string) (string, error) {
 		return mint, nil
 	}
 
+	// Upstream refresh on miss
+	if mint, ok := r.lookupUpstream(tokenNameOrCA); ok {
+		return mint, nil
+	}
+
 	log.Debug().
 		Str("token", tokenNameOrCA).
 		Msg("token not found in cache")
 	return "", ErrTokenNotFound
 }
 
+// lookupUpstream queries the upstream source, rate-limited per symbol
+func (r *Resolver) lookupUpstream(symbol string) (string, bool) {
+	if r.upstream == nil {
+		return "", false
+	}
+
+	r.lookupMu.Lock()
+	if last, ok := r.lastLookup[symbol]; ok && time.Since(last) < r.cooldown {
+		r.lookupMu.Unlock()
+		return "", false
+	}
+	r.lastLookup[symbol] = time.Now()
+	r.lookupMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.lookupTimeout)
+	defer cancel()
+
+	mint, err := r.upstream.LookupSymbol(ctx, symbol)
+	if errors.Is(err, ErrAmbiguousSymbol) {
+		log.Warn().Err(err).Str("token", symbol).Msg("⚠️ token symbol ambiguous upstream - not cached or traded")
+		return "", false
+	}
+	if err != nil {
+		log.Debug().Err(err).Str("token", symbol).Msg("upstream token lookup failed")
+		return "", false
+	}
+
+	if err := r.AddToken(symbol, mint); err != nil {
+		log.Warn().Err(err).Str("token", symbol).Msg("failed to save resolved token to cache")
+	}
+	log.Info().
+		Str("token", symbol).
+		Str("mint", mint).
+		Msg("🔎 token resolved upstream and cached")
+	return mint, true
+}
+
 // AddToken adds a new token to the cache and saves
 func (r *Resolver) AddToken(name, mint string) error {
 	r.cache.Set(name, mint)
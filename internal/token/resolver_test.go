@@ -0,0 +1,213 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const newMint = "7GCihgDB8fe6KNjn2MYtkzZcRjQy3t9GHdC8uHYmW2hr"
+
+func TestResolver_MissFetchesUpstreamAndCaches(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var tokens []jupiterToken
+		if r.URL.Query().Get("query") == "NEWTOK" {
+			tokens = []jupiterToken{
+				{ID: "FakeUnverifiedMint111111111111111111111111", Symbol: "NEWTOK"},
+				{ID: newMint, Symbol: "newtok", IsVerified: true},
+			}
+		}
+		if r.URL.Query().Get("query") == "COPY" {
+			tokens = []jupiterToken{
+				{ID: "FakeUnverifiedMint111111111111111111111111", Symbol: "COPY"},
+				{ID: "FakeUnverifiedMint222222222222222222222222", Symbol: "COPY"},
+			}
+		}
+		if r.URL.Query().Get("query") == "TWIN" {
+			tokens = []jupiterToken{
+				{ID: "FakeVerifiedMint1111111111111111111111111", Symbol: "TWIN", IsVerified: true},
+				{ID: "FakeVerifiedMint2222222222222222222222222", Symbol: "TWIN", IsVerified: true},
+			}
+		}
+		json.NewEncoder(w).Encode(tokens)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "tokens_cache.json")
+	if err := os.WriteFile(path, []byte(`{"SOL": "So11111111111111111111111111111111111111112"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewCache(path)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	r := NewResolver(cache)
+	r.SetUpstream(NewJupiterLookup(srv.URL, time.Second), time.Minute, time.Second)
+
+	mint, err := r.Resolve("NEWTOK")
+	if err != nil || mint != newMint {
+		t.Fatalf("Resolve(NEWTOK) = %q, %v; want verified mint", mint, err)
+	}
+
+	// Written back to the file
+	data, _ := os.ReadFile(path)
+	var saved map[string]string
+	if err := json.Unmarshal(data, &saved); err != nil || saved["NEWTOK"] != newMint || saved["SOL"] == "" {
+		t.Errorf("cache file = %s, want NEWTOK added and SOL kept", data)
+	}
+
+	// Several unverified matches and none verified: not resolved, not cached
+	if _, err := r.Resolve("COPY"); err != ErrTokenNotFound {
+		t.Errorf("Resolve(COPY) err = %v, want ErrTokenNotFound", err)
+	}
+	if _, ok := cache.Get("COPY"); ok {
+		t.Error("ambiguous symbol COPY was cached")
+	}
+	// ...nor are two verified ones
+	if _, err := r.Resolve("TWIN"); err != ErrTokenNotFound {
+		t.Errorf("Resolve(TWIN) err = %v, want ErrTokenNotFound", err)
+	}
+
+	// Unknown symbol: one upstream attempt, then rate-limited
+	for i := 0; i < 3; i++ {
+		if _, err := r.Resolve("NOPE"); err != ErrTokenNotFound {
+			t.Fatalf("Resolve(NOPE) err = %v, want ErrTokenNotFound", err)
+		}
+	}
+	if got := calls.Load(); got != 4 {
+		t.Errorf("upstream calls = %d, want 4 (NEWTOK, COPY, TWIN and NOPE once each)", got)
+	}
+}
+
+// newTempCache creates an empty cache backed by a temp file
+func newTempCache(t *testing.T) *Cache {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tokens_cache.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewCache(path)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	return cache
+}
+
+func TestResolver_DexScreenerRequiresOneMint(t *testing.T) {
+	recorded, err := os.ReadFile("testdata/dexscreener_search.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The same search once the copycat's pool is gone
+	var search struct {
+		Pairs []map[string]interface{} `json:"pairs"`
+	}
+	if err := json.Unmarshal(recorded, &search); err != nil {
+		t.Fatal(err)
+	}
+	var kept []map[string]interface{}
+	for _, p := range search.Pairs {
+		if p["baseToken"].(map[string]interface{})["address"] != "CopycatMint1111111111111111111111111111111" {
+			kept = append(kept, p)
+		}
+	}
+	delisted, _ := json.Marshal(map[string]interface{}{"pairs": kept})
+
+	var metadataCalls atomic.Int32
+	dex := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("q") {
+		case "FDOG":
+			w.Write(recorded)
+		case "FDOG2":
+			w.Write(bytes.ReplaceAll(delisted, []byte(`"FDOG"`), []byte(`"FDOG2"`)))
+		default:
+			w.Write([]byte(`{"schemaVersion":"1.0.0","pairs":[]}`))
+		}
+	}))
+	defer dex.Close()
+	jup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metadataCalls.Add(1)
+		json.NewEncoder(w).Encode([]jupiterToken{})
+	}))
+	defer jup.Close()
+
+	cache := newTempCache(t)
+	dexScreener := NewDexScreenerResolver(dex.URL, time.Second)
+	r := NewResolver(cache)
+	r.SetUpstream(Lookups{dexScreener, NewJupiterLookup(jup.URL, time.Second)}, time.Minute, 2*time.Second)
+
+	// A Solana copycat shares the symbol: however shallow its pool, the
+	// lookup is refused and the chain stops there
+	if mint, err := r.Resolve("FDOG"); err != ErrTokenNotFound {
+		t.Fatalf("Resolve(FDOG) = %q, %v; want ErrTokenNotFound for an ambiguous symbol", mint, err)
+	}
+	if _, ok := cache.Get("FDOG"); ok {
+		t.Error("ambiguous symbol FDOG was cached")
+	}
+
+	// One Solana mint left (the EVM token doesn't count): its deepest pair wins
+	mint, err := r.Resolve("FDOG2")
+	if err != nil || mint != newMint {
+		t.Fatalf("Resolve(FDOG2) = %q, %v; want the only Solana mint", mint, err)
+	}
+	if got, ok := cache.Get("FDOG2"); !ok || got != newMint {
+		t.Errorf("cache has %q (%v), want the resolved mint", got, ok)
+	}
+	pair, ok := dexScreener.PairFor(newMint)
+	if !ok || pair.Pool != "58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2" || !pair.SOLPool() {
+		t.Errorf("PairFor = %+v, %v; want the Raydium AMM pool", pair, ok)
+	}
+	if usdc := (Pair{DexID: "raydium", QuoteMint: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"}); usdc.SOLPool() {
+		t.Error("USDC-quoted pool offered to the SOL price feed")
+	}
+	if metadataCalls.Load() != 0 {
+		t.Error("metadata source queried after a DexScreener hit")
+	}
+
+	// A DexScreener miss falls through to the next source
+	if _, err := r.Resolve("NOPE"); err != ErrTokenNotFound {
+		t.Errorf("Resolve(NOPE) err = %v, want ErrTokenNotFound", err)
+	}
+	if metadataCalls.Load() != 1 {
+		t.Errorf("metadata calls = %d, want 1 after a DexScreener miss", metadataCalls.Load())
+	}
+}
+
+func TestDexScreenerResolver_BacksOffWhenRateLimited(t *testing.T) {
+	var calls atomic.Int32
+	dex := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer dex.Close()
+	jup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]jupiterToken{{ID: newMint, Symbol: r.URL.Query().Get("query")}})
+	}))
+	defer jup.Close()
+
+	d := NewDexScreenerResolver(dex.URL, time.Second)
+	if _, err := d.LookupSymbol(context.Background(), "AAA"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("429: err = %v, want ErrRateLimited", err)
+	}
+
+	// During the backoff the chain skips DexScreener without calling it
+	r := NewResolver(newTempCache(t))
+	r.SetUpstream(Lookups{d, NewJupiterLookup(jup.URL, time.Second)}, time.Minute, 2*time.Second)
+	if mint, err := r.Resolve("BBB"); err != nil || mint != newMint {
+		t.Errorf("Resolve(BBB) = %q, %v; want the metadata source's mint", mint, err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("DexScreener calls = %d, want 1 (backing off)", got)
+	}
+}
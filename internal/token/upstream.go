@@ -0,0 +1,155 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultLookupURL is Jupiter's token search endpoint (query=<symbol>)
+const DefaultLookupURL = "https://lite-api.jup.ag/tokens/v2/search"
+
+// ErrAmbiguousSymbol is returned when a symbol matches several unverified
+// tokens: guessing would risk trading (and caching) a copycat
+var ErrAmbiguousSymbol = errors.New("ambiguous token symbol")
+
+// Lookup resolves a symbol to a mint from an upstream source
+type Lookup interface {
+	LookupSymbol(ctx context.Context, symbol string) (string, error)
+}
+
+// Upstream sources, as named in tokens.resolver_chain
+const (
+	SourceDexScreener = "dexscreener" // DexScreener pair search: the one Solana mint with the symbol
+	SourceMetadata    = "metadata"    // On-chain Metaplex metadata of the Jupiter search's mints: the one mint with the symbol
+	SourceJupiter     = "jupiter"     // Jupiter token list: the one verified symbol, else a single unverified match
+)
+
+// Lookups tries each lookup in order and returns the first mint found. A
+// source that finds the symbol ambiguous ends the chain: a later source
+// settling on one of the tokens would only be a guess.
+type Lookups []Lookup
+
+// LookupSymbol implements Lookup
+func (l Lookups) LookupSymbol(ctx context.Context, symbol string) (string, error) {
+	var errs []error
+	for _, lookup := range l {
+		mint, err := lookup.LookupSymbol(ctx, symbol)
+		if err == nil {
+			return mint, nil
+		}
+		if errors.Is(err, ErrAmbiguousSymbol) {
+			return "", err
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return "", ErrTokenNotFound
+	}
+	return "", errors.Join(errs...)
+}
+
+// JupiterLookup queries the Jupiter token list for a symbol
+type JupiterLookup struct {
+	url    string
+	client *http.Client
+}
+
+// NewJupiterLookup creates an upstream lookup against baseURL (DefaultLookupURL if empty)
+func NewJupiterLookup(baseURL string, timeout time.Duration) *JupiterLookup {
+	if baseURL == "" {
+		baseURL = DefaultLookupURL
+	}
+	return &JupiterLookup{
+		url:    baseURL,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// jupiterToken is the subset of the token search response we use
+type jupiterToken struct {
+	ID         string  `json:"id"`
+	Symbol     string  `json:"symbol"`
+	IsVerified bool    `json:"isVerified"`
+	Liquidity  float64 `json:"liquidity"` // USD
+}
+
+// search returns the tokens Jupiter's search finds for query
+func (j *JupiterLookup) search(ctx context.Context, query string) ([]jupiterToken, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", j.url+"?query="+url.QueryEscape(query), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token lookup: status %d", resp.StatusCode)
+	}
+
+	var tokens []jupiterToken
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("token lookup: decode: %w", err)
+	}
+	return tokens, nil
+}
+
+// Candidates implements CandidateSource: every mint the search returns,
+// whatever symbol the list gives it
+func (j *JupiterLookup) Candidates(ctx context.Context, query string) ([]Candidate, error) {
+	tokens, err := j.search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]Candidate, 0, len(tokens))
+	for _, t := range tokens {
+		if t.ID != "" {
+			candidates = append(candidates, Candidate{Mint: t.ID, LiquidityUSD: t.Liquidity})
+		}
+	}
+	return candidates, nil
+}
+
+// LookupSymbol returns the mint for an exact (case-insensitive) symbol match.
+// A single verified token wins; without one, a single unverified match is
+// accepted. Several of either are refused as ambiguous.
+func (j *JupiterLookup) LookupSymbol(ctx context.Context, symbol string) (string, error) {
+	tokens, err := j.search(ctx, symbol)
+	if err != nil {
+		return "", err
+	}
+
+	verified, unverified := map[string]bool{}, map[string]bool{}
+	for _, t := range tokens {
+		if !strings.EqualFold(t.Symbol, symbol) || t.ID == "" {
+			continue
+		}
+		if t.IsVerified {
+			verified[t.ID] = true
+		} else {
+			unverified[t.ID] = true
+		}
+	}
+	matches, kind := verified, "verified"
+	if len(verified) == 0 {
+		matches, kind = unverified, "unverified"
+	}
+	switch {
+	case len(matches) == 0:
+		return "", ErrTokenNotFound
+	case len(matches) > 1:
+		return "", fmt.Errorf("%w: %s matches %d %s tokens", ErrAmbiguousSymbol, symbol, len(matches), kind)
+	}
+	for mint := range matches {
+		return mint, nil
+	}
+	return "", ErrTokenNotFound
+}
@@ -0,0 +1,103 @@
+package trading
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/jupiter"
+)
+
+// AdoptedTxSig marks positions created from wallet holdings rather than a buy
+const AdoptedTxSig = "ADOPTED"
+
+// orphanHolding is a wallet token with no tracked position
+type orphanHolding struct {
+	mint     string
+	amount   uint64
+	valueSol float64
+}
+
+// AdoptOrphans scans the wallet on startup and turns untracked token holdings
+// (e.g. after a crash lost the DB) into positions so the monitor manages their
+// exit. Ignored mints, holdings worth less than trading.min_adopt_value_sol and
+// anything beyond trading.max_adopt_positions (most valuable first) or the
+// open-position limit are left alone, so a wallet full of dust doesn't flood
+// the TUI and monitor loop. Returns how many positions were adopted.
+func (e *ExecutorFast) AdoptOrphans(ctx context.Context) int {
+	cfg := e.cfg.GetTrading()
+	if !cfg.AdoptOrphansOnStartup || cfg.MaxAdoptPositions <= 0 || e.wallet == nil {
+		return 0
+	}
+
+	accounts, err := e.rpc.GetAllTokenAccounts(ctx, e.wallet.Address())
+	if err != nil {
+		log.Warn().Err(err).Msg("orphan scan failed: could not list token accounts")
+		return 0
+	}
+
+	// A mint can sit in several accounts; value the combined balance
+	amounts := make(map[string]uint64)
+	for _, acc := range accounts {
+		if acc.Amount == 0 || cfg.IsIgnoredMint(acc.Mint) || e.positions.Has(acc.Mint) {
+			continue
+		}
+		amounts[acc.Mint] += acc.Amount
+	}
+
+	var candidates []orphanHolding
+	dust := 0
+	for mint, amount := range amounts {
+		quote, err := e.jupiter.GetQuote(ctx, mint, jupiter.SOLMint, amount)
+		if err != nil {
+			log.Debug().Err(err).Str("mint", mint).Msg("orphan not routable, skipping")
+			dust++
+			continue
+		}
+		out, _ := strconv.ParseUint(quote.OutAmount, 10, 64)
+		value := float64(out) / 1e9
+		if value < cfg.MinAdoptValueSol {
+			dust++
+			continue
+		}
+		candidates = append(candidates, orphanHolding{mint: mint, amount: amount, valueSol: value})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].valueSol > candidates[j].valueSol })
+
+	adopted := 0
+	for _, c := range candidates {
+		if adopted >= cfg.MaxAdoptPositions || !e.positions.CanOpen() {
+			break
+		}
+		// Cost basis is unknown: use today's value so PnL tracks from adoption
+		e.positions.Add(&Position{
+			Mint:         c.mint,
+			TokenName:    c.mint[:8],
+			Size:         c.valueSol,
+			EntryValue:   1,
+			EntryUnit:    "X",
+			EntryTime:    time.Now(),
+			EntryTxSig:   AdoptedTxSig,
+			CurrentValue: 1,
+			TokenBalance: c.amount,
+		})
+		adopted++
+		log.Info().
+			Str("mint", c.mint).
+			Uint64("amount", c.amount).
+			Float64("valueSol", c.valueSol).
+			Msg("🧲 adopted orphan holding as position")
+	}
+
+	if adopted > 0 || dust > 0 || len(candidates) > adopted {
+		log.Info().
+			Int("adopted", adopted).
+			Int("skippedDust", dust).
+			Int("skippedOverCap", len(candidates)-adopted).
+			Msg("orphan scan complete")
+	}
+	return adopted
+}
@@ -0,0 +1,149 @@
+package trading
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxConcurrentAccountLookups bounds the per-mint getTokenAccountsByOwner
+// calls a monitor pass makes for mints whose accounts are not known yet
+const maxConcurrentAccountLookups = 5
+
+// accountBook remembers which of the wallet's token accounts hold each
+// position's mint, so a monitor pass reads every balance with a single
+// getMultipleAccounts instead of one getTokenAccountsByOwner per position
+type accountBook struct {
+	mu       sync.Mutex
+	accounts map[string][]string // mint -> token account addresses
+}
+
+func newAccountBook() *accountBook {
+	return &accountBook{accounts: make(map[string][]string)}
+}
+
+func (b *accountBook) get(mint string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.accounts[mint]
+}
+
+func (b *accountBook) set(mint string, accounts []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(accounts) == 0 {
+		delete(b.accounts, mint)
+		return
+	}
+	b.accounts[mint] = accounts
+}
+
+// retain forgets every mint not in keep (closed positions)
+func (b *accountBook) retain(keep map[string]bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for mint := range b.accounts {
+		if !keep[mint] {
+			delete(b.accounts, mint)
+		}
+	}
+}
+
+// fetchBalances returns the token balance of each mint; a mint missing from
+// the result could not be read this pass. Mints with known accounts share
+// one getMultipleAccounts; the rest (and any that read as empty, in case the
+// tokens moved to another account) are looked up by owner, which learns
+// their accounts for the next pass.
+func (e *ExecutorFast) fetchBalances(ctx context.Context, mints []string) map[string]uint64 {
+	balances := make(map[string]uint64, len(mints))
+	if e.simMode || e.cfg.Get().Trading.SimulationMode {
+		for _, mint := range mints {
+			if held, err := e.getTokenBalance(ctx, mint); err == nil {
+				balances[mint] = held
+			}
+		}
+		return balances
+	}
+	// Paper positions hold nothing on-chain
+	onChain := make([]string, 0, len(mints))
+	for _, mint := range mints {
+		if !e.isPaperPosition(mint) {
+			onChain = append(onChain, mint)
+		} else if held, err := e.paperTokenBalance(ctx, mint); err == nil {
+			balances[mint] = held
+		}
+	}
+	mints = onChain
+
+	keep := make(map[string]bool, len(mints))
+	var pubkeys, owners []string // owners[i] is the mint pubkeys[i] holds
+	var unknown []string
+	for _, mint := range mints {
+		keep[mint] = true
+		accounts := e.accounts.get(mint)
+		if len(accounts) == 0 {
+			unknown = append(unknown, mint)
+			continue
+		}
+		for _, acc := range accounts {
+			pubkeys = append(pubkeys, acc)
+			owners = append(owners, mint)
+		}
+	}
+	e.accounts.retain(keep)
+
+	if len(pubkeys) > 0 {
+		infos, err := e.rpc.GetMultipleAccounts(ctx, pubkeys)
+		if err != nil {
+			log.Debug().Err(err).Int("accounts", len(pubkeys)).Msg("batched balance read failed")
+		} else {
+			found := make(map[string]uint64)
+			for i, info := range infos {
+				if info != nil && info.Mint == owners[i] {
+					found[owners[i]] += info.Amount
+				}
+			}
+			for _, mint := range mints {
+				if len(e.accounts.get(mint)) == 0 {
+					continue
+				}
+				if found[mint] == 0 {
+					unknown = append(unknown, mint) // Closed or emptied: confirm by owner
+					continue
+				}
+				balances[mint] = found[mint]
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentAccountLookups)
+	for _, mint := range unknown {
+		wg.Add(1)
+		go func(mint string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			accounts, err := e.rpc.GetTokenAccountsByOwner(ctx, e.wallet.Address(), mint)
+			if err != nil {
+				log.Debug().Err(err).Str("mint", mint).Msg("failed to get balance")
+				return
+			}
+			var total uint64
+			addrs := make([]string, 0, len(accounts))
+			for _, acc := range accounts {
+				total += acc.Amount
+				addrs = append(addrs, acc.Address)
+			}
+			e.accounts.set(mint, addrs)
+			mu.Lock()
+			balances[mint] = total
+			mu.Unlock()
+		}(mint)
+	}
+	wg.Wait()
+	return balances
+}
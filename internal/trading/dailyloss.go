@@ -0,0 +1,98 @@
+package trading
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/notify"
+	"solana-pump-bot/internal/storage"
+)
+
+// dailyPnL sums realized SOL PnL of sells since UTC midnight (the daily
+// loss cap window, trading.max_daily_loss_sol)
+type dailyPnL struct {
+	mu  sync.Mutex
+	day string // UTC date the sum belongs to
+	sol float64
+}
+
+// add records pnl at now and returns the day's total
+func (d *dailyPnL) add(pnl float64, now time.Time) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.roll(now)
+	d.sol += pnl
+	return d.sol
+}
+
+// total returns the day's total at now
+func (d *dailyPnL) total(now time.Time) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.roll(now)
+	return d.sol
+}
+
+// roll starts a fresh window when the UTC date changed; caller holds mu
+func (d *dailyPnL) roll(now time.Time) {
+	if day := now.UTC().Format("2006-01-02"); day != d.day {
+		d.day = day
+		d.sol = 0
+	}
+}
+
+// GetDailyPnL returns the realized SOL PnL of sells since UTC midnight
+func (e *ExecutorFast) GetDailyPnL() float64 {
+	return e.daily.total(time.Now())
+}
+
+// seedDailyPnL starts the window from today's realized PnL in the trade
+// history, so a restart doesn't reset the loss cap
+func (e *ExecutorFast) seedDailyPnL(db *storage.DB) {
+	now := time.Now().UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sol, err := db.GetRealizedPnLSince(midnight)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to load today's realized PnL, daily loss window starts at 0")
+		return
+	}
+	e.checkDailyLossCap(e.daily.add(sol, now))
+}
+
+// recordDailyPnL adds a sell's realized PnL to the day's total and checks
+// the daily loss cap
+func (e *ExecutorFast) recordDailyPnL(pnl float64) {
+	e.checkDailyLossCap(e.daily.add(pnl, time.Now()))
+}
+
+// checkDailyLossCap switches auto-trading off (and saves it to the config
+// file) once the day's losses reach trading.max_daily_loss_sol. It stays off
+// until turned back on; a sell still under the cap switches it off again.
+func (e *ExecutorFast) checkDailyLossCap(total float64) {
+	capSol := e.cfg.GetTrading().MaxDailyLossSol
+	if capSol <= 0 || -total < capSol {
+		return
+	}
+	switched := false
+	if err := e.cfg.Update(func(c *config.Config) {
+		switched = c.Trading.AutoTradingEnabled
+		c.Trading.AutoTradingEnabled = false
+	}); err != nil {
+		log.Error().Err(err).Msg("failed to save auto-trading off at the daily loss cap")
+	}
+	if !switched {
+		return
+	}
+	log.Error().
+		Float64("dailyPnlSol", total).
+		Float64("capSol", capSol).
+		Msg("🛑🛑🛑 DAILY LOSS CAP HIT - AUTO-TRADING DISABLED 🛑🛑🛑")
+	e.notify(notify.Event{
+		Kind:   notify.KindKillSwitch,
+		Detail: fmt.Sprintf("daily PnL %.4f SOL reached the %.4f SOL loss cap; auto-trading disabled", total, capSol),
+	})
+}
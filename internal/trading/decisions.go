@@ -0,0 +1,48 @@
+package trading
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	signalPkg "solana-pump-bot/internal/signal"
+	"solana-pump-bot/internal/storage"
+)
+
+// Decision actions in the decisions audit table; a SKIP's reason is one of
+// the Skip* codes
+const (
+	DecisionBuy  = "BUY"  // buy sent
+	DecisionSell = "SELL" // sell sent
+	DecisionSkip = "SKIP" // signal not traded
+	DecisionFail = "FAIL" // trade attempted, every retry failed
+)
+
+// Reasons recorded with the non-skip actions
+const (
+	ReasonSent             = "sent"
+	ReasonPaperFill        = "paper_fill" // trading.paper_trading: booked at the quote, not sent
+	ReasonSimulated        = "simulated"  // trading.simulation_mode: nothing quoted for real or sent
+	ReasonRetriesExhausted = "retries_exhausted"
+)
+
+// recordDecision appends why a signal did or didn't trade to the audit log
+// (no-op without a database)
+func (e *ExecutorFast) recordDecision(signal *signalPkg.Signal, action, reason, detail string) {
+	if e.db == nil {
+		return
+	}
+	err := e.db.InsertDecision(&storage.Decision{
+		Mint:       signal.Mint,
+		TokenName:  signal.TokenName,
+		SignalType: string(signal.Type),
+		MsgID:      signal.MsgID,
+		Action:     action,
+		Reason:     reason,
+		Detail:     detail,
+		Timestamp:  time.Now().Unix(),
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("action", action).Str("reason", reason).Msg("failed to record trade decision")
+	}
+}
@@ -0,0 +1,109 @@
+package trading
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	signalPkg "solana-pump-bot/internal/signal"
+	"solana-pump-bot/internal/storage"
+)
+
+func TestExecutorFast_RecordsTradeDecisions(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  min_entry_percent: 50
+  take_profit_multiple: 2
+  max_price_impact_percent: 5
+jupiter:
+  slippage_bps: 500
+  timeout_seconds: 5
+`)
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	h.executor.db = db
+
+	latest := func() *storage.Decision {
+		t.Helper()
+		decisions, err := db.GetRecentDecisions(1)
+		if err != nil || len(decisions) != 1 {
+			t.Fatalf("GetRecentDecisions = %v, %v", decisions, err)
+		}
+		return decisions[0]
+	}
+
+	// Rejected: the quote moves the price too far
+	h.chain.mu.Lock()
+	h.chain.impactPct = "0.12" // 12%
+	h.chain.mu.Unlock()
+	h.executor.ProcessSignalFast(context.Background(), entrySignal(1))
+	if d := latest(); d.Action != DecisionSkip || d.Reason != SkipPriceImpact || d.Mint != testMint || d.MsgID != 1 || d.SignalType != "ENTRY" {
+		t.Errorf("price impact decision = %+v", d)
+	}
+
+	// Rejected: same message again
+	h.executor.ProcessSignalFast(context.Background(), entrySignal(1))
+	if d := latest(); d.Action != DecisionSkip || d.Reason != SkipDuplicate {
+		t.Errorf("duplicate decision = %+v", d)
+	}
+
+	// Accepted: the buy is sent
+	h.chain.mu.Lock()
+	h.chain.impactPct = "0.01"
+	h.chain.mu.Unlock()
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(2)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	if d := latest(); d.Action != DecisionBuy || d.Reason != ReasonSent || d.Detail == "" || d.MsgID != 2 {
+		t.Errorf("buy decision = %+v, want BUY sent with the tx signature", d)
+	}
+
+	all, _ := db.GetRecentDecisions(10)
+	if len(all) != 3 {
+		t.Errorf("recorded %d decisions, want 3", len(all))
+	}
+}
+
+func TestExecutorFast_SimulationRecordsDecisions(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  min_entry_percent: 50
+  take_profit_multiple: 2
+  simulation_mode: true
+`)
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	h.executor.db = db
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(1)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	waitFor(t, "simulated position", func() bool { return h.positions.Get(testMint) != nil })
+	exit := entrySignal(2)
+	exit.Type, exit.Value, exit.Unit = signalPkg.SignalExit, 2, "X"
+	if err := h.executor.ProcessSignalFast(context.Background(), exit); err != nil {
+		t.Fatalf("ProcessSignalFast(exit): %v", err)
+	}
+
+	all, _ := db.GetRecentDecisions(10)
+	if len(all) != 2 {
+		t.Fatalf("recorded %d decisions, want the simulated buy and sell", len(all))
+	}
+	for _, d := range all {
+		if d.Reason != ReasonSimulated || (d.Action != DecisionBuy && d.Action != DecisionSell) {
+			t.Errorf("decision = %+v, want a simulated BUY or SELL", d)
+		}
+	}
+}
@@ -118,7 +118,7 @@ func (e *Executor) executeBuy(ctx context.Context, signal *signalPkg.Signal) err
 	// Calculate trade size
 	cfg := e.cfg.GetTrading()
 	balanceLamports := e.balance.BalanceLamports()
-	allocLamports := uint64(float64(balanceLamports) * cfg.MaxAllocPercent / 100)
+	allocLamports := uint64(float64(balanceLamports) * cfg.AllocPercentFor(signal.Mint, signal.TokenName) / 100)
 
 	// Check balance including fees
 	feesCfg := e.cfg.Get().Fees
@@ -270,7 +270,7 @@ func (e *Executor) executeSell(ctx context.Context, signal *signalPkg.Signal) er
 	// Log trade
 	if e.db != nil && removedPos != nil {
 		duration := time.Since(removedPos.EntryTime).Seconds()
-		// Note: Actual PnL would require querying actual received amount
+		// Actual SOL PnL is filled in by recordRealizedPnL once the sell is fetched
 		e.db.InsertTrade(&storage.Trade{
 			Mint:       signal.Mint,
 			TokenName:  signal.TokenName,
@@ -282,6 +282,7 @@ func (e *Executor) executeSell(ctx context.Context, signal *signalPkg.Signal) er
 			ExitTxSig:  txSig,
 			Timestamp:  storage.Now(),
 		})
+		go recordRealizedPnL(e.rpc, e.db, e.wallet.Address(), removedPos, txSig)
 	}
 
 	// Refresh balance
@@ -358,11 +359,12 @@ func (e *Executor) monitorPositions(ctx context.Context) {
 			log.Info().Str("token", pos.TokenName).Msg("reached 2X! marked as win")
 		}
 		
-		// Logic: Partial Profit-Taking
-		if cfg.PartialProfitPercent > 0 && cfg.PartialProfitMultiple > 1.0 {
-			if multiple >= cfg.PartialProfitMultiple && !pos.IsPartialSold() {
-				log.Info().Str("token", pos.TokenName).Float64("mult", multiple).Msg("triggering partial profit take")
-				e.executePartialSell(ctx, pos, cfg.PartialProfitPercent)
+		// Logic: Partial profit ladder (each level sells once)
+		if due, percent, soldAfter := dueProfitLevels(cfg.ProfitLevels(), pos.GetProfitLevelsHit(), pos.GetCurveSold(), multiple); due != 0 {
+			log.Info().Str("token", pos.TokenName).Float64("mult", multiple).Msg("triggering partial profit take")
+			if e.executePartialSell(ctx, pos, percent) {
+				pos.MarkProfitLevelsHit(due, soldAfter)
+				e.positions.PersistPartials(pos)
 			}
 		}
 		
@@ -383,10 +385,10 @@ func (e *Executor) monitorPositions(ctx context.Context) {
 	}
 }
 
-func (e *Executor) executePartialSell(ctx context.Context, pos *Position, percent float64) {
+func (e *Executor) executePartialSell(ctx context.Context, pos *Position, percent float64) bool {
 	// 1. Calculate Amount
 	balance, err := e.getTokenBalance(ctx, pos.Mint)
-	if err != nil { return }
+	if err != nil { return false }
 	
 	sellAmount := uint64(float64(balance) * (percent / 100.0))
 	
@@ -396,16 +398,16 @@ func (e *Executor) executePartialSell(ctx context.Context, pos *Position, percen
 	swapTx, err := e.jupiter.GetSwapTransaction(ctx, pos.Mint, jupiter.SOLMint, e.wallet.Address(), sellAmount)
 	if err != nil {
 		log.Error().Err(err).Msg("failed partial swap tx")
-		return
+		return false
 	}
 	
 	signedTx, err := e.txBuilder.SignSerializedTransaction(swapTx)
-	if err != nil { return }
+	if err != nil { return false }
 	
 	txSig, err := e.rpc.SendTransaction(ctx, signedTx, true)
 	if err != nil {
 		log.Error().Err(err).Msg("failed partial sell send")
-		return
+		return false
 	}
 	
 	// 3. Update Position State
@@ -413,8 +415,9 @@ func (e *Executor) executePartialSell(ctx context.Context, pos *Position, percen
 	log.Info().Str("txSig", txSig).Msg("PARTIAL SELL executed ✓")
 	
 	// Note: We don't remove position, just mark sold. 
-	// Adjusting Size (Cost Basis) is complex, usually we just reduce it?
-	// For simplicity, we keep original Size to track overall ROI against initial investment.
+	// Size stays the original cost; UpdateStats scales it by SoldFraction
+	// so the multiple tracks the price of what is still held.
+	return true
 }
 
 // ForceClose manually closes a position
@@ -437,19 +440,18 @@ func (e *Executor) ForceClose(ctx context.Context, mint string) error {
 	return e.executeSell(ctx, signal)
 }
 
-// getTokenBalance queries the actual token balance for a given mint
+// getTokenBalance queries the actual token balance for a given mint, summed
+// over every token account the wallet holds for it (0, nil when there are none)
 func (e *Executor) getTokenBalance(ctx context.Context, mint string) (uint64, error) {
-	// This would typically use getTokenAccountsByOwner or similar RPC call
-	// For now, we'll use a simplified approach that may require enhancement
-	// 
-	// In a full implementation, you would:
-	// 1. Derive the Associated Token Account (ATA) address
-	// 2. Call getTokenAccountBalance on that address
-	//
-	// For Jupiter swaps, often the actual balance doesn't need to be exact
-	// as Jupiter will use all available balance when you specify a high amount
-	
-	// Return a max value which Jupiter will interpret as "sell all"
-	// Jupiter API handles partial fills appropriately
-	return 0xFFFFFFFFFFFFFFFF, nil
+	tokenAccounts, err := e.rpc.GetTokenAccountsByOwner(ctx, e.wallet.Address(), mint)
+	if err != nil {
+		return 0, err
+	}
+
+	var totalBalance uint64
+	for _, acc := range tokenAccounts {
+		totalBalance += acc.Amount
+	}
+
+	return totalBalance, nil
 }
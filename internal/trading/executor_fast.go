@@ -2,16 +2,24 @@ package trading
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"solana-pump-bot/internal/blockchain"
 	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/events"
 	"solana-pump-bot/internal/jupiter"
+	"solana-pump-bot/internal/notify"
 	signalPkg "solana-pump-bot/internal/signal"
 	"solana-pump-bot/internal/storage"
+	"solana-pump-bot/internal/token"
 	ws "solana-pump-bot/internal/websocket"
 
 	"github.com/rs/zerolog/log"
@@ -29,10 +37,24 @@ type ExecutorFast struct {
 	balance   *blockchain.BalanceTracker
 	db        *storage.DB
 	metrics   *Metrics
+	issues    *IssueLog        // Categorized failures for the TUI issues panel
+	skips     *SkipCounter     // Signals not traded, by reason
+	warm      *warmCache       // Pre-quoted watchlist tokens (tokens.watchlist)
+	pools     *ws.PoolResolver // Mint -> AMM pool for WebSocket price tracking
+	accounts  *accountBook     // Mint -> wallet token accounts, for batched balance reads
+	daily     dailyPnL         // Realized PnL since UTC midnight (trading.max_daily_loss_sol)
+	queue     *tradeQueue      // Bounds in-flight signals (trading.max_concurrent_trades)
+
+	// Buy filter (trading.blacklist / trading.whitelist), rebuilt on reload
+	tokenFilter atomic.Pointer[token.Filter]
+	resolve     func(string) (string, error)
 
 	// Duplicate protection
 	recentSignals map[int64]time.Time  // msgID -> timestamp
+	recentContent map[uint64]time.Time // signalContentKey -> first seen (trading.content_dedup_seconds)
 	recentMints   map[string]time.Time // mint -> last buy time
+	sellsInFlight map[string]bool      // mint -> sell sent, awaiting confirmation
+	exitChecks    map[string]bool      // mint -> evaluateExits running
 	mu            sync.RWMutex
 
 	// Stats for TUI
@@ -44,14 +66,43 @@ type ExecutorFast struct {
 	// Retry config
 	maxRetries int
 
+	// Startup grace period (trading.startup_grace_seconds) is measured from here
+	startedAt time.Time
+
 	// Simulation Override
-	simMode bool
+	simMode     bool
+	simHoldings map[string]uint64 // mint -> raw tokens bought in simulation or on paper (guarded by mu)
+
+	// Paper wallet (trading.paper_trading), guarded by mu
+	paperLamports uint64
+	paperFunded   bool // paperLamports seeded from trading.paper_balance_sol
 
 	// WebSocket Real-Time
 	wsClient  *ws.Client
 	priceFeed *ws.PriceFeed
 	walletMon *ws.WalletMonitor
 	stopCh    chan struct{}
+
+	// WebSocket outage safety (websocket.max_downtime_seconds)
+	wsDownSince time.Time // zero while connected
+	degraded    string    // "" | DegradedSellOnly | DegradedPause
+
+	// Optional event bus for the TUI and other consumers; may be set after
+	// trading has started, hence atomic
+	events atomic.Pointer[events.Bus]
+
+	// Revaluation events per mint, throttled to PositionUpdateThrottle
+	revalued   map[string]revalState
+	revaluedMu sync.Mutex
+
+	// Optional chat notifications (buy sent, sell confirmed, kill switch)
+	notifier notify.Notifier
+
+	// Priority fee bump (fees.priority_bump_*), guarded by mu
+	unlandedStreak int
+	landedStreak   int
+	feeBumpSteps   int
+	feeBase        uint64 // cap before bumps (fees.dynamic estimate); 0 = Jupiter default
 }
 
 // NewExecutorFast creates an ultra-speed executor
@@ -65,7 +116,7 @@ func NewExecutorFast(
 	balance *blockchain.BalanceTracker,
 	db *storage.DB,
 ) *ExecutorFast {
-	return &ExecutorFast{
+	e := &ExecutorFast{
 		cfg:           cfg,
 		wallet:        wallet,
 		rpc:           rpc,
@@ -75,12 +126,52 @@ func NewExecutorFast(
 		balance:       balance,
 		db:            db,
 		metrics:       NewMetrics(),
+		issues:        NewIssueLog(IssueLogSize),
+		skips:         NewSkipCounter(),
+		warm:          newWarmCache(),
+		pools:         ws.NewPoolResolver(rpc),
+		accounts:      newAccountBook(),
+		queue:         newTradeQueue(),
 		recentSignals: make(map[int64]time.Time),
+		recentContent: make(map[uint64]time.Time),
 		recentMints:   make(map[string]time.Time),
+		sellsInFlight: make(map[string]bool),
+		exitChecks:    make(map[string]bool),
+		simHoldings:   make(map[string]uint64),
+		revalued:      make(map[string]revalState),
 		seen2X:        make(map[string]bool),
 		maxRetries:    2,
+		startedAt:     time.Now(),
 		stopCh:        make(chan struct{}), // FIX: Initialize stopCh in constructor
 	}
+	e.rebuildTokenFilter(cfg.GetTrading())
+	if db != nil {
+		e.seedDailyPnL(db)
+	}
+	return e
+}
+
+// SetTokenResolver resolves symbols in the blacklist/whitelist to mints
+// (e.g. token.Resolver.Resolve) and rebuilds the filter with it
+func (e *ExecutorFast) SetTokenResolver(resolve func(string) (string, error)) {
+	e.mu.Lock()
+	e.resolve = resolve
+	e.mu.Unlock()
+	e.rebuildTokenFilter(e.cfg.GetTrading())
+}
+
+// SetPoolHint passes pools learned while resolving symbols (e.g. from
+// DexScreener) to pool discovery, saving its getProgramAccounts scan
+func (e *ExecutorFast) SetPoolHint(hint func(mint string) (string, bool)) {
+	e.pools.SetHint(hint)
+}
+
+// rebuildTokenFilter swaps in a filter built from the given lists
+func (e *ExecutorFast) rebuildTokenFilter(t config.TradingConfig) {
+	e.mu.RLock()
+	resolve := e.resolve
+	e.mu.RUnlock()
+	e.tokenFilter.Store(token.NewFilter(t.Blacklist, t.Whitelist, resolve))
 }
 
 // SetSimulationMode overrides config simulation mode
@@ -107,15 +198,23 @@ func (e *ExecutorFast) SetupWebSocket() error {
 	e.wsClient.SetCallbacks(
 		func() {
 			log.Info().Msg("📡 WebSocket connected - real-time mode active")
-			// Re-subscribe to all tracked positions
+			// Subscription IDs die with the old connection: re-issue wallet,
+			// pending TX confirmations and position price feeds
+			if e.walletMon != nil {
+				e.walletMon.Resubscribe()
+			}
 			e.resubscribePositions()
+			e.markWSUp()
 		},
 		func(err error) {
 			log.Warn().Err(err).Msg("📡 WebSocket disconnected")
+			e.markWSDown()
 		},
 	)
 
 	// Connect
+	e.markWSDown() // Counts as down until the first connect succeeds
+	go e.watchWSDowntime()
 	if err := e.wsClient.Connect(); err != nil {
 		return fmt.Errorf("WebSocket connect: %w", err)
 	}
@@ -143,11 +242,7 @@ func (e *ExecutorFast) SetupWebSocket() error {
 		log.Warn().Err(err).Msg("failed to start wallet subscription")
 	}
 
-	urlDisplay := wsCfg.ShyftURL
-	if len(urlDisplay) > 40 {
-		urlDisplay = urlDisplay[:40] + "..."
-	}
-	log.Info().Str("url", urlDisplay).Msg("WebSocket + PriceFeed + WalletMonitor initialized")
+	log.Info().Str("url", config.RedactURL(wsCfg.ShyftURL)).Msg("WebSocket + PriceFeed + WalletMonitor initialized")
 	return nil
 }
 
@@ -178,23 +273,63 @@ func (e *ExecutorFast) resubscribePositions() {
 	if e.priceFeed == nil {
 		return
 	}
+	e.priceFeed.Resubscribe()
 	for _, pos := range e.positions.GetAll() {
-		if pos.PoolAddr == "" {
-			continue // Skip positions without pool address
+		poolAddr := pos.GetPoolAddr()
+		if poolAddr == "" {
+			go e.discoverPool(pos.Mint) // Loaded from DB or not resolved yet
+			continue
 		}
-		if err := e.priceFeed.TrackToken(pos.Mint, pos.PoolAddr); err != nil {
+		if err := e.priceFeed.TrackToken(pos.Mint, poolAddr); err != nil {
 			log.Warn().Err(err).Str("mint", pos.Mint[:8]+"...").Msg("failed to resubscribe")
 		}
 	}
 }
 
-// handleRealTimePriceUpdate processes WebSocket price updates for INSTANT 2X detection
+// discoverPool finds the AMM pool of a held token, stores it on the
+// position and starts tracking its price through it
+func (e *ExecutorFast) discoverPool(mint string) {
+	if e.priceFeed == nil {
+		return // No WebSocket: nothing to track the pool with
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), PoolResolveTimeout)
+	defer cancel()
+
+	poolAddr, err := e.pools.Resolve(ctx, mint)
+	if err != nil {
+		log.Debug().Err(err).Str("mint", mint).Msg("no pool to track, relying on polling")
+		return
+	}
+	pos := e.positions.Get(mint)
+	if pos == nil {
+		return // Closed meanwhile
+	}
+	pos.SetPoolAddr(poolAddr)
+	if err := e.priceFeed.TrackToken(mint, poolAddr); err != nil {
+		log.Warn().Err(err).Str("mint", mint).Msg("failed to track pool")
+	}
+}
+
+// handleRealTimePriceUpdate revalues a position on each WebSocket price and
+// runs its exits right away instead of waiting for the next monitor pass
 func (e *ExecutorFast) handleRealTimePriceUpdate(update ws.PriceUpdate) {
 	pos := e.positions.Get(update.Mint)
 	if pos == nil {
 		return
 	}
 
+	// Pool updates carry reserves: check for a liquidity pull (rug) first
+	if update.PoolReserves.QuoteReserve > 0 {
+		e.checkPoolLiquidity(pos, update.PoolReserves)
+		if update.TokenBalance == 0 {
+			// Pool-only update (0 here is not a sell-out): value what we hold at the pool price
+			update.TokenBalance = pos.GetTokenBalance()
+			if update.TokenBalance == 0 || update.PriceSOL <= 0 {
+				return
+			}
+		}
+	}
+
 	// Update position with new balance
 	if update.TokenBalance == 0 && pos.TokenBalance > 0 {
 		log.Warn().Str("mint", update.Mint[:8]+"...").Msg("token balance dropped to 0 - removing position")
@@ -204,32 +339,15 @@ func (e *ExecutorFast) handleRealTimePriceUpdate(update ws.PriceUpdate) {
 
 	// Real-time price check (if price available from WebSocket)
 	if update.PriceSOL > 0 {
-		// Calculate current value
-		currentValueSOL := update.PriceSOL * float64(update.TokenBalance)
-
-		// Calculate PnL multiple safely
-		multiple := pos.UpdateStats(currentValueSOL, update.TokenBalance)
-
-		// INSTANT 2X CHECK (per ms, not per 5 seconds!)
-		cfg := e.cfg.GetTrading()
-		if cfg.AutoTradingEnabled && multiple >= cfg.TakeProfitMultiple && !pos.IsReached2X() {
-			pos.SetReached2X(true)
-			log.Info().
-				Str("token", pos.TokenName).
-				Float64("multiple", multiple).
-				Msg("🚀 REAL-TIME 2X DETECTED - TRIGGERING AUTO-SELL")
-
-			// Trigger sell immediately
-			go func() {
-				signal := &signalPkg.Signal{
-					Mint:      update.Mint,
-					TokenName: pos.TokenName,
-					Type:      signalPkg.SignalExit,
-					Value:     multiple,
-					Unit:      "X",
-				}
-				e.executeSellFast(context.Background(), signal, NewTradeTimer())
-			}()
+		// Calculate current value (PriceSOL is per whole token, TokenBalance in base units)
+		currentValueSOL := update.PriceSOL * float64(update.TokenBalance) / math.Pow10(update.Decimals)
+
+		// Streamed prices run the same exits as a monitor pass, without
+		// counting as one (LastUpdate is left for the monitor's quotes)
+		multiple := pos.Revalue(currentValueSOL, update.TokenBalance)
+		e.publishRevalued(update.Mint)
+		if cfg := e.cfg.GetTrading(); !cfg.IsIgnoredMint(pos.Mint) {
+			go e.evaluateExits(context.Background(), cfg, pos, multiple, currentValueSOL)
 		}
 
 		log.Debug().
@@ -249,6 +367,7 @@ func (e *ExecutorFast) handleWalletBalanceUpdate(update ws.BalanceUpdate) {
 	if e.balance != nil {
 		e.balance.SetBalance(update.Lamports)
 	}
+	e.publish(events.BalanceChanged{SOL: float64(update.Lamports) / 1e9})
 
 	log.Debug().
 		Float64("sol", float64(update.Lamports)/1e9).
@@ -262,16 +381,23 @@ func (e *ExecutorFast) ProcessSignalFast(ctx context.Context, signal *signalPkg.
 	timer := NewTradeTimer()
 
 	if signal.Mint == "" {
+		e.skipSignal(signal, SkipUnresolved, "")
 		return nil
 	}
 
 	// FIX #4: Duplicate signal protection
 	if e.isDuplicateSignal(signal.MsgID) {
-		log.Debug().Int64("msgID", signal.MsgID).Msg("duplicate signal ignored")
+		e.skipSignal(signal, SkipDuplicate, "")
 		return nil
 	}
 	e.markSignalSeen(signal.MsgID)
 
+	// Same call relayed under another message ID (forward, edit)
+	if e.isDuplicateContent(signal) {
+		e.skipSignal(signal, SkipDuplicate, "same content")
+		return nil
+	}
+
 	timer.MarkParseDone()
 	timer.MarkResolveDone()
 
@@ -295,21 +421,102 @@ func (e *ExecutorFast) ProcessSignalFast(ctx context.Context, signal *signalPkg.
 
 	// Check if trading enabled (only for execution, not counting)
 	if !e.cfg.GetTrading().AutoTradingEnabled {
+		e.skipSignal(signal, SkipAutoTradingOff, "")
+		return nil
+	}
+
+	// Startup grace: let a relay's backlog of stale calls drain without buying
+	if signal.Type == signalPkg.SignalEntry && e.inStartupGrace() {
+		e.skipSignal(signal, SkipStartupGrace, "remaining "+e.startupGraceRemaining().Round(time.Second).String())
+		return nil
+	}
+
+	// WebSocket outage: no new entries (sell-only) or no trading at all (pause)
+	if mode := e.DegradedMode(); mode != "" {
+		if mode == DegradedPause || signal.Type == signalPkg.SignalEntry {
+			e.skipSignal(signal, SkipDegraded, "mode "+mode+" (WebSocket down)")
+			return nil
+		}
+	}
+
+	// Reserve assets (stables, WSOL) are never positions
+	if e.cfg.GetTrading().IsIgnoredMint(signal.Mint) {
+		e.skipSignal(signal, SkipIgnoredMint, "")
 		return nil
 	}
 
 	// Execute trades
 	switch signal.Type {
 	case signalPkg.SignalEntry:
-		return e.executeBuyFast(ctx, signal, timer)
+		return e.executeBuyFast(ctx, signal, timer, 0)
 	case signalPkg.SignalExit:
 		if e.hasMintPosition(signal.Mint) {
 			return e.executeSellFast(ctx, signal, timer)
 		}
+		e.skipSignal(signal, SkipNoPosition, "")
 	}
 	return nil
 }
 
+// startupGraceRemaining returns how much of the startup grace period is left
+func (e *ExecutorFast) startupGraceRemaining() time.Duration {
+	grace := time.Duration(e.cfg.GetTrading().StartupGraceSeconds) * time.Second
+	if remaining := grace - time.Since(e.startedAt); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// inStartupGrace reports whether entry signals should still be ignored
+func (e *ExecutorFast) inStartupGrace() bool {
+	return e.startupGraceRemaining() > 0
+}
+
+// buyBalanceLamports is the cached wallet balance buys are sized from
+// (NO RPC CALL); a fixed 1 SOL in simulation mode, the paper wallet when
+// paper trading
+func (e *ExecutorFast) buyBalanceLamports() uint64 {
+	if e.simMode || e.cfg.Get().Trading.SimulationMode {
+		return 1_000_000_000 // 1 SOL
+	}
+	if e.paperTrading() {
+		return e.paperBalanceLamports()
+	}
+	return e.balance.BalanceLamports()
+}
+
+// reserveLamports is trading.reserve_sol: SOL kept back from buys for
+// priority fees and token account rent
+func reserveLamports(cfg config.TradingConfig) uint64 {
+	if cfg.ReserveSol <= 0 {
+		return 0
+	}
+	return uint64(cfg.ReserveSol * 1e9)
+}
+
+// spendableLamports is the balance buys are sized from: balance less the reserve
+func spendableLamports(cfg config.TradingConfig, balanceLamports uint64) uint64 {
+	if reserve := reserveLamports(cfg); balanceLamports > reserve {
+		return balanceLamports - reserve
+	}
+	return 0
+}
+
+// allocLamports sizes a buy: the percent of the spendable balance
+// trading.sizing gives a call of this strength (see allocPercentFor), at
+// least MinAllocLamports but never into the reserve
+func (e *ExecutorFast) allocLamports(cfg config.TradingConfig, mint, name string, strength float64, balanceLamports uint64) uint64 {
+	spendable := spendableLamports(cfg, balanceLamports)
+	alloc := uint64(float64(spendable) * allocPercentFor(cfg, mint, name, strength) / 100)
+	if alloc < MinAllocLamports {
+		alloc = MinAllocLamports
+	}
+	if alloc > spendable {
+		alloc = spendable
+	}
+	return alloc
+}
+
 // executeBuyFast - FIRE AND FORGET buy execution with retry
 // Constants for trade limits (configurable via config in future)
 const (
@@ -319,15 +526,28 @@ const (
 	FailedPositionTTL  = 1 * time.Minute
 	DuplicateSignalTTL = 5 * time.Minute
 	SignalCleanupTTL   = 10 * time.Minute
+	IssueLogSize       = 200
+
+	SellConfirmPollInterval   = 400 * time.Millisecond
+	DefaultSellConfirmTimeout = 60 * time.Second
+
+	PoolResolveTimeout = 20 * time.Second // getProgramAccounts over the AMM program is slow
+
+	PositionUpdateThrottle = 250 * time.Millisecond // min gap between revaluation events of one position
 )
 
-func (e *ExecutorFast) executeBuyFast(ctx context.Context, signal *signalPkg.Signal, timer *TradeTimer) error {
+// amountLamports fixes the buy size (manual buys); 0 sizes it from balance
+func (e *ExecutorFast) executeBuyFast(ctx context.Context, signal *signalPkg.Signal, timer *TradeTimer, amountLamports uint64) error {
+	// Blacklist / whitelist
+	if ok, verdict := e.tokenFilter.Load().Allow(signal.Mint, signal.TokenName); !ok {
+		log.Warn().Str("mint", signal.Mint).Str("token", signal.TokenName).Str("reason", verdict).Msg("🚫 buy refused by token filter")
+		e.skipSignal(signal, verdict, "")
+		return nil
+	}
+
 	// Check if we can open more positions (enforce max_open_positions)
 	if !e.positions.CanOpen() {
-		log.Warn().
-			Str("token", signal.TokenName).
-			Int("current", e.positions.Count()).
-			Msg("❌ MAX POSITIONS REACHED - skipping buy")
+		e.skipSignal(signal, SkipMaxPositions, fmt.Sprintf("%d open", e.positions.Count()))
 		return fmt.Errorf("max open positions reached")
 	}
 
@@ -341,23 +561,27 @@ func (e *ExecutorFast) executeBuyFast(ctx context.Context, signal *signalPkg.Sig
 			e.positions.Add(pos)
 		}
 
-		log.Warn().Str("mint", signal.Mint).Msg("already have position, updated stats, skipping buy")
+		e.skipSignal(signal, SkipHavePosition, "stats updated")
 		return nil
 	}
 
 	cfg := e.cfg.GetTrading()
 
-	// Calculate amount based on cached balance (NO RPC CALL)
-	balanceLamports := e.balance.BalanceLamports()
-	if e.simMode || e.cfg.Get().Trading.SimulationMode {
-		balanceLamports = 1_000_000_000 // 1 SOL
+	// Re-buy cooldown: a token sold moments ago is usually the same pump re-posted
+	if left := e.rebuyCooldownLeft(signal.Mint, cfg.RebuyCooldownSeconds); left > 0 {
+		e.skipSignal(signal, SkipRebuyCooldown, left.Round(time.Second).String()+" left")
+		return nil
 	}
 
+	// Calculate amount based on cached balance (NO RPC CALL)
+	balanceLamports := e.buyBalanceLamports()
+
 	// FIX: FAIL LOUDLY if balance is 0
 	if balanceLamports == 0 {
 		log.Error().
 			Str("token", signal.TokenName).
 			Msg("❌ CANNOT BUY: Wallet balance is 0 SOL! Fund your wallet.")
+		e.skipSignal(signal, SkipZeroBalance, "")
 		return fmt.Errorf("wallet balance is 0 - fund your wallet to trade")
 	}
 
@@ -368,14 +592,44 @@ func (e *ExecutorFast) executeBuyFast(ctx context.Context, signal *signalPkg.Sig
 			Float64("balanceSOL", float64(balanceLamports)/1e9).
 			Float64("minRequired", float64(MinTradeLamports)/1e9).
 			Msg("❌ CANNOT BUY: Balance too low for trade + fees")
+		e.skipSignal(signal, SkipLowBalance, fmt.Sprintf("%.4f SOL", float64(balanceLamports)/1e9))
 		return fmt.Errorf("balance %.4f SOL too low (need %.4f)", float64(balanceLamports)/1e9, float64(MinTradeLamports)/1e9)
 	}
 
-	allocLamports := uint64(float64(balanceLamports) * cfg.MaxAllocPercent / 100)
+	// FAIL LOUDLY if the fee reserve leaves nothing to buy with
+	if amountLamports == 0 && spendableLamports(cfg, balanceLamports) < MinAllocLamports {
+		log.Error().
+			Str("token", signal.TokenName).
+			Float64("balanceSOL", float64(balanceLamports)/1e9).
+			Float64("reserveSOL", cfg.ReserveSol).
+			Msg("❌ CANNOT BUY: Balance does not cover the fee reserve (trading.reserve_sol)")
+		e.skipSignal(signal, SkipLowBalance, fmt.Sprintf("%.4f SOL, reserve %.4f", float64(balanceLamports)/1e9, cfg.ReserveSol))
+		return fmt.Errorf("balance %.4f SOL leaves nothing above the %.4f SOL reserve", float64(balanceLamports)/1e9, cfg.ReserveSol)
+	}
+
+	if o, key, ok := cfg.OverrideFor(signal.Mint, signal.TokenName); ok {
+		log.Info().
+			Str("token", signal.TokenName).
+			Str("override", key).
+			Float64("takeProfit", o.TakeProfit).
+			Float64("stopLoss", o.StopLoss).
+			Float64("alloc", o.Alloc).
+			Msg("🎯 token override applied")
+	}
+	allocLamports := amountLamports
+	if allocLamports == 0 {
+		allocLamports = e.allocLamports(cfg, signal.Mint, signal.TokenName, signalStrength(signal), balanceLamports)
+	}
 
-	// Minimum allocation per trade
-	if allocLamports < MinAllocLamports {
-		allocLamports = MinAllocLamports
+	// First buy of a token also pays its token account's rent
+	if !e.simMode && !e.cfg.Get().Trading.SimulationMode && !e.paperTrading() {
+		fitted, err := e.fitATARent(ctx, signal.Mint, balanceLamports, allocLamports)
+		if err != nil {
+			log.Error().Err(err).Str("token", signal.TokenName).Msg("❌ CANNOT BUY: no room for token account rent")
+			e.skipSignal(signal, SkipLowBalance, "token account rent")
+			return err
+		}
+		allocLamports = fitted
 	}
 
 	log.Info().
@@ -397,13 +651,19 @@ func (e *ExecutorFast) executeBuyFast(ctx context.Context, signal *signalPkg.Sig
 		CurrentValue: signal.Value,
 		PnLPercent:   0,
 		EntryTxSig:   "PENDING",
+		EntryFeesSol: e.entryFeesSol(),
 	}
 	e.positions.Add(pendingPos)
 
 	// FIX #11: Retry logic with EXPONENTIAL BACKOFF
 	var lastErr error
+	lastBps := 0 // slippage of the previous attempt
 	for attempt := 0; attempt <= e.maxRetries; attempt++ {
-		if attempt > 0 {
+		if attempt > 0 && blockchain.ErrorCategory(lastErr) == blockchain.CategoryBlockhash {
+			// Resending those bytes can't land; every attempt asks Jupiter for a
+			// new swap (fresh blockhash), so rebuild now instead of backing off
+			log.Warn().Int("attempt", attempt+1).Msg("🔄 blockhash expired, rebuilding buy swap")
+		} else if attempt > 0 {
 			backoffMs := 100 * (1 << (attempt - 1)) // 100ms, 200ms, 400ms, 800ms...
 			log.Warn().Int("attempt", attempt+1).Int("backoffMs", backoffMs).Msg("retrying buy...")
 			time.Sleep(time.Duration(backoffMs) * time.Millisecond)
@@ -419,16 +679,61 @@ func (e *ExecutorFast) executeBuyFast(ctx context.Context, signal *signalPkg.Sig
 			timer.MarkSendDone()
 			// Mock Success
 			txSig := "SIM_BUY_" + signal.TokenName
+			// Paper fill: what the quote says the SOL buys (the simulated
+			// market when Jupiter is simulated too)
+			held := e.jupiter.SimBuyAmount(signal.Mint, allocLamports)
+			if q, err := e.jupiter.GetQuote(ctx, jupiter.SOLMint, signal.Mint, allocLamports); err == nil {
+				held, _ = strconv.ParseUint(q.OutAmount, 10, 64)
+			}
+			e.setSimHolding(signal.Mint, held)
 			e.metrics.RecordTrade(true, 0, 0, 0, 0, 0)
 			log.Info().Str("txSig", txSig).Msg("⚡ SIMULATION BUY EXECUTED")
+			e.recordDecision(signal, DecisionBuy, ReasonSimulated, txSig)
+			e.markMintBought(signal.Mint)
 			go e.trackPositionAsync(signal, allocLamports, txSig)
 			return nil
 		}
 
-		// Get swap TX from Jupiter
-		swapTx, err := e.jupiter.GetSwapTransaction(ctx, jupiter.SOLMint, signal.Mint, e.wallet.Address(), allocLamports)
+		// Quote first (warm standby skips the round-trip) so the price impact
+		// can be checked before a swap is built
+		slippageBps := e.retrySlippageFor(signal.Mint, lastBps, lastErr)
+		lastBps = slippageBps
+		var swapTx string
+		var usedQuote *jupiter.QuoteResponse
+		var lastValidBlockHeight uint64
+		var err error
+		buyQuote := e.takeWarmQuote(signal.Mint, allocLamports, slippageBps)
+		if buyQuote != nil {
+			log.Debug().Str("token", signal.TokenName).Dur("quoteAge", buyQuote.Age()).Msg("🔥 using warm quote")
+		} else {
+			buyQuote, err = e.jupiter.GetQuoteWithSlippage(ctx, jupiter.SOLMint, signal.Mint, allocLamports, slippageBps)
+		}
+		if err == nil {
+			if maxImpact := e.cfg.GetTrading().MaxPriceImpactPercent; maxImpact > 0 {
+				if impact := buyQuote.PriceImpactPercent(); impact > maxImpact {
+					log.Warn().
+						Str("token", signal.TokenName).
+						Float64("impactPct", impact).
+						Float64("maxPct", maxImpact).
+						Msg("🚫 buy refused: price impact too high")
+					e.positions.Remove(signal.Mint)
+					e.skipSignal(signal, SkipPriceImpact, fmt.Sprintf("%.1f%% > %.1f%%", impact, maxImpact))
+					return nil
+				}
+			}
+			// Paper trading fills at the quote; nothing is built or sent
+			if e.paperTrading() {
+				e.fillPaperBuy(signal, timer, buyQuote, allocLamports)
+				return nil
+			}
+			var swap *jupiter.SwapResponse
+			if swap, err = e.jupiter.GetSwapFromQuote(ctx, buyQuote, e.wallet.Address()); err == nil {
+				swapTx, usedQuote, lastValidBlockHeight = swap.SwapTransaction, swap.Quote, swap.LastValidBlockHeight
+			}
+		}
 		if err != nil {
 			log.Error().Str("error", blockchain.HumanErrorWithAction(err)).Msg("⚡ JUPITER FAILED")
+			e.issues.Record("buy", err)
 			lastErr = err
 			continue
 		}
@@ -438,11 +743,19 @@ func (e *ExecutorFast) executeBuyFast(ctx context.Context, signal *signalPkg.Sig
 		signedTx, err := e.txBuilder.SignSerializedTransaction(swapTx)
 		if err != nil {
 			log.Error().Str("error", blockchain.HumanError(err)).Msg("⚡ SIGN FAILED")
+			e.issues.Record("buy", err)
 			lastErr = err
 			continue
 		}
 		timer.MarkSignDone()
 
+		// Optional simulation (trading.preflight_simulate): don't pay for a doomed send
+		if err := e.preflightSimulate(ctx, signedTx, "buy"); err != nil {
+			e.issues.Record("buy", err)
+			lastErr = err
+			continue
+		}
+
 		// SEND - skipPreflight = true for max speed
 		txSig, err := e.rpc.SendTransaction(ctx, signedTx, true)
 		timer.MarkSendDone()
@@ -452,7 +765,9 @@ func (e *ExecutorFast) executeBuyFast(ctx context.Context, signal *signalPkg.Sig
 		e.metrics.RecordTrade(err == nil, parse, resolve, quote, sign, send)
 
 		if err != nil {
-			log.Error().Str("error", blockchain.HumanErrorWithAction(err)).Msg("⚡ TX SEND FAILED")
+			log.Error().Str("error", blockchain.HumanErrorWithAction(err)).Int("slippageBps", slippageBps).Msg("⚡ TX SEND FAILED")
+			e.recordSlippage(signal.Mint, err)
+			e.issues.Record("buy", err)
 			lastErr = err
 			continue
 		}
@@ -464,33 +779,72 @@ func (e *ExecutorFast) executeBuyFast(ctx context.Context, signal *signalPkg.Sig
 			Int64("signMs", sign).
 			Int64("sendMs", send).
 			Msg("⚡ BUY SENT")
+		e.markMintBought(signal.Mint)
+		e.recordDecision(signal, DecisionBuy, ReasonSent, txSig)
+		e.publish(events.TradeExecuted{
+			Side:      "BUY",
+			Mint:      signal.Mint,
+			TokenName: signal.TokenName,
+			AmountSol: float64(allocLamports) / 1e9,
+			TxSig:     txSig,
+		})
+		e.notify(notify.Event{
+			Kind:      notify.KindBuy,
+			TokenName: signal.TokenName,
+			Mint:      signal.Mint,
+			AmountSol: float64(allocLamports) / 1e9,
+			TxSig:     txSig,
+		})
 
-		// WebSocket TX Confirmation (instant feedback)
+		// WebSocket TX Confirmation (instant feedback); the outcome, not the
+		// send, is what adaptive slippage learns from
+		wsErr := errors.New("no wallet monitor")
 		if e.walletMon != nil {
-			e.walletMon.WaitForConfirmation(txSig, func(conf ws.TxConfirmation) {
+			wsErr = e.walletMon.WaitForConfirmation(txSig, func(conf ws.TxConfirmation) {
 				if conf.Confirmed {
 					log.Info().Str("sig", txSig[:12]+"...").Msg("✅ BUY CONFIRMED via WebSocket")
+					e.recordSlippage(signal.Mint, nil)
 				} else {
 					log.Error().Str("sig", txSig[:12]+"...").Str("err", conf.Error).Msg("❌ BUY FAILED via WebSocket")
+					e.recordSlippage(signal.Mint, errors.New(conf.Error))
 					// Remove failed position
 					e.positions.Remove(signal.Mint)
 				}
 			})
 		}
+		if wsErr != nil {
+			go e.learnSlippageOnConfirm(signal.Mint, txSig, lastValidBlockHeight)
+		}
 
 		// Track position ASYNC (don't block) - FIX #12: Use sync.WaitGroup for cleanup
 		go e.trackPositionAsync(signal, allocLamports, txSig)
+		go e.recordTradeSlippage("BUY", signal.Mint, txSig, usedQuote)
 
 		return nil // Success
 	}
 
 	// Failed after retries - remove pending position
 	e.positions.Remove(signal.Mint)
+	if lastErr != nil {
+		e.recordDecision(signal, DecisionFail, ReasonRetriesExhausted, blockchain.HumanError(lastErr))
+	}
 	return lastErr
 }
 
 // executeSellFast - FIRE AND FORGET sell execution with retry
 func (e *ExecutorFast) executeSellFast(ctx context.Context, signal *signalPkg.Signal, timer *TradeTimer) error {
+	// A sent sell keeps its position until confirmation - don't fire a second one meanwhile
+	if !e.beginSell(signal.Mint) {
+		log.Debug().Str("mint", signal.Mint).Msg("sell already awaiting confirmation, skipping")
+		return nil
+	}
+	handedOff := false
+	defer func() {
+		if !handedOff {
+			e.endSell(signal.Mint)
+		}
+	}()
+
 	// Update position value for TUI display before selling
 	if pos := e.positions.Get(signal.Mint); pos != nil {
 		pos.SetStatsFromSignal(signal.Value, signal.Unit)
@@ -518,6 +872,7 @@ func (e *ExecutorFast) executeSellFast(ctx context.Context, signal *signalPkg.Si
 
 	// FIX #11: Retry logic with EXPONENTIAL BACKOFF
 	var lastErr error
+	fullBalance := tokenAmount
 
 	// Simulation Bypass (Sell)
 	if e.simMode || e.cfg.Get().Trading.SimulationMode {
@@ -527,35 +882,58 @@ func (e *ExecutorFast) executeSellFast(ctx context.Context, signal *signalPkg.Si
 		timer.MarkSignDone()
 		timer.MarkSendDone()
 		txSig := "SIM_SELL_" + signal.TokenName
+		e.setSimHolding(signal.Mint, 0)
 		e.removePositionAsync(signal.Mint)
 		log.Info().Str("txSig", txSig).Msg("⚡ SIMULATION SELL EXECUTED")
+		e.recordDecision(signal, DecisionSell, ReasonSimulated, txSig)
 		return nil
 	}
+	if e.isPaperPosition(signal.Mint) {
+		return e.paperSell(ctx, signal, timer, tokenAmount)
+	}
+	lastBps := 0 // slippage of the previous attempt
 	for attempt := 0; attempt <= e.maxRetries; attempt++ {
-		if attempt > 0 {
+		if attempt > 0 && blockchain.ErrorCategory(lastErr) == blockchain.CategoryBlockhash {
+			// Resending those bytes can't land; every attempt asks Jupiter for a
+			// new swap (fresh blockhash), so rebuild now instead of backing off
+			log.Warn().Int("attempt", attempt+1).Msg("🔄 blockhash expired, rebuilding sell swap")
+		} else if attempt > 0 {
 			backoffMs := 100 * (1 << (attempt - 1)) // 100ms, 200ms, 400ms, 800ms...
 			log.Warn().Int("attempt", attempt+1).Int("backoffMs", backoffMs).Msg("retrying sell...")
 			time.Sleep(time.Duration(backoffMs) * time.Millisecond)
 		}
 
 		// Get swap TX
-		swapTx, err := e.jupiter.GetSwapTransaction(ctx, signal.Mint, jupiter.SOLMint, e.wallet.Address(), tokenAmount)
+		slippageBps := e.retrySlippageFor(signal.Mint, lastBps, lastErr)
+		lastBps = slippageBps
+		swap, err := e.jupiter.GetSwapWithSlippage(ctx, signal.Mint, jupiter.SOLMint, e.wallet.Address(), tokenAmount, slippageBps)
 		if err != nil {
 			log.Error().Str("error", blockchain.HumanErrorWithAction(err)).Msg("⚡ JUPITER FAILED")
+			e.issues.Record("sell", err)
 			lastErr = err
+			tokenAmount = e.reducedSellAmount(signal.Mint, tokenAmount, fullBalance, err)
 			continue
 		}
 		timer.MarkQuoteDone()
 
 		// Sign
-		signedTx, err := e.txBuilder.SignSerializedTransaction(swapTx)
+		signedTx, err := e.txBuilder.SignSerializedTransaction(swap.SwapTransaction)
 		if err != nil {
 			log.Error().Str("error", blockchain.HumanError(err)).Msg("⚡ SIGN FAILED")
+			e.issues.Record("sell", err)
 			lastErr = err
 			continue
 		}
 		timer.MarkSignDone()
 
+		// Optional simulation (trading.preflight_simulate)
+		if err := e.preflightSimulate(ctx, signedTx, "sell"); err != nil {
+			e.issues.Record("sell", err)
+			lastErr = err
+			tokenAmount = e.reducedSellAmount(signal.Mint, tokenAmount, fullBalance, err)
+			continue
+		}
+
 		// SEND
 		txSig, err := e.rpc.SendTransaction(ctx, signedTx, true)
 		timer.MarkSendDone()
@@ -564,8 +942,11 @@ func (e *ExecutorFast) executeSellFast(ctx context.Context, signal *signalPkg.Si
 		e.metrics.RecordTrade(err == nil, parse, resolve, quote, sign, send)
 
 		if err != nil {
-			log.Error().Str("error", blockchain.HumanErrorWithAction(err)).Msg("⚡ TX SEND FAILED")
+			log.Error().Str("error", blockchain.HumanErrorWithAction(err)).Int("slippageBps", slippageBps).Msg("⚡ TX SEND FAILED")
+			e.recordSlippage(signal.Mint, err)
+			e.issues.Record("sell", err)
 			lastErr = err
+			tokenAmount = e.reducedSellAmount(signal.Mint, tokenAmount, fullBalance, err)
 			continue
 		}
 
@@ -573,6 +954,13 @@ func (e *ExecutorFast) executeSellFast(ctx context.Context, signal *signalPkg.Si
 			Str("txSig", txSig).
 			Int64("totalMs", timer.TotalMs()).
 			Msg("⚡ SELL SENT")
+		e.recordDecision(signal, DecisionSell, ReasonSent, txSig)
+		e.publish(events.TradeExecuted{
+			Side:      "SELL",
+			Mint:      signal.Mint,
+			TokenName: signal.TokenName,
+			TxSig:     txSig,
+		})
 
 		// Log SELL trade to history
 		if pos := e.positions.Get(signal.Mint); pos != nil && e.db != nil {
@@ -592,37 +980,53 @@ func (e *ExecutorFast) executeSellFast(ctx context.Context, signal *signalPkg.Si
 			})
 		}
 
-		// WebSocket TX Confirmation for sell
-		if e.walletMon != nil {
-			mintCopy := signal.Mint // Capture for closure
-			e.walletMon.WaitForConfirmation(txSig, func(conf ws.TxConfirmation) {
-				if conf.Confirmed {
-					log.Info().Str("sig", txSig[:12]+"...").Msg("✅ SELL CONFIRMED via WebSocket")
-					// Remove position only after confirmed
-					e.positions.Remove(mintCopy)
-				} else {
-					log.Error().Str("sig", txSig[:12]+"...").Str("err", conf.Error).Msg("❌ SELL FAILED via WebSocket")
-				}
-			})
-		} else {
-			// Remove position ASYNC - FIX #12
-			go e.removePositionAsync(signal.Mint)
-		}
+		go e.recordTradeSlippage("SELL", signal.Mint, txSig, swap.Quote)
+
+		// Remove position only once the sell lands (WS or poll)
+		handedOff = true
+		go e.confirmSellAndRemove(signal.Mint, txSig, swap.LastValidBlockHeight)
 
 		return nil // Success
 	}
 
+	if lastErr != nil {
+		e.recordDecision(signal, DecisionFail, ReasonRetriesExhausted, blockchain.HumanError(lastErr))
+	}
 	return lastErr
 }
 
+// reducedSellAmount returns the amount for the next sell attempt. Selling the
+// exact full balance can fail for quirky tokens: Token-2022 transfer fees
+// must stay in the account, and balance/decimals rounding can leave it a few
+// base units short of what the route tries to move. When a full-balance
+// attempt fails with an amount error, retry with
+// trading.sell_retry_amount_factor of the balance (dust is left behind).
+// Any other failure, or an amount that was already reduced, is kept as is.
+func (e *ExecutorFast) reducedSellAmount(mint string, amount, fullBalance uint64, err error) uint64 {
+	factor := e.cfg.GetTrading().SellRetryAmountFactor
+	if amount != fullBalance || factor <= 0 || factor >= 1 || !blockchain.IsAmountError(err) {
+		return amount
+	}
+	reduced := uint64(float64(fullBalance) * factor)
+	if reduced == 0 || reduced >= fullBalance {
+		return amount
+	}
+	log.Warn().
+		Str("mint", mint).
+		Uint64("balance", fullBalance).
+		Uint64("retryAmount", reduced).
+		Float64("factor", factor).
+		Msg("✂️ full-balance sell rejected, retrying with reduced amount")
+	return reduced
+}
+
 // FIX #2: Get actual token balance
 func (e *ExecutorFast) getTokenBalance(ctx context.Context, mint string) (uint64, error) {
 	if e.simMode || e.cfg.Get().Trading.SimulationMode {
-		// Return 1,000,000 "lamports" or whatever units (Token Decimals?)
-		// To match Jupiter expectations, we should probably check what Jupiter expects.
-		// For monitoring, we just need > 0.
-		// Let's assume 1000 tokens * 1e6 decimals = 1_000_000_000
-		return 1_000_000_000, nil
+		return e.simTokenBalance(mint), nil
+	}
+	if e.isPaperPosition(mint) {
+		return e.paperTokenBalance(ctx, mint)
 	}
 	// Get token accounts for this mint
 	tokenAccounts, err := e.rpc.GetTokenAccountsByOwner(ctx, e.wallet.Address(), mint)
@@ -638,6 +1042,35 @@ func (e *ExecutorFast) getTokenBalance(ctx context.Context, mint string) (uint64
 	return totalBalance, nil
 }
 
+// simTokenBalance is the simulated holding of mint: what the simulated buy
+// received, in the mint's simulated decimals. A position without one (e.g.
+// restored from the database) is valued as bought at the current price.
+func (e *ExecutorFast) simTokenBalance(mint string) uint64 {
+	e.mu.RLock()
+	held, ok := e.simHoldings[mint]
+	e.mu.RUnlock()
+	if ok {
+		return held
+	}
+	pos := e.positions.Get(mint)
+	if pos == nil {
+		return 0
+	}
+	held = e.jupiter.SimBuyAmount(mint, uint64(pos.Size*1e9))
+	e.setSimHolding(mint, held)
+	return held
+}
+
+func (e *ExecutorFast) setSimHolding(mint string, amount uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if amount == 0 {
+		delete(e.simHoldings, mint)
+		return
+	}
+	e.simHoldings[mint] = amount
+}
+
 // FIX #4: Duplicate signal protection
 func (e *ExecutorFast) isDuplicateSignal(msgID int64) bool {
 	e.mu.RLock()
@@ -650,6 +1083,63 @@ func (e *ExecutorFast) isDuplicateSignal(msgID int64) bool {
 	return false
 }
 
+// signalContentKey hashes what makes two signals the same call: token,
+// type, value rounded to a whole number and the minute it was sent in
+func signalContentKey(signal *signalPkg.Signal) uint64 {
+	ts := signal.Timestamp
+	if ts == 0 {
+		ts = time.Now().Unix()
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s|%s|%.0f|%d", strings.ToUpper(signal.TokenName), signal.Type, signal.Value, ts/60)
+	return h.Sum64()
+}
+
+// isDuplicateContent reports whether a signal with the same content was seen
+// within trading.content_dedup_seconds, recording it if not
+func (e *ExecutorFast) isDuplicateContent(signal *signalPkg.Signal) bool {
+	window := time.Duration(e.cfg.GetTrading().ContentDedupSeconds) * time.Second
+	if window <= 0 {
+		return false
+	}
+	key := signalContentKey(signal)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if ts, ok := e.recentContent[key]; ok && time.Since(ts) < window {
+		return true
+	}
+	e.recentContent[key] = time.Now()
+	for k, ts := range e.recentContent {
+		if time.Since(ts) > window {
+			delete(e.recentContent, k)
+		}
+	}
+	return false
+}
+
+// markMintBought records a sent buy for the re-buy cooldown
+func (e *ExecutorFast) markMintBought(mint string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.recentMints[mint] = time.Now()
+}
+
+// rebuyCooldownLeft returns how long until mint may be bought again (0 = now)
+func (e *ExecutorFast) rebuyCooldownLeft(mint string, cooldownSeconds int) time.Duration {
+	if cooldownSeconds <= 0 {
+		return 0
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	ts, ok := e.recentMints[mint]
+	if !ok {
+		return 0
+	}
+	return time.Duration(cooldownSeconds)*time.Second - time.Since(ts)
+}
+
 func (e *ExecutorFast) markSignalSeen(msgID int64) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -663,9 +1153,14 @@ func (e *ExecutorFast) markSignalSeen(msgID int64) {
 		}
 	}
 
-	// Cleanup old entries from recentMints (was never cleaned - memory leak)
+	// Cleanup old entries from recentMints (was never cleaned - memory leak),
+	// keeping any still inside the re-buy cooldown
+	mintTTL := SignalCleanupTTL
+	if cooldown := time.Duration(e.cfg.GetTrading().RebuyCooldownSeconds) * time.Second; cooldown > mintTTL {
+		mintTTL = cooldown
+	}
 	for mint, ts := range e.recentMints {
-		if time.Since(ts) > SignalCleanupTTL {
+		if time.Since(ts) > mintTTL {
 			delete(e.recentMints, mint)
 		}
 	}
@@ -705,9 +1200,18 @@ func (e *ExecutorFast) trackPositionAsync(signal *signalPkg.Signal, allocLamport
 		MsgID:        signal.MsgID,
 		CurrentValue: signal.Value, // Initialize to entry value
 		PnLPercent:   0,            // Start at 0% PnL
+		EntryFeesSol: e.entryFeesSol(),
+	}
+	if isPaperTx(txSig) {
+		// Exits follow the position, not whatever mode is on by then
+		pos.Paper = true
+		e.mu.RLock()
+		pos.PaperTokens = e.simHoldings[signal.Mint]
+		e.mu.RUnlock()
 	}
 	e.positions.Add(pos)
 	e.balance.Refresh(context.Background())
+	go e.discoverPool(pos.Mint)
 
 	// Log BUY trade to history
 	if e.db != nil {
@@ -723,6 +1227,7 @@ func (e *ExecutorFast) trackPositionAsync(signal *signalPkg.Signal, allocLamport
 			EntryTxSig: txSig,
 			ExitTxSig:  "",
 			Timestamp:  time.Now().Unix(),
+			Paper:      isPaperTx(txSig),
 		})
 	}
 }
@@ -736,9 +1241,152 @@ func (e *ExecutorFast) removePositionAsync(mint string) {
 	}()
 
 	e.positions.Remove(mint)
+	if e.priceFeed != nil {
+		e.priceFeed.UntrackToken(mint)
+	}
 	e.balance.Refresh(context.Background())
 }
 
+// beginSell marks a mint as having a sell in flight; false if one already is
+func (e *ExecutorFast) beginSell(mint string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.sellsInFlight[mint] {
+		return false
+	}
+	e.sellsInFlight[mint] = true
+	return true
+}
+
+func (e *ExecutorFast) endSell(mint string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.sellsInFlight, mint)
+}
+
+// beginExitCheck marks a mint's exits as being evaluated; false if they
+// already are (a streamed price and a monitor pass landing together must
+// not fire the same ladder rung twice)
+func (e *ExecutorFast) beginExitCheck(mint string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.exitChecks[mint] {
+		return false
+	}
+	e.exitChecks[mint] = true
+	return true
+}
+
+func (e *ExecutorFast) endExitCheck(mint string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.exitChecks, mint)
+}
+
+// confirmSellAndRemove waits for the sell to reach the configured commitment
+// before removing the position. A sell that reverts on-chain (or never lands)
+// leaves the position open so the monitor can retry it.
+func (e *ExecutorFast) confirmSellAndRemove(mint, txSig string, lastValidBlockHeight uint64) {
+	defer e.endSell(mint)
+
+	cfg := e.cfg.GetTrading()
+	commitment := cfg.SellConfirmCommitment
+	timeout := time.Duration(cfg.SellConfirmTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = DefaultSellConfirmTimeout
+	}
+
+	err := e.awaitCommitment(txSig, commitment, timeout, lastValidBlockHeight)
+	e.recordLanding(!errors.Is(err, errTxNotLanded))
+	e.recordSlippage(mint, err)
+	if err != nil {
+		e.issues.Record("sell", err)
+		log.Error().
+			Str("sig", txSig).
+			Str("mint", mint).
+			Str("error", blockchain.HumanError(err)).
+			Msg("❌ SELL NOT CONFIRMED - keeping position")
+		// The position is still open: let a stop-loss fire again next pass
+		if pos := e.positions.Get(mint); pos != nil {
+			pos.SetStopLossed(false)
+		}
+		return
+	}
+
+	log.Info().Str("sig", txSig).Str("commitment", commitment).Msg("✅ SELL CONFIRMED")
+	if pos := e.positions.Get(mint); pos != nil {
+		go e.accountSell(pos.Snapshot(), txSig)
+	}
+	e.removePositionAsync(mint)
+}
+
+// awaitCommitment blocks until txSig reaches commitment, fails, or times out.
+// Uses the WebSocket signature subscription when connected, RPC polling otherwise.
+// When lastValidBlockHeight is known (non-zero) polling stops as soon as the
+// chain passes it with the transaction still unseen: its blockhash has expired
+// and it can never land, so there is no point waiting out the timeout.
+func (e *ExecutorFast) awaitCommitment(txSig, commitment string, timeout time.Duration, lastValidBlockHeight uint64) error {
+	deadline := time.Now().Add(timeout)
+
+	if e.walletMon != nil && e.wsClient != nil && e.wsClient.IsConnected() {
+		result := make(chan ws.TxConfirmation, 1)
+		err := e.walletMon.WaitForCommitment(txSig, commitment, func(conf ws.TxConfirmation) {
+			result <- conf
+		})
+		if err == nil {
+			select {
+			case conf := <-result:
+				if !conf.Confirmed {
+					return fmt.Errorf("transaction failed: %s", conf.Error)
+				}
+				return nil
+			case <-time.After(timeout):
+				// No notification in time - fall through to one last poll
+				e.walletMon.CancelConfirmation(txSig)
+				deadline = time.Now().Add(SellConfirmPollInterval)
+			}
+		} else {
+			log.Debug().Err(err).Msg("signature subscribe failed, polling instead")
+		}
+	}
+
+	seen := false // the tx reached the chain at some point (landed, even if not yet at commitment)
+	for {
+		time.Sleep(SellConfirmPollInterval)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		// Read the height before the status so a NOT_FOUND after it is conclusive
+		var height uint64
+		if lastValidBlockHeight > 0 {
+			height, _ = e.rpc.GetBlockHeight(ctx) // 0 on error = no expiry check this round
+		}
+		res, err := e.rpc.CheckTransaction(ctx, txSig)
+		cancel()
+		if err == nil {
+			switch res.Status {
+			case "FAILED":
+				return fmt.Errorf("transaction failed: %s", res.Message)
+			case "SUCCESS":
+				seen = true
+				if blockchain.CommitmentReached(res.ConfirmationStatus, commitment) {
+					return nil
+				}
+			case "NOT_FOUND":
+				if lastValidBlockHeight > 0 && height > lastValidBlockHeight {
+					return fmt.Errorf("%w: block height exceeded (%d > %d)", errTxNotLanded, height, lastValidBlockHeight)
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if !seen {
+				return fmt.Errorf("%w: not %s after %s", errTxNotLanded, commitment, timeout)
+			}
+			return fmt.Errorf("not %s after %s", commitment, timeout)
+		}
+	}
+}
+
 // FIX #3: Stats tracking for TUI
 func (e *ExecutorFast) incrementEntrySignals() {
 	e.statsMu.Lock()
@@ -764,6 +1412,7 @@ func (e *ExecutorFast) ResetStats() {
 	defer e.statsMu.Unlock()
 	e.totalEntrySignals = 0
 	e.reached2X = 0
+	e.skips.Reset()
 }
 
 // GetMetrics returns the metrics tracker
@@ -771,19 +1420,105 @@ func (e *ExecutorFast) GetMetrics() *Metrics {
 	return e.metrics
 }
 
-// SellAllPositions triggers a ForceClose for every active position
-func (e *ExecutorFast) SellAllPositions(ctx context.Context) {
+// GetIssues returns the recent categorized failure log
+func (e *ExecutorFast) GetIssues() *IssueLog {
+	return e.issues
+}
+
+// SellResult is one position's outcome from SellAllPositions
+type SellResult struct {
+	Mint      string
+	TokenName string
+	Err       error
+	Sent      bool // the sell went out; with Err set it was not confirmed in time
+}
+
+// errSellNotConfirmed is a sell that was sent but reverted or never landed:
+// its position is still open
+var errSellNotConfirmed = errors.New("sell not confirmed, position kept")
+
+// SellAllPositions triggers a ForceClose for every active position and waits
+// for the sells to confirm, or for ctx to end: sells not confirmed (or not
+// started) by then report ctx's error
+func (e *ExecutorFast) SellAllPositions(ctx context.Context) []SellResult {
 	positions := e.positions.GetAll()
 	log.Warn().Int("count", len(positions)).Msg("🚨 PANIC SELL TRIGGERED: Selling ALL positions")
 
-	for _, pos := range positions {
-		go func(mint string) {
-			if err := e.ForceClose(ctx, mint); err != nil {
-				log.Error().Err(err).Str("mint", mint).Msg("failed to force close during panic sell")
+	type outcome struct {
+		i   int
+		err error
+	}
+	results := make([]SellResult, len(positions))
+	for i, pos := range positions {
+		results[i] = SellResult{Mint: pos.Mint, TokenName: pos.TokenName}
+	}
+	finished := make([]bool, len(positions))
+	var sentMu sync.Mutex
+	sent := make([]bool, len(positions))
+	done := make(chan outcome, len(positions))
+	started := 0
+launch:
+	for i, pos := range positions {
+		go func(i int, mint string) {
+			err := e.ForceClose(ctx, mint)
+			if err == nil {
+				sentMu.Lock()
+				sent[i] = true
+				sentMu.Unlock()
+				err = e.awaitSellSettled(ctx, mint)
 			}
-		}(pos.Mint)
+			done <- outcome{i, err}
+		}(i, pos.Mint)
+		started++
 		// Small stagger to avoid rate limits
-		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			break launch
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+wait:
+	for pending := started; pending > 0; pending-- {
+		select {
+		case o := <-done:
+			results[o.i].Err = o.err
+			finished[o.i] = true
+		case <-ctx.Done():
+			break wait
+		}
+	}
+	sentMu.Lock()
+	defer sentMu.Unlock()
+	for i := range results {
+		results[i].Sent = sent[i]
+		if !finished[i] && results[i].Err == nil {
+			results[i].Err = ctx.Err()
+		}
+	}
+	return results
+}
+
+// awaitSellSettled waits until mint has no sell awaiting confirmation, then
+// reports whether that sell closed the position (ctx's error if it ends first)
+func (e *ExecutorFast) awaitSellSettled(ctx context.Context, mint string) error {
+	ticker := time.NewTicker(SellConfirmPollInterval / 4)
+	defer ticker.Stop()
+	for {
+		e.mu.Lock()
+		inFlight := e.sellsInFlight[mint]
+		e.mu.Unlock()
+		if !inFlight {
+			if e.positions.Has(mint) {
+				return errSellNotConfirmed
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
 	}
 }
 
@@ -800,10 +1535,44 @@ func (e *ExecutorFast) ForceClose(ctx context.Context, mint string) error {
 	return e.executeSellFast(ctx, signal, timer)
 }
 
+// ManualBuy enters a position in mint for amountSol as if an entry signal had
+// arrived: the token filter, position limit and retries all apply. It is
+// refused above the cached balance, while degraded, or if mint is held.
+func (e *ExecutorFast) ManualBuy(ctx context.Context, mint string, amountSol float64) error {
+	if mint == "" || amountSol <= 0 {
+		return fmt.Errorf("manual buy needs a mint and a positive amount")
+	}
+	lamports := uint64(amountSol * 1e9)
+	if balance := e.buyBalanceLamports(); lamports > balance {
+		return fmt.Errorf("manual buy %.4f SOL exceeds balance %.4f SOL", amountSol, float64(balance)/1e9)
+	} else if spendable := spendableLamports(e.cfg.GetTrading(), balance); lamports > spendable {
+		return fmt.Errorf("manual buy %.4f SOL dips into the fee reserve: %.4f SOL spendable", amountSol, float64(spendable)/1e9)
+	}
+	if mode := e.DegradedMode(); mode != "" {
+		return fmt.Errorf("manual buy refused: mode %s (WebSocket down)", mode)
+	}
+	if e.hasMintPosition(mint) {
+		return fmt.Errorf("manual buy refused: position already open")
+	}
+
+	name := mint
+	if len(name) > 8 {
+		name = name[:8] + "..."
+	}
+	signal := &signalPkg.Signal{
+		Mint:      mint,
+		TokenName: name,
+		Type:      signalPkg.SignalEntry,
+		Timestamp: time.Now().Unix(),
+	}
+	log.Info().Str("mint", name).Float64("sol", amountSol).Msg("🖐️ MANUAL BUY")
+	return e.executeBuyFast(ctx, signal, NewTradeTimer(), lamports)
+}
+
 // StartMonitoring starts the background active trade monitor
 func (e *ExecutorFast) StartMonitoring(ctx context.Context) {
 	log.Info().Msg("starting active trade monitor (FAST mode)...")
-	ticker := time.NewTicker(5 * time.Second)
+	ticker := time.NewTicker(config.MonitorInterval)
 	go func() {
 		defer ticker.Stop()
 		for {
@@ -825,6 +1594,16 @@ func (e *ExecutorFast) monitorPositions(ctx context.Context) {
 
 	cfg := e.cfg.GetTrading()
 
+	// One batched read for every balance rather than an RPC call per position
+	mints := make([]string, 0, len(positions))
+	for _, pos := range positions {
+		if !cfg.IsIgnoredMint(pos.Mint) {
+			mints = append(mints, pos.Mint)
+		}
+	}
+	balances := e.fetchBalances(ctx, mints)
+	requoteAfter := time.Duration(cfg.RequoteUnchangedSeconds) * time.Second
+
 	// ⚡ Bolt Optimization: Parallelize position monitoring
 	// Use a semaphore to limit concurrency and avoid API rate limits
 	const maxConcurrentChecks = 5
@@ -836,7 +1615,13 @@ func (e *ExecutorFast) monitorPositions(ctx context.Context) {
 		go func(pos *Position) {
 			defer wg.Done()
 
-			// Optimization: Skip RPC check if position was updated recently via WebSocket
+			// Never auto-sell reserve assets, even if a position for one exists
+			if cfg.IsIgnoredMint(pos.Mint) {
+				return
+			}
+
+			// Quoted moments ago (back-to-back passes, or stats set from a signal);
+			// streamed prices don't count and run their own exits
 			if time.Since(pos.GetLastUpdate()) < 2*time.Second {
 				return
 			}
@@ -858,12 +1643,9 @@ func (e *ExecutorFast) monitorPositions(ctx context.Context) {
 				}
 			}
 
-			// Get current token balance
-			balance, err := e.getTokenBalance(ctx, pos.Mint)
-
-			// FIX: Handle 0 balance - mark position as lost/failed
-			if err != nil {
-				log.Debug().Err(err).Str("mint", pos.Mint[:8]+"...").Msg("failed to get balance")
+			// Current token balance (fetchBalances logged any failure)
+			balance, ok := balances[pos.Mint]
+			if !ok {
 				return
 			}
 
@@ -885,8 +1667,20 @@ func (e *ExecutorFast) monitorPositions(ctx context.Context) {
 				return
 			}
 
-			// Get Quote for ALL tokens -> SOL
-			quote, err := e.jupiter.GetQuote(ctx, pos.Mint, jupiter.SOLMint, balance)
+			// Balance unchanged and quoted recently: skip the quote this pass,
+			// unless a stop watches the price (only a fresh quote can trip it)
+			if requoteAfter > 0 && balance == pos.GetTokenBalance() && time.Since(pos.GetLastUpdate()) < requoteAfter &&
+				!priceStopArmed(cfg, pos) {
+				return
+			}
+
+			// Get Quote for ALL tokens -> SOL (valuation: a quote from the last pass
+			// is fine, unless a stop needs the current price)
+			getQuote := e.jupiter.GetQuoteCached
+			if priceStopArmed(cfg, pos) {
+				getQuote = e.jupiter.GetQuote
+			}
+			quote, err := getQuote(ctx, pos.Mint, jupiter.SOLMint, balance)
 			if err != nil {
 				return
 			}
@@ -896,94 +1690,368 @@ func (e *ExecutorFast) monitorPositions(ctx context.Context) {
 
 			// Update Position Stats safely
 			multiple := pos.UpdateStats(currentValSOL, balance)
+			pos.SetExitValue(e.exitValueSol(quote))
+			e.publishRevalued(pos.Mint)
+			e.positions.PersistStats(pos)
 
-			if multiple >= cfg.TakeProfitMultiple { // Use config multiple (e.g. 2.0)
-				if !pos.IsReached2X() {
-					pos.SetReached2X(true)
-					log.Info().Str("token", pos.TokenName).Float64("mult", multiple).Msg("reached target! marked as win")
-					e.Increment2XHit()
-				}
+			e.evaluateExits(ctx, cfg, pos, multiple, currentValSOL)
+		}(pos)
+	}
+
+	wg.Wait()
+}
+
+// evaluateExits runs every automatic exit against pos at multiple (value
+// currentValSOL): take-profit and its curve, the ladder, stop-loss (break-even
+// once armed), trailing stop, give-back and max hold. Both monitor passes and
+// streamed pool prices call it; one evaluation per mint runs at a time.
+func (e *ExecutorFast) evaluateExits(ctx context.Context, cfg config.TradingConfig, pos *Position, multiple, currentValSOL float64) {
+	if !e.beginExitCheck(pos.Mint) {
+		return
+	}
+	defer e.endExitCheck(pos.Mint)
 
-				// Trigger Auto-Sell
-				if cfg.AutoTradingEnabled {
-					log.Info().Str("token", pos.TokenName).Msg("triggering take-profit sell")
+	// Paused by WebSocket outage: keep stats fresh but take no automatic exits
+	if e.DegradedMode() == DegradedPause {
+		return
+	}
 
-					// Create timer
-					timer := NewTradeTimer()
+	_, overrideKey, _ := cfg.OverrideFor(pos.Mint, pos.TokenName)
 
-					// Create Exit Signal
-					exitSig := &signalPkg.Signal{
-						Mint:      pos.Mint,
-						TokenName: pos.TokenName,
-						Type:      signalPkg.SignalExit,
-						Value:     multiple,
-					}
+	if multiple >= cfg.TakeProfitFor(pos.Mint, pos.TokenName) { // Config multiple (e.g. 2.0) or token override
+		if !pos.IsReached2X() {
+			pos.SetReached2X(true)
+			log.Info().Str("token", pos.TokenName).Float64("mult", multiple).Msg("reached target! marked as win")
+			e.Increment2XHit()
+		}
 
-					// Execute Sell
-					go e.executeSellFast(ctx, exitSig, timer)
-				}
+		// Trigger Auto-Sell (the take-profit curve below handles exits when configured)
+		if cfg.AutoTradingEnabled && len(cfg.TakeProfitCurve) == 0 {
+			log.Info().Str("token", pos.TokenName).Str("override", overrideKey).Msg("triggering take-profit sell")
+
+			// Create timer
+			timer := NewTradeTimer()
+
+			// Create Exit Signal
+			exitSig := &signalPkg.Signal{
+				Mint:      pos.Mint,
+				TokenName: pos.TokenName,
+				Type:      signalPkg.SignalExit,
+				Value:     multiple,
 			}
 
-			// Logic: Partial Profit-Taking
-			if cfg.PartialProfitPercent > 0 && cfg.PartialProfitMultiple > 1.0 {
-				if multiple >= cfg.PartialProfitMultiple && !pos.IsPartialSold() {
-					log.Info().Str("token", pos.TokenName).Float64("mult", multiple).Msg("triggering partial profit take")
-					e.executePartialSell(ctx, pos, cfg.PartialProfitPercent)
-				}
+			// Execute Sell
+			go e.executeSellFast(ctx, exitSig, timer)
+		}
+	}
+
+	// Logic: Take-Profit Curve (sell more the further past target)
+	if cfg.AutoTradingEnabled && len(cfg.TakeProfitCurve) > 0 {
+		target := TakeProfitFraction(cfg.TakeProfitCurve, multiple)
+		sold := pos.GetCurveSold()
+		held := 1 - pos.GetSoldFraction() // the ladder may have sold some too
+		if (target >= 1.0 || target-sold >= held) && sold < 1.0 {
+			log.Info().Str("token", pos.TokenName).Float64("mult", multiple).Msg("take-profit curve complete, selling rest")
+			sig := &signalPkg.Signal{
+				Mint:      pos.Mint,
+				TokenName: pos.TokenName,
+				Type:      signalPkg.SignalExit,
+				Value:     multiple,
+			}
+			e.executeSellFast(ctx, sig, NewTradeTimer())
+			return
+		}
+		if target-sold >= TakeProfitCurveMinStep {
+			// Convert "fraction of original" into "percent of what's left"
+			percent := (target - sold) / held * 100
+			log.Info().
+				Str("token", pos.TokenName).
+				Float64("mult", multiple).
+				Float64("soldFraction", sold).
+				Float64("targetFraction", target).
+				Msg("take-profit curve step")
+			if e.executePartialSell(ctx, pos, percent) {
+				pos.SetCurveSold(target)
+				e.armBreakEven(pos, cfg)
+				e.positions.PersistPartials(pos)
 			}
+		}
+	}
 
-			// Logic: Time-Based Exit
-			if cfg.MaxHoldMinutes > 0 {
-				if time.Since(pos.EntryTime) > time.Duration(cfg.MaxHoldMinutes)*time.Minute {
-					log.Info().Str("token", pos.TokenName).Msg("max hold time reached, selling all")
-					// Create a fake signal to trigger full sell
-					sig := &signalPkg.Signal{
-						Mint:      pos.Mint,
-						TokenName: pos.TokenName,
-						Type:      signalPkg.SignalExit,
-						Value:     currentValSOL,
-					}
-					e.executeSellFast(ctx, sig, NewTradeTimer())
-				}
+	// Logic: Partial profit ladder (each level sells once)
+	if due, percent, soldAfter := dueProfitLevels(cfg.ProfitLevels(), pos.GetProfitLevelsHit(), pos.GetCurveSold(), multiple); due != 0 {
+		log.Info().
+			Str("token", pos.TokenName).
+			Float64("mult", multiple).
+			Float64("percentOfRemaining", percent).
+			Msg("triggering partial profit take")
+		if percent >= 100 {
+			// Ladder sells the whole position: close it like any exit
+			sig := &signalPkg.Signal{
+				Mint:      pos.Mint,
+				TokenName: pos.TokenName,
+				Type:      signalPkg.SignalExit,
+				Value:     multiple,
 			}
-		}(pos)
+			if err := e.executeSellFast(ctx, sig, NewTradeTimer()); err == nil {
+				pos.MarkProfitLevelsHit(due, soldAfter)
+			}
+			return
+		}
+		if e.executePartialSell(ctx, pos, percent) {
+			pos.MarkProfitLevelsHit(due, soldAfter)
+			e.armBreakEven(pos, cfg)
+			e.positions.PersistPartials(pos)
+		}
 	}
 
-	wg.Wait()
+	// Logic: Stop-loss (token override, else trading.stop_loss_percent),
+	// raised to entry once break-even is armed
+	stopLoss := EffectiveStopLoss(cfg.StopLossFor(pos.Mint, pos.TokenName), pos.IsBreakEvenArmed())
+	entrySig := pos.GetEntryTxSig()
+	if cfg.AutoTradingEnabled && stopLoss > 0 && multiple > 0 && multiple <= stopLoss &&
+		entrySig != "PENDING" && entrySig != "FAILED" && !pos.IsStopLossed() {
+		pos.SetStopLossed(true)
+		log.Info().
+			Str("token", pos.TokenName).
+			Str("override", overrideKey).
+			Float64("mult", multiple).
+			Float64("stopLoss", stopLoss).
+			Msg("🛑 stop-loss hit, selling all")
+		sig := &signalPkg.Signal{
+			Mint:      pos.Mint,
+			TokenName: pos.TokenName,
+			Type:      signalPkg.SignalExit,
+			Value:     multiple,
+		}
+		if err := e.executeSellFast(ctx, sig, NewTradeTimer()); err != nil {
+			pos.SetStopLossed(false) // sell never went out; retry next pass
+		}
+		return
+	}
+
+	// Logic: Trailing stop from peak
+	if cfg.AutoTradingEnabled && TrailingStopHit(pos.GetPeakMultiple(), multiple, cfg.TrailingStopPercent) {
+		log.Info().
+			Str("token", pos.TokenName).
+			Float64("peakMult", pos.GetPeakMultiple()).
+			Float64("mult", multiple).
+			Float64("trailPercent", cfg.TrailingStopPercent).
+			Msg("📉 trailing stop hit, selling all")
+		sig := &signalPkg.Signal{
+			Mint:      pos.Mint,
+			TokenName: pos.TokenName,
+			Type:      signalPkg.SignalExit,
+			Value:     multiple,
+		}
+		e.executeSellFast(ctx, sig, NewTradeTimer())
+		return
+	}
+
+	// Logic: SOL give-back from peak
+	if cfg.MaxGiveBackSol > 0 {
+		givenBack := (pos.GetPeakMultiple() - multiple) * pos.Size
+		if givenBack > cfg.MaxGiveBackSol {
+			log.Info().
+				Str("token", pos.TokenName).
+				Float64("peakMult", pos.GetPeakMultiple()).
+				Float64("mult", multiple).
+				Float64("givenBackSol", givenBack).
+				Msg("max give-back from peak exceeded, selling all")
+			sig := &signalPkg.Signal{
+				Mint:      pos.Mint,
+				TokenName: pos.TokenName,
+				Type:      signalPkg.SignalExit,
+				Value:     multiple,
+			}
+			e.executeSellFast(ctx, sig, NewTradeTimer())
+			return
+		}
+	}
+
+	// Logic: Time-Based Exit
+	if cfg.MaxHoldMinutes > 0 {
+		if time.Since(pos.EntryTime) > time.Duration(cfg.MaxHoldMinutes)*time.Minute {
+			log.Info().Str("token", pos.TokenName).Msg("max hold time reached, selling all")
+			// Create a fake signal to trigger full sell
+			sig := &signalPkg.Signal{
+				Mint:      pos.Mint,
+				TokenName: pos.TokenName,
+				Type:      signalPkg.SignalExit,
+				Value:     currentValSOL,
+			}
+			e.executeSellFast(ctx, sig, NewTradeTimer())
+		}
+	}
+}
+
+// exitValueSol is what a sell at this quote would realistically return: the
+// minimum out after slippage (OtherAmountThreshold) less priority and gas fees
+func (e *ExecutorFast) exitValueSol(quote *jupiter.QuoteResponse) float64 {
+	minOut, err := strconv.ParseUint(quote.OtherAmountThreshold, 10, 64)
+	if err != nil {
+		minOut, _ = strconv.ParseUint(quote.OutAmount, 10, 64)
+	}
+	fees := e.cfg.Get().Fees
+	exit := float64(minOut)/1e9 - fees.StaticPriorityFeeSol - fees.StaticGasFeeSol
+	if exit < 0 {
+		return 0
+	}
+	return exit
+}
+
+// entryFeesSol estimates what a buy costs on top of its size (from config)
+func (e *ExecutorFast) entryFeesSol() float64 {
+	fees := e.cfg.Get().Fees
+	return fees.StaticPriorityFeeSol + fees.StaticGasFeeSol
 }
 
-func (e *ExecutorFast) executePartialSell(ctx context.Context, pos *Position, percent float64) {
+// executePartialSell sells percent of the current token balance; true once the TX is sent
+func (e *ExecutorFast) executePartialSell(ctx context.Context, pos *Position, percent float64) bool {
 	// 1. Calculate Amount
 	balance, err := e.getTokenBalance(ctx, pos.Mint)
 	if err != nil {
-		return
+		return false
 	}
 
 	sellAmount := uint64(float64(balance) * (percent / 100.0))
 
 	log.Info().Str("token", pos.TokenName).Msgf("selling %.0f%% of position...", percent)
+	if pos.IsPaper() {
+		return e.paperPartialSell(ctx, pos, balance, sellAmount)
+	}
 
 	// 2. Perform Swap (Token -> SOL)
-	swapTx, err := e.jupiter.GetSwapTransaction(ctx, pos.Mint, jupiter.SOLMint, e.wallet.Address(), sellAmount)
+	swapTx, err := e.jupiter.GetSwapTransactionWithSlippage(ctx, pos.Mint, jupiter.SOLMint, e.wallet.Address(), sellAmount, e.slippageFor(pos.Mint))
 	if err != nil {
 		log.Error().Err(err).Msg("failed partial swap tx")
-		return
+		e.issues.Record("partial_sell", err)
+		return false
 	}
 
 	signedTx, err := e.txBuilder.SignSerializedTransaction(swapTx)
 	if err != nil {
-		return
+		return false
 	}
 
 	txSig, err := e.rpc.SendTransaction(ctx, signedTx, true)
 	if err != nil {
 		log.Error().Err(err).Msg("failed partial sell send")
-		return
+		e.recordSlippage(pos.Mint, err)
+		e.issues.Record("partial_sell", err)
+		return false
 	}
+	go e.learnSlippageOnConfirm(pos.Mint, txSig, 0)
 
-	// 3. Update Position State
-	pos.SetPartialSold(true)
 	log.Info().Str("txSig", txSig).Msg("PARTIAL SELL executed ✓")
+	e.publish(events.TradeExecuted{
+		Side:      "PARTIAL_SELL",
+		Mint:      pos.Mint,
+		TokenName: pos.TokenName,
+		TxSig:     txSig,
+	})
+	return true
+}
+
+// SetEventBus publishes executor activity (trades, position changes, balance,
+// failures) on bus. Call before trading starts.
+func (e *ExecutorFast) SetEventBus(bus *events.Bus) {
+	e.events.Store(bus)
+	e.positions.SetOnChange(func(mint string, closed bool) {
+		if closed {
+			e.revaluedMu.Lock()
+			delete(e.revalued, mint)
+			e.revaluedMu.Unlock()
+		}
+		bus.Publish(events.PositionUpdated{Mint: mint, Closed: closed})
+	})
+	e.issues.SetOnRecord(func(is Issue) {
+		bus.Publish(events.Error{Stage: is.Stage, Category: is.Category, Message: is.Message})
+	})
+}
+
+// ApplyConfig pushes hot-reloaded settings into components that copied
+// them at construction (position limit, default slippage, token filter). Registered with
+// config.Manager.SetOnChange, which calls it under the config lock, so it
+// must only read c.
+func (e *ExecutorFast) ApplyConfig(c *config.Config) {
+	e.positions.SetMaxPositions(c.Trading.MaxOpenPositions)
+	if c.Jupiter.SlippageBps > 0 {
+		e.jupiter.SetSlippageBps(c.Jupiter.SlippageBps)
+	}
+	// Resolving symbols may hit the network; don't hold the config lock for it
+	go func() { e.rebuildTokenFilter(e.cfg.GetTrading()) }()
+}
+
+// SetNotifier pushes buys, confirmed sells and kill-switch trips to n
+// (see notify.New; nil = off). Call before trading starts.
+func (e *ExecutorFast) SetNotifier(n notify.Notifier) {
+	e.notifier = n
+}
+
+// notify delivers ev in the background, if a notifier is set. A failed or
+// rate-limited notification is logged and never affects trading.
+func (e *ExecutorFast) notify(ev notify.Event) {
+	if e.notifier == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notify.RequestTimeout)
+		defer cancel()
+		if err := e.notifier.Notify(ctx, ev); errors.Is(err, notify.ErrRateLimited) {
+			log.Debug().Str("kind", string(ev.Kind)).Msg("notification dropped (rate limit)")
+		} else if err != nil {
+			log.Warn().Err(err).Str("kind", string(ev.Kind)).Msg("⚠️ notification failed")
+		}
+	}()
+}
+
+// publish sends ev on the event bus, if one is set
+func (e *ExecutorFast) publish(ev events.Event) {
+	if bus := e.events.Load(); bus != nil {
+		bus.Publish(ev)
+	}
+}
+
+// revalState is the throttle state of one position's revaluation events
+type revalState struct {
+	last    time.Time
+	pending bool // a trailing event is scheduled
+}
+
+// publishRevalued publishes a revaluation of mint (price ticks can arrive
+// many times a second) at most once per PositionUpdateThrottle. A throttled
+// update schedules one trailing event so the latest value still goes out.
+func (e *ExecutorFast) publishRevalued(mint string) {
+	if e.events.Load() == nil {
+		return
+	}
+	e.revaluedMu.Lock()
+	st := e.revalued[mint]
+	if st.pending {
+		e.revaluedMu.Unlock()
+		return
+	}
+	wait := PositionUpdateThrottle - time.Since(st.last)
+	if wait <= 0 {
+		e.revalued[mint] = revalState{last: time.Now()}
+		e.revaluedMu.Unlock()
+		e.publish(events.PositionUpdated{Mint: mint})
+		return
+	}
+	e.revalued[mint] = revalState{last: st.last, pending: true}
+	e.revaluedMu.Unlock()
+
+	time.AfterFunc(wait, func() {
+		e.revaluedMu.Lock()
+		if !e.revalued[mint].pending { // closed meanwhile
+			e.revaluedMu.Unlock()
+			return
+		}
+		e.revalued[mint] = revalState{last: time.Now()}
+		e.revaluedMu.Unlock()
+		e.publish(events.PositionUpdated{Mint: mint})
+	})
 }
 
 // GetOpenPositions returns all open positions (safe copies for TUI)
@@ -0,0 +1,1489 @@
+package trading
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/events"
+	"solana-pump-bot/internal/jupiter"
+	signalPkg "solana-pump-bot/internal/signal"
+	"solana-pump-bot/internal/storage"
+	ws "solana-pump-bot/internal/websocket"
+)
+
+func entrySignal(msgID int64) *signalPkg.Signal {
+	return &signalPkg.Signal{
+		Mint:      testMint,
+		TokenName: "TEST",
+		Type:      signalPkg.SignalEntry,
+		Value:     60,
+		Unit:      "%",
+		MsgID:     msgID,
+	}
+}
+
+func TestExecutorFast_BuySuccess(t *testing.T) {
+	h := newTestHarness(t, "")
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(1)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+
+	waitFor(t, "position to be confirmed", func() bool {
+		pos := h.positions.Get(testMint)
+		return pos != nil && pos.GetEntryTxSig() != "PENDING"
+	})
+
+	if got := h.chain.Calls("sendTransaction"); got != 1 {
+		t.Errorf("sendTransaction calls = %d, want 1", got)
+	}
+	if got := h.chain.Calls("swap"); got != 1 {
+		t.Errorf("swap calls = %d, want 1", got)
+	}
+
+	// 10% of the 0.99 SOL above the default 0.01 SOL fee reserve
+	if size := h.positions.Get(testMint).Size; math.Abs(size-0.099) > 1e-12 {
+		t.Errorf("position size = %v, want 0.099", size)
+	}
+}
+
+func TestExecutorFast_ManualBuyUsesGivenAmount(t *testing.T) {
+	h := newTestHarness(t, "")
+
+	if err := h.executor.ManualBuy(context.Background(), testMint, 5); err == nil {
+		t.Fatal("ManualBuy above the 1 SOL balance succeeded")
+	}
+	if err := h.executor.ManualBuy(context.Background(), testMint, 0.05); err != nil {
+		t.Fatalf("ManualBuy: %v", err)
+	}
+	waitFor(t, "position to be confirmed", func() bool {
+		pos := h.positions.Get(testMint)
+		return pos != nil && pos.GetEntryTxSig() != "PENDING"
+	})
+	if size := h.positions.Get(testMint).Size; size != 0.05 {
+		t.Errorf("position size = %v, want 0.05 (not the 10%% alloc)", size)
+	}
+	if err := h.executor.ManualBuy(context.Background(), testMint, 0.05); err == nil {
+		t.Error("second ManualBuy of a held mint succeeded")
+	}
+}
+
+func TestExecutorFast_SellAllPositionsReportsEachResult(t *testing.T) {
+	h := newTestHarness(t, "")
+	h.openPosition(0.1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results := h.executor.SellAllPositions(ctx)
+	if len(results) != 1 || results[0].Mint != testMint || results[0].TokenName != "TEST" || results[0].Err != nil {
+		t.Fatalf("results = %+v, want one successful sell of %s", results, testMint)
+	}
+	if got := h.chain.Calls("sendTransaction"); got != 1 {
+		t.Errorf("sendTransaction calls = %d, want 1", got)
+	}
+
+	// Nothing started once the deadline has passed
+	h.openPosition(0.1)
+	expired, cancelExpired := context.WithCancel(context.Background())
+	cancelExpired()
+	if results := h.executor.SellAllPositions(expired); len(results) != 1 || results[0].Err == nil {
+		t.Errorf("results = %+v, want the sell reported as cancelled", results)
+	}
+}
+
+func TestExecutorFast_SellAllPositionsWaitsForConfirmation(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  sell_confirm_timeout_seconds: 2
+`)
+	h.openPosition(0.1)
+	h.chain.rpcOverride["getSignatureStatuses"] = signatureStatus("processed", nil) // never reaches confirmed
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	results := h.executor.SellAllPositions(ctx)
+	if len(results) != 1 || !results[0].Sent || !errors.Is(results[0].Err, context.DeadlineExceeded) {
+		t.Fatalf("results = %+v, want the sell reported sent but unconfirmed", results)
+	}
+	if h.positions.Get(testMint) == nil {
+		t.Error("position removed before its sell confirmed")
+	}
+
+	// Once the confirmation gives up, a later sell-all says so
+	waitFor(t, "sell to leave flight", func() bool { return h.executor.beginSell(testMint) })
+	h.executor.endSell(testMint)
+	h.chain.rpcOverride["getSignatureStatuses"] = signatureStatus("confirmed", map[string]interface{}{"InstructionError": []interface{}{2, "Custom"}})
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel2()
+	if results := h.executor.SellAllPositions(ctx2); len(results) != 1 || !errors.Is(results[0].Err, errSellNotConfirmed) {
+		t.Errorf("results = %+v, want the reverted sell reported as not confirmed", results)
+	}
+}
+
+func TestExecutorFast_RebuyCooldownSkipsRepeatEntry(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  rebuy_cooldown_seconds: 60
+`)
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(50)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	waitFor(t, "position to be confirmed", func() bool {
+		pos := h.positions.Get(testMint)
+		return pos != nil && pos.GetEntryTxSig() != "PENDING"
+	})
+
+	// Sold (or closed) since: only the cooldown stands between us and a re-buy
+	h.positions.Remove(testMint)
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(51)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+
+	if got := h.chain.Calls("swap"); got != 1 {
+		t.Errorf("swap calls = %d, want 1", got)
+	}
+	want := []IssueCount{{Category: SkipRebuyCooldown, Count: 1}}
+	if got := h.executor.SkipCounts(); len(got) != 1 || got[0] != want[0] {
+		t.Errorf("skip counts = %v, want %v", got, want)
+	}
+}
+
+func TestExecutorFast_ContentDedupSkipsRelayedSignal(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  content_dedup_seconds: 30
+`)
+
+	sent := time.Now().Unix()
+	first, relayed := entrySignal(70), entrySignal(71)
+	first.Timestamp, relayed.Timestamp = sent, sent
+
+	if err := h.executor.ProcessSignalFast(context.Background(), first); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	waitFor(t, "position to be confirmed", func() bool {
+		pos := h.positions.Get(testMint)
+		return pos != nil && pos.GetEntryTxSig() != "PENDING"
+	})
+
+	// Same call forwarded under a new message ID
+	h.positions.Remove(testMint)
+	if err := h.executor.ProcessSignalFast(context.Background(), relayed); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+
+	if got := h.chain.Calls("swap"); got != 1 {
+		t.Errorf("swap calls = %d, want 1", got)
+	}
+	want := []IssueCount{{Category: SkipDuplicate, Count: 1}}
+	if got := h.executor.SkipCounts(); len(got) != 1 || got[0] != want[0] {
+		t.Errorf("skip counts = %v, want %v", got, want)
+	}
+}
+
+func TestExecutorFast_TokenFilterRefusesBuy(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  blacklist: ["$test"]
+`)
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(60)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+
+	if got := h.chain.Calls("swap"); got != 0 {
+		t.Errorf("swap calls = %d, want 0", got)
+	}
+	if h.positions.Get(testMint) != nil {
+		t.Error("blacklisted token was bought")
+	}
+	want := []IssueCount{{Category: SkipBlacklisted, Count: 1}}
+	if got := h.executor.SkipCounts(); len(got) != 1 || got[0] != want[0] {
+		t.Errorf("skip counts = %v, want %v", got, want)
+	}
+}
+
+func TestExecutorFast_PriceImpactRefusesBuy(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  max_price_impact_percent: 15
+`)
+	h.chain.impactPct = "0.4" // 40%
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(61)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+
+	if got := h.chain.Calls("quote"); got != 1 {
+		t.Errorf("quote calls = %d, want 1", got)
+	}
+	if got := h.chain.Calls("swap"); got != 0 {
+		t.Errorf("swap calls = %d, want 0", got)
+	}
+	if h.positions.Get(testMint) != nil {
+		t.Error("high-impact token was bought")
+	}
+	want := []IssueCount{{Category: SkipPriceImpact, Count: 1}}
+	if got := h.executor.SkipCounts(); len(got) != 1 || got[0] != want[0] {
+		t.Errorf("skip counts = %v, want %v", got, want)
+	}
+}
+
+func TestExecutorFast_BuyRetriesAfterSlippageFailure(t *testing.T) {
+	h := newTestHarness(t, "")
+	h.chain.sendErrs = []string{"Transaction simulation failed: custom program error: ExceededSlippage"}
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(2)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+
+	waitFor(t, "position after retry", func() bool {
+		pos := h.positions.Get(testMint)
+		return pos != nil && pos.GetEntryTxSig() != "PENDING"
+	})
+
+	if got := h.chain.Calls("sendTransaction"); got != 2 {
+		t.Errorf("sendTransaction calls = %d, want 2 (1 failure + 1 retry)", got)
+	}
+	if got := h.chain.Calls("swap"); got != 2 {
+		t.Errorf("swap calls = %d, want 2", got)
+	}
+	if got := h.chain.QuoteSlippages(); len(got) != 2 || got[0] != 500 || got[1] != 1000 {
+		t.Errorf("quote slippages = %v, want [500 1000] (retry escalated one step)", got)
+	}
+	_, success, failed, _ := h.executor.GetMetrics().Stats()
+	if success != 1 || failed != 1 {
+		t.Errorf("metrics success/failed = %d/%d, want 1/1", success, failed)
+	}
+
+	counts := h.executor.GetIssues().CountsSince(time.Minute)
+	if len(counts) != 1 || counts[0].Category != blockchain.CategorySlippage || counts[0].Count != 1 {
+		t.Errorf("issue counts = %+v, want 1 slippage", counts)
+	}
+}
+
+func TestExecutorFast_BlockhashFailureRebuildsSwap(t *testing.T) {
+	h := newTestHarness(t, "")
+	h.chain.sendErrs = []string{"Transaction simulation failed: Blockhash not found"}
+	// The rebuilt swap differs from the first in its last message byte
+	const rebuiltSwapTx = "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABAQ=="
+	h.chain.swapTxs = []string{dummySwapTx, rebuiltSwapTx}
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(4)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+
+	waitFor(t, "position after retry", func() bool {
+		pos := h.positions.Get(testMint)
+		return pos != nil && pos.GetEntryTxSig() != "PENDING"
+	})
+
+	if got := h.chain.Calls("swap"); got != 2 {
+		t.Errorf("swap calls = %d, want 2 (stale swap rebuilt, not resent)", got)
+	}
+	if got := h.chain.Calls("sendTransaction"); got != 2 {
+		t.Errorf("sendTransaction calls = %d, want 2", got)
+	}
+
+	// The second swap is fetched between the failed send and the retry...
+	var order []string
+	for _, call := range h.chain.CallLog() {
+		if call == "swap" || call == "sendTransaction" {
+			order = append(order, call)
+		}
+	}
+	if want := []string{"swap", "sendTransaction", "swap", "sendTransaction"}; strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("swap/send order = %v, want %v", order, want)
+	}
+	// ...and the retry sends its bytes, not the stale transaction again
+	sent := h.chain.SentTxs()
+	if len(sent) != 2 {
+		t.Fatalf("sent %d transactions, want 2", len(sent))
+	}
+	first, _ := base64.StdEncoding.DecodeString(sent[0].tx)
+	retry, _ := base64.StdEncoding.DecodeString(sent[1].tx)
+	rebuilt, _ := base64.StdEncoding.DecodeString(rebuiltSwapTx)
+	if bytes.Equal(first, retry) || len(retry) != len(rebuilt) || !bytes.Equal(retry[65:], rebuilt[65:]) {
+		t.Errorf("retry sent message %x, want the rebuilt swap's %x", retry[min(65, len(retry)):], rebuilt[65:])
+	}
+	// ...right away, without the 100ms backoff other failures wait
+	if gap := sent[1].at.Sub(sent[0].at); gap >= 100*time.Millisecond {
+		t.Errorf("retry sent %v after the blockhash error, want no backoff", gap)
+	}
+}
+
+func TestExecutorFast_PreflightSimulationFailureSkipsSend(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  preflight_simulate: true
+`)
+	h.chain.rpcOverride["simulateTransaction"] = func(_ []json.RawMessage) (interface{}, string) {
+		return map[string]interface{}{
+			"context": map[string]interface{}{"slot": 1},
+			"value": map[string]interface{}{
+				"err":  map[string]interface{}{"InstructionError": []interface{}{3, map[string]interface{}{"Custom": 1}}},
+				"logs": []string{"Program JUP6 invoke [1]", "Program log: Error: insufficient funds"},
+			},
+		}, ""
+	}
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(3)); err == nil {
+		t.Error("ProcessSignalFast succeeded, want the simulation error")
+	}
+
+	if got := h.chain.Calls("simulateTransaction"); got == 0 {
+		t.Error("simulateTransaction was never called")
+	}
+	if got := h.chain.Calls("sendTransaction"); got != 0 {
+		t.Errorf("sendTransaction calls = %d, want 0 (simulation failed)", got)
+	}
+	if h.positions.Get(testMint) != nil {
+		t.Error("position kept after every attempt failed simulation")
+	}
+}
+
+func TestExecutorFast_TakeProfitTriggersSell(t *testing.T) {
+	h := newTestHarness(t, "")
+	h.openPosition(0.1)
+
+	// Quote the held tokens back at 2.5x the entry size
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 250_000_000 })
+
+	h.executor.monitorPositions(context.Background())
+
+	waitFor(t, "take-profit sell to remove position", func() bool {
+		return h.positions.Get(testMint) == nil
+	})
+
+	if got := h.chain.Calls("sendTransaction"); got != 1 {
+		t.Errorf("sendTransaction calls = %d, want 1", got)
+	}
+	if _, hits := h.executor.GetStats(); hits != 1 {
+		t.Errorf("2X hits = %d, want 1", hits)
+	}
+}
+
+// signatureStatus returns a getSignatureStatuses override reporting a fixed state
+func signatureStatus(confirmationStatus string, txErr interface{}) func([]json.RawMessage) (interface{}, string) {
+	return func([]json.RawMessage) (interface{}, string) {
+		return map[string]interface{}{
+			"value": []interface{}{map[string]interface{}{
+				"slot":               1,
+				"confirmations":      nil,
+				"err":                txErr,
+				"confirmationStatus": confirmationStatus,
+			}},
+		}, ""
+	}
+}
+
+func TestExecutorFast_RevertedSellKeepsPosition(t *testing.T) {
+	h := newTestHarness(t, "")
+	pos := h.openPosition(0.1)
+	pos.SetStopLossed(true) // as if this were the stop-loss sell
+	h.chain.rpcOverride["getSignatureStatuses"] = signatureStatus("confirmed", map[string]interface{}{"InstructionError": []interface{}{2, "Custom"}})
+
+	if err := h.executor.ForceClose(context.Background(), testMint); err != nil {
+		t.Fatalf("ForceClose: %v", err)
+	}
+
+	waitFor(t, "sell status to be polled", func() bool {
+		return h.chain.Calls("getSignatureStatuses") > 0
+	})
+	waitFor(t, "sell to leave flight", func() bool {
+		return h.executor.beginSell(testMint)
+	})
+	h.executor.endSell(testMint)
+
+	if h.positions.Get(testMint) == nil {
+		t.Fatal("position removed although the sell reverted on-chain")
+	}
+	if pos.IsStopLossed() {
+		t.Error("stop-loss still marked as fired after its sell reverted")
+	}
+}
+
+func TestExecutorFast_SellWaitsForCommitment(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  sell_confirm_commitment: finalized
+  sell_confirm_timeout_seconds: 1
+`)
+	h.openPosition(0.1)
+	h.chain.rpcOverride["getSignatureStatuses"] = signatureStatus("confirmed", nil)
+
+	if err := h.executor.ForceClose(context.Background(), testMint); err != nil {
+		t.Fatalf("ForceClose: %v", err)
+	}
+
+	// A second sell while the first is pending must not hit the chain
+	if err := h.executor.ForceClose(context.Background(), testMint); err != nil {
+		t.Fatalf("ForceClose: %v", err)
+	}
+	if got := h.chain.Calls("sendTransaction"); got != 1 {
+		t.Errorf("sendTransaction calls = %d, want 1", got)
+	}
+
+	waitFor(t, "confirmation timeout", func() bool {
+		if !h.executor.beginSell(testMint) {
+			return false
+		}
+		h.executor.endSell(testMint)
+		return true
+	})
+	if h.positions.Get(testMint) == nil {
+		t.Fatal("position removed before reaching finalized commitment")
+	}
+}
+
+func TestExecutorFast_MaxGiveBackSolSells(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  take_profit_multiple: 10
+  max_give_back_sol: 0.1
+`)
+	pos := h.openPosition(0.1)
+	pos.PeakMultiple = 5 // peaked at 0.5 SOL
+
+	// Now worth 0.35 SOL: 0.15 SOL given back from peak
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 350_000_000 })
+
+	h.executor.monitorPositions(context.Background())
+
+	waitFor(t, "give-back sell to remove position", func() bool {
+		return h.positions.Get(testMint) == nil
+	})
+	if got := h.chain.Calls("sendTransaction"); got != 1 {
+		t.Errorf("sendTransaction calls = %d, want 1", got)
+	}
+}
+
+func TestExecutorFast_TakeProfitCurveSellsPartially(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  take_profit_multiple: 2
+  take_profit_curve:
+    - {multiple: 2, fraction: 0.5}
+    - {multiple: 4, fraction: 1.0}
+`)
+	pos := h.openPosition(0.125)
+
+	// Worth 3x: curve says 75% of the position should be sold
+	var mu sync.Mutex
+	var soldAmounts []uint64
+	h.chain.setQuoteOut(func(in, _ string, amount uint64) uint64 {
+		if in == testMint && amount != 1_000_000 {
+			mu.Lock()
+			soldAmounts = append(soldAmounts, amount)
+			mu.Unlock()
+		}
+		return 375_000_000
+	})
+
+	h.executor.monitorPositions(context.Background())
+
+	if h.positions.Get(testMint) == nil {
+		t.Fatal("position removed; curve should only sell part of it")
+	}
+	if got := pos.GetSoldFraction(); got != 0.75 {
+		t.Errorf("sold fraction = %v, want 0.75", got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(soldAmounts) != 1 || soldAmounts[0] != 750_000 {
+		t.Errorf("partial sell amounts = %v, want [750000]", soldAmounts)
+	}
+}
+
+func TestExecutorFast_ProfitLadderSellsEachLevelOnce(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  take_profit_multiple: 10
+  partial_profit_levels:
+    - {multiple: 1.5, percent: 25}
+    - {multiple: 2, percent: 25}
+    - {multiple: 3, percent: 25}
+`)
+	pos := h.openPosition(0.1)
+
+	var mu sync.Mutex
+	var balance, valueLamports uint64
+	var soldAmounts []uint64
+	h.chain.setQuoteOut(func(in, _ string, amount uint64) uint64 {
+		mu.Lock()
+		defer mu.Unlock()
+		if in == testMint && amount != balance {
+			soldAmounts = append(soldAmounts, amount)
+		}
+		return valueLamports
+	})
+	// price is the token's multiple of entry; the quote scales with what is held
+	step := func(tokens uint64, price float64) {
+		mu.Lock()
+		balance, valueLamports = tokens, uint64(price*1e8*float64(tokens)/1e6)
+		mu.Unlock()
+		h.chain.setTokenBalance(tokens)
+		pos.mu.Lock()
+		pos.LastUpdate = time.Time{} // each step is a fresh monitor pass
+		pos.mu.Unlock()
+		h.executor.monitorPositions(context.Background())
+	}
+
+	// Each rung sells 25% of the original 1,000,000 tokens; 1.5x is seen twice
+	step(1_000_000, 1.2)
+	step(1_000_000, 1.6)
+	step(750_000, 1.7)
+	step(750_000, 2.2)
+	step(500_000, 3.1)
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Percent of remaining balance: allow a token of float truncation
+	if len(soldAmounts) != 3 {
+		t.Fatalf("partial sell amounts = %v, want three sells of ~250000", soldAmounts)
+	}
+	for _, a := range soldAmounts {
+		if a < 249_999 || a > 250_000 {
+			t.Errorf("partial sell amounts = %v, want three sells of ~250000", soldAmounts)
+			break
+		}
+	}
+	if got := pos.GetProfitLevelsHit(); got != 0b111 {
+		t.Errorf("levels hit = %b, want 111", got)
+	}
+	if h.positions.Get(testMint) == nil {
+		t.Error("position removed; the ladder leaves 25% running")
+	}
+}
+
+func TestExecutorFast_BreakEvenStopSellsRemainderAfterPartial(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  take_profit_multiple: 10
+  move_stop_to_break_even_after_partial: true
+  partial_profit_levels:
+    - {multiple: 1.5, percent: 50}
+`)
+	pos := h.openPosition(0.1)
+
+	// 1.6X: the partial fires and arms break-even
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 160_000_000 })
+	h.executor.monitorPositions(context.Background())
+	if !pos.IsBreakEvenArmed() {
+		t.Fatal("break-even not armed after the partial take")
+	}
+	if got := h.chain.Calls("sendTransaction"); got != 1 {
+		t.Fatalf("sendTransaction calls = %d, want 1 (the partial)", got)
+	}
+
+	// Back to entry: no stop-loss is configured, break-even sells the rest
+	pos.LastUpdate = time.Time{}
+	h.chain.setTokenBalance(500_000)
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 50_000_000 })
+	h.executor.monitorPositions(context.Background())
+	if !pos.IsStopLossed() {
+		t.Error("remainder not sold at break-even")
+	}
+	waitFor(t, "break-even sell to remove position", func() bool {
+		return h.positions.Get(testMint) == nil
+	})
+	if got := h.chain.Calls("sendTransaction"); got != 2 {
+		t.Errorf("sendTransaction calls = %d, want 2", got)
+	}
+}
+
+func TestExecutorFast_BreakEvenStopSurvivesRestart(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  take_profit_multiple: 10
+  move_stop_to_break_even_after_partial: true
+  partial_profit_levels:
+    - {multiple: 1.5, percent: 50}
+`)
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	h.executor.db = db
+	h.positions.db = db
+	pos := h.openPosition(0.1)
+
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 160_000_000 })
+	h.executor.monitorPositions(context.Background())
+	if !pos.IsBreakEvenArmed() {
+		t.Fatal("break-even not armed after the partial take")
+	}
+
+	restored := NewPositionTracker(db, 5).Get(testMint)
+	if restored == nil {
+		t.Fatal("position not loaded from DB")
+	}
+	if !restored.IsBreakEvenArmed() {
+		t.Error("break-even stop lost on restart")
+	}
+	if got := EffectiveStopLoss(h.cfg.GetTrading().StopLossFor(testMint, "TEST"), restored.IsBreakEvenArmed()); got != BreakEvenMultiple {
+		t.Errorf("restored stop = %v, want break-even %v", got, BreakEvenMultiple)
+	}
+}
+
+func TestExecutorFast_WSOutageEntersSellOnly(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  min_entry_percent: 50
+websocket:
+  max_downtime_seconds: 30
+  max_rpc_latency_ms: 20
+`)
+	// Never connected; pretend the outage started a minute ago, with RPC slow too
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(40 * time.Millisecond)
+		h.chain.rpcServer.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(slow.Close)
+	h.executor.rpc = blockchain.NewRPCClient(slow.URL, slow.URL, "")
+	h.executor.wsClient = ws.NewClient("ws://127.0.0.1:0", time.Second, time.Second)
+	h.executor.wsDownSince = time.Now().Add(-time.Minute)
+
+	h.executor.checkWSDowntime()
+	if got := h.executor.DegradedMode(); got != DegradedSellOnly {
+		t.Fatalf("degraded mode = %q, want %q", got, DegradedSellOnly)
+	}
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(10)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	if got := h.chain.Calls("sendTransaction"); got != 0 {
+		t.Errorf("sendTransaction calls = %d, want 0 while sell-only", got)
+	}
+
+	h.executor.markWSUp()
+	if got := h.executor.DegradedMode(); got != "" {
+		t.Errorf("degraded mode after reconnect = %q, want normal", got)
+	}
+}
+
+func TestExecutorFast_WSOutageWithFastRPCKeepsTrading(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  min_entry_percent: 50
+websocket:
+  max_downtime_seconds: 30
+  max_rpc_latency_ms: 1000
+`)
+	h.executor.wsClient = ws.NewClient("ws://127.0.0.1:0", time.Second, time.Second)
+	h.executor.wsDownSince = time.Now().Add(-time.Minute)
+
+	h.executor.checkWSDowntime()
+	if got := h.executor.DegradedMode(); got != "" {
+		t.Fatalf("degraded mode = %q, want normal while RPC answers fast", got)
+	}
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(10)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	waitFor(t, "entry buy", func() bool { return h.chain.Calls("sendTransaction") == 1 })
+}
+
+func TestExecutorFast_StartupGraceSkipsEntries(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  min_entry_percent: 50
+  startup_grace_seconds: 60
+`)
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(20)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	if got := h.chain.Calls("sendTransaction"); got != 0 {
+		t.Errorf("sendTransaction calls = %d, want 0 during grace", got)
+	}
+	if entries, _ := h.executor.GetStats(); entries != 1 {
+		t.Errorf("entry signals = %d, want 1 (still counted)", entries)
+	}
+
+	// Grace over: the next entry trades normally
+	h.executor.startedAt = time.Now().Add(-time.Minute)
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(21)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	if got := h.chain.Calls("sendTransaction"); got != 1 {
+		t.Errorf("sendTransaction calls = %d, want 1 after grace", got)
+	}
+}
+
+func TestExecutorFast_MonitorStoresExitEstimate(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  take_profit_multiple: 2
+fees:
+  static_priority_fee_sol: 0.001
+`)
+	pos := h.openPosition(0.1)
+	pos.EntryFeesSol = 0.001
+
+	// Worth 0.08 SOL mid; the fake quote's minimum out is 95% of that
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 80_000_000 })
+
+	h.executor.monitorPositions(context.Background())
+
+	want := 0.076 - 0.001
+	snap := pos.Snapshot()
+	if got := snap.ExitValueSol; math.Abs(got-want) > 1e-9 {
+		t.Errorf("exit estimate = %v, want %v", got, want)
+	}
+	// Gross is -20%; net also pays entry fees and exit slippage/fees
+	if got := snap.NetPnLPercent; math.Abs(got-(-26)) > 1e-6 {
+		t.Errorf("net PnL = %v%%, want -26%%", got)
+	}
+}
+
+func TestExecutorFast_TokenOverrideStopLossSells(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  take_profit_multiple: 2
+  token_overrides:
+    `+testMint+`:
+      take_profit: 5
+      stop_loss: 0.5
+`)
+	pos := h.openPosition(0.1)
+
+	// 2.5X would take profit globally, but the override rides to 5X
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 250_000_000 })
+	h.executor.monitorPositions(context.Background())
+	if got := h.chain.Calls("sendTransaction"); got != 0 {
+		t.Fatalf("sendTransaction calls = %d, want 0 below override take-profit", got)
+	}
+
+	// Down to 0.4X: below the override stop-loss
+	pos.LastUpdate = time.Time{} // let the monitor re-check right away
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 40_000_000 })
+	h.executor.monitorPositions(context.Background())
+
+	waitFor(t, "stop-loss sell to remove position", func() bool {
+		return h.positions.Get(testMint) == nil
+	})
+	if got := h.chain.Calls("sendTransaction"); got != 1 {
+		t.Errorf("sendTransaction calls = %d, want 1", got)
+	}
+}
+
+func TestExecutorFast_GlobalStopLossSells(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  take_profit_multiple: 2
+  stop_loss_percent: 40
+`)
+	pos := h.openPosition(0.1)
+
+	// 0.7X: down 30%, above the stop-loss
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 70_000_000 })
+	h.executor.monitorPositions(context.Background())
+	if got := h.chain.Calls("sendTransaction"); got != 0 {
+		t.Fatalf("sendTransaction calls = %d, want 0 above stop-loss", got)
+	}
+
+	// 0.5X: past -40%
+	pos.LastUpdate = time.Time{}
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 50_000_000 })
+	h.executor.monitorPositions(context.Background())
+	if !pos.IsStopLossed() {
+		t.Error("position not marked as stop-lossed")
+	}
+
+	waitFor(t, "stop-loss sell to remove position", func() bool {
+		return h.positions.Get(testMint) == nil
+	})
+	if got := h.chain.Calls("sendTransaction"); got != 1 {
+		t.Errorf("sendTransaction calls = %d, want 1", got)
+	}
+}
+
+func TestExecutorFast_ExpiredSellStopsPolling(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  sell_confirm_timeout_seconds: 30
+`)
+	h.openPosition(0.1)
+	h.chain.rpcOverride["getSignatureStatuses"] = func(_ []json.RawMessage) (interface{}, string) {
+		return map[string]interface{}{"value": []interface{}{nil}}, ""
+	}
+	// Past the fake swap's lastValidBlockHeight (1000)
+	h.chain.rpcOverride["getBlockHeight"] = func(_ []json.RawMessage) (interface{}, string) {
+		return 1001, ""
+	}
+
+	if err := h.executor.ForceClose(context.Background(), testMint); err != nil {
+		t.Fatalf("ForceClose: %v", err)
+	}
+
+	// Gives up long before the 30s timeout
+	waitFor(t, "expired sell to be abandoned", func() bool {
+		if !h.executor.beginSell(testMint) {
+			return false
+		}
+		h.executor.endSell(testMint)
+		return true
+	})
+	if h.positions.Get(testMint) == nil {
+		t.Fatal("position removed although the sell expired")
+	}
+	issues := h.executor.GetIssues().Recent(1)
+	if len(issues) != 1 || issues[0].Category != blockchain.CategoryBlockhash {
+		t.Errorf("issues = %+v, want one blockhash issue", issues)
+	}
+}
+
+func TestExecutorFast_IgnoredMintNotTraded(t *testing.T) {
+	h := newTestHarness(t, "")
+
+	sig := entrySignal(30)
+	sig.Mint = jupiter.SOLMint // WSOL is in the default ignored set
+	if err := h.executor.ProcessSignalFast(context.Background(), sig); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	if got := h.chain.Calls("swap"); got != 0 {
+		t.Errorf("swap calls = %d, want 0 for an ignored mint", got)
+	}
+	if h.positions.Get(jupiter.SOLMint) != nil {
+		t.Error("position opened for an ignored mint")
+	}
+
+	// Replaying the same message counts as a duplicate, not another ignored mint
+	h.executor.ProcessSignalFast(context.Background(), sig)
+	want := []IssueCount{{Category: SkipDuplicate, Count: 1}, {Category: SkipIgnoredMint, Count: 1}}
+	if got := h.executor.SkipCounts(); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("skip counts = %v, want %v", got, want)
+	}
+
+	// A token that never resolved to a mint is skipped with its own reason
+	unresolved := entrySignal(301)
+	unresolved.Mint = ""
+	h.executor.ProcessSignalFast(context.Background(), unresolved)
+	if got := h.executor.SkipCounts(); len(got) != 3 || got[2] != (IssueCount{Category: SkipUnresolved, Count: 1}) {
+		t.Errorf("skip counts = %v, want %s counted", got, SkipUnresolved)
+	}
+}
+
+func TestExecutorFast_PublishesBuyEvents(t *testing.T) {
+	h := newTestHarness(t, "")
+	bus := events.NewBus(32)
+	h.executor.SetEventBus(bus)
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(40)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	waitFor(t, "buy event", func() bool {
+		for _, env := range bus.History() {
+			if ev, ok := env.Event.(events.TradeExecuted); ok && ev.Side == "BUY" {
+				return true
+			}
+		}
+		return false
+	})
+
+	var opened bool
+	for _, env := range bus.History() {
+		if ev, ok := env.Event.(events.PositionUpdated); ok && ev.Mint == testMint && !ev.Closed {
+			opened = true
+		}
+	}
+	if !opened {
+		t.Error("no PositionUpdated event for the new position")
+	}
+}
+
+func TestExecutorFast_ThrottlesRevaluationEvents(t *testing.T) {
+	h := newTestHarness(t, "")
+	bus := events.NewBus(64)
+	h.executor.SetEventBus(bus)
+
+	revaluations := func() int {
+		n := 0
+		for _, env := range bus.History() {
+			if ev, ok := env.Event.(events.PositionUpdated); ok && ev.Mint == testMint && !ev.Closed {
+				n++
+			}
+		}
+		return n
+	}
+	for i := 0; i < 20; i++ {
+		h.executor.publishRevalued(testMint)
+	}
+	if got := revaluations(); got != 1 {
+		t.Fatalf("revaluation events = %d right after a burst, want 1", got)
+	}
+	// The burst's latest value still goes out once the throttle passes
+	waitFor(t, "trailing revaluation event", func() bool { return revaluations() == 2 })
+}
+
+func TestExecutorFast_FullBalanceSellRetriesReduced(t *testing.T) {
+	h := newTestHarness(t, "")
+	h.openPosition(0.1)
+	h.chain.setTokenBalance(1_000_000)
+	h.chain.sendErrs = []string{"Transaction simulation failed: Error processing Instruction 3: custom program error: 0x1"}
+
+	var mu sync.Mutex
+	var sold []uint64
+	h.chain.setQuoteOut(func(inputMint, _ string, amount uint64) uint64 {
+		if inputMint == testMint {
+			mu.Lock()
+			sold = append(sold, amount)
+			mu.Unlock()
+		}
+		return amount
+	})
+
+	if err := h.executor.ForceClose(context.Background(), testMint); err != nil {
+		t.Fatalf("ForceClose: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(sold) != 2 || sold[0] != 1_000_000 || sold[1] != 999_000 {
+		t.Errorf("sell amounts = %v, want [1000000 999000] (default factor 0.999)", sold)
+	}
+}
+
+func TestExecutorFast_AdoptOrphansSkipsDustAndCaps(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  max_open_positions: 5
+  adopt_orphans_on_startup: true
+  max_adopt_positions: 1
+  min_adopt_value_sol: 0.01
+`)
+	const dustMint = "DustMint111111111111111111111111111111111111"
+	const bigMint = "BigMint1111111111111111111111111111111111111"
+	h.chain.rpcOverride["getTokenAccountsByOwner"] = func(params []json.RawMessage) (interface{}, string) {
+		if !strings.Contains(string(params[1]), blockchain.TokenProgramID) {
+			return map[string]interface{}{"value": []interface{}{}}, ""
+		}
+		return map[string]interface{}{"value": []interface{}{
+			tokenAccountJSON("Acc1", testMint, 20_000_000),           // 0.02 SOL
+			tokenAccountJSON("Acc2", dustMint, 1_000),                // dust
+			tokenAccountJSON("Acc3", bigMint, 50_000_000),            // 0.05 SOL
+			tokenAccountJSON("Acc4", jupiter.SOLMint, 1_000_000_000), // ignored
+		}}, ""
+	}
+
+	if got := h.executor.AdoptOrphans(context.Background()); got != 1 {
+		t.Fatalf("adopted = %d, want 1 (cap)", got)
+	}
+	pos := h.positions.Get(bigMint)
+	if pos == nil {
+		t.Fatal("most valuable holding not adopted")
+	}
+	if pos.EntryTxSig != AdoptedTxSig {
+		t.Errorf("EntryTxSig = %q, want %q", pos.EntryTxSig, AdoptedTxSig)
+	}
+	if h.positions.Get(dustMint) != nil || h.positions.Get(jupiter.SOLMint) != nil {
+		t.Error("dust or ignored mint adopted")
+	}
+}
+
+func TestExecutorFast_LiquidityPullSells(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_liquidity_drop_percent: 50
+  liquidity_exit_slippage_bps: 3000
+`)
+	pos := h.openPosition(0.1)
+
+	h.executor.handleRealTimePriceUpdate(ws.PriceUpdate{Mint: testMint, PoolReserves: ws.PoolReserves{QuoteReserve: 100_000_000_000}})
+	if got := h.chain.Calls("swap"); got != 0 {
+		t.Fatalf("swap calls = %d after a healthy pool update, want 0", got)
+	}
+	if pos.EntryPoolSol != 100 {
+		t.Errorf("EntryPoolSol = %v, want 100", pos.EntryPoolSol)
+	}
+
+	h.executor.handleRealTimePriceUpdate(ws.PriceUpdate{Mint: testMint, PoolReserves: ws.PoolReserves{QuoteReserve: 20_000_000_000}})
+	waitFor(t, "emergency sell", func() bool { return h.chain.Calls("sendTransaction") == 1 })
+	if got := h.executor.slippageFor(testMint); got != 3000 {
+		t.Errorf("exit slippage = %d bps, want 3000", got)
+	}
+}
+
+func TestExecutorFast_StreamedPricesRunEveryExit(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  take_profit_multiple: 10
+  stop_loss_percent: 40
+  partial_profit_levels:
+    - {multiple: 2, percent: 50}
+`)
+	pos := h.openPosition(0.1)
+	h.chain.setTokenBalance(1_000_000)
+	pos.SetTokenBalance(1_000_000)
+
+	// A busy pool: a price every few ms, as the multiple of entry in price
+	var mu sync.Mutex
+	price := 1.0
+	setPrice := func(p float64) { mu.Lock(); price = p; mu.Unlock() }
+	h.chain.setQuoteOut(func(_, _ string, amount uint64) uint64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return uint64(price * 1e8 * float64(amount) / 1e6) // quotes agree with the pool
+	})
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(2 * time.Millisecond):
+			}
+			mu.Lock()
+			p := price
+			mu.Unlock()
+			// 1 whole token (6 decimals) cost 0.1 SOL
+			h.executor.handleRealTimePriceUpdate(ws.PriceUpdate{
+				Mint:         testMint,
+				PriceSOL:     p * 0.1,
+				Decimals:     6,
+				PoolReserves: ws.PoolReserves{QuoteReserve: 100_000_000_000},
+			})
+		}
+	}()
+	t.Cleanup(func() { close(stop); <-done })
+
+	// The monitor still quotes the position between streamed prices
+	time.Sleep(20 * time.Millisecond)
+	h.executor.monitorPositions(context.Background())
+	if got := h.chain.Calls("quote"); got == 0 {
+		t.Error("monitor skipped a position on a streaming pool")
+	}
+
+	setPrice(2.2)
+	waitFor(t, "ladder rung on a streamed price", func() bool { return pos.GetProfitLevelsHit() == 1 })
+	if got := h.chain.Calls("swap"); got != 1 {
+		t.Errorf("swap calls = %d after the rung, want 1 partial sell", got)
+	}
+	pos.SetTokenBalance(500_000)
+
+	setPrice(0.5)
+	waitFor(t, "stop-loss on a streamed price", pos.IsStopLossed)
+}
+
+func TestExecutorFast_WarmQuoteSkipsQuoting(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+tokens:
+  watchlist: [TEST]
+  warm_quote_ttl_ms: 5000
+`)
+	resolve := func(string) (string, error) { return testMint, nil }
+	h.executor.refreshWarm(context.Background(), resolve)
+	if got := h.chain.Calls("quote"); got != 1 {
+		t.Fatalf("quote calls after warm-up = %d, want 1", got)
+	}
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(50)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	waitFor(t, "position to be confirmed", func() bool {
+		pos := h.positions.Get(testMint)
+		return pos != nil && pos.GetEntryTxSig() != "PENDING"
+	})
+	if got := h.chain.Calls("quote"); got != 1 {
+		t.Errorf("quote calls = %d, want 1 (buy used the warm quote)", got)
+	}
+	if got := h.chain.Calls("swap"); got != 1 {
+		t.Errorf("swap calls = %d, want 1", got)
+	}
+}
+
+func TestExecutorFast_RecordsRealizedPnLFromBalanceChange(t *testing.T) {
+	// The buy cost 0.102 SOL (fees included), the sell brought in 0.15 SOL.
+	// After a half partial take the sell only closes half of what was bought.
+	for _, tc := range []struct {
+		soldFraction float64
+		want         float64
+	}{
+		{0, 0.048},
+		{0.5, 0.099},
+	} {
+		t.Run(fmt.Sprintf("sold %.1f", tc.soldFraction), func(t *testing.T) {
+			h := newTestHarness(t, "")
+			db, err := storage.NewDB(filepath.Join(t.TempDir(), "trades.db"))
+			if err != nil {
+				t.Fatalf("NewDB: %v", err)
+			}
+			t.Cleanup(func() { db.Close() })
+			h.executor.db = db
+			h.openPosition(0.1).SetCurveSold(tc.soldFraction)
+
+			owner := h.wallet.Address()
+			deltas := map[string][2]uint64{
+				"FakeEntrySig": {1_000_000_000, 898_000_000},
+			}
+			h.chain.rpcOverride["getTransaction"] = func(params []json.RawMessage) (interface{}, string) {
+				var sig string
+				json.Unmarshal(params[0], &sig)
+				balances, ok := deltas[sig]
+				if !ok {
+					balances = [2]uint64{898_000_000, 1_048_000_000} // any other sig is the sell
+				}
+				return map[string]interface{}{
+					"slot": 1,
+					"transaction": map[string]interface{}{
+						"message": map[string]interface{}{
+							"accountKeys": []interface{}{map[string]interface{}{"pubkey": owner, "signer": true}},
+						},
+					},
+					"meta": map[string]interface{}{
+						"fee":          5000,
+						"preBalances":  []uint64{balances[0]},
+						"postBalances": []uint64{balances[1]},
+						"err":          nil,
+					},
+				}, ""
+			}
+
+			if err := h.executor.ForceClose(context.Background(), testMint); err != nil {
+				t.Fatalf("ForceClose: %v", err)
+			}
+
+			var realized float64
+			waitFor(t, "realized PnL on the sell trade", func() bool {
+				trades, err := db.GetRecentTrades(10)
+				if err != nil || len(trades) == 0 {
+					return false
+				}
+				realized = trades[0].RealizedPnLSol
+				return realized != 0
+			})
+			if math.Abs(realized-tc.want) > 1e-9 {
+				t.Errorf("realized PnL = %v SOL, want %v", realized, tc.want)
+			}
+		})
+	}
+}
+
+func TestExecutorFast_ApplyConfigUpdatesPositionLimit(t *testing.T) {
+	h := newTestHarness(t, "")
+	h.cfg.SetOnChange(h.executor.ApplyConfig)
+	h.openPosition(0.1)
+	if !h.positions.CanOpen() {
+		t.Fatal("CanOpen = false with 1 of 5 positions open")
+	}
+
+	if err := h.cfg.Update(func(c *config.Config) {
+		c.Trading.MaxOpenPositions = 1
+		c.Jupiter.SlippageBps = 900
+	}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if h.positions.CanOpen() {
+		t.Error("CanOpen = true after lowering max_open_positions to 1")
+	}
+	if got := h.jupiter.SlippageBps(); got != 900 {
+		t.Errorf("jupiter slippage = %d, want 900", got)
+	}
+}
+
+func TestExecutorFast_DailyLossCapDisablesAutoTrading(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  max_daily_loss_sol: 0.05
+`)
+	// Three confirmed sells losing 0.02 SOL each (no db: quote-based PnL)
+	losing := &Position{Mint: testMint, TokenName: "TEST", Size: 0.04, PnLPercent: -50}
+	for i := 1; i <= 3; i++ {
+		h.executor.accountSell(losing, "LossSig"+strconv.Itoa(i))
+		enabled := h.cfg.GetTrading().AutoTradingEnabled
+		if i < 3 && !enabled {
+			t.Fatalf("auto-trading off after %d sells (%.3f SOL), cap is 0.05", i, h.executor.GetDailyPnL())
+		}
+		if i == 3 && enabled {
+			t.Errorf("auto-trading still on at %.3f SOL, cap is 0.05", h.executor.GetDailyPnL())
+		}
+	}
+	if got := h.executor.GetDailyPnL(); got > -0.0599 || got < -0.0601 {
+		t.Errorf("daily PnL = %v, want -0.06", got)
+	}
+
+	// A later profit doesn't switch it back on; new buys are skipped
+	h.executor.accountSell(&Position{Mint: testMint, TokenName: "TEST", Size: 0.04, PnLPercent: 100}, "WinSig")
+	if h.cfg.GetTrading().AutoTradingEnabled {
+		t.Error("auto-trading back on after a profit")
+	}
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(77)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	if got := h.chain.Calls("sendTransaction"); got != 0 {
+		t.Errorf("sendTransaction calls = %d, want 0 (auto-trading off)", got)
+	}
+	if counts := h.executor.SkipCounts(); len(counts) != 1 || counts[0].Category != SkipAutoTradingOff {
+		t.Errorf("skip counts = %v, want one %s", counts, SkipAutoTradingOff)
+	}
+
+	// The window starts over at UTC midnight
+	var d dailyPnL
+	d.add(-1, time.Date(2024, 5, 1, 23, 59, 0, 0, time.UTC))
+	if got := d.add(-0.5, time.Date(2024, 5, 2, 0, 1, 0, 0, time.UTC)); got != -0.5 {
+		t.Errorf("total after midnight = %v, want -0.5", got)
+	}
+}
+
+func TestExecutorFast_DailyLossSeededFromTodaysTrades(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_daily_loss_sol: 0.05
+`)
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "trades.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	now := time.Now().Unix()
+	for _, tr := range []*storage.Trade{
+		{Mint: testMint, Side: "SELL", Timestamp: now, RealizedPnLSol: -0.04},
+		{Mint: testMint, Side: "SELL", Timestamp: now, ExitTxSig: "QuoteOnlySig"},
+		{Mint: testMint, Side: "SELL", Timestamp: now, RealizedPnLSol: -1, Paper: true},
+		{Mint: testMint, Side: "SELL", Timestamp: now - 2*86400, RealizedPnLSol: -1},
+	} {
+		if err := db.InsertTrade(tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// No on-chain figure for this one: its quote-based PnL was stored instead
+	if err := db.SetQuotePnL("QuoteOnlySig", -0.02); err != nil {
+		t.Fatal(err)
+	}
+
+	h.executor.seedDailyPnL(db)
+	if got := h.executor.GetDailyPnL(); math.Abs(got-(-0.06)) > 1e-9 {
+		t.Errorf("seeded daily PnL = %v, want -0.06 (today's real sells, quote-based included)", got)
+	}
+	if h.cfg.GetTrading().AutoTradingEnabled {
+		t.Error("auto-trading still on after restarting over the cap")
+	}
+}
+
+func TestExecutorFast_MonitorBatchesBalanceReads(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  take_profit_multiple: 100
+  requote_unchanged_seconds: 60
+`)
+	mints := []string{
+		"MintA111111111111111111111111111111111111111",
+		"MintB111111111111111111111111111111111111111",
+		"MintC111111111111111111111111111111111111111",
+	}
+	for _, mint := range mints {
+		h.positions.Add(&Position{Mint: mint, TokenName: mint[:5], Size: 0.1, EntryTime: time.Now(), EntryTxSig: "FakeEntrySig"})
+	}
+	// Each mint sits in its own token account, "Acc-<mint>"
+	h.chain.rpcOverride["getTokenAccountsByOwner"] = func(params []json.RawMessage) (interface{}, string) {
+		var filter map[string]string
+		json.Unmarshal(params[1], &filter)
+		return map[string]interface{}{"value": []interface{}{tokenAccountJSON("Acc-"+filter["mint"], filter["mint"], 1_000_000)}}, ""
+	}
+	var batched [][]string
+	h.chain.rpcOverride["getMultipleAccounts"] = func(params []json.RawMessage) (interface{}, string) {
+		var pubkeys []string
+		json.Unmarshal(params[0], &pubkeys)
+		batched = append(batched, pubkeys)
+		value := make([]interface{}, len(pubkeys))
+		for i, acc := range pubkeys {
+			value[i] = tokenAccountJSON(acc, strings.TrimPrefix(acc, "Acc-"), 1_000_000)["account"]
+		}
+		return map[string]interface{}{"value": value}, ""
+	}
+	stale := func() {
+		for _, pos := range h.positions.GetAll() {
+			pos.mu.Lock()
+			pos.LastUpdate = time.Now().Add(-10 * time.Second) // past the 2s WebSocket skip
+			pos.mu.Unlock()
+		}
+	}
+
+	// First pass learns each mint's account
+	h.executor.monitorPositions(context.Background())
+	if got := h.chain.Calls("getTokenAccountsByOwner"); got != 3 {
+		t.Fatalf("first pass: %d getTokenAccountsByOwner, want 3", got)
+	}
+	if got := h.chain.Calls("quote"); got != 3 {
+		t.Fatalf("first pass: %d quotes, want 3", got)
+	}
+
+	// Later passes read every balance in one call and skip quotes for
+	// unchanged balances within requote_unchanged_seconds
+	stale()
+	h.executor.monitorPositions(context.Background())
+	if got := h.chain.Calls("getTokenAccountsByOwner"); got != 3 {
+		t.Errorf("second pass: %d getTokenAccountsByOwner, want none beyond the first pass", got)
+	}
+	if len(batched) != 1 || len(batched[0]) != 3 {
+		t.Fatalf("second pass batches = %v, want one call with 3 accounts", batched)
+	}
+	if got := h.chain.Calls("quote"); got != 3 {
+		t.Errorf("second pass: %d quotes, want none for unchanged balances", got)
+	}
+
+	// A changed balance is re-quoted
+	h.positions.Get(mints[1]).SetTokenBalance(500_000)
+	stale()
+	h.executor.monitorPositions(context.Background())
+	if got := h.chain.Calls("quote"); got != 4 {
+		t.Errorf("third pass: %d quotes, want 4 (only the changed balance)", got)
+	}
+
+	// A stop-loss needs a fresh price: unchanged balances are re-quoted
+	h.cfg.Update(func(c *config.Config) { c.Trading.StopLossPercent = 40 })
+	h.chain.setQuoteOut(func(_, _ string, _ uint64) uint64 { return 1e8 }) // 1X: no stop fires
+	stale()
+	h.executor.monitorPositions(context.Background())
+	if got := h.chain.Calls("quote"); got != 7 {
+		t.Errorf("pass with a stop-loss: %d quotes, want 7 (every position)", got)
+	}
+}
+
+func TestExecutorFast_SimulationReportsMultiplier(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  min_entry_percent: 50
+  take_profit_multiple: 3
+  simulation_mode: true
+`)
+	h.jupiter.SetSimulation(true, 1.0)
+	h.jupiter.SetSimPrices(map[string]jupiter.SimPrice{testMint: {PriceSOL: 0.00002, Decimals: 9}})
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(1)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	waitFor(t, "simulated position", func() bool { return h.positions.Get(testMint) != nil })
+	pos := h.positions.Get(testMint)
+
+	// 0.099 SOL (10% above the fee reserve) at 0.00002 SOL per 9-decimal token
+	if got, want := h.executor.simTokenBalance(testMint), uint64(4950*1e9); got != want {
+		t.Errorf("simulated holding = %d, want %d", got, want)
+	}
+
+	h.jupiter.SetSimulation(true, 2.5)
+	pos.mu.Lock()
+	pos.LastUpdate = time.Now().Add(-10 * time.Second)
+	pos.mu.Unlock()
+	h.executor.monitorPositions(context.Background())
+
+	if got := pos.GetPeakMultiple(); got < 2.49 || got > 2.51 {
+		t.Errorf("reported multiple = %.3f, want ~2.5 after a 2.5x price move", got)
+	}
+	if h.chain.Calls("quote") != 0 {
+		t.Errorf("simulation made %d Jupiter HTTP quotes, want 0", h.chain.Calls("quote"))
+	}
+}
+
+func TestExecutorFast_SlippageLearnedFromConfirmationNotSend(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+jupiter:
+  adaptive_slippage: true
+`)
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "slippage.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	h.executor.db = db
+	h.openPosition(0.1)
+	// The send is accepted, then the swap reverts on slippage
+	h.chain.rpcOverride["getSignatureStatuses"] = signatureStatus("confirmed", map[string]interface{}{"InstructionError": []interface{}{2, "ExceededSlippage"}})
+
+	if err := h.executor.ForceClose(context.Background(), testMint); err != nil {
+		t.Fatalf("ForceClose: %v", err)
+	}
+	waitFor(t, "sell to leave flight", func() bool {
+		if !h.executor.beginSell(testMint) {
+			return false
+		}
+		h.executor.endSell(testMint)
+		return true
+	})
+
+	rec, err := db.GetMintSlippage(testMint)
+	if err != nil || rec == nil {
+		t.Fatalf("GetMintSlippage = %v, %v; want a record", rec, err)
+	}
+	if rec.Successes != 0 || rec.Failures != 1 {
+		t.Errorf("successes/failures = %d/%d, want 0/1 (the reverted swap, not the accepted send)", rec.Successes, rec.Failures)
+	}
+}
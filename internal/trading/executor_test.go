@@ -0,0 +1,30 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestExecutor_GetTokenBalanceSumsAccounts(t *testing.T) {
+	h := newTestHarness(t, "")
+	e := NewExecutor(h.cfg, h.wallet, h.rpc, h.jupiter, nil, h.positions, h.balance, nil)
+
+	h.chain.rpcOverride["getTokenAccountsByOwner"] = func([]json.RawMessage) (interface{}, string) {
+		return map[string]interface{}{"value": []interface{}{
+			tokenAccountJSON("TokenAcc1", testMint, 700_000),
+			tokenAccountJSON("TokenAcc2", testMint, 300_000),
+		}}, ""
+	}
+	got, err := e.getTokenBalance(context.Background(), testMint)
+	if err != nil || got != 1_000_000 {
+		t.Errorf("getTokenBalance = %d, %v; want 1000000, nil", got, err)
+	}
+
+	h.chain.setTokenBalance(0)
+	delete(h.chain.rpcOverride, "getTokenAccountsByOwner")
+	got, err = e.getTokenBalance(context.Background(), testMint)
+	if err != nil || got != 0 {
+		t.Errorf("getTokenBalance with no accounts = %d, %v; want 0, nil", got, err)
+	}
+}
@@ -0,0 +1,104 @@
+package trading
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"solana-pump-bot/internal/jupiter"
+)
+
+// errTxNotLanded marks a send that never reached the chain (blockhash expired
+// or confirmation timed out without the signature ever being seen). A
+// transaction that landed and reverted is not "unlanded": its fee was enough.
+var errTxNotLanded = errors.New("transaction did not land")
+
+// Priority fee bump policy (fees.priority_bump_*):
+//
+//   - After priority_bump_after consecutive unlanded sends, Jupiter's priority
+//     fee cap is raised by priority_bump_step_lamports, up to
+//     priority_bump_max_lamports.
+//   - After priority_decay_after consecutive landed sends, it steps back down
+//     by one step, until it is back at the base: the fees.dynamic estimate
+//     when enabled, else jupiter.DefaultMaxPriorityLamports.
+//
+// Only sells are confirmed, so only sells feed the streaks; the raised cap
+// applies to every Jupiter swap.
+
+// recordLanding updates the landed/unlanded streaks after a confirmed send attempt
+func (e *ExecutorFast) recordLanding(landed bool) {
+	fc := e.cfg.Get().Fees
+	if fc.PriorityBumpAfter <= 0 || fc.PriorityBumpStepLamports == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	before := e.feeBumpSteps
+	if landed {
+		e.unlandedStreak = 0
+		if e.feeBumpSteps > 0 {
+			e.landedStreak++
+			if e.landedStreak >= fc.PriorityDecayAfter {
+				e.landedStreak = 0
+				e.feeBumpSteps--
+			}
+		}
+	} else {
+		e.landedStreak = 0
+		e.unlandedStreak++
+		if e.unlandedStreak >= fc.PriorityBumpAfter {
+			e.unlandedStreak = 0
+			if priorityFeeCapFrom(e.feeBase, e.feeBumpSteps+1, fc.PriorityBumpStepLamports, fc.PriorityBumpMaxLamports) >
+				priorityFeeCapFrom(e.feeBase, e.feeBumpSteps, fc.PriorityBumpStepLamports, fc.PriorityBumpMaxLamports) {
+				e.feeBumpSteps++
+			}
+		}
+	}
+	steps := e.feeBumpSteps
+	base := e.feeBase
+	e.mu.Unlock()
+
+	if steps == before {
+		return
+	}
+	feeCap := priorityFeeCapFrom(base, steps, fc.PriorityBumpStepLamports, fc.PriorityBumpMaxLamports)
+	e.jupiter.SetMaxPriorityFee(feeCap)
+
+	msg := "📉 priority fee cap decayed"
+	if steps > before {
+		msg = "📈 priority fee cap raised (sends not landing)"
+	}
+	log.Info().
+		Uint64("maxLamports", feeCap).
+		Int("steps", steps).
+		Msg(msg)
+}
+
+// SetPriorityFeeBase sets the cap that bump steps are added to (the
+// fees.dynamic estimate) and applies it to Jupiter right away
+func (e *ExecutorFast) SetPriorityFeeBase(lamports uint64) {
+	fc := e.cfg.Get().Fees
+
+	e.mu.Lock()
+	e.feeBase = lamports
+	steps := e.feeBumpSteps
+	e.mu.Unlock()
+
+	e.jupiter.SetMaxPriorityFee(priorityFeeCapFrom(lamports, steps, fc.PriorityBumpStepLamports, fc.PriorityBumpMaxLamports))
+}
+
+// priorityFeeCapFrom is the Jupiter fee cap after a number of bump steps on
+// top of base (0 = Jupiter default). The bump ceiling never pulls the cap
+// below the base itself.
+func priorityFeeCapFrom(base uint64, steps int, stepLamports, maxLamports uint64) uint64 {
+	if base == 0 {
+		base = jupiter.DefaultMaxPriorityLamports
+	}
+	feeCap := base + uint64(steps)*stepLamports
+	if maxLamports > 0 && feeCap > maxLamports {
+		feeCap = maxLamports
+		if feeCap < base {
+			feeCap = base
+		}
+	}
+	return feeCap
+}
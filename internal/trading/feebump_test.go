@@ -0,0 +1,68 @@
+package trading
+
+import (
+	"testing"
+
+	"solana-pump-bot/internal/jupiter"
+)
+
+func TestRecordLanding_BumpsAndDecaysPriorityFee(t *testing.T) {
+	h := newTestHarness(t, `
+fees:
+  priority_bump_after: 2
+  priority_bump_step_lamports: 1000000
+  priority_bump_max_lamports: 3000000
+  priority_decay_after: 2
+`)
+	e := h.executor
+	base := uint64(jupiter.DefaultMaxPriorityLamports)
+
+	e.recordLanding(false)
+	if got := e.jupiter.MaxPriorityFee(); got != base {
+		t.Fatalf("after 1 unlanded: cap = %d, want %d", got, base)
+	}
+	e.recordLanding(false)
+	if got := e.jupiter.MaxPriorityFee(); got != base+1_000_000 {
+		t.Fatalf("after 2 unlanded: cap = %d, want %d", got, base+1_000_000)
+	}
+
+	// Capped at priority_bump_max_lamports
+	for i := 0; i < 6; i++ {
+		e.recordLanding(false)
+	}
+	if got := e.jupiter.MaxPriorityFee(); got != 3_000_000 {
+		t.Fatalf("after many unlanded: cap = %d, want 3000000", got)
+	}
+
+	// Landed sends walk it back down to the default
+	for i := 0; i < 10; i++ {
+		e.recordLanding(true)
+	}
+	if got := e.jupiter.MaxPriorityFee(); got != base {
+		t.Errorf("after landed sends: cap = %d, want %d", got, base)
+	}
+}
+
+func TestSetPriorityFeeBase_BumpsStackOnDynamicBase(t *testing.T) {
+	h := newTestHarness(t, `
+fees:
+  priority_bump_after: 1
+  priority_bump_step_lamports: 500000
+  priority_bump_max_lamports: 4000000
+`)
+	e := h.executor
+
+	e.SetPriorityFeeBase(2_000_000)
+	if got := e.jupiter.MaxPriorityFee(); got != 2_000_000 {
+		t.Fatalf("cap = %d, want dynamic base 2000000", got)
+	}
+	e.recordLanding(false)
+	if got := e.jupiter.MaxPriorityFee(); got != 2_500_000 {
+		t.Fatalf("after unlanded: cap = %d, want 2500000", got)
+	}
+	// A lower network estimate keeps the bump on top
+	e.SetPriorityFeeBase(1_000_000)
+	if got := e.jupiter.MaxPriorityFee(); got != 1_500_000 {
+		t.Errorf("after lower estimate: cap = %d, want 1500000", got)
+	}
+}
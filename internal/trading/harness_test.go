@@ -0,0 +1,420 @@
+package trading
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mr-tron/base58"
+
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/jupiter"
+)
+
+// dummySwapTx is a minimal one-signer transaction accepted by SignSerializedTransaction
+const dummySwapTx = "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABAA=="
+
+const testMint = "TestMint1111111111111111111111111111111111"
+
+// fakeChain is an in-memory Solana RPC + Jupiter Metis server.
+// Every response is canned and every call is counted so executor
+// flows can be asserted end-to-end without touching the network.
+type fakeChain struct {
+	t *testing.T
+
+	mu    sync.Mutex
+	calls map[string]int
+
+	// RPC state
+	balanceLamports uint64
+	tokenBalance    uint64
+	sendErrs        []string // consumed in order by sendTransaction, "" = success
+	sentTxs         []sentTx // each sendTransaction, in order
+	sigCounter      int
+
+	// Jupiter state
+	quoteOut  func(inputMint, outputMint string, amount uint64) uint64
+	quoteErrs []int    // HTTP status codes consumed in order by /quote, 0 = success
+	impactPct string   // priceImpactPct on every quote (Jupiter's fraction, "0.4" = 40%)
+	slippages []int    // slippageBps of each /quote, in order
+	swapTxs   []string // SwapTransaction of each /swap, consumed in order (then dummySwapTx)
+	callLog   []string // every RPC method and Jupiter route hit, in order
+
+	// Optional per-method RPC overrides (return result, rpc error message)
+	rpcOverride map[string]func(params []json.RawMessage) (interface{}, string)
+
+	rpcServer *httptest.Server
+	jupServer *httptest.Server
+}
+
+func newFakeChain(t *testing.T) *fakeChain {
+	t.Helper()
+	f := &fakeChain{
+		t:               t,
+		calls:           make(map[string]int),
+		balanceLamports: 1_000_000_000,
+		tokenBalance:    1_000_000,
+		rpcOverride:     make(map[string]func(params []json.RawMessage) (interface{}, string)),
+		quoteOut: func(_, _ string, amount uint64) uint64 {
+			return amount
+		},
+		impactPct: "0.01",
+	}
+	f.rpcServer = httptest.NewServer(http.HandlerFunc(f.serveRPC))
+	f.jupServer = httptest.NewServer(http.HandlerFunc(f.serveJupiter))
+	t.Cleanup(func() {
+		f.rpcServer.Close()
+		f.jupServer.Close()
+	})
+	return f
+}
+
+// Calls returns how many times an RPC method or Jupiter route was hit
+func (f *fakeChain) Calls(name string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[name]
+}
+
+// CallLog returns every RPC method and Jupiter route hit so far, in order
+func (f *fakeChain) CallLog() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.callLog...)
+}
+
+// sentTx is one sendTransaction: the base64 transaction and when it arrived
+type sentTx struct {
+	tx string
+	at time.Time
+}
+
+// SentTxs returns every sendTransaction so far, in order
+func (f *fakeChain) SentTxs() []sentTx {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]sentTx(nil), f.sentTxs...)
+}
+
+// QuoteSlippages returns the slippageBps of every quote so far, in order
+func (f *fakeChain) QuoteSlippages() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]int(nil), f.slippages...)
+}
+
+func (f *fakeChain) setTokenBalance(amount uint64) {
+	f.mu.Lock()
+	f.tokenBalance = amount
+	f.mu.Unlock()
+}
+
+func (f *fakeChain) setQuoteOut(fn func(inputMint, outputMint string, amount uint64) uint64) {
+	f.mu.Lock()
+	f.quoteOut = fn
+	f.mu.Unlock()
+}
+
+func (f *fakeChain) serveRPC(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     int               `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	f.calls[req.Method]++
+	f.callLog = append(f.callLog, req.Method)
+	override := f.rpcOverride[req.Method]
+	f.mu.Unlock()
+
+	var result interface{}
+	var rpcErr string
+
+	if override != nil {
+		result, rpcErr = override(req.Params)
+	} else {
+		result, rpcErr = f.defaultRPC(req.Method, req.Params)
+	}
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if rpcErr != "" {
+		resp["error"] = map[string]interface{}{"code": -32002, "message": rpcErr}
+	} else {
+		resp["result"] = result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeChain) defaultRPC(method string, params []json.RawMessage) (interface{}, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch method {
+	case "getLatestBlockhash":
+		return map[string]interface{}{
+			"value": map[string]interface{}{
+				"blockhash":            "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N",
+				"lastValidBlockHeight": 1000,
+			},
+		}, ""
+	case "getBalance":
+		return map[string]interface{}{"value": f.balanceLamports}, ""
+	case "getBlockHeight":
+		return 900, "" // below the fake swap's lastValidBlockHeight
+	case "getTokenAccountsByOwner":
+		if f.tokenBalance == 0 {
+			return map[string]interface{}{"value": []interface{}{}}, ""
+		}
+		return map[string]interface{}{"value": []interface{}{tokenAccountJSON("TokenAcc1", testMint, f.tokenBalance)}}, ""
+	case "getMultipleAccounts":
+		// Every requested account holds testMint; all are closed at 0 tokens
+		var pubkeys []string
+		if len(params) > 0 {
+			json.Unmarshal(params[0], &pubkeys)
+		}
+		value := make([]interface{}, len(pubkeys))
+		for i := range pubkeys {
+			if f.tokenBalance > 0 {
+				value[i] = tokenAccountJSON("", testMint, f.tokenBalance)["account"]
+			}
+		}
+		return map[string]interface{}{"value": value}, ""
+	case "sendTransaction":
+		var tx string
+		if len(params) > 0 {
+			json.Unmarshal(params[0], &tx)
+		}
+		f.sentTxs = append(f.sentTxs, sentTx{tx: tx, at: time.Now()})
+		if len(f.sendErrs) > 0 {
+			next := f.sendErrs[0]
+			f.sendErrs = f.sendErrs[1:]
+			if next != "" {
+				return nil, next
+			}
+		}
+		f.sigCounter++
+		return fmt.Sprintf("FakeSig%040d", f.sigCounter), ""
+	case "getSignatureStatuses":
+		return map[string]interface{}{
+			"value": []interface{}{map[string]interface{}{
+				"slot":               1,
+				"confirmations":      nil,
+				"err":                nil,
+				"confirmationStatus": "finalized",
+			}},
+		}, ""
+	}
+	return nil, "method not found: " + method
+}
+
+func tokenAccountJSON(pubkey, mint string, amount uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"pubkey": pubkey,
+		"account": map[string]interface{}{
+			"data": map[string]interface{}{
+				"parsed": map[string]interface{}{
+					"info": map[string]interface{}{
+						"mint": mint,
+						"tokenAmount": map[string]interface{}{
+							"amount":   fmt.Sprintf("%d", amount),
+							"decimals": 6,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (f *fakeChain) serveJupiter(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/quote"):
+		f.mu.Lock()
+		f.calls["quote"]++
+		f.callLog = append(f.callLog, "quote")
+		status := 0
+		if len(f.quoteErrs) > 0 {
+			status = f.quoteErrs[0]
+			f.quoteErrs = f.quoteErrs[1:]
+		}
+		quoteOut := f.quoteOut
+		impactPct := f.impactPct
+		bps, _ := strconv.Atoi(r.URL.Query().Get("slippageBps"))
+		f.slippages = append(f.slippages, bps)
+		f.mu.Unlock()
+
+		if status != 0 {
+			http.Error(w, `{"error":"fake failure"}`, status)
+			return
+		}
+
+		q := r.URL.Query()
+		var amount uint64
+		fmt.Sscanf(q.Get("amount"), "%d", &amount)
+		out := quoteOut(q.Get("inputMint"), q.Get("outputMint"), amount)
+		json.NewEncoder(w).Encode(jupiter.QuoteResponse{
+			InputMint:            q.Get("inputMint"),
+			InAmount:             q.Get("amount"),
+			OutputMint:           q.Get("outputMint"),
+			OutAmount:            fmt.Sprintf("%d", out),
+			OtherAmountThreshold: fmt.Sprintf("%d", out*95/100),
+			SwapMode:             "ExactIn",
+			PriceImpactPct:       impactPct,
+		})
+
+	case strings.HasSuffix(r.URL.Path, "/swap"):
+		f.mu.Lock()
+		f.calls["swap"]++
+		f.callLog = append(f.callLog, "swap")
+		swapTx := dummySwapTx
+		if len(f.swapTxs) > 0 {
+			swapTx = f.swapTxs[0]
+			f.swapTxs = f.swapTxs[1:]
+		}
+		f.mu.Unlock()
+		json.NewEncoder(w).Encode(jupiter.SwapResponse{
+			SwapTransaction:      swapTx,
+			LastValidBlockHeight: 1000,
+		})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// testConfigYAML is the baseline config written for each harness instance
+const testConfigYAML = `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  max_open_positions: 5
+  min_entry_percent: 50
+  take_profit_multiple: 2
+  simulation_mode: false
+jupiter:
+  slippage_bps: 500
+  timeout_seconds: 5
+`
+
+const testRPCYAML = `
+rpc:
+  shyft_url: http://127.0.0.1:0
+`
+
+// newTestConfig writes yaml (or the baseline) to a temp file and loads it
+func newTestConfig(t *testing.T, yaml string) *config.Manager {
+	t.Helper()
+	if yaml == "" {
+		yaml = testConfigYAML
+	}
+	if !strings.Contains(yaml, "\nrpc:") {
+		yaml += testRPCYAML // the harness talks to fakeChain; this only satisfies validation
+	}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := config.NewManager(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	return cfg
+}
+
+// testHarness wires a real ExecutorFast to a fakeChain
+type testHarness struct {
+	chain     *fakeChain
+	cfg       *config.Manager
+	wallet    *blockchain.Wallet
+	rpc       *blockchain.RPCClient
+	jupiter   *jupiter.Client
+	positions *PositionTracker
+	balance   *blockchain.BalanceTracker
+	executor  *ExecutorFast
+}
+
+func newTestHarness(t *testing.T, yaml string) *testHarness {
+	t.Helper()
+	chain := newFakeChain(t)
+	cfg := newTestConfig(t, yaml)
+
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	wallet, err := blockchain.NewWallet(base58.Encode(priv))
+	if err != nil {
+		t.Fatalf("wallet: %v", err)
+	}
+
+	rpc := blockchain.NewRPCClient(chain.rpcServer.URL, chain.rpcServer.URL, "")
+	jup := jupiter.NewClient("", cfg.Get().Jupiter.SlippageBps, 5*time.Second)
+	jup.SetBaseURL(chain.jupServer.URL)
+
+	txBuilder := blockchain.NewTransactionBuilder(wallet, nil, 0)
+	balance := blockchain.NewBalanceTracker(wallet, rpc)
+	if err := balance.Refresh(context.Background()); err != nil {
+		t.Fatalf("balance refresh: %v", err)
+	}
+	positions := NewPositionTracker(nil, cfg.GetTrading().MaxOpenPositions)
+
+	executor := NewExecutorFast(cfg, wallet, rpc, jup, txBuilder, positions, balance, nil)
+
+	return &testHarness{
+		chain:     chain,
+		cfg:       cfg,
+		wallet:    wallet,
+		rpc:       rpc,
+		jupiter:   jup,
+		positions: positions,
+		balance:   balance,
+		executor:  executor,
+	}
+}
+
+// openPosition seeds a confirmed position of sizeSol SOL for testMint
+func (h *testHarness) openPosition(sizeSol float64) *Position {
+	pos := &Position{
+		Mint:       testMint,
+		TokenName:  "TEST",
+		Size:       sizeSol,
+		EntryValue: 50,
+		EntryUnit:  "%",
+		EntryTime:  time.Now(),
+		EntryTxSig: "FakeEntrySig",
+	}
+	h.positions.Add(pos)
+	return pos
+}
+
+// waitFor polls cond until it returns true or the deadline passes
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
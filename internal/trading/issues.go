@@ -0,0 +1,119 @@
+package trading
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"solana-pump-bot/internal/blockchain"
+)
+
+// Issue is a single categorized execution failure
+type Issue struct {
+	Time     time.Time
+	Stage    string // "buy", "sell", "partial_sell"
+	Category string // blockchain.Category*
+	Message  string
+}
+
+// IssueCount is the number of issues in a category
+type IssueCount struct {
+	Category string
+	Count    int
+}
+
+// IssueLog keeps the last N execution failures in a ring buffer
+type IssueLog struct {
+	items    []Issue
+	next     int
+	full     bool
+	onRecord func(Issue) // Optional hook (e.g. event bus), called outside the lock
+	mu       sync.Mutex
+}
+
+// NewIssueLog creates a ring buffer holding up to size issues
+func NewIssueLog(size int) *IssueLog {
+	if size <= 0 {
+		size = 100
+	}
+	return &IssueLog{items: make([]Issue, size)}
+}
+
+// Record categorizes err via ParseTxError and stores it
+func (l *IssueLog) Record(stage string, err error) {
+	if err == nil {
+		return
+	}
+	txErr := blockchain.ParseTxError(err)
+	issue := Issue{
+		Time:     time.Now(),
+		Stage:    stage,
+		Category: txErr.Category,
+		Message:  txErr.Message,
+	}
+
+	l.mu.Lock()
+	l.items[l.next] = issue
+	l.next = (l.next + 1) % len(l.items)
+	if l.next == 0 {
+		l.full = true
+	}
+	hook := l.onRecord
+	l.mu.Unlock()
+
+	if hook != nil {
+		hook(issue)
+	}
+}
+
+// SetOnRecord registers a hook called for every recorded issue
+func (l *IssueLog) SetOnRecord(fn func(Issue)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onRecord = fn
+}
+
+// Recent returns up to n issues, newest first
+func (l *IssueLog) Recent(n int) []Issue {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	size := l.next
+	if l.full {
+		size = len(l.items)
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+
+	out := make([]Issue, 0, n)
+	for i := 1; i <= n; i++ {
+		idx := (l.next - i + len(l.items)) % len(l.items)
+		out = append(out, l.items[idx])
+	}
+	return out
+}
+
+// CountsSince returns per-category counts for issues newer than window, highest first
+func (l *IssueLog) CountsSince(window time.Duration) []IssueCount {
+	cutoff := time.Now().Add(-window)
+	counts := make(map[string]int)
+	for _, is := range l.Recent(0) {
+		if is.Time.Before(cutoff) {
+			break // Recent is newest first
+		}
+		counts[is.Category]++
+	}
+
+	out := make([]IssueCount, 0, len(counts))
+	for cat, n := range counts {
+		out = append(out, IssueCount{Category: cat, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Category < out[j].Category
+	})
+	return out
+}
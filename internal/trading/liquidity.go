@@ -0,0 +1,75 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/rs/zerolog/log"
+
+	signalPkg "solana-pump-bot/internal/signal"
+	ws "solana-pump-bot/internal/websocket"
+)
+
+// poolSol converts a pool's quote (SOL) reserve to SOL. Decoders that don't
+// fill in QuoteDecimals get the native SOL decimals.
+func poolSol(reserves ws.PoolReserves) float64 {
+	decimals := reserves.QuoteDecimals
+	if decimals == 0 {
+		decimals = 9
+	}
+	return float64(reserves.QuoteReserve) / math.Pow10(decimals)
+}
+
+// liquidityExitReason returns why the pool looks rugged ("" if it doesn't):
+// the SOL reserve is under trading.min_pool_liquidity_sol, or it fell more
+// than trading.max_liquidity_drop_percent below the first reserve seen for
+// the position.
+func liquidityExitReason(poolSol, entryPoolSol, floorSol, maxDropPercent float64) string {
+	if floorSol > 0 && poolSol < floorSol {
+		return fmt.Sprintf("pool %.2f SOL below floor %.2f SOL", poolSol, floorSol)
+	}
+	if maxDropPercent > 0 && entryPoolSol > 0 {
+		if drop := (entryPoolSol - poolSol) / entryPoolSol * 100; drop > maxDropPercent {
+			return fmt.Sprintf("pool down %.0f%% since entry (%.2f -> %.2f SOL)", drop, entryPoolSol, poolSol)
+		}
+	}
+	return ""
+}
+
+// checkPoolLiquidity runs on every decoded pool update. A liquidity pull is
+// the strongest rug signal and shows up in the reserves before the price
+// fully reflects it, so the position is sold at once with the wider
+// trading.liquidity_exit_slippage_bps rather than waiting for stop-loss.
+func (e *ExecutorFast) checkPoolLiquidity(pos *Position, reserves ws.PoolReserves) {
+	cfg := e.cfg.GetTrading()
+	if cfg.MinPoolLiquiditySol <= 0 && cfg.MaxLiquidityDropPercent <= 0 {
+		return
+	}
+
+	current := poolSol(reserves)
+	entry := pos.ObservePoolLiquidity(current)
+	reason := liquidityExitReason(current, entry, cfg.MinPoolLiquiditySol, cfg.MaxLiquidityDropPercent)
+	if reason == "" || !cfg.AutoTradingEnabled || cfg.IsIgnoredMint(pos.Mint) {
+		return
+	}
+
+	log.Error().
+		Str("token", pos.TokenName).
+		Str("mint", pos.Mint).
+		Float64("poolSol", current).
+		Float64("entryPoolSol", entry).
+		Int("slippageBps", cfg.LiquidityExitSlippageBps).
+		Str("reason", reason).
+		Msg("🚨 LIQUIDITY PULLED - EMERGENCY SELL")
+
+	if cfg.LiquidityExitSlippageBps > 0 {
+		pos.SetExitSlippageBps(cfg.LiquidityExitSlippageBps)
+	}
+	sig := &signalPkg.Signal{
+		Mint:      pos.Mint,
+		TokenName: pos.TokenName,
+		Type:      signalPkg.SignalExit,
+	}
+	go e.executeSellFast(context.Background(), sig, NewTradeTimer())
+}
@@ -0,0 +1,22 @@
+package trading
+
+import "testing"
+
+func TestLiquidityExitReason(t *testing.T) {
+	cases := []struct {
+		name                     string
+		pool, entry, floor, drop float64
+		want                     bool
+	}{
+		{"healthy", 80, 100, 10, 50, false},
+		{"below floor", 5, 100, 10, 0, true},
+		{"dropped past limit", 40, 100, 0, 50, true},
+		{"drop without baseline", 40, 0, 0, 50, false},
+		{"disabled", 1, 100, 0, 0, false},
+	}
+	for _, c := range cases {
+		if got := liquidityExitReason(c.pool, c.entry, c.floor, c.drop) != ""; got != c.want {
+			t.Errorf("%s: exit = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
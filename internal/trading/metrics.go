@@ -1,6 +1,8 @@
 package trading
 
 import (
+	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -154,6 +156,21 @@ func (m *Metrics) Stats() (total, success, failed int64, successRate float64) {
 	return
 }
 
+// WritePrometheus writes trade counters and latency percentiles in
+// Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) {
+	metric := func(name, typ, help string, value interface{}) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, typ, name, value)
+	}
+	total, success, failed, _ := m.Stats()
+	metric("pumpbot_trades_total", "counter", "Trades executed.", total)
+	metric("pumpbot_trades_success_total", "counter", "Trades sent successfully.", success)
+	metric("pumpbot_trades_failed_total", "counter", "Trades that failed.", failed)
+	metric("pumpbot_trade_latency_p50_ms", "gauge", "Median signal-to-send latency over the last 100 trades, in milliseconds.", m.P50())
+	metric("pumpbot_trade_latency_p95_ms", "gauge", "95th percentile signal-to-send latency, in milliseconds.", m.P95())
+	metric("pumpbot_trade_latency_p99_ms", "gauge", "99th percentile signal-to-send latency, in milliseconds.", m.P99())
+}
+
 // TradeTimer helps time individual trade components
 type TradeTimer struct {
 	start     time.Time
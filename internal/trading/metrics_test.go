@@ -0,0 +1,45 @@
+package trading
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	signalPkg "solana-pump-bot/internal/signal"
+)
+
+func TestMetrics_PrometheusHandler(t *testing.T) {
+	m := NewMetrics()
+	m.RecordTrade(true, 1, 2, 30, 4, 13)   // 50ms
+	m.RecordTrade(true, 1, 2, 60, 4, 33)   // 100ms
+	m.RecordTrade(false, 1, 2, 100, 4, 43) // 150ms
+
+	handler := signalPkg.MetricsHandler(func() string {
+		var b strings.Builder
+		m.WritePrometheus(&b)
+		return b.String()
+	})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if rec.Code != 200 {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != signalPkg.MetricsContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, signalPkg.MetricsContentType)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE pumpbot_trades_total counter\n",
+		"pumpbot_trades_total 3\n",
+		"pumpbot_trades_success_total 2\n",
+		"pumpbot_trades_failed_total 1\n",
+		"pumpbot_trade_latency_p50_ms 100\n",
+		"pumpbot_trade_latency_p95_ms 150\n",
+		"pumpbot_trade_latency_p99_ms 150\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+}
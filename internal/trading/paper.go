@@ -0,0 +1,256 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/events"
+	"solana-pump-bot/internal/jupiter"
+	"solana-pump-bot/internal/notify"
+	signalPkg "solana-pump-bot/internal/signal"
+	"solana-pump-bot/internal/storage"
+)
+
+// PaperTxPrefix starts the made-up signature of every paper fill
+const PaperTxPrefix = "PAPER_"
+
+// isPaperTx reports whether txSig names a paper fill rather than a sent transaction
+func isPaperTx(txSig string) bool {
+	return strings.HasPrefix(txSig, PaperTxPrefix)
+}
+
+// paperTxSig names a paper fill; unique so trade rows stay distinguishable
+func paperTxSig(side, token string) string {
+	return fmt.Sprintf("%s%s_%s_%d", PaperTxPrefix, side, token, time.Now().UnixNano())
+}
+
+// paperTrading reports whether trades are filled on paper
+// (trading.paper_trading); simulation mode takes precedence
+func (e *ExecutorFast) paperTrading() bool {
+	cfg := e.cfg.Get().Trading
+	return cfg.PaperTrading && !cfg.SimulationMode && !e.simMode
+}
+
+// isPaperPosition reports whether mint's open position was opened on paper;
+// its exits are booked on paper even if paper trading was turned off since
+// (and a live position is never sold on paper after it was turned on)
+func (e *ExecutorFast) isPaperPosition(mint string) bool {
+	pos := e.positions.Get(mint)
+	return pos != nil && pos.IsPaper()
+}
+
+// paperBalanceLamports is the paper wallet: trading.paper_balance_sol plus
+// what paper trades have made or lost so far, less paper buys still open,
+// plus paper sell proceeds
+func (e *ExecutorFast) paperBalanceLamports() uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fundPaperLocked()
+	return e.paperLamports
+}
+
+// PaperBalanceSOL returns the paper wallet in SOL; ok is false when not
+// paper trading (or on a nil executor)
+func (e *ExecutorFast) PaperBalanceSOL() (sol float64, ok bool) {
+	if e == nil || !e.paperTrading() {
+		return 0, false
+	}
+	return float64(e.paperBalanceLamports()) / 1e9, true
+}
+
+// adjustPaperBalance moves the paper wallet by delta lamports (never below 0)
+func (e *ExecutorFast) adjustPaperBalance(delta int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fundPaperLocked()
+	if delta < 0 && uint64(-delta) > e.paperLamports {
+		e.paperLamports = 0
+		return
+	}
+	e.paperLamports = uint64(int64(e.paperLamports) + delta)
+}
+
+// fundPaperLocked seeds the paper wallet once; caller holds e.mu. A restart
+// picks up where the last run left off: the realized PnL of closed paper
+// trades, and the open paper positions' cost less their partial proceeds.
+func (e *ExecutorFast) fundPaperLocked() {
+	if e.paperFunded {
+		return
+	}
+	sol := e.cfg.GetTrading().PaperBalanceSol
+	if e.db != nil {
+		if realized, err := e.db.GetPaperRealizedPnL(); err == nil {
+			sol += realized
+		} else {
+			log.Warn().Err(err).Msg("failed to read paper trades; paper wallet starts from paper_balance_sol")
+		}
+	}
+	for _, pos := range e.positions.GetAll() {
+		if pos.IsPaper() {
+			sol += pos.GetPaperProceeds() - pos.Size
+		}
+	}
+	e.paperLamports = uint64(max(sol, 0) * 1e9)
+	e.paperFunded = true
+}
+
+// paperTokenBalance is the paper holding of mint: what its paper buy got
+// for the quote, less paper partial takes. A restored position uses the
+// holding stored with it; only one opened before that was stored is valued
+// at what its size buys now (which resets its PnL to the current price).
+func (e *ExecutorFast) paperTokenBalance(ctx context.Context, mint string) (uint64, error) {
+	e.mu.RLock()
+	held, ok := e.simHoldings[mint]
+	e.mu.RUnlock()
+	if ok {
+		return held, nil
+	}
+	pos := e.positions.Get(mint)
+	if pos == nil {
+		return 0, nil
+	}
+	if held := pos.GetPaperTokens(); held > 0 {
+		e.setSimHolding(mint, held)
+		return held, nil
+	}
+	q, err := e.jupiter.GetQuote(ctx, jupiter.SOLMint, mint, uint64(pos.Size*1e9))
+	if err != nil {
+		return 0, err
+	}
+	held, _ = strconv.ParseUint(q.OutAmount, 10, 64)
+	e.setSimHolding(mint, held)
+	return held, nil
+}
+
+// fillPaperBuy books a buy at its live quote instead of swapping: the paper
+// wallet pays allocLamports and holds the quoted tokens
+func (e *ExecutorFast) fillPaperBuy(signal *signalPkg.Signal, timer *TradeTimer, quote *jupiter.QuoteResponse, allocLamports uint64) {
+	held, _ := strconv.ParseUint(quote.OutAmount, 10, 64)
+	txSig := paperTxSig("BUY", signal.TokenName)
+	timer.MarkQuoteDone()
+	timer.MarkSignDone()
+	timer.MarkSendDone()
+
+	e.setSimHolding(signal.Mint, held)
+	e.adjustPaperBalance(-int64(allocLamports))
+	parse, resolve, quoteMs, sign, send := timer.GetBreakdown()
+	e.metrics.RecordTrade(true, parse, resolve, quoteMs, sign, send)
+
+	log.Info().
+		Str("token", signal.TokenName).
+		Float64("sol", float64(allocLamports)/1e9).
+		Uint64("tokens", held).
+		Float64("paperBalanceSOL", float64(e.paperBalanceLamports())/1e9).
+		Msg("📝 PAPER BUY filled at quote")
+	e.markMintBought(signal.Mint)
+	e.recordDecision(signal, DecisionBuy, ReasonPaperFill, txSig)
+	e.publish(events.TradeExecuted{
+		Side:      "BUY",
+		Mint:      signal.Mint,
+		TokenName: signal.TokenName,
+		AmountSol: float64(allocLamports) / 1e9,
+		TxSig:     txSig,
+	})
+	go e.trackPositionAsync(signal, allocLamports, txSig)
+}
+
+// paperSell books a sell of tokenAmount at its live quote: the paper wallet
+// gets the quoted SOL and the position closes with that plus its partial
+// proceeds, less its size, as its realized PnL. Paper PnL stays out of the
+// daily loss cap, which guards real SOL.
+func (e *ExecutorFast) paperSell(ctx context.Context, signal *signalPkg.Signal, timer *TradeTimer, tokenAmount uint64) error {
+	quote, err := e.jupiter.GetQuoteWithSlippage(ctx, signal.Mint, jupiter.SOLMint, tokenAmount, e.slippageFor(signal.Mint))
+	if err != nil {
+		log.Error().Err(err).Str("token", signal.TokenName).Msg("📝 PAPER SELL quote failed - keeping position")
+		e.issues.Record("sell", err)
+		return err
+	}
+	outLamports, _ := strconv.ParseUint(quote.OutAmount, 10, 64)
+	txSig := paperTxSig("SELL", signal.TokenName)
+	timer.MarkQuoteDone()
+	timer.MarkSignDone()
+	timer.MarkSendDone()
+
+	e.adjustPaperBalance(int64(outLamports))
+	e.setSimHolding(signal.Mint, 0)
+	parse, resolve, quoteMs, sign, send := timer.GetBreakdown()
+	e.metrics.RecordTrade(true, parse, resolve, quoteMs, sign, send)
+
+	log.Info().
+		Str("token", signal.TokenName).
+		Uint64("tokens", tokenAmount).
+		Float64("sol", float64(outLamports)/1e9).
+		Float64("paperBalanceSOL", float64(e.paperBalanceLamports())/1e9).
+		Msg("📝 PAPER SELL filled at quote")
+	e.recordDecision(signal, DecisionSell, ReasonPaperFill, txSig)
+	e.publish(events.TradeExecuted{
+		Side:      "SELL",
+		Mint:      signal.Mint,
+		TokenName: signal.TokenName,
+		AmountSol: float64(outLamports) / 1e9,
+		TxSig:     txSig,
+	})
+
+	if pos := e.positions.Get(signal.Mint); pos != nil {
+		pnl := float64(outLamports)/1e9 + pos.GetPaperProceeds() - pos.Size
+		if e.db != nil {
+			e.db.InsertTrade(&storage.Trade{
+				Mint:           signal.Mint,
+				TokenName:      signal.TokenName,
+				Side:           "SELL",
+				AmountSol:      pos.Size,
+				EntryValue:     pos.EntryValue,
+				ExitValue:      pos.CurrentValue,
+				PnL:            pos.PnLPercent,
+				Duration:       int64(time.Since(pos.EntryTime).Seconds()),
+				EntryTxSig:     pos.EntryTxSig,
+				ExitTxSig:      txSig,
+				Timestamp:      time.Now().Unix(),
+				RealizedPnLSol: pnl,
+				Paper:          true,
+			})
+		}
+		e.notify(notify.Event{
+			Kind:       notify.KindSell,
+			TokenName:  pos.TokenName,
+			Mint:       pos.Mint,
+			TxSig:      txSig,
+			PnLSol:     pnl,
+			PnLPercent: pos.PnLPercent,
+			Paper:      true,
+		})
+	}
+	e.removePositionAsync(signal.Mint)
+	return nil
+}
+
+// paperPartialSell books a partial take of sellAmount out of held tokens at
+// its live quote
+func (e *ExecutorFast) paperPartialSell(ctx context.Context, pos *Position, held, sellAmount uint64) bool {
+	quote, err := e.jupiter.GetQuoteWithSlippage(ctx, pos.Mint, jupiter.SOLMint, sellAmount, e.slippageFor(pos.Mint))
+	if err != nil {
+		log.Error().Err(err).Msg("failed paper partial sell quote")
+		e.issues.Record("partial_sell", err)
+		return false
+	}
+	outLamports, _ := strconv.ParseUint(quote.OutAmount, 10, 64)
+	txSig := paperTxSig("PARTIAL_SELL", pos.TokenName)
+	e.adjustPaperBalance(int64(outLamports))
+	e.setSimHolding(pos.Mint, held-min(sellAmount, held))
+	pos.RecordPaperPartial(held-min(sellAmount, held), float64(outLamports)/1e9) // Persisted with the partial take
+
+	log.Info().Str("token", pos.TokenName).Float64("sol", float64(outLamports)/1e9).Msg("📝 PAPER PARTIAL SELL filled at quote")
+	e.publish(events.TradeExecuted{
+		Side:      "PARTIAL_SELL",
+		Mint:      pos.Mint,
+		TokenName: pos.TokenName,
+		AmountSol: float64(outLamports) / 1e9,
+		TxSig:     txSig,
+	})
+	return true
+}
@@ -0,0 +1,176 @@
+package trading
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/jupiter"
+	"solana-pump-bot/internal/storage"
+)
+
+func TestPaperTrading_FillsAtQuoteWithoutSending(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  paper_trading: true
+  paper_balance_sol: 2
+  max_alloc_percent: 10
+  reserve_sol: 0
+  max_open_positions: 5
+  min_entry_percent: 50
+  take_profit_multiple: 2
+jupiter:
+  slippage_bps: 500
+  timeout_seconds: 5
+`)
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "paper.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	h.executor.db = db
+
+	// 1000 tokens per lamport in; selling them back quotes 1.5x the SOL
+	h.chain.setQuoteOut(func(in, out string, amount uint64) uint64 {
+		if in == jupiter.SOLMint {
+			return amount * 1000
+		}
+		return amount / 1000 * 3 / 2
+	})
+
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(1)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	waitFor(t, "paper position", func() bool {
+		pos := h.positions.Get(testMint)
+		return pos != nil && isPaperTx(pos.EntryTxSig)
+	})
+	if held, _ := h.executor.getTokenBalance(context.Background(), testMint); held != 200_000_000*1000 {
+		t.Errorf("paper holding = %d tokens, want the quoted %d", held, 200_000_000*1000)
+	}
+	if sol, ok := h.executor.PaperBalanceSOL(); !ok || math.Abs(sol-1.8) > 1e-9 {
+		t.Errorf("paper wallet after buy = %v SOL (ok %v), want 1.8", sol, ok)
+	}
+
+	if err := h.executor.ForceClose(context.Background(), testMint); err != nil {
+		t.Fatalf("ForceClose: %v", err)
+	}
+	waitFor(t, "paper position closed", func() bool { return h.positions.Get(testMint) == nil })
+
+	for _, call := range []string{"sendTransaction", "swap", "simulateTransaction"} {
+		if n := h.chain.Calls(call); n != 0 {
+			t.Errorf("%s calls = %d, want 0 (paper trading never sends)", call, n)
+		}
+	}
+	if n := h.chain.Calls("quote"); n < 2 {
+		t.Errorf("quote calls = %d, want live quotes for the buy and the sell", n)
+	}
+	if sol, _ := h.executor.PaperBalanceSOL(); math.Abs(sol-2.1) > 1e-9 {
+		t.Errorf("paper wallet after sell = %v SOL, want 2.1", sol)
+	}
+
+	var trades []*storage.Trade
+	waitFor(t, "paper buy and sell logged", func() bool {
+		trades, _ = db.GetRecentTrades(10)
+		return len(trades) == 2
+	})
+	for _, tr := range trades {
+		if !tr.Paper {
+			t.Errorf("%s trade not flagged paper: %+v", tr.Side, tr)
+		}
+	}
+	sells := 0
+	for _, tr := range trades {
+		if tr.Side != "SELL" {
+			continue
+		}
+		sells++
+		if !strings.HasPrefix(tr.ExitTxSig, PaperTxPrefix) || math.Abs(tr.RealizedPnLSol-0.1) > 1e-9 {
+			t.Errorf("paper sell = %+v, want a %s exit and 0.1 SOL realized", tr, PaperTxPrefix)
+		}
+	}
+	if sells != 1 {
+		t.Errorf("logged %d sells, want 1", sells)
+	}
+}
+
+func TestPaperTrading_ExitsFollowThePosition(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  paper_trading: true
+  paper_balance_sol: 2
+  max_alloc_percent: 10
+  reserve_sol: 0
+  max_open_positions: 5
+  min_entry_percent: 50
+  take_profit_multiple: 2
+jupiter:
+  slippage_bps: 500
+  timeout_seconds: 5
+`)
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "paper.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	h.executor.db = db
+	h.positions.db = db
+
+	h.chain.setQuoteOut(func(in, out string, amount uint64) uint64 {
+		if in == jupiter.SOLMint {
+			return amount * 1000
+		}
+		return amount / 1000 * 3 / 2
+	})
+	if err := h.executor.ProcessSignalFast(context.Background(), entrySignal(1)); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	waitFor(t, "paper position", func() bool {
+		pos := h.positions.Get(testMint)
+		return pos != nil && pos.IsPaper()
+	})
+	pos := h.positions.Get(testMint)
+
+	// Half taken at 1.5x: 0.15 SOL booked, persisted with the position
+	if !h.executor.executePartialSell(context.Background(), pos, 50) {
+		t.Fatal("paper partial sell failed")
+	}
+	h.positions.PersistPartials(pos)
+	restored := NewPositionTracker(db, 5).Get(testMint)
+	if restored == nil || !restored.IsPaper() || restored.GetPaperTokens() != 100_000_000*1000 || math.Abs(restored.GetPaperProceeds()-0.15) > 1e-9 {
+		t.Fatalf("restored position = %+v, want paper with half the holding and 0.15 SOL booked", restored)
+	}
+
+	// A restart rebuilds the paper wallet from the trades and open positions
+	want := h.executor.paperBalanceLamports()
+	h.executor.paperFunded = false
+	if got := h.executor.paperBalanceLamports(); got != want {
+		t.Errorf("paper wallet after restart = %d lamports, want %d", got, want)
+	}
+
+	// Paper mode off: the paper position still exits on paper
+	h.cfg.Update(func(c *config.Config) { c.Trading.PaperTrading = false })
+	if err := h.executor.ForceClose(context.Background(), testMint); err != nil {
+		t.Fatalf("ForceClose: %v", err)
+	}
+	waitFor(t, "paper position closed", func() bool { return h.positions.Get(testMint) == nil })
+	if n := h.chain.Calls("swap"); n != 0 {
+		t.Errorf("swap calls = %d, want 0 (a paper position is never sold for real)", n)
+	}
+
+	trades, _ := db.GetRecentTrades(10)
+	for _, tr := range trades {
+		if tr.Side == "SELL" && math.Abs(tr.RealizedPnLSol-0.1) > 1e-9 {
+			t.Errorf("paper sell realized %v SOL, want 0.1 (0.15 + 0.15 back on 0.2)", tr.RealizedPnLSol)
+		}
+	}
+	if total := h.executor.daily.total(time.Now()); total != 0 {
+		t.Errorf("daily PnL = %v, want paper trades kept out of the loss cap", total)
+	}
+}
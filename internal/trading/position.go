@@ -1,6 +1,8 @@
 package trading
 
 import (
+	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -20,12 +22,27 @@ type Position struct {
 	MsgID        int64
 	PoolAddr     string // AMM pool address for price tracking
 	// Dynamic fields for TUI/Tracking
-	CurrentValue float64
-	PnLSol       float64
-	PnLPercent   float64
-	Reached2X    bool
-	PartialSold  bool   // True if partial profit has been taken
-	TokenBalance uint64 // Real-time balance from WebSocket
+	CurrentValue  float64
+	PnLSol        float64
+	PnLPercent    float64
+	PeakMultiple  float64 // Highest value/size multiple seen while held
+	Reached2X     bool
+	PartialSold   bool    // True if partial profit has been taken
+	ProfitLevels  uint32  // Bitmask of fired partial profit levels (index into TradingConfig.ProfitLevels)
+	StopLossed    bool    // True once the stop-loss sell was triggered
+	BreakEven     bool    // True once the stop moved to entry after a partial take
+	SoldFraction  float64 // Cumulative fraction of the original sold by partial takes (CurveSold + LadderSold)
+	CurveSold     float64 // Fraction of the original sold by the take-profit curve
+	LadderSold    float64 // Fraction of the original sold by the partial profit ladder
+	ExitValueSol  float64 // SOL from selling now: worst-case quote (slippage) minus fees
+	EntryFeesSol  float64 // Estimated fees paid on entry (priority + gas)
+	NetPnLPercent float64 // PnL after entry fees and ExitValueSol; valid once ExitValueSol > 0
+	TokenBalance  uint64  // Real-time balance from WebSocket
+	EntryPoolSol  float64 // Pool SOL reserve when first seen after entry (0 = no pool data)
+	ExitSlippage  int     // Slippage (bps) for this position's sells; 0 = normal
+	Paper         bool    // Opened by a paper fill: every exit is booked on paper
+	PaperTokens   uint64  // Paper holding left (paper positions)
+	PaperProceeds float64 // SOL booked by paper partial takes
 
 	mu         sync.RWMutex
 	LastUpdate time.Time
@@ -37,22 +54,37 @@ func (p *Position) Snapshot() *Position {
 	defer p.mu.RUnlock()
 
 	return &Position{
-		Mint:         p.Mint,
-		TokenName:    p.TokenName,
-		Size:         p.Size,
-		EntryValue:   p.EntryValue,
-		EntryUnit:    p.EntryUnit,
-		EntryTime:    p.EntryTime,
-		EntryTxSig:   p.EntryTxSig,
-		MsgID:        p.MsgID,
-		PoolAddr:     p.PoolAddr,
-		CurrentValue: p.CurrentValue,
-		PnLSol:       p.PnLSol,
-		PnLPercent:   p.PnLPercent,
-		Reached2X:    p.Reached2X,
-		PartialSold:  p.PartialSold,
-		TokenBalance: p.TokenBalance,
-		LastUpdate:   p.LastUpdate,
+		Mint:          p.Mint,
+		TokenName:     p.TokenName,
+		Size:          p.Size,
+		EntryValue:    p.EntryValue,
+		EntryUnit:     p.EntryUnit,
+		EntryTime:     p.EntryTime,
+		EntryTxSig:    p.EntryTxSig,
+		MsgID:         p.MsgID,
+		PoolAddr:      p.PoolAddr,
+		CurrentValue:  p.CurrentValue,
+		PnLSol:        p.PnLSol,
+		PnLPercent:    p.PnLPercent,
+		PeakMultiple:  p.PeakMultiple,
+		Reached2X:     p.Reached2X,
+		PartialSold:   p.PartialSold,
+		ProfitLevels:  p.ProfitLevels,
+		StopLossed:    p.StopLossed,
+		BreakEven:     p.BreakEven,
+		SoldFraction:  p.SoldFraction,
+		CurveSold:     p.CurveSold,
+		LadderSold:    p.LadderSold,
+		ExitValueSol:  p.ExitValueSol,
+		EntryFeesSol:  p.EntryFeesSol,
+		NetPnLPercent: p.NetPnLPercent,
+		TokenBalance:  p.TokenBalance,
+		EntryPoolSol:  p.EntryPoolSol,
+		ExitSlippage:  p.ExitSlippage,
+		Paper:         p.Paper,
+		PaperTokens:   p.PaperTokens,
+		PaperProceeds: p.PaperProceeds,
+		LastUpdate:    p.LastUpdate,
 		// mu is zero value (unlocked)
 	}
 }
@@ -62,20 +94,47 @@ func (p *Position) UpdateStats(currentValSol float64, tokenBalance uint64) float
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.TokenBalance = tokenBalance
-	p.PnLSol = currentValSol - p.Size
 	p.LastUpdate = time.Now()
+	return p.revalueLocked(currentValSol, tokenBalance)
+}
+
+// Revalue is UpdateStats for a streamed (WebSocket) price: LastUpdate still
+// says when the monitor last quoted, so a busy pool never keeps it from
+// quoting and running exits
+func (p *Position) Revalue(currentValSol float64, tokenBalance uint64) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.revalueLocked(currentValSol, tokenBalance)
+}
+
+func (p *Position) revalueLocked(currentValSol float64, tokenBalance uint64) float64 {
+	p.TokenBalance = tokenBalance
+
+	// Measure against the cost of what is still held: after a partial take
+	// the multiple tracks price, not the shrunken remainder's share of Size
+	cost := p.remainingCostLocked()
+	p.PnLSol = currentValSol - cost
 
 	multiple := 0.0
-	if p.Size > 0 {
-		multiple = currentValSol / p.Size
+	if cost > 0 {
+		multiple = currentValSol / cost
 		p.PnLPercent = (multiple - 1.0) * 100
 		// Fix: Maintain CurrentValue in EntryValue units (e.g. MCAP)
 		p.CurrentValue = multiple * p.EntryValue
+		if multiple > p.PeakMultiple {
+			p.PeakMultiple = multiple
+		}
 	}
 	return multiple
 }
 
+// GetPeakMultiple returns the highest multiple recorded by UpdateStats
+func (p *Position) GetPeakMultiple() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.PeakMultiple
+}
+
 func (p *Position) SetReached2X(reached bool) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -100,6 +159,154 @@ func (p *Position) IsPartialSold() bool {
 	return p.PartialSold
 }
 
+// GetProfitLevelsHit returns the bitmask of partial profit levels already sold
+func (p *Position) GetProfitLevelsHit() uint32 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.ProfitLevels
+}
+
+// MarkProfitLevelsHit records levels as sold (and the position as partially
+// sold); ladderSold is the ladder's cumulative share of the original sold
+func (p *Position) MarkProfitLevelsHit(levels uint32, ladderSold float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ProfitLevels |= levels
+	p.PartialSold = true
+	p.LadderSold = ladderSold
+	p.updateSoldFractionLocked()
+}
+
+// ArmBreakEven moves the position's stop to entry (see EffectiveStopLoss)
+func (p *Position) ArmBreakEven() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.BreakEven = true
+}
+
+func (p *Position) IsBreakEvenArmed() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.BreakEven
+}
+
+func (p *Position) SetStopLossed(hit bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.StopLossed = hit
+}
+
+func (p *Position) IsStopLossed() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.StopLossed
+}
+
+// SetExitValue stores the slippage- and fee-adjusted exit estimate and the
+// net PnL it implies: what selling the held tokens now returns, less their
+// entry cost and share of the entry fees, relative to that cost (after a
+// partial take only the remainder is held)
+func (p *Position) SetExitValue(sol float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ExitValueSol = sol
+	if cost := p.remainingCostLocked(); cost > 0 {
+		fees := p.EntryFeesSol * cost / p.Size
+		p.NetPnLPercent = (sol - fees - cost) / cost * 100
+	}
+}
+
+// remainingCostLocked is the entry cost of what is still held (Size less the
+// share sold by partial takes); caller holds p.mu
+func (p *Position) remainingCostLocked() float64 {
+	if p.SoldFraction > 0 && p.SoldFraction < 1 {
+		return p.Size * (1 - p.SoldFraction)
+	}
+	return p.Size
+}
+
+// ObservePoolLiquidity records the first pool SOL reserve seen for the
+// position and returns it (the baseline for liquidity-drop checks)
+func (p *Position) ObservePoolLiquidity(sol float64) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.EntryPoolSol == 0 {
+		p.EntryPoolSol = sol
+	}
+	return p.EntryPoolSol
+}
+
+func (p *Position) SetExitSlippageBps(bps int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ExitSlippage = bps
+}
+
+func (p *Position) GetExitSlippageBps() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.ExitSlippage
+}
+
+// GetSoldFraction returns the cumulative fraction of the original sold by partial takes
+func (p *Position) GetSoldFraction() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.SoldFraction
+}
+
+// GetCurveSold returns the fraction of the original sold by the take-profit curve
+func (p *Position) GetCurveSold() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.CurveSold
+}
+
+// SetCurveSold records the take-profit curve's cumulative share of the original sold
+func (p *Position) SetCurveSold(fraction float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.CurveSold = fraction
+	p.PartialSold = true
+	p.updateSoldFractionLocked()
+}
+
+// updateSoldFractionLocked totals the curve's and the ladder's shares;
+// caller holds p.mu
+func (p *Position) updateSoldFractionLocked() {
+	p.SoldFraction = math.Min(p.CurveSold+p.LadderSold, 1)
+}
+
+// IsPaper reports whether the position was opened by a paper fill
+func (p *Position) IsPaper() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Paper
+}
+
+// GetPaperTokens returns the paper holding left
+func (p *Position) GetPaperTokens() uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.PaperTokens
+}
+
+// GetPaperProceeds returns the SOL booked by paper partial takes
+func (p *Position) GetPaperProceeds() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.PaperProceeds
+}
+
+// RecordPaperPartial books a paper partial take: tokens left held, sol
+// added to the proceeds
+func (p *Position) RecordPaperPartial(tokensLeft uint64, sol float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PaperTokens = tokensLeft
+	p.PaperProceeds += sol
+}
+
 func (p *Position) SetEntryTxSig(sig string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -118,6 +325,24 @@ func (p *Position) GetLastUpdate() time.Time {
 	return p.LastUpdate
 }
 
+func (p *Position) GetPoolAddr() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.PoolAddr
+}
+
+func (p *Position) SetPoolAddr(pool string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PoolAddr = pool
+}
+
+func (p *Position) GetTokenBalance() uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.TokenBalance
+}
+
 func (p *Position) SetTokenBalance(balance uint64) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -149,14 +374,22 @@ type PositionTracker struct {
 	positions map[string]*Position // keyed by mint
 	db        *storage.DB
 	maxPos    int
+	onChange  func(mint string, closed bool) // Optional hook (e.g. event bus), called outside the lock
+
+	persistedAt map[string]time.Time // last PersistStats write per mint
 }
 
+// StatsPersistInterval throttles PersistStats: the monitor updates stats
+// every tick, the DB only needs them roughly current for restarts
+const StatsPersistInterval = 30 * time.Second
+
 // NewPositionTracker creates a new position tracker
 func NewPositionTracker(db *storage.DB, maxPositions int) *PositionTracker {
 	pt := &PositionTracker{
-		positions: make(map[string]*Position),
-		db:        db,
-		maxPos:    maxPositions,
+		positions:   make(map[string]*Position),
+		db:          db,
+		maxPos:      maxPositions,
+		persistedAt: make(map[string]time.Time),
 	}
 
 	// Load existing positions from DB
@@ -198,18 +431,36 @@ func (pt *PositionTracker) loadFromDB() {
 			continue
 		}
 		
+		// Last persisted stats stand in until the monitor re-quotes
+		currentValue := p.CurrentValue
+		if currentValue == 0 {
+			currentValue = p.EntryValue
+		}
 		pt.positions[p.Mint] = &Position{
-			Mint:         p.Mint,
-			TokenName:    p.TokenName,
-			Size:         p.Size,
-			EntryValue:   p.EntryValue,
-			EntryUnit:    p.EntryUnit,
-			EntryTime:    entryTime,
-			EntryTxSig:   p.EntryTxSig,
-			MsgID:        p.MsgID,
-			CurrentValue: p.EntryValue,
-			PnLPercent:   0,
+			Mint:          p.Mint,
+			TokenName:     p.TokenName,
+			Size:          p.Size,
+			EntryValue:    p.EntryValue,
+			EntryUnit:     p.EntryUnit,
+			EntryTime:     entryTime,
+			EntryTxSig:    p.EntryTxSig,
+			MsgID:         p.MsgID,
+			PoolAddr:      p.PoolAddr,
+			CurrentValue:  currentValue,
+			PnLSol:        p.Size * p.PnLPercent / 100,
+			PnLPercent:    p.PnLPercent,
+			PeakMultiple:  p.PeakMultiple,
+			Reached2X:     p.Reached2X,
+			ProfitLevels:  p.ProfitLevels,
+			CurveSold:     p.CurveSold,
+			LadderSold:    p.LadderSold,
+			BreakEven:     p.BreakEven,
+			PartialSold:   p.ProfitLevels != 0 || p.CurveSold > 0,
+			Paper:         p.Paper,
+			PaperTokens:   p.PaperTokens,
+			PaperProceeds: p.PaperProceeds,
 		}
+		pt.positions[p.Mint].updateSoldFractionLocked()
 		loaded++
 	}
 	
@@ -225,6 +476,7 @@ func (pt *PositionTracker) Clear() {
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
 	pt.positions = make(map[string]*Position)
+	pt.persistedAt = make(map[string]time.Time)
 }
 
 // Has checks if a position exists for a mint
@@ -260,32 +512,91 @@ func (pt *PositionTracker) CanOpen() bool {
 func (pt *PositionTracker) Add(pos *Position) error {
 	pt.mu.Lock()
 	pt.positions[pos.Mint] = pos
+	hook := pt.onChange
 	pt.mu.Unlock()
 
+	if hook != nil {
+		hook(pos.Mint, false)
+	}
+
 	// Persist to DB
 	if pt.db != nil {
-		dbPos := &storage.Position{
-			Mint:       pos.Mint,
-			TokenName:  pos.TokenName,
-			Size:       pos.Size,
-			EntryValue: pos.EntryValue,
-			EntryUnit:  pos.EntryUnit,
-			EntryTime:  pos.EntryTime.Unix(),
-			EntryTxSig: pos.GetEntryTxSig(), // Use getter
-			MsgID:      pos.MsgID,
-		}
-		return pt.db.InsertPosition(dbPos)
+		return pt.db.InsertPosition(toStorage(pos.Snapshot()))
 	}
 	return nil
 }
 
+// toStorage converts a position snapshot to its DB row
+func toStorage(snap *Position) *storage.Position {
+	return &storage.Position{
+		Mint:          snap.Mint,
+		TokenName:     snap.TokenName,
+		Size:          snap.Size,
+		EntryValue:    snap.EntryValue,
+		EntryUnit:     snap.EntryUnit,
+		EntryTime:     snap.EntryTime.Unix(),
+		EntryTxSig:    snap.EntryTxSig,
+		MsgID:         snap.MsgID,
+		CurrentValue:  snap.CurrentValue,
+		PnLPercent:    snap.PnLPercent,
+		Reached2X:     snap.Reached2X,
+		PeakMultiple:  snap.PeakMultiple,
+		PoolAddr:      snap.PoolAddr,
+		ProfitLevels:  snap.ProfitLevels,
+		CurveSold:     snap.CurveSold,
+		LadderSold:    snap.LadderSold,
+		BreakEven:     snap.BreakEven,
+		Paper:         snap.Paper,
+		PaperTokens:   snap.PaperTokens,
+		PaperProceeds: snap.PaperProceeds,
+	}
+}
+
+// PersistStats stores pos's live stats (value, PnL, peak, 2X flag, pool) so
+// they survive a restart. Throttled to once per StatsPersistInterval per mint.
+func (pt *PositionTracker) PersistStats(pos *Position) {
+	if pt.db == nil {
+		return
+	}
+	now := time.Now()
+	pt.mu.Lock()
+	if now.Sub(pt.persistedAt[pos.Mint]) < StatsPersistInterval {
+		pt.mu.Unlock()
+		return
+	}
+	pt.persistedAt[pos.Mint] = now
+	pt.mu.Unlock()
+
+	if err := pt.db.UpdatePositionStats(toStorage(pos.Snapshot())); err != nil {
+		log.Warn().Err(err).Str("token", pos.TokenName).Msg("failed to persist position stats")
+	}
+}
+
+// PersistPartials stores pos's partial takes (ladder rungs fired, fractions
+// sold, break-even stop) right away so a restart neither sells a rung twice nor loses the cost
+// basis already sold. Not throttled: it runs once per partial take.
+func (pt *PositionTracker) PersistPartials(pos *Position) {
+	if pt.db == nil {
+		return
+	}
+	if err := pt.db.UpdatePositionPartials(toStorage(pos.Snapshot())); err != nil {
+		log.Warn().Err(err).Str("token", pos.TokenName).Msg("failed to persist partial takes")
+	}
+}
+
 // Remove removes a position
 func (pt *PositionTracker) Remove(mint string) (*Position, error) {
 	pt.mu.Lock()
 	pos := pt.positions[mint]
 	delete(pt.positions, mint)
+	delete(pt.persistedAt, mint)
+	hook := pt.onChange
 	pt.mu.Unlock()
 
+	if hook != nil && pos != nil {
+		hook(mint, true)
+	}
+
 	// Persist to DB
 	if pt.db != nil {
 		return pos, pt.db.DeletePosition(mint)
@@ -305,7 +616,8 @@ func (pt *PositionTracker) GetAll() []*Position {
 	return positions
 }
 
-// GetAllSnapshots returns thread-safe copies of all positions (for TUI)
+// GetAllSnapshots returns thread-safe copies of all positions (for TUI),
+// oldest entry first so the list (and a cursor into it) is stable between refreshes
 func (pt *PositionTracker) GetAllSnapshots() []*Position {
 	pt.mu.RLock()
 	defer pt.mu.RUnlock()
@@ -314,9 +626,22 @@ func (pt *PositionTracker) GetAllSnapshots() []*Position {
 	for _, p := range pt.positions {
 		snaps = append(snaps, p.Snapshot())
 	}
+	sort.Slice(snaps, func(i, j int) bool {
+		if !snaps[i].EntryTime.Equal(snaps[j].EntryTime) {
+			return snaps[i].EntryTime.Before(snaps[j].EntryTime)
+		}
+		return snaps[i].Mint < snaps[j].Mint
+	})
 	return snaps
 }
 
+// SetOnChange registers a hook called when a position is added or removed
+func (pt *PositionTracker) SetOnChange(fn func(mint string, closed bool)) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.onChange = fn
+}
+
 // SetMaxPositions updates the max positions limit
 func (pt *PositionTracker) SetMaxPositions(max int) {
 	pt.mu.Lock()
@@ -338,6 +663,7 @@ func (pt *PositionTracker) ClearAll() {
 	
 	// Clear memory
 	pt.positions = make(map[string]*Position)
+	pt.persistedAt = make(map[string]time.Time)
 	
 	log.Info().Msg("all positions cleared")
 }
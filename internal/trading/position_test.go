@@ -0,0 +1,77 @@
+package trading
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"solana-pump-bot/internal/storage"
+)
+
+func TestPositionTracker_RestoresPersistedStats(t *testing.T) {
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+
+	pt := NewPositionTracker(db, 5)
+	pos := &Position{Mint: "Mint111", TokenName: "PEPE", Size: 0.1, EntryValue: 60, EntryUnit: "%",
+		EntryTime: time.Now(), EntryTxSig: "EntrySig", MsgID: 42}
+	if err := pt.Add(pos); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	pos.UpdateStats(0.25, 1000) // 2.5X
+	pos.SetReached2X(true)
+	pos.SetPoolAddr("Pool111")
+	pt.PersistStats(pos)
+
+	loaded := NewPositionTracker(db, 5).Get("Mint111")
+	if loaded == nil {
+		t.Fatal("position not loaded from DB")
+	}
+	if !loaded.Reached2X || loaded.PoolAddr != "Pool111" || loaded.PeakMultiple != 2.5 ||
+		loaded.PnLPercent != 150 || loaded.CurrentValue != 150 {
+		t.Errorf("loaded = reached2X %v pool %q peak %v pnl %v%% value %v; want true Pool111 2.5 150%% 150",
+			loaded.Reached2X, loaded.PoolAddr, loaded.PeakMultiple, loaded.PnLPercent, loaded.CurrentValue)
+	}
+}
+
+func TestPositionTracker_RestoresPartialTakes(t *testing.T) {
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+
+	pt := NewPositionTracker(db, 5)
+	pos := &Position{Mint: "Mint111", TokenName: "PEPE", Size: 0.1, EntryTime: time.Now(), EntryTxSig: "EntrySig"}
+	pt.Add(pos)
+	pos.SetCurveSold(0.2)
+	pos.MarkProfitLevelsHit(0b011, 0.5)
+	pt.PersistPartials(pos)
+
+	loaded := NewPositionTracker(db, 5).Get("Mint111")
+	if loaded == nil {
+		t.Fatal("position not loaded from DB")
+	}
+	if loaded.ProfitLevels != 0b011 || loaded.CurveSold != 0.2 || loaded.LadderSold != 0.5 ||
+		math.Abs(loaded.SoldFraction-0.7) > 1e-9 || !loaded.PartialSold {
+		t.Errorf("loaded = levels %b curve %v ladder %v sold %v partial %v; want 11 0.2 0.5 0.7 true",
+			loaded.ProfitLevels, loaded.CurveSold, loaded.LadderSold, loaded.SoldFraction, loaded.PartialSold)
+	}
+}
+
+func TestPosition_NetPnLUsesRemainingCost(t *testing.T) {
+	pos := &Position{Size: 0.1, EntryFeesSol: 0.002}
+	pos.SetExitValue(0.099)
+	if got := pos.Snapshot().NetPnLPercent; math.Abs(got-(-3)) > 1e-9 {
+		t.Errorf("net PnL = %v%%, want -3%%", got)
+	}
+
+	// Half taken: the remainder cost 0.05 and carries half the entry fees
+	pos.SetCurveSold(0.5)
+	pos.SetExitValue(0.1)
+	if got := pos.Snapshot().NetPnLPercent; math.Abs(got-98) > 1e-9 {
+		t.Errorf("net PnL after a half take = %v%%, want 98%%", got)
+	}
+}
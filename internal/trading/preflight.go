@@ -0,0 +1,38 @@
+package trading
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/blockchain"
+)
+
+// preflightSimulate simulates a signed swap when trading.preflight_simulate is
+// on and returns an error if it would fail, so the caller skips the send.
+// Sends use skipPreflight for speed, which otherwise means paying the fee for
+// transactions the RPC node could have told us were doomed. A simulation
+// that can't be run (RPC down) doesn't block the send.
+func (e *ExecutorFast) preflightSimulate(ctx context.Context, signedTx, side string) error {
+	if !e.cfg.GetTrading().PreflightSimulate {
+		return nil
+	}
+
+	res, err := e.rpc.SimulateTransaction(ctx, signedTx)
+	if err != nil {
+		log.Warn().Err(err).Str("side", side).Msg("preflight simulation unavailable, sending anyway")
+		return nil
+	}
+	if !res.Failed() {
+		return nil
+	}
+
+	simErr := errors.New(res.Error())
+	log.Error().
+		Str("side", side).
+		Str("error", blockchain.HumanErrorWithAction(simErr)).
+		Strs("logs", res.Logs).
+		Msg("🧪 PREFLIGHT SIMULATION FAILED - not sending")
+	return simErr
+}
@@ -0,0 +1,37 @@
+package trading
+
+import "solana-pump-bot/internal/config"
+
+// dueProfitLevels returns the partial profit levels newly reached at
+// multiple, as a bitmask over levels, what to sell for them as a percent of
+// the tokens still held, and the ladder's fraction of the original sold once
+// they are. hit holds the levels already sold and otherSold the fraction of
+// the original sold outside the ladder (the take-profit curve); level
+// percents are of the original position, so each rung sells the same amount
+// no matter how many came before it. Levels are matched by index, so a
+// ladder edited while a position is open applies by position in the list.
+func dueProfitLevels(levels []config.ProfitLevel, hit uint32, otherSold, multiple float64) (due uint32, percentOfRemaining, soldAfter float64) {
+	sold, selling := 0.0, 0.0
+	for i, l := range levels {
+		if i >= config.MaxProfitLevels {
+			break
+		}
+		bit := uint32(1) << i
+		switch {
+		case hit&bit != 0:
+			sold += l.Percent
+		case multiple >= l.Multiple:
+			due |= bit
+			selling += l.Percent
+		}
+	}
+	held := 100 - sold - otherSold*100
+	if due == 0 || held <= 0 {
+		return 0, 0, sold / 100
+	}
+	percentOfRemaining = selling / held * 100
+	if percentOfRemaining > 100 {
+		percentOfRemaining = 100
+	}
+	return due, percentOfRemaining, (sold + selling) / 100
+}
@@ -0,0 +1,62 @@
+package trading
+
+import (
+	"math"
+	"testing"
+
+	"solana-pump-bot/internal/config"
+)
+
+func TestDueProfitLevels_RisingMultiple(t *testing.T) {
+	ladder := []config.ProfitLevel{{Multiple: 1.5, Percent: 25}, {Multiple: 2, Percent: 25}, {Multiple: 3, Percent: 50}}
+
+	var hit uint32
+	steps := []struct {
+		multiple    float64
+		wantDue     uint32
+		wantPercent float64 // of what is left
+	}{
+		{1.2, 0, 0},
+		{1.5, 0b001, 25},
+		{1.8, 0, 0},        // level 1 already sold
+		{2.5, 0b010, 33.3}, // 25 of the remaining 75
+		{1.1, 0, 0},        // falling back fires nothing
+		{3.0, 0b100, 100},  // last 50 of 50: sell the rest
+	}
+	for _, s := range steps {
+		due, percent, _ := dueProfitLevels(ladder, hit, 0, s.multiple)
+		if due != s.wantDue || math.Abs(percent-s.wantPercent) > 0.05 {
+			t.Errorf("at %vx: due %03b, %.1f%%; want %03b, %.1f%%", s.multiple, due, percent, s.wantDue, s.wantPercent)
+		}
+		hit |= due
+	}
+}
+
+func TestDueProfitLevels_GapFiresSkippedLevelsTogether(t *testing.T) {
+	ladder := []config.ProfitLevel{{Multiple: 1.5, Percent: 20}, {Multiple: 2, Percent: 20}, {Multiple: 4, Percent: 20}}
+	due, percent, soldAfter := dueProfitLevels(ladder, 0, 0, 2.4)
+	if due != 0b011 || percent != 40 || soldAfter != 0.4 {
+		t.Errorf("jump to 2.4x: due %03b, %v%%, sold %v; want 011, 40%%, 0.4", due, percent, soldAfter)
+	}
+}
+
+func TestDueProfitLevels_CountsCurveSales(t *testing.T) {
+	ladder := []config.ProfitLevel{{Multiple: 2, Percent: 25}}
+	// The curve already sold half the original: 25 of it is half of what is left
+	due, percent, soldAfter := dueProfitLevels(ladder, 0, 0.5, 2)
+	if due != 0b1 || percent != 50 || soldAfter != 0.25 {
+		t.Errorf("after curve sold 50%%: due %b, %v%%, ladder sold %v; want 1, 50%%, 0.25", due, percent, soldAfter)
+	}
+}
+
+func TestProfitLevels_LegacySingleLevel(t *testing.T) {
+	legacy := config.TradingConfig{PartialProfitPercent: 50, PartialProfitMultiple: 1.5}
+	if got := legacy.ProfitLevels(); len(got) != 1 || got[0] != (config.ProfitLevel{Multiple: 1.5, Percent: 50}) {
+		t.Errorf("legacy ProfitLevels = %v, want [{1.5 50}]", got)
+	}
+	ladder := config.TradingConfig{PartialProfitLevels: []config.ProfitLevel{{Multiple: 3, Percent: 10}, {Multiple: 2, Percent: 10}},
+		PartialProfitPercent: 50, PartialProfitMultiple: 1.5}
+	if got := ladder.ProfitLevels(); len(got) != 2 || got[0].Multiple != 2 {
+		t.Errorf("ProfitLevels = %v, want the ladder sorted by multiple", got)
+	}
+}
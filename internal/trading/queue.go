@@ -0,0 +1,132 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	signalPkg "solana-pump-bot/internal/signal"
+)
+
+// DefaultMaxConcurrentTrades is the in-flight limit when
+// trading.max_concurrent_trades is unset
+const DefaultMaxConcurrentTrades = 3
+
+// MaxQueuedSignals caps how many entry signals wait for a trade slot; a
+// relay dumping its backlog is skipped past this instead of piling up
+const MaxQueuedSignals = 50
+
+// MaxQueueWait is how long an entry signal may wait for a slot; a call
+// older than this has already moved and is skipped rather than bought late
+const MaxQueueWait = 30 * time.Second
+
+// tradeQueue runs signals in arrival order with at most limit of them in
+// flight, so a burst of calls doesn't send a burst of quotes and swaps past
+// the RPC and Jupiter rate limits
+type tradeQueue struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	next     uint64 // ticket handed to the next enqueued signal
+	head     uint64 // ticket allowed to start next
+	inFlight int
+}
+
+func newTradeQueue() *tradeQueue {
+	q := &tradeQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// acquire blocks until ticket is first in line and a slot is free; the
+// limit is read on every wake-up so a config reload applies to waiters
+func (q *tradeQueue) acquire(ticket uint64, limit func() int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for ticket != q.head || q.inFlight >= max(limit(), 1) {
+		q.cond.Wait()
+	}
+	q.head++
+	q.inFlight++
+	q.cond.Broadcast() // The next ticket may fit too
+}
+
+func (q *tradeQueue) release() {
+	q.mu.Lock()
+	q.inFlight--
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// ticket hands out the next place in line, or false when limit signals
+// already wait
+func (q *tradeQueue) ticket(limit int) (uint64, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if int(q.next-q.head) >= limit {
+		return 0, false
+	}
+	t := q.next
+	q.next++
+	return t, true
+}
+
+// depth returns how many signals wait for a slot and how many are running
+func (q *tradeQueue) depth() (queued, inFlight int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return int(q.next - q.head), q.inFlight
+}
+
+// maxConcurrentTrades is trading.max_concurrent_trades, defaulted
+func (e *ExecutorFast) maxConcurrentTrades() int {
+	if n := e.cfg.GetTrading().MaxConcurrentTrades; n > 0 {
+		return n
+	}
+	return DefaultMaxConcurrentTrades
+}
+
+// EnqueueSignal hands a signal to the trade queue and returns at once; it
+// runs through ProcessSignalFast when its turn comes. Duplicate and
+// pending-position checks still happen there, in arrival order. Exits skip
+// the queue: they sell what is already held and must not wait behind buys.
+// Entries are skipped when MaxQueuedSignals already wait, or when they
+// waited past MaxQueueWait by the time a slot frees up.
+func (e *ExecutorFast) EnqueueSignal(ctx context.Context, signal *signalPkg.Signal) {
+	run := func() {
+		if err := e.ProcessSignalFast(ctx, signal); err != nil {
+			log.Debug().Err(err).Str("token", signal.TokenName).Msg("queued signal failed")
+		}
+	}
+	if signal.Type == signalPkg.SignalExit {
+		go run()
+		return
+	}
+
+	ticket, ok := e.queue.ticket(MaxQueuedSignals)
+	if !ok {
+		e.skipSignal(signal, SkipQueueFull, fmt.Sprintf("%d waiting", MaxQueuedSignals))
+		return
+	}
+	if queued, inFlight := e.queue.depth(); queued > 1 || inFlight >= e.maxConcurrentTrades() {
+		log.Debug().Str("token", signal.TokenName).Int("queued", queued).Int("inFlight", inFlight).Msg("⏳ signal queued")
+	}
+	enqueued := time.Now()
+	go func() {
+		e.queue.acquire(ticket, e.maxConcurrentTrades)
+		defer e.queue.release()
+		if waited := time.Since(enqueued); waited > MaxQueueWait {
+			e.skipSignal(signal, SkipQueueStale, fmt.Sprintf("waited %s", waited.Round(time.Second)))
+			return
+		}
+		run()
+	}()
+}
+
+// QueueDepth returns how many signals wait in the trade queue and how many
+// are being processed
+func (e *ExecutorFast) QueueDepth() (queued, inFlight int) {
+	return e.queue.depth()
+}
@@ -0,0 +1,117 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"solana-pump-bot/internal/jupiter"
+	signalPkg "solana-pump-bot/internal/signal"
+)
+
+func TestEnqueueSignal_BoundsInFlightTrades(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 1
+  max_open_positions: 100
+  max_concurrent_trades: 2
+  min_entry_percent: 50
+  take_profit_multiple: 2
+jupiter:
+  slippage_bps: 500
+  timeout_seconds: 5
+`)
+
+	// Buy quotes hang until release, so queued signals pile up behind them
+	release := make(chan struct{})
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	h.chain.setQuoteOut(func(in, out string, amount uint64) uint64 {
+		if in != jupiter.SOLMint {
+			return amount / 1000
+		}
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return amount * 1000
+	})
+
+	const signals = 10
+	for i := 0; i < signals; i++ {
+		h.executor.EnqueueSignal(context.Background(), &signalPkg.Signal{
+			Mint:      fmt.Sprintf("QueueMint%033d", i),
+			TokenName: fmt.Sprintf("Q%d", i),
+			Type:      signalPkg.SignalEntry,
+			Value:     60,
+			Unit:      "%",
+			MsgID:     int64(100 + i),
+		})
+	}
+
+	waitFor(t, "two buys blocked on their quote", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return inFlight == 2
+	})
+	if queued, running := h.executor.QueueDepth(); queued != signals-2 || running != 2 {
+		t.Errorf("QueueDepth = %d queued, %d in flight; want %d, 2", queued, running, signals-2)
+	}
+
+	// Exits don't wait behind the blocked buys
+	h.executor.EnqueueSignal(context.Background(), &signalPkg.Signal{
+		Mint: testMint, TokenName: "EXIT", Type: signalPkg.SignalExit, Value: 2, Unit: "X", MsgID: 99,
+	})
+	waitFor(t, "the exit to run past the queue", func() bool {
+		for _, c := range h.executor.SkipCounts() {
+			if c.Category == SkipNoPosition {
+				return true
+			}
+		}
+		return false
+	})
+
+	// Entries past MaxQueuedSignals are skipped, not queued
+	for i := signals; i < MaxQueuedSignals+3; i++ { // 2 running + the cap + 1
+		h.executor.EnqueueSignal(context.Background(), &signalPkg.Signal{
+			Mint:      fmt.Sprintf("QueueMint%033d", i),
+			TokenName: fmt.Sprintf("Q%d", i),
+			Type:      signalPkg.SignalEntry,
+			Value:     60,
+			Unit:      "%",
+			MsgID:     int64(100 + i),
+		})
+	}
+	if queued, _ := h.executor.QueueDepth(); queued != MaxQueuedSignals {
+		t.Errorf("queued = %d, want capped at %d", queued, MaxQueuedSignals)
+	}
+	full := 0
+	for _, c := range h.executor.SkipCounts() {
+		if c.Category == SkipQueueFull {
+			full = c.Count
+		}
+	}
+	if full != 1 {
+		t.Errorf("queue_full skips = %d, want 1", full)
+	}
+
+	close(release)
+	waitFor(t, "every queued signal to run", func() bool {
+		queued, running := h.executor.QueueDepth()
+		return queued == 0 && running == 0
+	})
+	if n := h.chain.Calls("swap"); n != MaxQueuedSignals+2 {
+		t.Errorf("swaps = %d, want %d (one per admitted signal)", n, MaxQueuedSignals+2)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if peak != 2 {
+		t.Errorf("peak in-flight buys = %d, want max_concurrent_trades = 2", peak)
+	}
+}
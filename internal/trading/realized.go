@@ -0,0 +1,194 @@
+package trading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/jupiter"
+	"solana-pump-bot/internal/notify"
+	"solana-pump-bot/internal/storage"
+)
+
+// Fetching the sell's transaction: it may take a moment to be served at
+// "confirmed" even after the signature status says so
+const (
+	realizedFetchAttempts = 5
+	realizedFetchInterval = 2 * time.Second
+)
+
+// onChainEntrySig reports whether sig is a real buy transaction (not a
+// placeholder such as PENDING, FAILED, ADOPTED or a simulated buy)
+func onChainEntrySig(sig string) bool {
+	switch sig {
+	case "", "PENDING", "FAILED", AdoptedTxSig:
+		return false
+	}
+	return !strings.HasPrefix(sig, "SIM_")
+}
+
+// realizedPnLSol returns the SOL the sell brought in minus the SOL the buy
+// took out, both from the wallet's balance change in the transactions (so
+// fees and actual slippage are included). Without an on-chain buy the
+// position's Size stands in for its cost. After partial takes the sell only
+// closes the rest, so it is charged that share of the cost.
+func realizedPnLSol(ctx context.Context, rpc *blockchain.RPCClient, owner string, pos *Position, exitTxSig string) (float64, error) {
+	exit, err := fetchTransaction(ctx, rpc, exitTxSig)
+	if err != nil {
+		return 0, err
+	}
+	received, err := exit.SolDelta(owner)
+	if err != nil {
+		return 0, err
+	}
+
+	cost := pos.Size
+	if onChainEntrySig(pos.EntryTxSig) {
+		if entry, err := fetchTransaction(ctx, rpc, pos.EntryTxSig); err == nil {
+			if spent, err := entry.SolDelta(owner); err == nil {
+				cost = float64(-spent) / 1e9
+			}
+		}
+	}
+	if f := pos.SoldFraction; f > 0 && f < 1 {
+		cost *= 1 - f
+	}
+	return float64(received)/1e9 - cost, nil
+}
+
+// fetchTransaction retries GetTransaction while the transaction is not yet served
+func fetchTransaction(ctx context.Context, rpc *blockchain.RPCClient, sig string) (*blockchain.TxDetails, error) {
+	var lastErr error
+	for attempt := 0; attempt < realizedFetchAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(realizedFetchInterval):
+			}
+		}
+		tx, err := rpc.GetTransaction(ctx, sig)
+		if err == nil {
+			return tx, nil
+		}
+		lastErr = err
+		if !errors.Is(err, blockchain.ErrTransactionNotFound) {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// recordRealizedPnL is the post-sell accounting step: it computes the
+// realized PnL of a confirmed sell and stores it on the trade record.
+// Meant to run in its own goroutine; failures are logged and leave the
+// quote-based PnL as the only figure (ok is false).
+func recordRealizedPnL(rpc *blockchain.RPCClient, db *storage.DB, owner string, pos *Position, exitTxSig string) (pnl float64, ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(realizedFetchAttempts)*realizedFetchInterval+30*time.Second)
+	defer cancel()
+
+	pnl, err := realizedPnLSol(ctx, rpc, owner, pos, exitTxSig)
+	if err != nil {
+		log.Warn().Err(err).Str("token", pos.TokenName).Str("sig", exitTxSig).Msg("realized PnL unavailable")
+		return 0, false
+	}
+	if err := db.SetRealizedPnL(exitTxSig, pnl); err != nil {
+		log.Error().Err(err).Str("sig", exitTxSig).Msg("failed to store realized PnL")
+		return pnl, true
+	}
+	log.Info().
+		Str("token", pos.TokenName).
+		Float64("realizedSol", pnl).
+		Float64("quotePnlPercent", pos.PnLPercent).
+		Msg("💰 realized PnL recorded")
+	return pnl, true
+}
+
+// accountSell settles a confirmed full sell: the realized PnL is stored
+// (with a db), notified and added to the daily loss window. Without an on-chain
+// figure the quote-based PnL stands in, and is stored so a restart still
+// counts it. Meant to run in its own goroutine.
+func (e *ExecutorFast) accountSell(pos *Position, exitTxSig string) {
+	pnl := pos.remainingCostLocked() * pos.PnLPercent / 100 // pos is a snapshot; no lock needed
+	if e.db != nil {
+		if realized, ok := recordRealizedPnL(e.rpc, e.db, e.wallet.Address(), pos, exitTxSig); ok {
+			pnl = realized
+		} else if err := e.db.SetQuotePnL(exitTxSig, pnl); err != nil {
+			log.Error().Err(err).Str("sig", exitTxSig).Msg("failed to store quote-based PnL")
+		}
+	}
+	e.notify(notify.Event{
+		Kind:       notify.KindSell,
+		TokenName:  pos.TokenName,
+		Mint:       pos.Mint,
+		TxSig:      exitTxSig,
+		PnLSol:     pnl,
+		PnLPercent: pos.PnLPercent,
+	})
+	e.recordDailyPnL(pnl)
+}
+
+// fillOut returns what the wallet received from a confirmed swap: raw
+// tokens of mint for a buy, lamports for a sell. A sell's SOL balance change
+// has the transaction fee taken out already; it is added back, the fee is
+// not slippage.
+func fillOut(tx *blockchain.TxDetails, owner, side, mint string) (int64, error) {
+	if tx.Err != nil {
+		return 0, fmt.Errorf("transaction failed: %v", tx.Err)
+	}
+	if side == "BUY" {
+		return tx.TokenDelta(owner, mint), nil
+	}
+	delta, err := tx.SolDelta(owner)
+	if err != nil {
+		return 0, err
+	}
+	if len(tx.AccountKeys) > 0 && tx.AccountKeys[0] == owner {
+		delta += int64(tx.Fee)
+	}
+	return delta, nil
+}
+
+// realizedSlippagePct is how far the actual output fell short of the
+// quote's expected output, in percent (negative = filled better)
+func realizedSlippagePct(expectedOut uint64, actualOut int64) float64 {
+	if expectedOut == 0 {
+		return 0
+	}
+	return (float64(expectedOut) - float64(actualOut)) / float64(expectedOut) * 100
+}
+
+// recordTradeSlippage compares a sent swap's confirmed output with its
+// quote and stores the realized slippage on the trade record. Meant to run
+// in its own goroutine; failures are logged and leave the trade at 0.
+func (e *ExecutorFast) recordTradeSlippage(side, mint, txSig string, quote *jupiter.QuoteResponse) {
+	if e.db == nil || quote == nil {
+		return
+	}
+	expected, err := strconv.ParseUint(quote.OutAmount, 10, 64)
+	if err != nil || expected == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(realizedFetchAttempts)*realizedFetchInterval+30*time.Second)
+	defer cancel()
+	tx, err := fetchTransaction(ctx, e.rpc, txSig)
+	if err == nil {
+		var actual int64
+		if actual, err = fillOut(tx, e.wallet.Address(), side, mint); err == nil {
+			pct := realizedSlippagePct(expected, actual)
+			if err := e.db.SetTradeSlippage(txSig, pct); err != nil {
+				log.Error().Err(err).Str("sig", txSig).Msg("failed to store realized slippage")
+			}
+			log.Debug().Str("side", side).Str("mint", mint).Float64("slippagePct", pct).Msg("realized slippage recorded")
+			return
+		}
+	}
+	log.Debug().Err(err).Str("side", side).Str("sig", txSig).Msg("realized slippage unavailable")
+}
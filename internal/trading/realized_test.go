@@ -0,0 +1,48 @@
+package trading
+
+import (
+	"math"
+	"testing"
+
+	"solana-pump-bot/internal/blockchain"
+)
+
+func TestRealizedSlippage_FromConfirmedFill(t *testing.T) {
+	const owner, mint = "Owner111", "Mint111"
+
+	buy := &blockchain.TxDetails{
+		Fee:               5000,
+		AccountKeys:       []string{owner},
+		PreBalances:       []uint64{2_000_000_000},
+		PostBalances:      []uint64{1_899_995_000},
+		PostTokenBalances: []blockchain.TokenBalance{{AccountIndex: 1, Mint: mint, Owner: owner, Amount: 990_000}},
+	}
+	got, err := fillOut(buy, owner, "BUY", mint)
+	if err != nil || got != 990_000 {
+		t.Fatalf("buy fill = %d, %v; want 990000 tokens", got, err)
+	}
+	if pct := realizedSlippagePct(1_000_000, got); math.Abs(pct-1) > 1e-9 {
+		t.Errorf("buy slippage = %.4f%%, want 1%%", pct)
+	}
+
+	// The fee comes out of the SOL delta but is not slippage
+	sell := &blockchain.TxDetails{
+		Fee:              5000,
+		AccountKeys:      []string{owner},
+		PreBalances:      []uint64{1_000_000_000},
+		PostBalances:     []uint64{1_204_995_000},
+		PreTokenBalances: []blockchain.TokenBalance{{AccountIndex: 1, Mint: mint, Owner: owner, Amount: 990_000}},
+	}
+	got, err = fillOut(sell, owner, "SELL", mint)
+	if err != nil || got != 205_000_000 {
+		t.Fatalf("sell fill = %d, %v; want 205000000 lamports", got, err)
+	}
+	if pct := realizedSlippagePct(200_000_000, got); math.Abs(pct+2.5) > 1e-9 {
+		t.Errorf("sell slippage = %.4f%%, want -2.5%% (filled better)", pct)
+	}
+
+	sell.Err = map[string]interface{}{"InstructionError": []interface{}{2, "Custom"}}
+	if _, err := fillOut(sell, owner, "SELL", mint); err == nil {
+		t.Error("failed transaction should not report a fill")
+	}
+}
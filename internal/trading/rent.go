@@ -0,0 +1,67 @@
+package trading
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/blockchain"
+)
+
+// hasTokenAccount reports whether the wallet's associated token account for
+// mint exists under either token program (one getMultipleAccounts)
+func (e *ExecutorFast) hasTokenAccount(ctx context.Context, mint string) (bool, error) {
+	var atas []string
+	for _, program := range []string{blockchain.TokenProgramID, blockchain.Token2022ProgramID} {
+		ata, err := blockchain.DeriveATAForProgram(e.wallet.Address(), mint, program)
+		if err != nil {
+			return false, err
+		}
+		atas = append(atas, ata)
+	}
+	infos, err := e.rpc.GetMultipleAccounts(ctx, atas)
+	if err != nil {
+		return false, err
+	}
+	for _, info := range infos {
+		if info != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fitATARent leaves room for the token account a first buy of mint creates
+// (blockchain.TokenAccountRentLamports). Only a buy that would leave less
+// than the rent looks the account up; if it is missing the buy shrinks to
+// fit, and fails once that leaves less than MinAllocLamports. A failed
+// lookup keeps the buy as sized.
+func (e *ExecutorFast) fitATARent(ctx context.Context, mint string, balanceLamports, allocLamports uint64) (uint64, error) {
+	if allocLamports+blockchain.TokenAccountRentLamports <= balanceLamports {
+		return allocLamports, nil
+	}
+	exists, err := e.hasTokenAccount(ctx, mint)
+	if err != nil {
+		log.Debug().Err(err).Str("mint", mint).Msg("token account lookup failed, buying as sized")
+		return allocLamports, nil
+	}
+	if exists {
+		return allocLamports, nil
+	}
+
+	var fitted uint64
+	if balanceLamports > blockchain.TokenAccountRentLamports {
+		fitted = balanceLamports - blockchain.TokenAccountRentLamports
+	}
+	if fitted < MinAllocLamports {
+		return 0, fmt.Errorf("balance %.4f SOL does not cover the %.4f SOL token account rent", float64(balanceLamports)/1e9, float64(blockchain.TokenAccountRentLamports)/1e9)
+	}
+	log.Info().
+		Str("mint", mint).
+		Float64("rentSOL", float64(blockchain.TokenAccountRentLamports)/1e9).
+		Float64("fromSOL", float64(allocLamports)/1e9).
+		Float64("toSOL", float64(fitted)/1e9).
+		Msg("🏦 first buy of token: buy reduced to leave token account rent")
+	return fitted, nil
+}
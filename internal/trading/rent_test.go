@@ -0,0 +1,65 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mr-tron/base58"
+
+	"solana-pump-bot/internal/blockchain"
+)
+
+func TestExecutorFast_FirstBuyLeavesTokenAccountRent(t *testing.T) {
+	const fullAlloc = `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 100
+  reserve_sol: 0
+  max_open_positions: 5
+  min_entry_percent: 50
+  take_profit_multiple: 2
+jupiter:
+  slippage_bps: 500
+  timeout_seconds: 5
+`
+	mintKey := make([]byte, 32)
+	mintKey[0] = 7
+	mint := base58.Encode(mintKey) // a valid address: the ATA is derived from it
+	buy := func(h *testHarness) float64 {
+		t.Helper()
+		sig := entrySignal(1)
+		sig.Mint = mint
+		if err := h.executor.ProcessSignalFast(context.Background(), sig); err != nil {
+			t.Fatalf("ProcessSignalFast: %v", err)
+		}
+		pos := h.positions.Get(mint)
+		if pos == nil {
+			t.Fatal("no position opened")
+		}
+		return pos.Size
+	}
+
+	// No token account yet: the buy shrinks by the rent
+	h := newTestHarness(t, fullAlloc)
+	h.chain.rpcOverride["getMultipleAccounts"] = func(params []json.RawMessage) (interface{}, string) {
+		return map[string]interface{}{"value": []interface{}{nil, nil}}, ""
+	}
+	want := float64(1_000_000_000-blockchain.TokenAccountRentLamports) / 1e9
+	if size := buy(h); size != want {
+		t.Errorf("first buy size = %v, want %v (balance less rent)", size, want)
+	}
+
+	// Account already there (bought before): full size
+	h = newTestHarness(t, fullAlloc)
+	if size := buy(h); size != 1 {
+		t.Errorf("repeat buy size = %v, want 1", size)
+	}
+
+	// Room to spare: no lookup at all
+	h = newTestHarness(t, "")
+	buy(h)
+	if n := h.chain.Calls("getMultipleAccounts"); n != 0 {
+		t.Errorf("getMultipleAccounts calls = %d, want 0 when the rent fits", n)
+	}
+}
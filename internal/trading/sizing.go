@@ -0,0 +1,33 @@
+package trading
+
+import (
+	"math"
+
+	"solana-pump-bot/internal/config"
+	signalPkg "solana-pump-bot/internal/signal"
+)
+
+// signalStrength is the call's "is up N%" value for sizing; value-less
+// entries and other units count as the weakest call (0)
+func signalStrength(signal *signalPkg.Signal) float64 {
+	if signal.Unit != "%" {
+		return 0
+	}
+	return signal.Value
+}
+
+// allocPercentFor is the percent of balance a buy of this strength uses.
+// fixed: the (per-token) alloc percent. linear: trading.sizing.min_alloc_percent
+// at min_entry_percent, rising to the alloc percent at full_size_percent;
+// values outside that range are clamped to its ends.
+func allocPercentFor(cfg config.TradingConfig, mint, name string, strength float64) float64 {
+	full := cfg.AllocPercentFor(mint, name)
+	s := cfg.Sizing
+	if s.Strategy != config.SizingLinear || s.FullSizePercent <= cfg.MinEntryPercent {
+		return full
+	}
+	floor := math.Min(s.MinAllocPercent, full)
+	frac := (strength - cfg.MinEntryPercent) / (s.FullSizePercent - cfg.MinEntryPercent)
+	frac = math.Max(0, math.Min(1, frac))
+	return floor + (full-floor)*frac
+}
@@ -0,0 +1,129 @@
+package trading
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"solana-pump-bot/internal/config"
+)
+
+func TestAllocLamports_SizingStrategies(t *testing.T) {
+	e := &ExecutorFast{}
+	fixed := config.TradingConfig{MinEntryPercent: 50, MaxAllocPercent: 20}
+	linear := fixed
+	linear.Sizing = config.SizingConfig{Strategy: config.SizingLinear, MinAllocPercent: 5, FullSizePercent: 200}
+
+	tests := []struct {
+		name     string
+		cfg      config.TradingConfig
+		strength float64
+		balance  uint64
+		want     uint64
+	}{
+		{"fixed ignores strength", fixed, 50, 1_000_000_000, 200_000_000},
+		{"fixed strong call", fixed, 500, 1_000_000_000, 200_000_000},
+		{"linear at entry threshold", linear, 50, 1_000_000_000, 50_000_000},
+		{"linear halfway", linear, 125, 1_000_000_000, 125_000_000},
+		{"linear at full size", linear, 200, 1_000_000_000, 200_000_000},
+		{"linear above full size clamps", linear, 900, 1_000_000_000, 200_000_000},
+		{"linear value-less call gets the floor", linear, 0, 1_000_000_000, 50_000_000},
+		{"linear floor under minimum", linear, 50, 10_000_000, MinAllocLamports},
+		{"fixed under minimum", fixed, 100, 2_000_000, MinAllocLamports},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.allocLamports(tt.cfg, testMint, "TEST", tt.strength, tt.balance); got != tt.want {
+				t.Errorf("allocLamports = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// A per-token alloc override is the linear cap; the floor never exceeds it
+	linear.TokenOverrides = map[string]config.TokenOverride{"TEST": {Alloc: 4}}
+	for _, strength := range []float64{50, 125, math.Inf(1)} {
+		if got := e.allocLamports(linear, testMint, "TEST", strength, 1_000_000_000); got != 40_000_000 {
+			t.Errorf("override strength %v: allocLamports = %d, want 40000000", strength, got)
+		}
+	}
+}
+
+func TestExecutorFast_LinearSizingScalesBuy(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 10
+  reserve_sol: 0
+  max_open_positions: 5
+  min_entry_percent: 50
+  take_profit_multiple: 2
+  sizing:
+    strategy: linear
+    min_alloc_percent: 2
+    full_size_percent: 150
+jupiter:
+  slippage_bps: 500
+  timeout_seconds: 5
+`)
+
+	sig := entrySignal(1)
+	sig.Value = 100 // halfway from 50% to 150%: 6% of 1 SOL
+	if err := h.executor.ProcessSignalFast(context.Background(), sig); err != nil {
+		t.Fatalf("ProcessSignalFast: %v", err)
+	}
+	pos := h.positions.Get(testMint)
+	if pos == nil {
+		t.Fatal("no position opened")
+	}
+	if math.Abs(pos.Size-0.06) > 1e-9 {
+		t.Errorf("position size = %v, want 0.06", pos.Size)
+	}
+}
+
+func TestAllocLamports_KeepsFeeReserve(t *testing.T) {
+	e := &ExecutorFast{}
+	cfg := config.TradingConfig{MinEntryPercent: 50, MaxAllocPercent: 100, ReserveSol: 0.01}
+
+	for _, balance := range []uint64{1_000_000_000, 50_000_000, 10_500_000, 10_000_000, 5_000_000} {
+		alloc := e.allocLamports(cfg, testMint, "TEST", 60, balance)
+		if balance <= 10_000_000 {
+			if alloc != 0 {
+				t.Errorf("balance %d: alloc = %d, want 0 (all reserve)", balance, alloc)
+			}
+			continue
+		}
+		if alloc+10_000_000 > balance {
+			t.Errorf("balance %d: alloc = %d dips into the 0.01 SOL reserve", balance, alloc)
+		}
+	}
+	if got := e.allocLamports(cfg, testMint, "TEST", 60, 1_000_000_000); got != 990_000_000 {
+		t.Errorf("100%% alloc of 1 SOL = %d, want 990000000", got)
+	}
+}
+
+func TestExecutorFast_ReserveAboveBalanceRefusesBuy(t *testing.T) {
+	h := newTestHarness(t, `
+trading:
+  auto_trading_enabled: true
+  max_alloc_percent: 100
+  reserve_sol: 1
+  max_open_positions: 5
+  min_entry_percent: 50
+  take_profit_multiple: 2
+jupiter:
+  slippage_bps: 500
+  timeout_seconds: 5
+`)
+
+	err := h.executor.ProcessSignalFast(context.Background(), entrySignal(1))
+	if err == nil || !strings.Contains(err.Error(), "reserve") {
+		t.Fatalf("ProcessSignalFast err = %v, want a reserve error", err)
+	}
+	if h.chain.Calls("quote") != 0 || h.positions.Get(testMint) != nil {
+		t.Error("buy went ahead with the whole balance reserved")
+	}
+	if err := h.executor.ManualBuy(context.Background(), testMint, 0.5); err == nil || !strings.Contains(err.Error(), "reserve") {
+		t.Errorf("ManualBuy err = %v, want a reserve error", err)
+	}
+}
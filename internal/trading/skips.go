@@ -0,0 +1,107 @@
+package trading
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/events"
+	signalPkg "solana-pump-bot/internal/signal"
+	"solana-pump-bot/internal/token"
+)
+
+// Reasons a signal was not traded (the "reason" field of "⏭️ SIGNAL SKIPPED")
+const (
+	SkipUnresolved     = "unresolved_mint" // token name could not be resolved to a mint
+	SkipDuplicate      = "duplicate"
+	SkipAutoTradingOff = "auto_trading_off"
+	SkipStartupGrace   = "startup_grace"
+	SkipDegraded       = "degraded"
+	SkipIgnoredMint    = "ignored_mint"
+	SkipMaxPositions   = "max_positions"
+	SkipHavePosition   = "already_have_position"
+	SkipRebuyCooldown  = "rebuy_cooldown"
+	SkipBlacklisted    = token.FilterBlacklisted
+	SkipNotWhitelisted = token.FilterNotWhitelisted
+	SkipNoPosition     = "no_position" // exit signal for a token we don't hold
+	SkipZeroBalance    = "zero_balance"
+	SkipLowBalance     = "low_balance"
+	SkipPriceImpact    = "price_impact" // quote moves the price past trading.max_price_impact_percent
+	SkipQueueFull      = "queue_full"   // MaxQueuedSignals already waiting for a trade slot
+	SkipQueueStale     = "queue_stale"  // waited longer than MaxQueueWait for a trade slot
+)
+
+// SkipCounter counts skipped signals by reason since start (or the last reset)
+type SkipCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewSkipCounter creates an empty counter
+func NewSkipCounter() *SkipCounter {
+	return &SkipCounter{counts: make(map[string]int)}
+}
+
+// Inc counts one skip for reason
+func (c *SkipCounter) Inc(reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[reason]++
+}
+
+// Counts returns per-reason totals, highest first
+func (c *SkipCounter) Counts() []IssueCount {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]IssueCount, 0, len(c.counts))
+	for reason, n := range c.counts {
+		out = append(out, IssueCount{Category: reason, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Category < out[j].Category
+	})
+	return out
+}
+
+// Reset clears all counts
+func (c *SkipCounter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts = make(map[string]int)
+}
+
+// skipSignal is the single log line for a signal that is not traded, so
+// "why didn't the bot buy X" is one grep for the token away. detail is
+// optional free text (e.g. the remaining grace or the balance).
+func (e *ExecutorFast) skipSignal(signal *signalPkg.Signal, reason, detail string) {
+	e.skips.Inc(reason)
+
+	ev := log.Warn()
+	if reason == SkipDuplicate {
+		ev = log.Debug() // relays resend constantly; not worth a warning
+	}
+	ev = ev.
+		Str("reason", reason).
+		Str("token", signal.TokenName).
+		Str("mint", signal.Mint).
+		Str("type", string(signal.Type)).
+		Int64("msgID", signal.MsgID)
+	if detail != "" {
+		ev = ev.Str("detail", detail)
+	}
+	ev.Msg("⏭️ SIGNAL SKIPPED")
+
+	e.recordDecision(signal, DecisionSkip, reason, detail)
+
+	e.publish(events.SignalSkipped{Mint: signal.Mint, TokenName: signal.TokenName, Reason: reason})
+}
+
+// SkipCounts returns skipped-signal totals by reason, highest first
+func (e *ExecutorFast) SkipCounts() []IssueCount {
+	return e.skips.Counts()
+}
@@ -0,0 +1,177 @@
+package trading
+
+import (
+	"math"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/storage"
+)
+
+// Adaptive slippage policy (jupiter.adaptive_slippage):
+//
+//   - Each mint keeps a learned base in bps, starting at jupiter.slippage_bps.
+//   - A swap is quoted at base * (1 + adaptive_pad_percent/100), clamped to
+//     [adaptive_min_bps, adaptive_max_bps].
+//   - A confirmed swap tightens the base by SlippageTightenFactor.
+//   - A slippage-category failure (a rejected send, or a swap that reverted
+//     on-chain) raises the base to SlippageWidenFactor times the base that
+//     was in effect, so the next retry quotes wider.
+//   - History older than adaptive_max_age_hours is ignored, so a mint that has
+//     not traded for a while starts again from the default.
+//
+// Sends skip preflight, so an accepted send says nothing yet: success is
+// only learned once the transaction confirms.
+//
+// Only slippage failures widen; other failures (blockhash, balance, ...) say
+// nothing about price impact and leave the base untouched.
+//
+// Independently, within one buy/sell the retry after a slippage failure is
+// quoted jupiter.retry_slippage_step_bps wider than the failed attempt, up
+// to jupiter.retry_slippage_max_bps, so it doesn't fail the same way again.
+const (
+	SlippageTightenFactor = 0.95
+	SlippageWidenFactor   = 1.5
+)
+
+// effectiveSlippageBps pads a learned base and clamps it to the configured bounds
+func effectiveSlippageBps(baseBps int, jc config.JupiterConfig) int {
+	bps := int(math.Round(float64(baseBps) * (1 + jc.AdaptivePadPercent/100)))
+	if jc.AdaptiveMinBps > 0 && bps < jc.AdaptiveMinBps {
+		bps = jc.AdaptiveMinBps
+	}
+	if jc.AdaptiveMaxBps > 0 && bps > jc.AdaptiveMaxBps {
+		bps = jc.AdaptiveMaxBps
+	}
+	return bps
+}
+
+// nextSlippageBase returns the learned base after an outcome at baseBps
+func nextSlippageBase(baseBps int, success bool, jc config.JupiterConfig) int {
+	next := float64(baseBps) * SlippageWidenFactor
+	if success {
+		next = float64(baseBps) * SlippageTightenFactor
+	}
+	bps := int(math.Round(next))
+	// Keep the base inside the bounds so it can't run away in either direction
+	if jc.AdaptiveMinBps > 0 && bps < jc.AdaptiveMinBps {
+		bps = jc.AdaptiveMinBps
+	}
+	if jc.AdaptiveMaxBps > 0 && bps > jc.AdaptiveMaxBps {
+		bps = jc.AdaptiveMaxBps
+	}
+	return bps
+}
+
+// escalatedSlippageBps returns the slippage for a retry after an attempt at
+// prevBps failed with prevErr, or 0 when that failure doesn't call for more
+func escalatedSlippageBps(prevBps int, prevErr error, jc config.JupiterConfig) int {
+	if prevErr == nil || jc.RetrySlippageStepBps <= 0 || blockchain.ErrorCategory(prevErr) != blockchain.CategorySlippage {
+		return 0
+	}
+	bps := prevBps + jc.RetrySlippageStepBps
+	if jc.RetrySlippageMaxBps > 0 && bps > jc.RetrySlippageMaxBps {
+		bps = jc.RetrySlippageMaxBps
+	}
+	return bps
+}
+
+// slippageBase loads the learned base for a mint (default if unknown or stale)
+func (e *ExecutorFast) slippageBase(mint string, jc config.JupiterConfig) (*storage.MintSlippage, int) {
+	def := e.jupiter.SlippageBps()
+	if e.db == nil {
+		return nil, def
+	}
+	rec, err := e.db.GetMintSlippage(mint)
+	if err != nil {
+		log.Debug().Err(err).Str("mint", mint).Msg("failed to load mint slippage")
+		return nil, def
+	}
+	if rec == nil {
+		return nil, def
+	}
+	if jc.AdaptiveMaxAgeHours > 0 &&
+		time.Since(time.Unix(rec.UpdatedAt, 0)) > time.Duration(jc.AdaptiveMaxAgeHours)*time.Hour {
+		return nil, def
+	}
+	return rec, rec.BaseBps
+}
+
+// slippageFor returns the slippage (bps) to quote a swap of this mint with
+func (e *ExecutorFast) slippageFor(mint string) int {
+	// Emergency exits (e.g. liquidity pulled) override the normal slippage
+	if pos := e.positions.Get(mint); pos != nil {
+		if bps := pos.GetExitSlippageBps(); bps > 0 {
+			return bps
+		}
+	}
+	jc := e.cfg.Get().Jupiter
+	if !jc.AdaptiveSlippage || e.db == nil {
+		return e.jupiter.SlippageBps()
+	}
+	_, base := e.slippageBase(mint, jc)
+	return effectiveSlippageBps(base, jc)
+}
+
+// retrySlippageFor is slippageFor for attempt N of a swap: after a slippage
+// failure at prevBps it quotes wider (never below the normal slippage). The
+// bump is per call; nothing shared is changed.
+func (e *ExecutorFast) retrySlippageFor(mint string, prevBps int, prevErr error) int {
+	bps := e.slippageFor(mint)
+	if next := escalatedSlippageBps(prevBps, prevErr, e.cfg.Get().Jupiter); next > bps {
+		log.Warn().
+			Str("mint", mint).
+			Int("prevBps", prevBps).
+			Int("slippageBps", next).
+			Msg("📈 slippage exceeded, retrying wider")
+		bps = next
+	}
+	return bps
+}
+
+// recordSlippage updates the learned base for a mint after a swap's outcome:
+// nil for a confirmed swap, else the send or on-chain failure
+func (e *ExecutorFast) recordSlippage(mint string, err error) {
+	jc := e.cfg.Get().Jupiter
+	if !jc.AdaptiveSlippage || e.db == nil {
+		return
+	}
+	success := err == nil
+	if !success && blockchain.ErrorCategory(err) != blockchain.CategorySlippage {
+		return
+	}
+
+	rec, base := e.slippageBase(mint, jc)
+	if rec == nil {
+		rec = &storage.MintSlippage{Mint: mint}
+	}
+	rec.BaseBps = nextSlippageBase(base, success, jc)
+	if success {
+		rec.Successes++
+	} else {
+		rec.Failures++
+	}
+	rec.UpdatedAt = time.Now().Unix()
+
+	if dbErr := e.db.UpsertMintSlippage(rec); dbErr != nil {
+		log.Warn().Err(dbErr).Str("mint", mint).Msg("failed to save mint slippage")
+		return
+	}
+	log.Debug().
+		Str("mint", mint).
+		Int("baseBps", rec.BaseBps).
+		Bool("success", success).
+		Msg("mint slippage updated")
+}
+
+// learnSlippageOnConfirm waits for a sent swap to settle and records its
+// outcome. Meant to run in its own goroutine; a swap that never lands is
+// not a slippage failure and leaves the base untouched.
+func (e *ExecutorFast) learnSlippageOnConfirm(mint, txSig string, lastValidBlockHeight uint64) {
+	if !e.cfg.Get().Jupiter.AdaptiveSlippage || e.db == nil {
+		return
+	}
+	e.recordSlippage(mint, e.awaitCommitment(txSig, "confirmed", DefaultSellConfirmTimeout, lastValidBlockHeight))
+}
@@ -0,0 +1,58 @@
+package trading
+
+import (
+	"errors"
+	"testing"
+
+	"solana-pump-bot/internal/config"
+)
+
+func TestAdaptiveSlippage_WidensOnFailureAndTightensOnSuccess(t *testing.T) {
+	jc := config.JupiterConfig{
+		AdaptiveSlippage:   true,
+		AdaptivePadPercent: 20,
+		AdaptiveMinBps:     100,
+		AdaptiveMaxBps:     3000,
+	}
+
+	if got := effectiveSlippageBps(500, jc); got != 600 {
+		t.Errorf("effective(500) = %d, want 600 (20%% pad)", got)
+	}
+	if got := effectiveSlippageBps(2900, jc); got != 3000 {
+		t.Errorf("effective(2900) = %d, want 3000 (clamped to max)", got)
+	}
+
+	base := nextSlippageBase(500, false, jc)
+	if base != 750 {
+		t.Errorf("base after failure = %d, want 750", base)
+	}
+	if got := nextSlippageBase(base, true, jc); got != 713 {
+		t.Errorf("base after success = %d, want 713", got)
+	}
+	if got := nextSlippageBase(105, true, jc); got != 100 {
+		t.Errorf("base after success at floor = %d, want 100", got)
+	}
+}
+
+func TestEscalatedSlippage_StepsOnSlippageFailuresUpToCeiling(t *testing.T) {
+	jc := config.JupiterConfig{RetrySlippageStepBps: 500, RetrySlippageMaxBps: 2000}
+	slipErr := errors.New("custom program error: ExceededSlippage")
+
+	bps := 500
+	for _, want := range []int{1000, 1500, 2000, 2000} {
+		bps = escalatedSlippageBps(bps, slipErr, jc)
+		if bps != want {
+			t.Fatalf("escalated = %d, want %d", bps, want)
+		}
+	}
+	if got := escalatedSlippageBps(500, errors.New("Blockhash not found"), jc); got != 0 {
+		t.Errorf("escalated after blockhash error = %d, want 0", got)
+	}
+	if got := escalatedSlippageBps(500, nil, jc); got != 0 {
+		t.Errorf("escalated after success = %d, want 0", got)
+	}
+	jc.RetrySlippageStepBps = 0
+	if got := escalatedSlippageBps(500, slipErr, jc); got != 0 {
+		t.Errorf("escalated with step 0 = %d, want 0", got)
+	}
+}
@@ -0,0 +1,49 @@
+package trading
+
+import (
+	"sort"
+
+	"solana-pump-bot/internal/config"
+)
+
+// TakeProfitCurveMinStep is the smallest additional fraction worth a sell.
+// Without it a linearly interpolated curve would fire a dust sell every tick.
+const TakeProfitCurveMinStep = 0.05
+
+// TakeProfitFraction maps the current multiple to the cumulative fraction of the
+// original position that should have been sold by now (0..1).
+//
+// Points are (multiple, fraction) pairs; between points the fraction is
+// linearly interpolated, below the first point nothing is sold and past the
+// last point its fraction holds. E.g. [{2, 0.5}, {4, 1.0}] sells half at 2x,
+// 75% by 3x and everything at 4x.
+func TakeProfitFraction(curve []config.TakeProfitPoint, multiple float64) float64 {
+	if len(curve) == 0 {
+		return 0
+	}
+	points := make([]config.TakeProfitPoint, len(curve))
+	copy(points, curve)
+	sort.Slice(points, func(i, j int) bool { return points[i].Multiple < points[j].Multiple })
+
+	if multiple < points[0].Multiple {
+		return 0
+	}
+
+	fraction := points[len(points)-1].Fraction
+	for i := 0; i < len(points)-1; i++ {
+		lo, hi := points[i], points[i+1]
+		if multiple >= lo.Multiple && multiple < hi.Multiple {
+			t := (multiple - lo.Multiple) / (hi.Multiple - lo.Multiple)
+			fraction = lo.Fraction + t*(hi.Fraction-lo.Fraction)
+			break
+		}
+	}
+
+	if fraction < 0 {
+		return 0
+	}
+	if fraction > 1 {
+		return 1
+	}
+	return fraction
+}
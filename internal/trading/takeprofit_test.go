@@ -0,0 +1,29 @@
+package trading
+
+import (
+	"math"
+	"testing"
+
+	"solana-pump-bot/internal/config"
+)
+
+func TestTakeProfitFraction(t *testing.T) {
+	curve := []config.TakeProfitPoint{
+		{Multiple: 4, Fraction: 1.0}, // unsorted on purpose
+		{Multiple: 2, Fraction: 0.5},
+	}
+	cases := []struct {
+		multiple, want float64
+	}{
+		{1.5, 0},
+		{2, 0.5},
+		{3, 0.75},
+		{4, 1},
+		{10, 1},
+	}
+	for _, c := range cases {
+		if got := TakeProfitFraction(curve, c.multiple); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("TakeProfitFraction(%v) = %v, want %v", c.multiple, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package trading
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/config"
+)
+
+// TrailingStopArmMultiple is the peak a position must reach before the
+// trailing stop arms; below it the plain stop-loss is the only floor.
+const TrailingStopArmMultiple = 1.2
+
+// TrailingStopHit reports whether multiple has retraced percent (0-100) from
+// peak, once peak is past TrailingStopArmMultiple. percent <= 0 disables it.
+func TrailingStopHit(peak, multiple, percent float64) bool {
+	if percent <= 0 || peak <= TrailingStopArmMultiple || multiple <= 0 {
+		return false
+	}
+	return multiple <= peak*(1-percent/100)
+}
+
+// BreakEvenMultiple is the stop a position gets once break-even is armed
+// (trading.move_stop_to_break_even_after_partial)
+const BreakEvenMultiple = 1.0
+
+// EffectiveStopLoss combines the configured stop-loss multiple (global or
+// token override, 0 = none) with the break-even stop. Armed break-even only
+// ever raises the floor: a tighter stop above 1.0X is kept. The trailing
+// stop is checked separately; whichever triggers first sells.
+func EffectiveStopLoss(stopLoss float64, breakEvenArmed bool) float64 {
+	if breakEvenArmed && stopLoss < BreakEvenMultiple {
+		return BreakEvenMultiple
+	}
+	return stopLoss
+}
+
+// priceStopArmed reports whether an exit driven by pos's price is armed: a
+// stop-loss (or break-even), the trailing stop or the give-back cap. The
+// monitor re-quotes such positions every pass.
+func priceStopArmed(cfg config.TradingConfig, pos *Position) bool {
+	return EffectiveStopLoss(cfg.StopLossFor(pos.Mint, pos.TokenName), pos.IsBreakEvenArmed()) > 0 ||
+		cfg.TrailingStopPercent > 0 || cfg.MaxGiveBackSol > 0
+}
+
+// armBreakEven moves pos's stop to entry after a partial take, when enabled
+func (e *ExecutorFast) armBreakEven(pos *Position, cfg config.TradingConfig) {
+	if !cfg.MoveStopToBreakEvenAfterPartial || pos.IsBreakEvenArmed() {
+		return
+	}
+	pos.ArmBreakEven()
+	log.Info().Str("token", pos.TokenName).Msg("⚖️ stop moved to break-even after partial take")
+}
@@ -0,0 +1,42 @@
+package trading
+
+import "testing"
+
+func TestTrailingStopHit(t *testing.T) {
+	tests := []struct {
+		name                    string
+		peak, multiple, percent float64
+		want                    bool
+	}{
+		{"disabled", 3, 1, 0, false},
+		{"not armed below 1.2x peak", 1.15, 0.5, 20, false},
+		{"within trail", 3, 2.5, 20, false},
+		{"retraced past trail", 3, 2.4, 20, true},
+		{"no quote", 3, 0, 20, false},
+	}
+	for _, tt := range tests {
+		if got := TrailingStopHit(tt.peak, tt.multiple, tt.percent); got != tt.want {
+			t.Errorf("%s: TrailingStopHit(%v, %v, %v) = %v, want %v", tt.name, tt.peak, tt.multiple, tt.percent, got, tt.want)
+		}
+	}
+}
+
+func TestEffectiveStopLoss(t *testing.T) {
+	tests := []struct {
+		name     string
+		stopLoss float64
+		armed    bool
+		want     float64
+	}{
+		{"no stop, not armed", 0, false, 0},
+		{"configured stop only", 0.6, false, 0.6},
+		{"armed without a stop", 0, true, 1.0},
+		{"armed raises a looser stop", 0.6, true, 1.0},
+		{"armed keeps a tighter stop", 1.1, true, 1.1},
+	}
+	for _, tt := range tests {
+		if got := EffectiveStopLoss(tt.stopLoss, tt.armed); got != tt.want {
+			t.Errorf("%s: EffectiveStopLoss(%v, %v) = %v, want %v", tt.name, tt.stopLoss, tt.armed, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package trading
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/jupiter"
+)
+
+// warmEntry is a pre-resolved, pre-quoted watchlist token
+type warmEntry struct {
+	symbol      string
+	quote       *jupiter.QuoteResponse // SOL -> token for the current allocation
+	slippageBps int
+}
+
+// warmCache holds buy quotes for watchlisted tokens, keyed by mint
+type warmCache struct {
+	mu      sync.Mutex
+	entries map[string]*warmEntry
+}
+
+func newWarmCache() *warmCache {
+	return &warmCache{entries: make(map[string]*warmEntry)}
+}
+
+// StartWarmStandby keeps tokens.watchlist warm: each symbol (or mint) is
+// resolved once through resolve and a buy quote for the current allocation
+// is refreshed every half TTL, so an entry signal for a watched token skips
+// straight to building the swap. Does nothing without a watchlist.
+func (e *ExecutorFast) StartWarmStandby(ctx context.Context, resolve func(string) (string, error)) {
+	tc := e.cfg.Get().Tokens
+	if len(tc.Watchlist) == 0 || tc.WarmQuoteTTLMs <= 0 {
+		return
+	}
+	interval := time.Duration(tc.WarmQuoteTTLMs) * time.Millisecond / 2
+	if interval < 250*time.Millisecond {
+		interval = 250 * time.Millisecond
+	}
+	log.Info().Int("tokens", len(tc.Watchlist)).Dur("refresh", interval).Msg("🔥 warm standby started")
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			e.refreshWarm(ctx, resolve)
+			select {
+			case <-ctx.Done():
+				return
+			case <-e.stopCh:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// refreshWarm re-quotes every watchlist token that could still be bought
+func (e *ExecutorFast) refreshWarm(ctx context.Context, resolve func(string) (string, error)) {
+	cfg := e.cfg.Get()
+	balance := e.buyBalanceLamports()
+	if balance < MinTradeLamports {
+		return
+	}
+
+	for _, symbol := range cfg.Tokens.Watchlist {
+		mint, err := resolve(symbol)
+		if err != nil {
+			log.Debug().Err(err).Str("token", symbol).Msg("warm standby: cannot resolve")
+			continue
+		}
+		if cfg.Trading.IsIgnoredMint(mint) || e.hasMintPosition(mint) {
+			e.warm.drop(mint)
+			continue
+		}
+
+		// Quoted at full size: under linear sizing only the strongest calls match it
+		amount := e.allocLamports(cfg.Trading, mint, symbol, math.Inf(1), balance)
+		slippageBps := e.slippageFor(mint)
+		quote, err := e.jupiter.GetQuoteWithSlippage(ctx, jupiter.SOLMint, mint, amount, slippageBps)
+		if err != nil {
+			log.Debug().Err(err).Str("token", symbol).Msg("warm standby: quote failed")
+			continue
+		}
+		e.warm.put(mint, &warmEntry{symbol: symbol, quote: quote, slippageBps: slippageBps})
+	}
+}
+
+// takeWarmQuote returns (and consumes) the warm quote for mint if it was
+// fetched within tokens.warm_quote_ttl_ms for exactly this amount and
+// slippage; otherwise nil and the buy quotes as usual.
+func (e *ExecutorFast) takeWarmQuote(mint string, amount uint64, slippageBps int) *jupiter.QuoteResponse {
+	ttl := time.Duration(e.cfg.Get().Tokens.WarmQuoteTTLMs) * time.Millisecond
+	entry := e.warm.take(mint)
+	if entry == nil || ttl <= 0 || entry.quote.Age() > ttl || entry.slippageBps != slippageBps {
+		return nil
+	}
+	if entry.quote.InAmount != strconv.FormatUint(amount, 10) {
+		return nil // balance or allocation changed since the quote
+	}
+	return entry.quote
+}
+
+func (w *warmCache) put(mint string, entry *warmEntry) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.entries[mint] = entry
+}
+
+func (w *warmCache) take(mint string) *warmEntry {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	entry := w.entries[mint]
+	delete(w.entries, mint)
+	return entry
+}
+
+func (w *warmCache) drop(mint string) {
+	w.take(mint)
+}
@@ -0,0 +1,149 @@
+package trading
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Degraded modes entered when the WebSocket has been down longer than
+// websocket.max_downtime_seconds while RPC is slower than
+// websocket.max_rpc_latency_ms. Without both price/balance updates and fast
+// confirmations stop, so the bot is trading on stale polling data.
+const (
+	DegradedSellOnly = "sell_only" // exits still run, no new entries
+	DegradedPause    = "pause"     // no automatic buys or sells
+)
+
+// WSWatchdogInterval is how often the outage duration is checked
+const WSWatchdogInterval = time.Second
+
+// watchWSDowntime runs checkWSDowntime until the executor shuts down
+func (e *ExecutorFast) watchWSDowntime() {
+	ticker := time.NewTicker(WSWatchdogInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			e.checkWSDowntime()
+		}
+	}
+}
+
+// markWSDown records the start of an outage (first disconnect wins)
+func (e *ExecutorFast) markWSDown() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.wsDownSince.IsZero() {
+		e.wsDownSince = time.Now()
+	}
+}
+
+// markWSUp clears the outage and leaves degraded mode. Called from the
+// connect callback, after positions have been resubscribed.
+func (e *ExecutorFast) markWSUp() {
+	e.mu.Lock()
+	mode := e.degraded
+	downFor := time.Duration(0)
+	if !e.wsDownSince.IsZero() {
+		downFor = time.Since(e.wsDownSince)
+	}
+	e.wsDownSince = time.Time{}
+	e.degraded = ""
+	e.mu.Unlock()
+
+	if mode != "" {
+		log.Info().
+			Str("mode", mode).
+			Dur("downtime", downFor).
+			Msg("✅ WebSocket restored - resuming normal trading")
+	}
+}
+
+// checkWSDowntime enters degraded mode once the outage exceeds the configured
+// limit and RPC is slow too: with RPC still answering fast, polled quotes and
+// balances are fresh enough to keep trading on
+func (e *ExecutorFast) checkWSDowntime() {
+	wsCfg := e.cfg.Get().WebSocket
+	if wsCfg.MaxDowntimeSeconds <= 0 || e.wsClient == nil {
+		return
+	}
+
+	e.mu.RLock()
+	since, mode := e.wsDownSince, e.degraded
+	e.mu.RUnlock()
+	if since.IsZero() || mode != "" {
+		return
+	}
+	downFor := time.Since(since)
+	if downFor < time.Duration(wsCfg.MaxDowntimeSeconds)*time.Second {
+		return
+	}
+	rpcMs := e.rpcLatencyMs()
+	if wsCfg.MaxRPCLatencyMs > 0 && rpcMs >= 0 && rpcMs < int64(wsCfg.MaxRPCLatencyMs) {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.wsDownSince.IsZero() || e.degraded != "" {
+		return // reconnected while RPC was probed
+	}
+	e.degraded = DegradedSellOnly
+	if wsCfg.DowntimeAction == DegradedPause {
+		e.degraded = DegradedPause
+	}
+	log.Error().
+		Str("mode", e.degraded).
+		Dur("downtime", downFor).
+		Int64("rpcP50Ms", rpcMs).
+		Msg("🛑 WebSocket down too long - entering degraded mode")
+}
+
+// rpcLatencyMs returns the preferred RPC endpoint's p50, probing once if the
+// latency probe hasn't measured it; -1 if RPC is failing (breaker open or probe error)
+func (e *ExecutorFast) rpcLatencyMs() int64 {
+	if e.rpc == nil {
+		return -1
+	}
+	best := e.rpc.BestEndpoint()
+	if best.CircuitOpen {
+		return -1
+	}
+	if best.P50Ms >= 0 {
+		return best.P50Ms
+	}
+	return e.rpc.LatencyMs()
+}
+
+// DegradedMode returns the active outage mode ("" when trading normally)
+func (e *ExecutorFast) DegradedMode() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.degraded
+}
+
+// WSDownFor returns how long the WebSocket has been disconnected (0 if connected)
+func (e *ExecutorFast) WSDownFor() time.Duration {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.wsDownSince.IsZero() {
+		return 0
+	}
+	return time.Since(e.wsDownSince)
+}
+
+// WSConnected reports whether the WebSocket is up (false before SetupWebSocket)
+func (e *ExecutorFast) WSConnected() bool {
+	return e.wsClient != nil && e.wsClient.IsConnected()
+}
+
+// WSLastError returns why the WebSocket is down (nil while connected or before SetupWebSocket)
+func (e *ExecutorFast) WSLastError() error {
+	if e.wsClient == nil {
+		return nil
+	}
+	return e.wsClient.LastError()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,8 +15,12 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 	
+	"solana-pump-bot/internal/blockchain"
 	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/health"
+	"solana-pump-bot/internal/notify"
 	signalPkg "solana-pump-bot/internal/signal"
+	"solana-pump-bot/internal/storage"
 	"solana-pump-bot/internal/trading"
 )
 
@@ -76,6 +81,8 @@ type Screen string
 const (
 	ScreenDashboard Screen = "dashboard"
 	ScreenConfig    Screen = "config"
+	ScreenBuy       Screen = "buy"
+	ScreenPosition  Screen = "position"
 	ScreenLogs      Screen = "logs"
 	ScreenTrades    Screen = "trades"
 	ScreenMetrics   Screen = "metrics"
@@ -87,6 +94,9 @@ type KeyMap struct {
 	Up, Down, Left, Right, Enter, Escape    key.Binding
 	Tab                                     key.Binding
 	Search, Clear, Export, Theme, Health    key.Binding
+	Mode                                    key.Binding
+	Issues, Buy, Yes, No                    key.Binding
+	PageUp, PageDown                        key.Binding
 	Tab1, Tab2, Tab3, Tab0                  key.Binding
 }
 var keys = KeyMap{
@@ -107,7 +117,14 @@ var keys = KeyMap{
 	Clear:  key.NewBinding(key.WithKeys("f9")),
 	Export: key.NewBinding(key.WithKeys("e")),
 	Theme:  key.NewBinding(key.WithKeys("t")),
+	Mode:   key.NewBinding(key.WithKeys("m")),
 	Health: key.NewBinding(key.WithKeys("5")),
+	Issues: key.NewBinding(key.WithKeys("i")),
+	Buy:    key.NewBinding(key.WithKeys("b")),
+	Yes:    key.NewBinding(key.WithKeys("y")),
+	No:     key.NewBinding(key.WithKeys("n")),
+	PageUp:   key.NewBinding(key.WithKeys("pgup")),
+	PageDown: key.NewBinding(key.WithKeys("pgdown")),
 	Tab1:   key.NewBinding(key.WithKeys("1")),
 	Tab2:   key.NewBinding(key.WithKeys("2")),
 	Tab3:   key.NewBinding(key.WithKeys("3")),
@@ -134,14 +151,21 @@ type Model struct {
 	Signals      SignalsPane
 	Positions    PositionsPane
 	ConfigModal  ConfigModal
+	BuyModal     BuyModal
+	Confirm      ConfirmModal
+	Detail       PositionDetailView
+	Pending      PendingAction // Destructive action awaiting y/n
 	LogsView     LogsView
 	TradesView   TradesHistoryView
+	Issues       IssuesPane
 	
 	// Callbacks
 	OnTogglePause func()
 	OnForceClose  func(mint string)
 	OnClear       func() // Clear stats callback
 	OnExport      func() // Export trades to CSV
+	OnManualBuy   func(mint string, amountSol float64) // B: buy from the modal
+	ResolveToken  func(string) (string, error)         // Symbol -> mint for manual buys
 	
 	// UI Mode: 1=Classic, 2=Crossterm, 3=Animated Premium, 4=Neon
 	UIMode int
@@ -153,11 +177,37 @@ type Model struct {
 	FocusPane     int // 0=Left, 1=Center, 2=Right
 	UniqueEntries map[string]bool
 	Unique2X      map[string]bool
+
+	// Blockhash cache metrics (metrics screen)
+	Blockhash blockchain.BlockhashStats
+
+	// RPC endpoint calls currently go to first, and every endpoint's
+	// breaker state (health screen)
+	RPCBest      blockchain.EndpointStat
+	RPCEndpoints []blockchain.EndpointStat
+
+	// Latest component probes (health screen)
+	Health health.Report
+
+	// WebSocket outage safety (sell_only | pause, "" = normal)
+	Degraded  string
+	WSDownFor time.Duration
+
+	// Bumped on every look change; only the latest pending save writes
+	lookSeq int
+	// UI mode cycled with M this session; only then is it saved, so a
+	// UI_MODE override stays out of the config file
+	modeChosen bool
 }
 
 func NewModel(cfg *config.Manager) Model {
-	// Read UI mode from environment (default: 4 = Neon Command Center)
+	// UI mode: UI_MODE env, else the saved tui.ui_mode (default: 4 = Neon Command Center)
 	uiMode := 4
+	if cfg != nil {
+		t := cfg.Get().TUI
+		if t.UIMode != 0 { uiMode = t.UIMode }
+		if t.Theme != "" { SetTheme(t.Theme) }
+	}
 	modeEnv := os.Getenv("UI_MODE")
 	if modeEnv == "1" { uiMode = 1 }
 	if modeEnv == "2" { uiMode = 2 }
@@ -178,7 +228,8 @@ func NewModel(cfg *config.Manager) Model {
 		Signals:       NewSignalsPane(),
 		Positions:     NewPositionsPane(),
 		LogsView:      NewLogsView(),
-		TradesView:    NewTradesHistoryView(),
+		TradesView:    NewTradesHistoryView(nil),
+		Issues:        NewIssuesPane(),
 		ConfigModal:   NewConfigModal(cfg),
 		CurrentScreen: ScreenDashboard,
 		UIMode:        uiMode,
@@ -189,6 +240,11 @@ func NewModel(cfg *config.Manager) Model {
 	}
 }
 
+// SetTradesDB points the trades history screen at the trade log
+func (m *Model) SetTradesDB(db *storage.DB) {
+	m.TradesView = NewTradesHistoryView(db)
+}
+
 func (m *Model) SetCallbacks(pause func(), close func(string), clear func(), export func()) {
 	m.OnTogglePause = pause
 	m.OnForceClose = close
@@ -196,6 +252,13 @@ func (m *Model) SetCallbacks(pause func(), close func(string), clear func(), exp
 	m.OnExport = export
 }
 
+// SetManualBuy wires the B hotkey: resolve maps a symbol to its mint (nil =
+// the input must be a mint), buy enters the position
+func (m *Model) SetManualBuy(resolve func(string) (string, error), buy func(mint string, amountSol float64)) {
+	m.ResolveToken = resolve
+	m.OnManualBuy = buy
+}
+
 func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
 		tea.SetWindowTitle("AFNEX Bot"),
@@ -217,7 +280,55 @@ type PositionMsg struct { Positions []*trading.Position }
 type BalanceMsg struct { SOL float64 }
 type LatencyMsg struct { Ms int64 }
 type LogMsg struct { Lines []string }
-type StatsMsg struct { Signals, Hits int }
+type StatsMsg struct { Signals, Hits int; DailyPnL float64 }
+type IssuesMsg struct { Recent []trading.Issue; Counts []trading.IssueCount }
+type SkipsMsg struct { Counts []trading.IssueCount }
+type DegradedMsg struct { Mode string; WSDownFor time.Duration }
+type WSStatusMsg struct { Connected bool; Err error }
+type QueueMsg struct { Queued, InFlight int }
+type BlockhashMsg struct { Stats blockchain.BlockhashStats }
+type RPCEndpointMsg struct { Best blockchain.EndpointStat; Endpoints []blockchain.EndpointStat }
+type HealthMsg struct { Report health.Report }
+type TradesMsg struct { Trades []*storage.Trade }
+type saveLookMsg struct { seq int }
+type lookSavedMsg struct { err error }
+
+// LookSaveDelay debounces writing the theme and UI mode back to the config file
+const LookSaveDelay = 2 * time.Second
+
+// scheduleLookSave persists the theme and UI mode once they stop changing
+func (m *Model) scheduleLookSave() tea.Cmd {
+	if m.Config == nil { return nil }
+	m.lookSeq++
+	seq := m.lookSeq
+	return tea.Tick(LookSaveDelay, func(time.Time) tea.Msg { return saveLookMsg{seq} })
+}
+
+// saveLook writes the current theme to tui.theme, and the UI mode to
+// tui.ui_mode once it was cycled with M, off the UI goroutine (the config
+// write hits the disk). An untouched mode may come from UI_MODE and is left
+// as configured.
+func (m Model) saveLook() tea.Cmd {
+	cfg, theme, mode := m.Config, GetTheme().Name, 0
+	if m.modeChosen { mode = m.UIMode }
+	return func() tea.Msg {
+		return lookSavedMsg{cfg.Update(func(c *config.Config) {
+			c.TUI.Theme = theme
+			if mode != 0 { c.TUI.UIMode = mode }
+		})}
+	}
+}
+
+// cycleUIMode steps to the next UI mode (1-4), starting the animations when
+// it lands on a mode that has them
+func (m *Model) cycleUIMode() tea.Cmd {
+	m.UIMode = m.UIMode%4 + 1
+	m.modeChosen = true
+	if m.UIMode < 3 { return nil }
+	m.Anim = NewAnimationState()
+	if m.UIMode == 3 { return AnimationTickCmd() }
+	return nil
+}
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -231,7 +342,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var mem runtime.MemStats
 		runtime.ReadMemStats(&mem)
 		m.Header.MemUsage = fmt.Sprintf("%dMB", mem.Alloc/1024/1024)
-		return m, tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg { return TickMsg(t) })
+		next := tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg { return TickMsg(t) })
+		// Keep the trades screen fresh while it's open
+		if m.CurrentScreen == ScreenTrades && time.Since(m.TradesView.LoadedAt) > TradesRefreshInterval {
+			m.TradesView.LoadedAt = time.Now()
+			return m, tea.Batch(next, m.TradesView.Load())
+		}
+		return m, next
 	
 	case AnimationTickMsg:
 		// Progress animation frames (Mode 3 only)
@@ -256,9 +373,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case SignalMsg:
 		// If EXIT signal, mark matching ENTRY signals as Reached2X
 		if msg.Signal.Type == signalPkg.SignalExit {
-			for _, s := range m.Signals.List {
-				if s.TokenName == msg.Signal.TokenName && s.Type == signalPkg.SignalEntry {
-					s.Reached2X = true
+			// Only the TUI's own rows are touched; the *Signal belongs to the executor
+			for i := range m.Signals.List {
+				if m.Signals.List[i].TokenName == msg.Signal.TokenName && m.Signals.List[i].Type == signalPkg.SignalEntry {
+					m.Signals.List[i].Reached2X = true
 				}
 			}
 			// Track Unique 2X Win (Mode 4 Stats)
@@ -271,6 +389,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Fallback for older modes if maps not init
 				m.Header.Reached2X++
 			}
+		} else if msg.Signal.Type == signalPkg.SignalReview {
+			// Token with no value/unit - park for manual review
+			m.Signals.AddReview(msg.Signal)
 		} else if msg.Signal.Type == signalPkg.SignalEntry {
 			// Track Unique Entry (Mode 4 Stats)
 			m.Signals.Add(msg.Signal)
@@ -296,17 +417,61 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case StatsMsg:
 		m.Header.TotalEntries = msg.Signals
 		m.Header.Reached2X = msg.Hits
+		m.Header.DailyPnL = msg.DailyPnL
+	case IssuesMsg:
+		m.Issues.Recent = msg.Recent
+		m.Issues.Counts = msg.Counts
+	case SkipsMsg:
+		m.Issues.Skips = msg.Counts
+	case BlockhashMsg:
+		m.Blockhash = msg.Stats
+	case RPCEndpointMsg:
+		m.RPCBest, m.RPCEndpoints = msg.Best, msg.Endpoints
+	case HealthMsg:
+		m.Health = msg.Report
+	case TradesMsg:
+		m.TradesView.Trades = msg.Trades
+		if m.TradesView.Offset >= len(msg.Trades) { m.TradesView.Offset = 0 }
+	case saveLookMsg:
+		if msg.seq == m.lookSeq && m.Config != nil { return m, m.saveLook() }
+	case lookSavedMsg:
+		if msg.err != nil { m.LogsView.Add([]string{"saving theme/UI mode: " + msg.err.Error()}) }
+	case DegradedMsg:
+		m.Degraded = msg.Mode
+		m.WSDownFor = msg.WSDownFor
+		m.Header.Degraded = m.degradedBanner()
+	case WSStatusMsg:
+		m.Header.WS = "down"
+		if msg.Connected { m.Header.WS = "up" }
+		m.Header.WSErr = ""
+		if msg.Err != nil && !msg.Connected { m.Header.WSErr = msg.Err.Error() }
+	case QueueMsg:
+		m.Header.Queued, m.Header.InFlight = msg.Queued, msg.InFlight
 	}
 	
 	return m, nil
 }
 
 func (m Model) handleGlobalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// 0. Confirmation gate: only y runs the pending action, every other key waits
+	if m.Pending != ActionNone {
+		switch {
+		case key.Matches(msg, keys.Yes):
+			m.runPending()
+		case key.Matches(msg, keys.No), key.Matches(msg, keys.Escape):
+			m.Pending = ActionNone
+		}
+		return m, nil
+	}
+
 	// 1. Config Modal Overlay Override
 	if m.CurrentScreen == ScreenConfig {
 		// Pass pointer to model for adjustment
 		return m.ConfigModal.Update(msg, &m)
 	}
+	if m.CurrentScreen == ScreenBuy {
+		return m.BuyModal.Update(msg, &m)
+	}
 
 	// 2. Global Hotkeys (visible on Dashboard)
 	switch {
@@ -321,32 +486,33 @@ func (m Model) handleGlobalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case ScreenDashboard:
 		switch {
 		case key.Matches(msg, keys.Config):
+			m.ConfigModal.Open()
 			m.CurrentScreen = ScreenConfig
 		case key.Matches(msg, keys.Pause):
 			m.Running = !m.Running
 			if m.OnTogglePause != nil { m.OnTogglePause() }
 		case key.Matches(msg, keys.Sell):
 			m.sellAll()
+		case key.Matches(msg, keys.Buy):
+			m.BuyModal.Open(m.selectedSignal())
+			m.CurrentScreen = ScreenBuy
 		case key.Matches(msg, keys.Logs):
 			m.CurrentScreen = ScreenLogs
 		case key.Matches(msg, keys.Trades):
 			m.CurrentScreen = ScreenTrades
+			m.TradesView.LoadedAt = time.Now()
+			return m, m.TradesView.Load()
 		case key.Matches(msg, keys.Clear):
-			// F9: Sell all, clear positions, clear signals, reset stats
-			m.sellAll()
-			m.Positions.Positions = nil
-			m.Positions.Offset = 0
-			m.Signals.List = nil
-			m.Header.TotalEntries = 0
-			m.Header.Reached2X = 0
-			if m.OnClear != nil { m.OnClear() }
+			// F9: Sell all, clear positions, clear signals, reset stats (after y/n)
+			m.askConfirm(ActionPanicSell, "Sell ALL positions?")
 		case key.Matches(msg, keys.Up):
 			if m.UIMode == 4 {
 				// Mode 4: Contextual Scrolling
 				if m.FocusPane == 1 && m.Signals.Offset > 0 {
 					m.Signals.Offset--
-				} else if m.FocusPane == 2 && m.Positions.Offset > 0 {
-					m.Positions.Offset--
+				} else if m.FocusPane == 2 && m.Positions.Selected > 0 {
+					m.Positions.Selected--
+					m.Positions.Offset = m.Positions.Selected
 				}
 			} else {
 				// Legacy
@@ -360,8 +526,9 @@ func (m Model) handleGlobalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					m.Signals.Offset++
 				}
 				// Focus 2: Positions
-				if m.FocusPane == 2 && m.Positions.Offset < len(m.Positions.Positions)-1 {
-					m.Positions.Offset++
+				if m.FocusPane == 2 && m.Positions.Selected < len(m.Positions.Positions)-1 {
+					m.Positions.Selected++
+					m.Positions.Offset = m.Positions.Selected
 				}
 			} else {
 				// Legacy
@@ -407,18 +574,20 @@ func (m Model) handleGlobalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.ActivePane = 3 // Full Metrics
 			} else {
 				CycleTheme()
-				if m.UIMode == 3 { m.Anim.TriggerButtonFlash("3"); return m, AnimationTickCmd() }
+				save := m.scheduleLookSave()
+				if m.UIMode == 3 { m.Anim.TriggerButtonFlash("3"); return m, tea.Batch(AnimationTickCmd(), save) }
+				return m, save
 			}
 		case key.Matches(msg, keys.Tab0):
 			// Key 4: Classic=nothing, Crossterm=Clear
 			if m.UIMode != 1 {
-				m.sellAll()
-				m.Positions.Positions = nil
-				m.Positions.Offset = 0
-				m.Signals.List = nil
-				m.Header.TotalEntries = 0
-				m.Header.Reached2X = 0
-				if m.OnClear != nil { m.OnClear() }
+				m.askConfirm(ActionPanicSell, "Sell ALL positions?")
+			}
+		case key.Matches(msg, keys.Enter):
+			// Mode 4: drill into the selected position
+			if p := m.Positions.SelectedPosition(); m.UIMode == 4 && m.FocusPane == 2 && p != nil {
+				m.Detail.Mint = p.Mint
+				m.CurrentScreen = ScreenPosition
 			}
 		case key.Matches(msg, keys.Escape):
 			m.ActivePane = 0 // Dashboard
@@ -426,18 +595,90 @@ func (m Model) handleGlobalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.OnExport != nil { m.OnExport() }
 		case key.Matches(msg, keys.Theme):
 			CycleTheme() // Cycle to next theme
+			return m, m.scheduleLookSave()
+		case key.Matches(msg, keys.Mode):
+			anim := m.cycleUIMode()
+			return m, tea.Batch(anim, m.scheduleLookSave())
 		case key.Matches(msg, keys.Health):
 			m.ActivePane = 4 // Full Health Dashboard
+		case key.Matches(msg, keys.Issues):
+			m.ActivePane = 5 // Full Issues View
 		}
 	case ScreenLogs:
 		return m.LogsView.Update(msg, m)
 	case ScreenTrades:
 		return m.TradesView.Update(msg, m)
+	case ScreenPosition:
+		return m.Detail.Update(msg, m)
 	}
 	
 	return m, nil
 }
 
+// askConfirm parks action behind the y/n confirm modal
+func (m *Model) askConfirm(action PendingAction, prompt string) {
+	m.Pending = action
+	m.Confirm.Prompt = prompt
+}
+
+// runPending performs the confirmed action
+func (m *Model) runPending() {
+	switch m.Pending {
+	case ActionPanicSell:
+		m.sellAll()
+		m.Positions.Positions = nil
+		m.Positions.Offset = 0
+		m.Signals.List = nil
+		m.Signals.Review = nil
+		m.Header.TotalEntries = 0
+		m.Header.Reached2X = 0
+		if m.OnClear != nil { m.OnClear() }
+	}
+	m.Pending = ActionNone
+}
+
+// selectedSignal is the signal row under the feed cursor, nil if the feed is empty
+func (m Model) selectedSignal() *SignalRow {
+	if m.Signals.Offset < 0 || m.Signals.Offset >= len(m.Signals.List) { return nil }
+	return &m.Signals.List[m.Signals.Offset]
+}
+
+// submitManualBuy validates the buy modal; on success it closes the modal and
+// returns a command that resolves the token and fires OnManualBuy
+func (m *Model) submitManualBuy() (tea.Model, tea.Cmd) {
+	bm := &m.BuyModal
+	token := strings.TrimSpace(bm.Token)
+	amount, err := strconv.ParseFloat(strings.TrimSpace(bm.Amount), 64)
+	switch {
+	case token == "":
+		bm.Err = "enter a mint or token name"
+	case err != nil || amount <= 0:
+		bm.Err = "enter an amount in SOL"
+	case amount > m.WalletBalance:
+		bm.Err = fmt.Sprintf("amount exceeds balance (%.4f SOL)", m.WalletBalance)
+	default:
+		bm.Err = ""
+		m.CurrentScreen = ScreenDashboard
+		return *m, manualBuyCmd(m.ResolveToken, m.OnManualBuy, token, amount)
+	}
+	return *m, nil
+}
+
+// manualBuyCmd resolves token off the UI goroutine and hands the mint to buy
+func manualBuyCmd(resolve func(string) (string, error), buy func(string, float64), token string, amountSol float64) tea.Cmd {
+	if buy == nil { return nil }
+	return func() tea.Msg {
+		mint := token
+		if resolve != nil {
+			resolved, err := resolve(token)
+			if err != nil { return LogMsg{Lines: []string{fmt.Sprintf("manual buy: cannot resolve %s: %v", token, err)}} }
+			mint = resolved
+		}
+		buy(mint, amountSol)
+		return LogMsg{Lines: []string{fmt.Sprintf("manual buy: %.4f SOL of %s requested", amountSol, token)}}
+	}
+}
+
 func (m Model) sellAll() {
 	if m.OnForceClose != nil {
 		for _, p := range m.Positions.Positions {
@@ -447,18 +688,34 @@ func (m Model) sellAll() {
 }
 
 // Config Adjustment Logic
+// Arrow presses only change the staged copy; nothing goes live until the
+// diff preview is confirmed (see ConfigModal.Update).
 func (m *Model) adjustConfig(delta float64) {
+	v := &m.ConfigModal.Staged
+	switch m.ConfigModal.Selected {
+	case 0: v.MinEntry = maxf(10, v.MinEntry + delta*5)
+	case 1: v.TakeProfit = maxf(1.5, v.TakeProfit + delta*0.5)
+	case 2: v.MaxAlloc = minf(100, maxf(5, v.MaxAlloc + delta*5))
+	case 3: v.MaxPos = mini(50, maxi(1, v.MaxPos + int(delta)))
+	case 4: v.PrioFee = minf(1.0, maxf(0.0001, v.PrioFee + delta*0.001))
+	case 5: v.AutoTrade = !v.AutoTrade
+	}
+}
+
+// commitConfig writes the edited values to the live config (and file).
+// Untouched fields are left alone, so a hot reload that landed while the
+// modal was open isn't reverted to the values it was opened with.
+func (m *Model) commitConfig() {
+	o, v := m.ConfigModal.Original, m.ConfigModal.Staged
 	m.Config.Update(func(c *config.Config) {
-		idx := m.ConfigModal.Selected
-		switch idx {
-		case 0: c.Trading.MinEntryPercent = maxf(10, c.Trading.MinEntryPercent + delta*5)
-		case 1: c.Trading.TakeProfitMultiple = maxf(1.5, c.Trading.TakeProfitMultiple + delta*0.5)
-		case 2: c.Trading.MaxAllocPercent = minf(100, maxf(5, c.Trading.MaxAllocPercent + delta*5))
-		case 3: c.Trading.MaxOpenPositions = mini(50, maxi(1, c.Trading.MaxOpenPositions + int(delta)))
-		case 4: c.Fees.StaticPriorityFeeSol = minf(1.0, maxf(0.0001, c.Fees.StaticPriorityFeeSol + delta*0.001))
-		case 5: c.Trading.AutoTradingEnabled = !c.Trading.AutoTradingEnabled; m.Running = c.Trading.AutoTradingEnabled
-		}
+		if v.MinEntry != o.MinEntry { c.Trading.MinEntryPercent = v.MinEntry }
+		if v.TakeProfit != o.TakeProfit { c.Trading.TakeProfitMultiple = v.TakeProfit }
+		if v.MaxAlloc != o.MaxAlloc { c.Trading.MaxAllocPercent = v.MaxAlloc }
+		if v.MaxPos != o.MaxPos { c.Trading.MaxOpenPositions = v.MaxPos }
+		if v.PrioFee != o.PrioFee { c.Fees.StaticPriorityFeeSol = v.PrioFee }
+		if v.AutoTrade != o.AutoTrade { c.Trading.AutoTradingEnabled = v.AutoTrade }
 	})
+	if v.AutoTrade != o.AutoTrade { m.Running = v.AutoTrade }
 }
 
 
@@ -466,14 +723,21 @@ func (m *Model) adjustConfig(delta float64) {
 
 func (m Model) View() string {
 	if m.Width == 0 { return "Loading..." }
+	if m.Pending != ActionNone {
+		return m.overlay(m.renderDashboard(), m.Confirm.Render(m.Width, m.Height))
+	}
 	
 	switch m.CurrentScreen {
 	case ScreenLogs:
 		return m.LogsView.Render(m.Width, m.Height)
 	case ScreenTrades:
 		return m.TradesView.Render(m.Width, m.Height)
+	case ScreenPosition:
+		return m.Detail.Render(m.Width, m.Height, m.Positions.Find(m.Detail.Mint))
 	case ScreenConfig:
 		return m.overlay(m.renderDashboard(), m.ConfigModal.Render(m.Width, m.Height))
+	case ScreenBuy:
+		return m.overlay(m.renderDashboard(), m.BuyModal.Render(m.Width, m.Height))
 	default:
 		// ActivePane full-screen views
 		switch m.ActivePane {
@@ -485,6 +749,8 @@ func (m Model) View() string {
 			return m.renderFullMetrics()
 		case 4:
 			return m.renderFullHealth()
+		case 5:
+			return m.renderFullIssues()
 		default:
 			// UIMode: 1=Classic, 2=Crossterm, 3=Animated Premium
 			switch m.UIMode {
@@ -695,7 +961,7 @@ func (m Model) renderClassicDashboard() string {
 
 	// 4. CLASSIC FOOTER (text hotkeys)
 	statusLine := fmt.Sprintf("Uptime: %s | PnL: %+.2f%%", time.Since(m.StartTime).Truncate(time.Second), m.Positions.TotalPnLPercent)
-	hotkeys := "[1]Signals [2]Positions [3]Metrics [5]Health [C]fg [P]ause [S]ell [F9]Clear [Q]uit"
+	hotkeys := "[1]Signals [2]Positions [3]Metrics [5]Health [M]ode [C]fg [P]ause [S]ell [F9]Clear [Q]uit"
 	footerBox := renderBox("Footer", lipgloss.NewStyle().Foreground(ColorText).Render(statusLine+"\n"+hotkeys), m.Width, 4)
 
 	content := lipgloss.JoinVertical(lipgloss.Left, tabsBox, graphsBox, listsRow, footerBox)
@@ -741,6 +1007,10 @@ func (m Model) renderAnimatedDashboard() string {
 		statusColor = ColorLoss
 		statusText = "● PAUSED"
 	}
+	if banner := m.degradedBanner(); banner != "" {
+		statusColor = ColorLoss
+		statusText = "● " + banner
+	}
 	
 	headerLeft := titleGlow.Render("⚡ AFNEX CYBERPUNK ⚡")
 	headerRight := lipgloss.NewStyle().Foreground(statusColor).Bold(true).Render(statusText)
@@ -823,8 +1093,8 @@ func (m Model) renderAnimatedDashboard() string {
 	// Positions with PnL coloring
 	posTitle := lipgloss.NewStyle().Foreground(colors[1]).Bold(true).Render("═══ POSITIONS ═══")
 	var posLines []string
-	for i, p := range m.Positions.Positions {
-		if i >= listHeight { break }
+	compact := m.compactPositions()
+	for _, p := range m.Positions.Positions {
 		pnlStyle := StyleProfit
 		pnlIcon := "▲"
 		if p.PnLPercent < 0 { 
@@ -832,7 +1102,12 @@ func (m Model) renderAnimatedDashboard() string {
 			pnlIcon = "▼"
 		}
 		row := fmt.Sprintf("  %s %-12s %s", pnlIcon, truncate(p.TokenName, 12), pnlStyle.Render(fmt.Sprintf("%+6.1f%%", p.PnLPercent)))
-		posLines = append(posLines, row)
+		rows := []string{row}
+		if !compact {
+			rows = append(rows, m.positionDetailLine("    ", p))
+		}
+		if len(posLines)+len(rows) > listHeight { break }
+		posLines = append(posLines, rows...)
 	}
 	if len(posLines) == 0 {
 		posLines = append(posLines, lipgloss.NewStyle().Foreground(lipgloss.Color("#555555")).Render("  No positions yet..."))
@@ -977,6 +1252,17 @@ func (m Model) renderFullSignals() string {
 		lines = append(lines, lipgloss.NewStyle().Foreground(ColorAccentGreen).Render(row))
 	}
 	
+	// Value-less signals parked for a human decision
+	if len(m.Signals.Review) > 0 {
+		lines = append(lines, "", StyleTableHeader.Render(fmt.Sprintf("NEEDS REVIEW (%d) - no value/unit in message", len(m.Signals.Review))))
+		for _, s := range m.Signals.Review {
+			if len(lines) >= listHeight { break }
+			t := time.Unix(s.Timestamp, 0).Format("15:04:05")
+			row := fmt.Sprintf("%s %-10s %s", t, truncate(s.TokenName, 10), truncate(s.RawText, m.Width-26))
+			lines = append(lines, lipgloss.NewStyle().Foreground(ColorAccentPurple).Render(row))
+		}
+	}
+	
 	body := renderBox("", strings.Join(lines, "\n"), m.Width, listHeight)
 	return StylePage.Render(lipgloss.JoinVertical(lipgloss.Left, header, body))
 }
@@ -998,6 +1284,12 @@ func (m Model) renderFullPositions() string {
 			pnlStyle.Render(fmt.Sprintf("%+.1f%%", p.PnLPercent)),
 			formatDuration(time.Since(p.EntryTime)),
 		)
+		if m.showNetPnL() {
+			row += " | " + netPnLLabel(p)
+		}
+		if m.showExitEstimate() {
+			row += " | " + exitLabel(p)
+		}
 		lines = append(lines, row)
 	}
 	
@@ -1021,6 +1313,12 @@ func (m Model) renderFullMetrics() string {
 	}
 	lineRow := fmt.Sprintf("Win Rate:  %s", renderLineGauge(winRate, m.Width-30, ColorActive))
 	
+	// No reads yet means no hit rate, not a red 0%
+	cacheRow := "no reads yet"
+	if m.Blockhash.Hits+m.Blockhash.Misses > 0 {
+		cacheRow = blockhashSummary(m.Blockhash)
+	}
+	
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		"",
 		gaugeRow,
@@ -1030,12 +1328,29 @@ func (m Model) renderFullMetrics() string {
 		lineRow,
 		"",
 		fmt.Sprintf("Stats: 50%%+ Entries: %d | 2X Hits: %d", m.Header.TotalEntries, m.Header.Reached2X),
+		"",
+		fmt.Sprintf("Blockhash: %s | sync refresh %d | fetch avg %.0fms (%d ok, %d err)",
+			cacheRow, m.Blockhash.SyncRefreshes,
+			m.Blockhash.AvgFetchMs, m.Blockhash.Fetches, m.Blockhash.FetchErrors),
 	)
 	
 	body := renderBox("", content, m.Width, m.Height-4)
 	return StylePage.Render(lipgloss.JoinVertical(lipgloss.Left, header, body))
 }
 
+// LowBlockhashHitRate is the cache hit rate (percent) below which sends
+// often wait on a synchronous fetch or risk a stale blockhash; shown red
+const LowBlockhashHitRate = 90.0
+
+// blockhashSummary renders the blockhash cache's hit rate and the age of the
+// blockhash it is handing out
+func blockhashSummary(st blockchain.BlockhashStats) string {
+	rate := lipgloss.NewStyle().Foreground(ColorProfit)
+	if st.HitRate < LowBlockhashHitRate { rate = lipgloss.NewStyle().Foreground(ColorLoss) }
+	return fmt.Sprintf("hit %s (%d hits, %d misses) | age %dms",
+		rate.Render(fmt.Sprintf("%.1f%%", st.HitRate)), st.Hits, st.Misses, st.AgeMs)
+}
+
 func (m Model) renderFullHealth() string {
 	header := renderBox("HEALTH DASHBOARD (Full View) [Press 0/Esc to go back]", "", m.Width, 2)
 	
@@ -1045,31 +1360,62 @@ func (m Model) renderFullHealth() string {
 	lines = append(lines, "  ─────────          ──────     ─────")
 	lines = append(lines, "")
 	
-	// Static status display (simplified without actual Checker integration for now)
-	// RPC
-	rpcIcon := lipgloss.NewStyle().Foreground(ColorProfit).Render("✓")
-	lines = append(lines, fmt.Sprintf("  RPC Endpoint       %s          Connected", rpcIcon))
+	okIcon := lipgloss.NewStyle().Foreground(ColorProfit).Render("✓")
+	badIcon := lipgloss.NewStyle().Foreground(ColorLoss).Render("✗")
+	if len(m.Health.Components) == 0 { lines = append(lines, "  Waiting for the first check...") }
+	for _, c := range m.Health.Components {
+		icon, note := okIcon, fmt.Sprintf("%dms", c.LatencyMs)
+		if !c.Healthy { icon, note = badIcon, fmt.Sprintf("%dms - %s", c.LatencyMs, c.Error) }
+		// A passing probe can still land on a fallback; every breaker open means RPC is down
+		if c.Name == "RPC" && m.RPCBest.CircuitOpen { icon, note = badIcon, note+" - circuit open" }
+		lines = append(lines, fmt.Sprintf("  %-18s %s          %s", c.Name, icon, note))
+	}
 	
-	// Telegram
-	teleIcon := lipgloss.NewStyle().Foreground(ColorProfit).Render("✓")
-	lines = append(lines, fmt.Sprintf("  Telegram Listener  %s          Active", teleIcon))
+	// Which endpoint RPC calls currently go to
+	if m.RPCBest.Host != "" {
+		rpcNote := m.RPCBest.Host
+		if m.RPCBest.P50Ms >= 0 { rpcNote += fmt.Sprintf(" (p50 %dms)", m.RPCBest.P50Ms) }
+		if m.RPCBest.CircuitOpen { rpcNote += " - all endpoints failing" }
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("  Best RPC:   %s", rpcNote))
+	}
 	
-	// DB
-	dbIcon := lipgloss.NewStyle().Foreground(ColorProfit).Render("✓")
-	lines = append(lines, fmt.Sprintf("  SQLite Database    %s          Healthy", dbIcon))
+	// Per-endpoint circuit breakers, in failover order
+	if len(m.RPCEndpoints) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, "  CIRCUIT BREAKERS")
+		openStyle := lipgloss.NewStyle().Foreground(ColorLoss).Bold(true)
+		for _, ep := range m.RPCEndpoints {
+			icon, state := okIcon, "closed"
+			if ep.CircuitOpen { icon, state = badIcon, openStyle.Render("OPEN") }
+			p50 := "-"
+			if ep.P50Ms >= 0 { p50 = fmt.Sprintf("%dms", ep.P50Ms) }
+			lines = append(lines, fmt.Sprintf("  %s %-28s %-6s  p50 %-6s fails %d (%d total)",
+				icon, truncate(ep.Host, 28), state, p50, ep.Failures, ep.TotalFailures))
+		}
+	}
 	
-	// Jupiter
-	jupIcon := lipgloss.NewStyle().Foreground(ColorProfit).Render("✓")
-	lines = append(lines, fmt.Sprintf("  Jupiter API        %s          Reachable", jupIcon))
+	if m.Blockhash.Hits+m.Blockhash.Misses > 0 {
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("  Blockhash:  %s", blockhashSummary(m.Blockhash)))
+	}
 	
 	lines = append(lines, "")
-	lines = append(lines, fmt.Sprintf("  Last Check: %s", time.Now().Format("15:04:05")))
+	lastCheck := "never"
+	if !m.Health.CheckedAt.IsZero() { lastCheck = m.Health.CheckedAt.Format("15:04:05") }
+	lines = append(lines, fmt.Sprintf("  Last Check: %s", lastCheck))
 	lines = append(lines, fmt.Sprintf("  Uptime:     %s", time.Since(m.StartTime).Truncate(time.Second)))
 	
 	body := renderBox("", strings.Join(lines, "\n"), m.Width, m.Height-4)
 	return StylePage.Render(lipgloss.JoinVertical(lipgloss.Left, header, body))
 }
 
+func (m Model) renderFullIssues() string {
+	header := renderBox("ISSUES (Full View) [Press 0/Esc to go back]", "", m.Width, 2)
+	body := renderBox("", m.Issues.Render(m.Width-4, m.Height-6), m.Width, m.Height-4)
+	return StylePage.Render(lipgloss.JoinVertical(lipgloss.Left, header, body))
+}
+
 func (m Model) overlay(base, modal string) string {
 	bLines := strings.Split(base, "\n")
 	mLines := strings.Split(modal, "\n")
@@ -1094,11 +1440,17 @@ type HeaderComponent struct {
 	Balance      float64
 	RPCLatency   time.Duration
 	PnLPercent   float64
+	DailyPnL     float64 // Realized SOL since UTC midnight
 	CurrentTime  time.Time
 	MemUsage     string
 	TotalEntries int    // 50%+ signals
 	Reached2X    int    // How many hit 2X
 	LatencyHistory []int // For sparkline
+	Degraded       string // Outage banner, "" when healthy
+	WS             string // "up", "down", "" before the first status
+	WSErr          string // Why the WebSocket is down
+	Queued         int    // Signals waiting for a trade slot
+	InFlight       int    // Signals being processed
 }
 
 const Version = "v2.1"
@@ -1111,6 +1463,14 @@ func (h HeaderComponent) Render(w int) string {
 	status := lipgloss.NewStyle().Foreground(statusColor).Render(statusDots + h.Status + " " + Version)
 	bal := fmt.Sprintf("Bal: %.2f SOL", h.Balance)
 	rpc := fmt.Sprintf("RPC: %dms", h.RPCLatency.Milliseconds())
+	switch h.WS {
+	case "up":
+		rpc += " " + lipgloss.NewStyle().Foreground(ColorSuccess).Render("WS ●")
+	case "down":
+		ws := "WS ✗"
+		if h.WSErr != "" { ws += " " + truncate(h.WSErr, 24) }
+		rpc += " " + lipgloss.NewStyle().Foreground(ColorLoss).Render(ws)
+	}
 	mem := fmt.Sprintf("MEM: %s", h.MemUsage)
 	
 	// Stats: 50%+ found and 2X hit rate
@@ -1119,15 +1479,25 @@ func (h HeaderComponent) Render(w int) string {
 		hitRate = float64(h.Reached2X) / float64(h.TotalEntries) * 100
 	}
 	stats := lipgloss.NewStyle().Foreground(ColorInfo).Render(fmt.Sprintf("50%%+: %d | 2X: %d (%.0f%%)", h.TotalEntries, h.Reached2X, hitRate))
+	if h.Queued > 0 || h.InFlight > 0 {
+		stats += " " + lipgloss.NewStyle().Foreground(ColorWarning).Render(fmt.Sprintf("Q: %d+%d", h.InFlight, h.Queued))
+	}
 	
 	pnlColor := ColorProfit
 	if h.PnLPercent < 0 { pnlColor = ColorLoss }
 	pnl := lipgloss.NewStyle().Foreground(pnlColor).Render(fmt.Sprintf("PnL: %+.1f%%", h.PnLPercent))
 	
+	dayColor := ColorProfit
+	if h.DailyPnL < 0 { dayColor = ColorLoss }
+	day := lipgloss.NewStyle().Foreground(dayColor).Render(fmt.Sprintf("Day: %+.3f SOL", h.DailyPnL))
+	
 	timeStr := h.CurrentTime.Format("15:04:05")
 	
-	// Layout: Status | Bal | RPC | MEM | Stats | PnL | Time
-	parts := []string{status, bal, rpc, mem, stats, pnl, timeStr}
+	// Layout: Status | Bal | RPC | MEM | Stats | PnL | Day | Time
+	parts := []string{status, bal, rpc, mem, stats, pnl, day, timeStr}
+	if h.Degraded != "" {
+		parts = append([]string{lipgloss.NewStyle().Foreground(ColorLoss).Bold(true).Render("⚠ " + h.Degraded)}, parts...)
+	}
 	content := strings.Join(parts, " │ ")
 	
 	return StyleHeader.Width(w).Render(content)
@@ -1139,9 +1509,9 @@ func (f FooterComponent) Render(w int) string {
 	var s string
 	switch f.Screen {
 	case "dashboard":
-		s = RenderHotKey("C", "fg") + " " + RenderHotKey("P", "ause") + " " + RenderHotKey("S", "ell") + " " + RenderHotKey("L", "og") + " " + RenderHotKey("T", "rades") + " " + RenderHotKey("F9", "Clr") + " " + RenderHotKey("Q", "uit")
+		s = RenderHotKey("C", "fg") + " " + RenderHotKey("P", "ause") + " " + RenderHotKey("S", "ell") + " " + RenderHotKey("B", "uy") + " " + RenderHotKey("L", "og") + " " + RenderHotKey("T", "rades") + " " + RenderHotKey("M", "ode") + " " + RenderHotKey("F9", "Clr") + " " + RenderHotKey("Q", "uit")
 	case "logs":
-		s = RenderHotKey("Esc", "Back") + " " + RenderHotKey("Up/Dn", "Scroll")
+		s = RenderHotKey("Esc", "Back") + " " + RenderHotKey("Up/Dn/PgUp/PgDn", "Scroll") + " " + RenderHotKey("/", "Search")
 	case "trades":
 		s = RenderHotKey("Esc", "Back")
 	case "config":
@@ -1153,19 +1523,51 @@ func (f FooterComponent) Render(w int) string {
 }
 
 // 3. SIGNALS PANE
+
+// SignalRow is the TUI's own copy of a signal. Signals arriving via SignalMsg
+// are shared with the executor, so the UI never stores or mutates them directly.
+type SignalRow struct {
+	TokenName string
+	Mint      string
+	Value     float64
+	Unit      string
+	Type      signalPkg.SignalType
+	Timestamp int64
+	RawText   string
+	Reached2X bool // UI state: an exit signal for this token has been seen
+}
+
+func newSignalRow(s *signalPkg.Signal) SignalRow {
+	return SignalRow{
+		TokenName: s.TokenName,
+		Mint:      s.Mint,
+		Value:     s.Value,
+		Unit:      s.Unit,
+		Type:      s.Type,
+		Timestamp: s.Timestamp,
+		RawText:   s.RawText,
+		Reached2X: s.Reached2X,
+	}
+}
+
 type SignalsPane struct {
-	List   []*signalPkg.Signal
+	List   []SignalRow
+	Review []SignalRow // Value-less signals awaiting manual review
 	Offset int // For scrolling
 }
-func NewSignalsPane() SignalsPane { return SignalsPane{List: []*signalPkg.Signal{}, Offset: 0} }
+func NewSignalsPane() SignalsPane { return SignalsPane{List: []SignalRow{}, Offset: 0} }
 func (sp *SignalsPane) Add(s *signalPkg.Signal) {
 	// Only show ENTRY signals (50%+) in the list, not EXIT (2X+)
 	if s.Type != signalPkg.SignalEntry {
 		return
 	}
-	sp.List = append([]*signalPkg.Signal{s}, sp.List...)
+	sp.List = append([]SignalRow{newSignalRow(s)}, sp.List...)
 	if len(sp.List) > 20 { sp.List = sp.List[:20] }
 }
+func (sp *SignalsPane) AddReview(s *signalPkg.Signal) {
+	sp.Review = append([]SignalRow{newSignalRow(s)}, sp.Review...)
+	if len(sp.Review) > 20 { sp.Review = sp.Review[:20] }
+}
 func (sp SignalsPane) Render(w, h int) string {
 	header := StyleTableHeader.Width(w).Render("📡 SIGNALS")
 	subHeader := fmt.Sprintf("%-6s %-6s %-6s %s", "TIME", "TOKEN", "VALUE", "2X?")
@@ -1195,16 +1597,32 @@ type PositionsPane struct {
 	Positions []*trading.Position
 	TotalPnLPercent float64
 	Offset int // Scroll offset
+	Selected int // Cursor (Mode 4 position focus); Enter opens its detail view
 }
 func NewPositionsPane() PositionsPane { return PositionsPane{Positions: []*trading.Position{}} }
 func (pp *PositionsPane) Update(pos []*trading.Position) {
 	// Preserve scroll position if list length hasn't changed drastically
 	// or reset if needed. For now, simple update.
 	pp.Positions = pos
+	if pp.Selected >= len(pos) { pp.Selected = maxi(len(pos)-1, 0) }
 	var total float64
 	for _, p := range pos { total += p.PnLPercent } 
 	if len(pos) > 0 { pp.TotalPnLPercent = total / float64(len(pos)) } else { pp.TotalPnLPercent = 0 }
 }
+// SelectedPosition is the position under the cursor, nil if there are none
+func (pp PositionsPane) SelectedPosition() *trading.Position {
+	if pp.Selected < 0 || pp.Selected >= len(pp.Positions) { return nil }
+	return pp.Positions[pp.Selected]
+}
+
+// Find returns the position for mint, nil once it has closed
+func (pp PositionsPane) Find(mint string) *trading.Position {
+	for _, p := range pp.Positions {
+		if p.Mint == mint { return p }
+	}
+	return nil
+}
+
 func (pp PositionsPane) Render(w, h int) string {
 	header := StyleTableHeader.Width(w).Render("💼 OPEN POSITIONS " + fmt.Sprintf("(%d)", len(pp.Positions)))
 	subHeader := fmt.Sprintf("%-8s %-7s %-7s %-3s %-6s %s", "TOKEN", "ENTRY%", "CURR%", "2X?", "PnL", "AGE")
@@ -1256,21 +1674,181 @@ func (pp PositionsPane) Render(w, h int) string {
 	return lipgloss.JoinVertical(lipgloss.Left, header, strings.Join(lines, "\n"))
 }
 
+// 4a. POSITION DETAIL (Mode 4: Enter on a focused position)
+type PositionDetailView struct { Mint string }
+func (dv PositionDetailView) Update(msg tea.KeyMsg, m Model) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, keys.Escape) || key.Matches(msg, keys.Enter) { m.CurrentScreen = ScreenDashboard }
+	return m, nil
+}
+func (dv PositionDetailView) Render(w, h int, p *trading.Position) string {
+	if p == nil {
+		header := StyleTableHeader.Width(w).Render("POSITION DETAIL")
+		return lipgloss.JoinVertical(lipgloss.Left, header, "Position closed: "+dv.Mint, "", "[Esc] Back")
+	}
+	header := StyleTableHeader.Width(w).Render("POSITION DETAIL  " + p.TokenName)
+	pnlStyle := StyleProfit
+	if p.PnLSol < 0 { pnlStyle = StyleLoss }
+	yesNo := func(b bool) string { if b { return StyleProfit.Render("yes") }; return "no" }
+	partial := yesNo(p.PartialSold)
+	if p.PartialSold && p.SoldFraction > 0 { partial += fmt.Sprintf(" (%.0f%% sold)", p.SoldFraction*100) }
+	tx := p.EntryTxSig
+	if tx != "" && tx != "PENDING" && !strings.HasPrefix(tx, "SIM_") { tx = notify.TxURL(tx) }
+
+	rows := [][2]string{
+		{"Mint", p.Mint},
+		{"Size", fmt.Sprintf("%.4f SOL", p.Size)},
+		{"Entry", fmt.Sprintf("%.1f%s at %s", p.EntryValue, p.EntryUnit, p.EntryTime.Format("15:04:05"))},
+		{"Current", fmt.Sprintf("%.1f%s", p.CurrentValue, p.EntryUnit)},
+		{"PnL", pnlStyle.Render(fmt.Sprintf("%+.4f SOL (%+.1f%%)", p.PnLSol, p.PnLPercent))},
+		{"Net", netPnLLabel(p) + "  " + exitLabel(p)},
+		{"Peak", fmt.Sprintf("%.2fx", p.PeakMultiple)},
+		{"Age", formatDuration(time.Since(p.EntryTime))},
+		{"Partial", partial},
+		{"2X", yesNo(p.Reached2X)},
+		{"Entry tx", tx},
+	}
+	lines := make([]string, 0, len(rows)+2)
+	for _, r := range rows {
+		lines = append(lines, fmt.Sprintf(" %-9s %s", r[0]+":", r[1]))
+	}
+	lines = append(lines, "", "[Esc] Back")
+	return lipgloss.JoinVertical(lipgloss.Left, header, strings.Join(lines, "\n"))
+}
+
+// 4b. ISSUES PANE (categorized execution failures)
+type IssuesPane struct {
+	Recent []trading.Issue      // Newest first
+	Counts []trading.IssueCount // Last minute, highest first
+	Skips  []trading.IssueCount // Signals not traded by reason, since start
+}
+func NewIssuesPane() IssuesPane { return IssuesPane{} }
+
+// Summary returns up to n "12 slippage" style lines for the last minute
+func (ip IssuesPane) Summary(n int) []string {
+	var lines []string
+	for i, c := range ip.Counts {
+		if i >= n { break }
+		lines = append(lines, fmt.Sprintf("%3d %s", c.Count, c.Category))
+	}
+	return lines
+}
+func (ip IssuesPane) Render(w, h int) string {
+	var lines []string
+	lines = append(lines, StyleTableHeader.Render("LAST MINUTE"))
+	if len(ip.Counts) == 0 {
+		lines = append(lines, StyleProfit.Render("  No errors"))
+	}
+	for _, l := range ip.Summary(len(ip.Counts)) {
+		lines = append(lines, StyleLoss.Render("  "+l))
+	}
+	if len(ip.Skips) > 0 {
+		lines = append(lines, "", StyleTableHeader.Render("SKIPPED SIGNALS"))
+		for _, s := range ip.Skips {
+			lines = append(lines, fmt.Sprintf("  %3d %s", s.Count, s.Category))
+		}
+	}
+	lines = append(lines, "", StyleTableHeader.Render(fmt.Sprintf("%-8s %-12s %-10s %s", "TIME", "STAGE", "CATEGORY", "ERROR")))
+	for _, is := range ip.Recent {
+		if len(lines) >= h { break }
+		row := fmt.Sprintf("%-8s %-12s %-10s %s", is.Time.Format("15:04:05"), is.Stage, is.Category, is.Message)
+		lines = append(lines, truncate(row, w))
+	}
+	return strings.Join(lines, "\n")
+}
+
 // 5. CONFIG MODAL
 type ConfigModal struct {
 	Cfg *config.Manager
 	Fields []string
 	Selected int
+
+	Original   ConfigValues // live values when the modal was opened
+	Staged     ConfigValues // edits, applied only after the diff is confirmed
+	Confirming bool         // showing the old→new preview
+}
+
+// ConfigValues are the fields editable from the config modal
+type ConfigValues struct {
+	MinEntry   float64
+	TakeProfit float64
+	MaxAlloc   float64
+	MaxPos     int
+	PrioFee    float64
+	AutoTrade  bool
 }
+
+// Rows returns label/value pairs in Fields order
+func (v ConfigValues) Rows() [][2]string {
+	auto := "OFF"
+	if v.AutoTrade {
+		auto = "ON"
+	}
+	return [][2]string{
+		{"Min Entry %", fmt.Sprintf("%.0f", v.MinEntry)},
+		{"Take Profit", fmt.Sprintf("%.1fx", v.TakeProfit)},
+		{"Max Alloc %", fmt.Sprintf("%.0f", v.MaxAlloc)},
+		{"Max Pos", fmt.Sprintf("%d", v.MaxPos)},
+		{"Priority Fee", fmt.Sprintf("%.4f", v.PrioFee)},
+		{"Auto Trade", auto},
+	}
+}
+
+// Diff returns "Label: old → new" for every field that differs
+func (v ConfigValues) Diff(next ConfigValues) []string {
+	var out []string
+	old, upd := v.Rows(), next.Rows()
+	for i := range old {
+		if old[i][1] != upd[i][1] {
+			out = append(out, fmt.Sprintf("%-13s %s → %s", old[i][0]+":", old[i][1], upd[i][1]))
+		}
+	}
+	return out
+}
+
 func NewConfigModal(cfg *config.Manager) ConfigModal {
 	return ConfigModal{Cfg: cfg, Fields: []string{"MinEntry", "TakeProfit", "MaxAlloc", "MaxPos", "PrioFee", "AutoTrade"}, Selected: 0}
 }
+
+// Open snapshots the live config into the staging copy
+func (cm *ConfigModal) Open() {
+	c := cm.Cfg.Get()
+	cm.Original = ConfigValues{
+		MinEntry:   c.Trading.MinEntryPercent,
+		TakeProfit: c.Trading.TakeProfitMultiple,
+		MaxAlloc:   c.Trading.MaxAllocPercent,
+		MaxPos:     c.Trading.MaxOpenPositions,
+		PrioFee:    c.Fees.StaticPriorityFeeSol,
+		AutoTrade:  c.Trading.AutoTradingEnabled,
+	}
+	cm.Staged = cm.Original
+	cm.Confirming = false
+}
+
 func (cm ConfigModal) Update(msg tea.KeyMsg, m *Model) (tea.Model, tea.Cmd) {
+	// Diff preview: Enter applies, Esc goes back to editing
+	if cm.Confirming {
+		switch {
+		case key.Matches(msg, keys.Enter):
+			m.commitConfig()
+			m.ConfigModal.Confirming = false
+			m.CurrentScreen = ScreenDashboard
+		case key.Matches(msg, keys.Escape):
+			m.ConfigModal.Confirming = false
+		}
+		return *m, nil
+	}
+
 	switch {
 	case key.Matches(msg, keys.Escape):
+		// Cancel: staged edits are dropped, live config was never touched
+		m.ConfigModal.Staged = m.ConfigModal.Original
 		m.CurrentScreen = ScreenDashboard
 	case key.Matches(msg, keys.Enter):
-		m.CurrentScreen = ScreenDashboard
+		if len(cm.Original.Diff(cm.Staged)) == 0 {
+			m.CurrentScreen = ScreenDashboard
+		} else {
+			m.ConfigModal.Confirming = true
+		}
 	case key.Matches(msg, keys.Up):
 		if cm.Selected > 0 { m.ConfigModal.Selected-- }
 	case key.Matches(msg, keys.Down):
@@ -1283,68 +1861,318 @@ func (cm ConfigModal) Update(msg tea.KeyMsg, m *Model) (tea.Model, tea.Cmd) {
 	return *m, nil
 }
 func (cm ConfigModal) Render(w, h int) string {
-	t := cm.Cfg.GetTrading()
-	f := cm.Cfg.Get() // Get full config for Fees
-
-	autoTrade := StyleLoss.Render("OFF")
-	if t.AutoTradingEnabled {
-		autoTrade = StyleProfit.Render("ON")
+	if cm.Confirming {
+		s := "APPLY CHANGES?\n\n"
+		for _, d := range cm.Original.Diff(cm.Staged) {
+			s += "  " + d + "\n"
+		}
+		s += "\n[Ent] Confirm  [Esc] Back"
+		return StyleModal.Render(s)
 	}
 
-	rows := []string{
-		fmt.Sprintf("Min Entry %%:  %.0f", t.MinEntryPercent),
-		fmt.Sprintf("Take Profit:  %.1fx", t.TakeProfitMultiple),
-		fmt.Sprintf("Max Alloc %%:  %.0f", t.MaxAllocPercent),
-		fmt.Sprintf("Max Pos:      %d", t.MaxOpenPositions),
-		fmt.Sprintf("Priority Fee: %.4f", f.Fees.StaticPriorityFeeSol),
-		fmt.Sprintf("Auto Trade:   %s", autoTrade),
-	}
-	
+	original := cm.Original.Rows()
 	s := "CONFIGURATION\n\n"
-	for i, r := range rows {
+	for i, r := range cm.Staged.Rows() {
 		cursor := "  "
 		if i == cm.Selected { cursor = "> " }
-		s += cursor + r + "\n"
+		value := r[1]
+		if i == 5 {
+			value = StyleLoss.Render(value)
+			if cm.Staged.AutoTrade { value = StyleProfit.Render(value) }
+		}
+		if r[1] != original[i][1] { value += " *" } // Unsaved edit
+		s += cursor + fmt.Sprintf("%-13s %s", r[0]+":", value) + "\n"
+	}
+	s += "\n[Ent] Review  [Esc] Cancel  [←/→] Adjust"
+	return StyleModal.Render(s)
+}
+
+// 5b. BUY MODAL (manual entry for a token seen in the feed)
+type BuyModal struct {
+	Token  string // Mint or symbol, resolved on submit
+	Amount string // SOL
+	Field  int    // 0=Token, 1=Amount
+	Err    string // Validation error, shown until the next submit
+}
+
+// Open clears the inputs, prefilling the token from the selected signal
+func (bm *BuyModal) Open(sel *SignalRow) {
+	*bm = BuyModal{}
+	if sel != nil {
+		bm.Token = sel.Mint
+		if bm.Token == "" { bm.Token = sel.TokenName }
+		bm.Field = 1
+	}
+}
+
+func (bm BuyModal) Update(msg tea.KeyMsg, m *Model) (tea.Model, tea.Cmd) {
+	// Typed text goes to the inputs first: j/k/q are letters here, not hotkeys
+	field := &m.BuyModal.Token
+	if bm.Field == 1 { field = &m.BuyModal.Amount }
+	switch {
+	case msg.Type == tea.KeyRunes:
+		*field += string(msg.Runes)
+	case msg.Type == tea.KeyBackspace:
+		if r := []rune(*field); len(r) > 0 { *field = string(r[:len(r)-1]) }
+	case key.Matches(msg, keys.Escape):
+		m.CurrentScreen = ScreenDashboard
+	case key.Matches(msg, keys.Enter):
+		return m.submitManualBuy()
+	case key.Matches(msg, keys.Tab), msg.Type == tea.KeyUp, msg.Type == tea.KeyDown:
+		m.BuyModal.Field = 1 - bm.Field
 	}
-	s += "\n[Ent] Save  [Esc] Cancel  [←/→] Adjust"
+	return *m, nil
+}
+func (bm BuyModal) Render(w, h int) string {
+	s := "MANUAL BUY\n\n"
+	for i, f := range [][2]string{{"Token", bm.Token}, {"Amount SOL", bm.Amount}} {
+		cursor := "  "
+		if i == bm.Field { cursor = "> "; f[1] += "_" }
+		s += cursor + fmt.Sprintf("%-11s %s", f[0]+":", f[1]) + "\n"
+	}
+	if bm.Err != "" { s += "\n" + StyleLoss.Render(bm.Err) + "\n" }
+	s += "\n[Ent] Buy  [Tab] Next  [Esc] Cancel"
+	return StyleModal.Render(s)
+}
+
+// 5c. CONFIRM MODAL (y/n gate for destructive actions)
+
+// PendingAction is a destructive action waiting on the confirm modal
+type PendingAction string
+const (
+	ActionNone      PendingAction = ""
+	ActionPanicSell PendingAction = "panic_sell" // F9: sell everything, reset stats
+)
+
+type ConfirmModal struct { Prompt string }
+func (cm ConfirmModal) Render(w, h int) string {
+	s := StyleLoss.Bold(true).Render(cm.Prompt) + " y/n\n\n[Y] Confirm  [N/Esc] Cancel"
 	return StyleModal.Render(s)
 }
 
 // 6. LOGS VIEW
-type LogsView struct { Lines []string }
+const LogsViewMaxLines = 500
+
+var StyleMatch = lipgloss.NewStyle().Reverse(true)
+
+type LogsView struct {
+	Lines     []string
+	Offset    int    // Lines scrolled back from the newest (0 = follow the tail)
+	Query     string // Filter: only lines containing it (case-insensitive)
+	Searching bool   // Typing the query after "/"
+}
 func NewLogsView() LogsView { return LogsView{Lines: []string{}} }
 func (lv *LogsView) Add(l []string) {
 	lv.Lines = append(lv.Lines, l...)
-	if len(lv.Lines) > 500 {
-		lv.Lines = lv.Lines[len(lv.Lines)-500:]
+	if len(lv.Lines) > LogsViewMaxLines {
+		lv.Lines = lv.Lines[len(lv.Lines)-LogsViewMaxLines:]
+	}
+	// Scrolled back: keep the same lines on screen as new ones arrive
+	if lv.Offset > 0 {
+		for _, line := range l {
+			if lv.matches(line) { lv.Offset++ }
+		}
 	}
 }
 func (lv LogsView) GetLastLine() string {
 	if len(lv.Lines) == 0 { return "" }
 	return lv.Lines[len(lv.Lines)-1]
 }
+
+func (lv LogsView) matches(line string) bool {
+	return lv.Query == "" || strings.Contains(strings.ToLower(line), strings.ToLower(lv.Query))
+}
+
+// Visible returns the lines passing the filter, oldest first
+func (lv LogsView) Visible() []string {
+	if lv.Query == "" { return lv.Lines }
+	var out []string
+	for _, line := range lv.Lines {
+		if lv.matches(line) { out = append(out, line) }
+	}
+	return out
+}
+
+// scroll moves delta lines back (+) or forward (-), clamped to the filtered lines
+func (lv *LogsView) scroll(delta, page int) {
+	lv.Offset += delta
+	if top := len(lv.Visible()) - page; lv.Offset > top { lv.Offset = top }
+	if lv.Offset < 0 { lv.Offset = 0 }
+}
+
+func logsPageSize(h int) int {
+	if h-4 < 1 { return 1 }
+	return h - 4
+}
+
 func (lv LogsView) Update(msg tea.KeyMsg, m Model) (tea.Model, tea.Cmd) {
-	if key.Matches(msg, keys.Escape) { m.CurrentScreen = ScreenDashboard }
+	v := &m.LogsView
+	page := logsPageSize(m.Height)
+
+	// Typing a search: letters are query text, Enter keeps the filter, Esc drops it
+	if lv.Searching {
+		switch {
+		case msg.Type == tea.KeyRunes:
+			v.Query += string(msg.Runes)
+		case msg.Type == tea.KeyBackspace:
+			if r := []rune(v.Query); len(r) > 0 { v.Query = string(r[:len(r)-1]) }
+		case key.Matches(msg, keys.Enter):
+			v.Searching = false
+		case key.Matches(msg, keys.Escape):
+			v.Searching, v.Query = false, ""
+		}
+		v.scroll(0, page)
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, keys.Escape):
+		if v.Query != "" {
+			v.Query = "" // First Esc clears the filter
+			v.scroll(0, page)
+		} else {
+			v.Offset = 0
+			m.CurrentScreen = ScreenDashboard
+		}
+	case key.Matches(msg, keys.Search):
+		v.Searching = true
+	case key.Matches(msg, keys.Up):
+		v.scroll(1, page)
+	case key.Matches(msg, keys.Down):
+		v.scroll(-1, page)
+	case key.Matches(msg, keys.PageUp):
+		v.scroll(page, page)
+	case key.Matches(msg, keys.PageDown):
+		v.scroll(-page, page)
+	}
 	return m, nil
 }
 func (lv LogsView) Render(w, h int) string {
-	header := StyleTableHeader.Width(w).Render("SYSTEM LOGS")
-	show := lv.Lines
-	if len(show) > h-4 { show = show[len(show)-(h-4):] }
-	return lipgloss.JoinVertical(lipgloss.Left, header, strings.Join(show, "\n"))
+	title := "SYSTEM LOGS"
+	show := lv.Visible()
+	if lv.Query != "" || lv.Searching {
+		title += fmt.Sprintf("  /%s", lv.Query)
+		if lv.Searching { title += "_" }
+		title += fmt.Sprintf(" (%d matches)", len(show))
+	}
+	if lv.Offset > 0 { title += fmt.Sprintf("  ↑%d", lv.Offset) }
+	header := StyleTableHeader.Width(w).Render(title)
+
+	page := logsPageSize(h)
+	end := len(show) - lv.Offset
+	if end < 0 { end = 0 }
+	start := end - page
+	if start < 0 { start = 0 }
+	lines := make([]string, 0, end-start)
+	for _, line := range show[start:end] {
+		lines = append(lines, highlight(line, lv.Query))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, strings.Join(lines, "\n"))
+}
+
+// highlight marks every case-insensitive occurrence of query in line
+func highlight(line, query string) string {
+	lower, q := strings.ToLower(line), strings.ToLower(query)
+	if q == "" || len(lower) != len(line) { return line } // Offsets only line up if lowering kept the length
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, q)
+		if i < 0 { break }
+		b.WriteString(line[:i])
+		b.WriteString(StyleMatch.Render(line[i : i+len(q)]))
+		line, lower = line[i+len(q):], lower[i+len(q):]
+	}
+	b.WriteString(line)
+	return b.String()
 }
 
-// 7. TRADES VIEW
-type TradesHistoryView struct {}
-func NewTradesHistoryView() TradesHistoryView { return TradesHistoryView{} }
+// 7. TRADES VIEW (trade log from SQLite, newest first)
+const (
+	TradesHistoryLimit    = 100
+	TradesRefreshInterval = 5 * time.Second
+)
+
+type TradesHistoryView struct {
+	db       *storage.DB
+	Trades   []*storage.Trade
+	Offset   int       // Scroll offset
+	LoadedAt time.Time // Last load request
+}
+func NewTradesHistoryView(db *storage.DB) TradesHistoryView { return TradesHistoryView{db: db} }
+
+// Load reads the most recent trades off the UI goroutine and delivers them as a TradesMsg
+func (thv TradesHistoryView) Load() tea.Cmd {
+	db := thv.db
+	if db == nil { return nil }
+	return func() tea.Msg {
+		trades, err := db.GetRecentTrades(TradesHistoryLimit)
+		if err != nil { return LogMsg{Lines: []string{"trade history: " + err.Error()}} }
+		return TradesMsg{Trades: trades}
+	}
+}
 func (thv TradesHistoryView) Update(msg tea.KeyMsg, m Model) (tea.Model, tea.Cmd) {
-	if key.Matches(msg, keys.Escape) { m.CurrentScreen = ScreenDashboard }
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.CurrentScreen = ScreenDashboard
+	case key.Matches(msg, keys.Up):
+		if m.TradesView.Offset > 0 { m.TradesView.Offset-- }
+	case key.Matches(msg, keys.Down):
+		if m.TradesView.Offset < len(m.TradesView.Trades)-1 { m.TradesView.Offset++ }
+	}
 	return m, nil
 }
 func (thv TradesHistoryView) Render(w, h int) string {
-	header := StyleTableHeader.Width(w).Render("TRADE HISTORY")
-	body := "No trades yet..." 
-	return lipgloss.JoinVertical(lipgloss.Left, header, body)
+	header := StyleTableHeader.Width(w).Render(fmt.Sprintf("📜 TRADE HISTORY (%d)", len(thv.Trades)))
+	if len(thv.Trades) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, "No trades yet...")
+	}
+	subHeader := fmt.Sprintf("%-11s %-8s %-5s %-8s %-8s %-8s %-9s %-6s %s", "TIME", "TOKEN", "SIDE", "ENTRY", "EXIT", "PnL", "SOL", "SLIP", "HELD")
+	lines := []string{subHeader}
+
+	// Visible rows: h - header - subheader - scroll hint
+	visibleHeight := h - 3
+	if visibleHeight < 1 { visibleHeight = 1 }
+	start := thv.Offset
+	if start >= len(thv.Trades) { start = len(thv.Trades) - 1 }
+	if start < 0 { start = 0 }
+	end := start + visibleHeight
+	if end > len(thv.Trades) { end = len(thv.Trades) }
+
+	for _, t := range thv.Trades[start:end] {
+		pnl, sol := "-", "-"
+		if t.Side != "BUY" {
+			pnlStyle := StyleProfit
+			if t.PnL < 0 { pnlStyle = StyleLoss }
+			pnl = pnlStyle.Render(fmt.Sprintf("%-8s", fmt.Sprintf("%+.0f%%", t.PnL)))
+			if t.RealizedPnLSol != 0 {
+				solStyle := StyleProfit
+				if t.RealizedPnLSol < 0 { solStyle = StyleLoss }
+				sol = solStyle.Render(fmt.Sprintf("%-9s", fmt.Sprintf("%+.4f", t.RealizedPnLSol)))
+			}
+		}
+		if pnl == "-" { pnl = fmt.Sprintf("%-8s", pnl) }
+		if sol == "-" { sol = fmt.Sprintf("%-9s", sol) }
+		slip := "-"
+		if t.SlippagePct != 0 { slip = fmt.Sprintf("%.1f%%", t.SlippagePct) }
+		side := t.Side
+		if t.Paper { side += "*" } // paper fill (trading.paper_trading), never sent
+		row := fmt.Sprintf("%-11s %-8s %-5s %-8s %-8s %s %s %-6s %s",
+			time.Unix(t.Timestamp, 0).Format("01-02 15:04"),
+			truncate(t.TokenName, 8),
+			side,
+			fmt.Sprintf("%.1f", t.EntryValue),
+			fmt.Sprintf("%.1f", t.ExitValue),
+			pnl,
+			sol,
+			slip,
+			formatDuration(time.Duration(t.Duration)*time.Second),
+		)
+		lines = append(lines, row)
+	}
+	if end < len(thv.Trades) {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorGray).Render(fmt.Sprintf("... %d more ↓", len(thv.Trades)-end)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, strings.Join(lines, "\n"))
 }
 
 
@@ -1364,8 +2192,16 @@ func SendSignal(p *tea.Program, s *signalPkg.Signal){ p.Send(SignalMsg{s}) }
 func SendPositions(p *tea.Program, pos []*trading.Position){ p.Send(PositionMsg{pos}) }
 func SendBalance(p *tea.Program, b float64){ p.Send(BalanceMsg{b}) }
 func SendLatency(p *tea.Program, l int64){ p.Send(LatencyMsg{l}) }
-func SendStats(p *tea.Program, e, x2 int){ p.Send(StatsMsg{e, x2}) }
+func SendStats(p *tea.Program, e, x2 int, daily float64){ p.Send(StatsMsg{e, x2, daily}) }
 func SendLogs(p *tea.Program, l []string){ p.Send(LogMsg{l}) }
+func SendIssues(p *tea.Program, recent []trading.Issue, counts []trading.IssueCount){ p.Send(IssuesMsg{recent, counts}) }
+func SendSkips(p *tea.Program, counts []trading.IssueCount){ p.Send(SkipsMsg{counts}) }
+func SendBlockhashStats(p *tea.Program, st blockchain.BlockhashStats){ p.Send(BlockhashMsg{st}) }
+func SendRPCEndpoint(p *tea.Program, best blockchain.EndpointStat, all []blockchain.EndpointStat){ p.Send(RPCEndpointMsg{best, all}) }
+func SendDegraded(p *tea.Program, mode string, wsDownFor time.Duration){ p.Send(DegradedMsg{mode, wsDownFor}) }
+func SendWSStatus(p *tea.Program, connected bool, err error){ p.Send(WSStatusMsg{connected, err}) }
+func SendQueue(p *tea.Program, queued, inFlight int){ p.Send(QueueMsg{queued, inFlight}) }
+func SendHealth(p *tea.Program, r health.Report){ p.Send(HealthMsg{r}) }
 
 // --- VISUAL COMPONENTS ---
 
@@ -1587,6 +2423,12 @@ func (m Model) renderNeonDashboard() string {
 	bal := m.WalletBalance
 	usdEst := bal * 185.0
 	
+	issueLines := lipgloss.NewStyle().Foreground(lipgloss.Color("#444")).Render(" none")
+	if summary := m.Issues.Summary(3); len(summary) > 0 {
+		for i := range summary { summary[i] = truncate(" "+summary[i], c1) }
+		issueLines = StyleLoss.Render(strings.Join(summary, "\n"))
+	}
+
 	leftContent := lipgloss.JoinVertical(lipgloss.Left,
 		lipgloss.NewStyle().Foreground(neonPink).Bold(true).Render(" [ SYSTEM ]"),
 		fmt.Sprintf(" RAM  %s %s", renderBar(ramPct, 10), m.Header.MemUsage),
@@ -1600,6 +2442,9 @@ func (m Model) renderNeonDashboard() string {
 		lipgloss.NewStyle().Foreground(neonPink).Bold(true).Render(" [ WALLET ]"),
 		fmt.Sprintf(" SOL: %.2f", bal),
 		fmt.Sprintf(" USD: $%.0f", usdEst),
+		"",
+		lipgloss.NewStyle().Foreground(neonPink).Bold(true).Render(" [ ISSUES 1m ]"),
+		issueLines,
 	)
 	leftPanel := boxStyle.Copy().Width(c1).BorderForeground(leftBorder).Render(leftContent)
 	
@@ -1627,6 +2472,14 @@ func (m Model) renderNeonDashboard() string {
 	// Fill
 	for len(feedLines) < visibleSignals { feedLines = append(feedLines, "") }
 	
+	// Value-less signals awaiting manual review (details in full signals view)
+	if n := len(m.Signals.Review); n > 0 && len(feedLines) > 0 {
+		var names []string
+		for _, s := range m.Signals.Review { names = append(names, s.TokenName) }
+		review := fmt.Sprintf(" REVIEW(%d): %s", n, strings.Join(names, " "))
+		feedLines[len(feedLines)-1] = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffaa00")).Render(truncate(review, c2-2))
+	}
+	
 	centerContent := lipgloss.JoinVertical(lipgloss.Left,
 		feedTitle,
 		strings.Join(feedLines, "\n"),
@@ -1649,7 +2502,12 @@ func (m Model) renderNeonDashboard() string {
 	// Auto-clamp offset if list shrank
 	if startPos > len(m.Positions.Positions) { startPos = len(m.Positions.Positions) }
 	
-	endPos := startPos + visiblePositions
+	// Few positions: two lines each (expanded detail); many: dense single line
+	compact := m.compactPositions()
+	perPosition := 1
+	if !compact { perPosition = 2 }
+	
+	endPos := startPos + maxi(visiblePositions/perPosition, 1)
 	if endPos > len(m.Positions.Positions) { endPos = len(m.Positions.Positions) }
 	
 	for i := startPos; i < endPos; i++ {
@@ -1659,7 +2517,10 @@ func (m Model) renderNeonDashboard() string {
 		nameLen := 6
 		// Format: TOKEN ENTRY CUR PnL% AGE
 		age := formatDuration(time.Since(p.EntryTime))
-		line := fmt.Sprintf(" %-6s %4.0f %4.0f %s %s", 
+		cursor := " "
+		if m.FocusPane == 2 && i == m.Positions.Selected { cursor = "›" }
+		line := fmt.Sprintf("%s%-6s %4.0f %4.0f %s %s", 
+			cursor,
 			truncate(p.TokenName, nameLen), 
 			p.EntryValue,
 			p.CurrentValue,
@@ -1667,6 +2528,9 @@ func (m Model) renderNeonDashboard() string {
 			age,
 		)
 		posLines = append(posLines, line)
+		if !compact {
+			posLines = append(posLines, truncate(m.positionDetailLine("   ", p), c3-4))
+		}
 	}
 	
 	// Fill empty space if list is short
@@ -1693,6 +2557,14 @@ func (m Model) renderNeonDashboard() string {
 		Align(lipgloss.Center).
 		Width(w).
 		Render(fmt.Sprintf("⚡ AFNEX COMMAND CENTER ⚡   [LIVE] 🔴 REC  %s", time.Now().Format("15:04:05")))
+	if banner := m.degradedBanner(); banner != "" {
+		header = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#ff0055")).
+			Align(lipgloss.Center).
+			Width(w).
+			Render(fmt.Sprintf("⚡ AFNEX COMMAND CENTER ⚡   [%s] %s", banner, time.Now().Format("15:04:05")))
+	}
 		
 	// Grid
 	grid := lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, centerPanel, rightPanel)
@@ -1704,6 +2576,73 @@ func (m Model) renderNeonDashboard() string {
 	return lipgloss.NewStyle().Background(bg).Render(ui)
 }
 
+// degradedBanner describes the WebSocket outage mode for headers ("" when healthy)
+func (m Model) degradedBanner() string {
+	switch m.Degraded {
+	case "":
+		return ""
+	case trading.DegradedPause:
+		return fmt.Sprintf("PAUSED · WS DOWN %s", formatDuration(m.WSDownFor))
+	default:
+		return fmt.Sprintf("SELL-ONLY · WS DOWN %s", formatDuration(m.WSDownFor))
+	}
+}
+
+// DefaultCompactPositionsThreshold is used when no config is attached
+const DefaultCompactPositionsThreshold = 4
+
+// compactPositions reports whether position lists should use the dense
+// single-line format (more open positions than tui.compact_positions_threshold)
+func (m Model) compactPositions() bool {
+	threshold := DefaultCompactPositionsThreshold
+	if m.Config != nil {
+		threshold = m.Config.Get().TUI.CompactPositionsThreshold
+	}
+	return len(m.Positions.Positions) > threshold
+}
+
+// positionDetailLine is the second row of an expanded position: size, SOL PnL, exit estimate, peak, age
+func (m Model) positionDetailLine(indent string, p *trading.Position) string {
+	style := StyleProfit
+	if p.PnLSol < 0 { style = StyleLoss }
+	exit := ""
+	if m.showNetPnL() {
+		exit += "  " + netPnLLabel(p)
+	}
+	if m.showExitEstimate() {
+		exit += "  " + exitLabel(p)
+	}
+	return lipgloss.NewStyle().Foreground(ColorGray).Render(fmt.Sprintf("%s%.3f SOL  ", indent, p.Size)) +
+		style.Render(fmt.Sprintf("%+.4f", p.PnLSol)) +
+		lipgloss.NewStyle().Foreground(ColorGray).Render(fmt.Sprintf("%s  pk %.2fx  %s", exit, p.PeakMultiple, formatDuration(time.Since(p.EntryTime))))
+}
+
+// showExitEstimate reports whether tui.show_exit_estimate is on (default on)
+func (m Model) showExitEstimate() bool {
+	return m.Config == nil || m.Config.Get().TUI.ShowExitEstimate
+}
+
+// showNetPnL reports whether tui.show_net_pnl is on (default on)
+func (m Model) showNetPnL() bool {
+	return m.Config == nil || m.Config.Get().TUI.ShowNetPnL
+}
+
+// netPnLLabel renders PnL after round-trip costs ("?" until first quote)
+func netPnLLabel(p *trading.Position) string {
+	if p.ExitValueSol <= 0 {
+		return "net ?"
+	}
+	return fmt.Sprintf("net %+.1f%%", p.NetPnLPercent)
+}
+
+// exitLabel renders the slippage/fee-adjusted exit value ("?" until first quote)
+func exitLabel(p *trading.Position) string {
+	if p.ExitValueSol <= 0 {
+		return "exit≈?"
+	}
+	return fmt.Sprintf("exit≈%.4f SOL", p.ExitValueSol)
+}
+
 func (m Model) renderNeonFooter(w int) string {
 	// Status
 	status := fmt.Sprintf(" ⏱ %s │ 💰 %+.2f%%", 
@@ -1712,7 +2651,7 @@ func (m Model) renderNeonFooter(w int) string {
 	)
 	
 	// Controls
-	controls := "[TAB/←→]Focus [↑↓]Scroll [Q]uit "
+	controls := "[TAB/←→]Focus [↑↓]Scroll [↵]Detail [I]ssues [Q]uit "
 	
 	// Spacer
 	spaceAvailable := w - lipgloss.Width(status) - lipgloss.Width(controls)
@@ -0,0 +1,579 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"solana-pump-bot/internal/blockchain"
+	"solana-pump-bot/internal/config"
+	"solana-pump-bot/internal/health"
+	signalPkg "solana-pump-bot/internal/signal"
+	"solana-pump-bot/internal/storage"
+	"solana-pump-bot/internal/trading"
+)
+
+// Signals sent to the TUI are the same pointers the executor works with.
+// Run with -race: the executor-side reads below must never overlap a TUI write.
+func TestUpdate_SignalMsgDoesNotMutateSharedSignals(t *testing.T) {
+	m := NewModel(nil)
+
+	entries := make([]*signalPkg.Signal, 10)
+	for i := range entries {
+		entries[i] = &signalPkg.Signal{TokenName: "TOK", Type: signalPkg.SignalEntry, Value: 60, Unit: "%", MsgID: int64(i)}
+	}
+
+	// Executor side: keeps reading its signals while the TUI processes messages
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				for _, s := range entries {
+					_ = s.Reached2X
+				}
+			}
+		}
+	}()
+
+	var model interface{} = m
+	for _, s := range entries {
+		model, _ = model.(Model).Update(SignalMsg{s})
+	}
+	exit := &signalPkg.Signal{TokenName: "TOK", Type: signalPkg.SignalExit, Value: 2, Unit: "X"}
+	model, _ = model.(Model).Update(SignalMsg{exit})
+
+	close(stop)
+	wg.Wait()
+
+	for _, s := range entries {
+		if s.Reached2X {
+			t.Fatalf("TUI mutated executor-owned signal %d", s.MsgID)
+		}
+	}
+	rows := model.(Model).Signals.List
+	if len(rows) != len(entries) {
+		t.Fatalf("signal rows = %d, want %d", len(rows), len(entries))
+	}
+	for i, r := range rows {
+		if !r.Reached2X {
+			t.Errorf("row %d not marked as reached 2X", i)
+		}
+	}
+}
+
+func TestConfigModal_StagesUntilConfirmed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("trading:\n  take_profit_multiple: 2\nrpc:\n  shyft_url: http://127.0.0.1:0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var model tea.Model = NewModel(cfg)
+	press := func(k tea.KeyMsg) { model, _ = model.Update(k) }
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	press(tea.KeyMsg{Type: tea.KeyDown}) // Take Profit
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if got := cfg.GetTrading().TakeProfitMultiple; got != 2 {
+		t.Fatalf("live take-profit changed to %v before confirm", got)
+	}
+
+	// Esc cancels and reverts the staged edit
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if got := model.(Model).ConfigModal.Staged.TakeProfit; got != 2 {
+		t.Fatalf("staged take-profit = %v after cancel, want 2", got)
+	}
+
+	// Enter shows the diff, a second Enter applies it (selection is kept)
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if !model.(Model).ConfigModal.Confirming {
+		t.Fatal("expected diff preview after Enter")
+	}
+	if diff := model.(Model).ConfigModal.Original.Diff(model.(Model).ConfigModal.Staged); len(diff) != 1 {
+		t.Errorf("diff = %v, want one changed field", diff)
+	}
+	// A change that lands while the modal is open survives the commit
+	cfg.Update(func(c *config.Config) { c.Trading.MaxAllocPercent = 20 })
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := cfg.GetTrading().TakeProfitMultiple; got != 2.5 {
+		t.Errorf("live take-profit = %v after confirm, want 2.5", got)
+	}
+	if got := cfg.GetTrading().MaxAllocPercent; got != 20 {
+		t.Errorf("live alloc = %v after confirm, want 20 (not edited in the modal)", got)
+	}
+}
+
+func TestTradesHistory_LoadsFromDBAndScrolls(t *testing.T) {
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "trades.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for i, name := range []string{"OLD", "MID", "NEW"} {
+		db.InsertTrade(&storage.Trade{Mint: name, TokenName: name, Side: "SELL", PnL: float64(i*50 - 50), Timestamp: int64(1000 + i)})
+	}
+
+	m := NewModel(nil)
+	m.SetTradesDB(db)
+	m.Width, m.Height = 100, 30
+	var model tea.Model = m
+
+	// "t" opens the screen and returns the load command
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if model.(Model).CurrentScreen != ScreenTrades || cmd == nil {
+		t.Fatalf("screen = %v, cmd = %v; want trades screen and a load", model.(Model).CurrentScreen, cmd)
+	}
+	model, _ = model.Update(cmd())
+
+	view := model.View()
+	if !strings.Contains(view, "TRADE HISTORY (3)") || strings.Index(view, "NEW") > strings.Index(view, "OLD") {
+		t.Fatalf("trades not rendered newest first:\n%s", view)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := model.(Model).TradesView.Offset; got != 1 {
+		t.Errorf("offset after down = %d, want 1", got)
+	}
+	if strings.Contains(model.View(), "NEW") {
+		t.Error("scrolled view still shows the first row")
+	}
+}
+
+func TestBuyModal_ValidatesAndFiresCallback(t *testing.T) {
+	m := NewModel(nil)
+	m.WalletBalance = 1
+	type buy struct {
+		mint string
+		sol  float64
+	}
+	var bought []buy
+	m.SetManualBuy(
+		func(s string) (string, error) { return "Mint" + s, nil },
+		func(mint string, sol float64) { bought = append(bought, buy{mint, sol}) },
+	)
+	var model tea.Model = m
+	var cmd tea.Cmd
+	press := func(k tea.KeyMsg) { model, cmd = model.Update(k) }
+	typ := func(s string) { press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}) }
+
+	typ("b")
+	if model.(Model).CurrentScreen != ScreenBuy {
+		t.Fatalf("screen = %v, want buy modal", model.(Model).CurrentScreen)
+	}
+	// Letters that are hotkeys elsewhere are just input here
+	typ("jkq")
+	press(tea.KeyMsg{Type: tea.KeyBackspace})
+	press(tea.KeyMsg{Type: tea.KeyTab})
+	typ("2")
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	bm := model.(Model).BuyModal
+	if bm.Token != "jk" || !strings.Contains(bm.Err, "exceeds balance") || cmd != nil {
+		t.Fatalf("modal = %+v, cmd = %v; want token jk and a balance error", bm, cmd)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyBackspace})
+	typ("0.25")
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.(Model).CurrentScreen != ScreenDashboard || cmd == nil {
+		t.Fatalf("screen = %v, cmd = %v; want dashboard and a buy command", model.(Model).CurrentScreen, cmd)
+	}
+	if len(bought) != 0 {
+		t.Fatal("callback fired on the UI goroutine")
+	}
+	cmd()
+	if len(bought) != 1 || bought[0] != (buy{"Mintjk", 0.25}) {
+		t.Errorf("bought = %v, want [{Mintjk 0.25}]", bought)
+	}
+}
+
+func TestBuyModal_PrefillsSelectedSignalAndCancels(t *testing.T) {
+	m := NewModel(nil)
+	m.Signals.List = []SignalRow{{TokenName: "PEPE", Mint: "PepeMint"}}
+	var model tea.Model = m
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if bm := model.(Model).BuyModal; bm.Token != "PepeMint" || bm.Field != 1 {
+		t.Fatalf("modal = %+v, want mint prefilled and the amount focused", bm)
+	}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(Model).CurrentScreen != ScreenDashboard {
+		t.Errorf("screen = %v after Esc, want dashboard", model.(Model).CurrentScreen)
+	}
+}
+
+func TestPanicSell_RequiresConfirmation(t *testing.T) {
+	m := NewModel(nil)
+	m.Positions.Positions = []*trading.Position{{Mint: "A"}, {Mint: "B"}}
+	m.Header.TotalEntries = 3
+	var closed []string
+	cleared := 0
+	m.SetCallbacks(nil, func(mint string) { closed = append(closed, mint) }, func() { cleared++ }, nil)
+	var model tea.Model = m
+	press := func(k tea.KeyMsg) { model, _ = model.Update(k) }
+	typ := func(s string) { press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}) }
+
+	// n, Esc and unrelated keys leave everything in place
+	for _, cancel := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("n")}, {Type: tea.KeyEsc}} {
+		press(tea.KeyMsg{Type: tea.KeyF9})
+		if model.(Model).Pending != ActionPanicSell {
+			t.Fatalf("pending = %q after F9, want panic sell", model.(Model).Pending)
+		}
+		typ("s")
+		press(cancel)
+		if model.(Model).Pending != ActionNone || cleared != 0 || len(closed) != 0 {
+			t.Fatalf("after %v: pending %q, cleared %d, closed %v; want nothing done", cancel, model.(Model).Pending, cleared, closed)
+		}
+	}
+	if len(model.(Model).Positions.Positions) != 2 {
+		t.Fatal("positions cleared without confirmation")
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyF9})
+	typ("y")
+	if cleared != 1 || len(closed) != 2 {
+		t.Errorf("cleared %d, closed %v; want OnClear once and both positions sold", cleared, closed)
+	}
+	if got := model.(Model); got.Pending != ActionNone || got.Positions.Positions != nil || got.Header.TotalEntries != 0 {
+		t.Errorf("state after confirm: pending %q, %d positions, %d entries", got.Pending, len(got.Positions.Positions), got.Header.TotalEntries)
+	}
+}
+
+func TestLogsView_ScrollClampsToBuffer(t *testing.T) {
+	m := NewModel(nil)
+	m.Width, m.Height = 80, 14 // 10 log lines per page
+	for i := 0; i < 25; i++ {
+		m.LogsView.Add([]string{"line " + strconv.Itoa(i)})
+	}
+	m.CurrentScreen = ScreenLogs
+	var model tea.Model = m
+	press := func(k tea.KeyMsg) { model, _ = model.Update(k) }
+
+	press(tea.KeyMsg{Type: tea.KeyPgUp})
+	press(tea.KeyMsg{Type: tea.KeyPgUp})
+	if got := model.(Model).LogsView.Offset; got != 15 {
+		t.Fatalf("offset after two page ups = %d, want 15 (clamped to the oldest page)", got)
+	}
+	if view := model.View(); !strings.Contains(view, "line 0") || !strings.Contains(view, "line 9") || strings.Contains(view, "line 10") {
+		t.Errorf("oldest page not shown:\n%s", view)
+	}
+
+	// New lines don't move a scrolled-back view
+	model, _ = model.Update(LogMsg{Lines: []string{"line 25"}})
+	if got := model.(Model).LogsView.Offset; got != 16 {
+		t.Errorf("offset after a new line = %d, want 16", got)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyPgDown})
+	press(tea.KeyMsg{Type: tea.KeyPgDown})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	if got := model.(Model).LogsView.Offset; got != 0 {
+		t.Errorf("offset = %d, want 0 (back at the tail)", got)
+	}
+}
+
+func TestLogsView_SearchFiltersAndHighlights(t *testing.T) {
+	m := NewModel(nil)
+	m.Width, m.Height = 80, 20
+	m.LogsView.Add([]string{"BUY SENT pepe", "quote ok", "SELL pepe", "quote failed"})
+	m.CurrentScreen = ScreenLogs
+	var model tea.Model = m
+	press := func(k tea.KeyMsg) { model, _ = model.Update(k) }
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("PEPEx")})
+	press(tea.KeyMsg{Type: tea.KeyBackspace})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+
+	lv := model.(Model).LogsView
+	if got := lv.Visible(); lv.Searching || len(got) != 2 || got[0] != "BUY SENT pepe" || got[1] != "SELL pepe" {
+		t.Fatalf("searching %v, visible %q; want the two pepe lines", lv.Searching, got)
+	}
+	if got := highlight("SELL pepe", "PEPE"); got != "SELL "+StyleMatch.Render("pepe") {
+		t.Errorf("highlight = %q", got)
+	}
+
+	// Esc clears the filter first, then leaves the screen
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if lv := model.(Model).LogsView; lv.Query != "" || len(lv.Visible()) != 4 || model.(Model).CurrentScreen != ScreenLogs {
+		t.Fatalf("after Esc: query %q, screen %v; want filter cleared, still on logs", lv.Query, model.(Model).CurrentScreen)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(Model).CurrentScreen != ScreenDashboard {
+		t.Errorf("screen = %v, want dashboard", model.(Model).CurrentScreen)
+	}
+}
+
+func TestPositionDetail_OpensSelectedAndReturns(t *testing.T) {
+	t.Setenv("UI_MODE", "4")
+	m := NewModel(nil)
+	m.Width, m.Height = 120, 40
+	m.Positions.Update([]*trading.Position{
+		{Mint: "MintAAA", TokenName: "AAA", Size: 0.1},
+		{Mint: "MintBBB", TokenName: "BBB", Size: 0.2, EntryTxSig: "SigBBB", PeakMultiple: 1.8, PartialSold: true, SoldFraction: 0.25},
+	})
+	m.FocusPane = 1
+	var model tea.Model = m
+	press := func(k tea.KeyMsg) { model, _ = model.Update(k) }
+
+	// Enter only drills in while the positions pane has focus
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.(Model).CurrentScreen != ScreenDashboard {
+		t.Fatalf("screen = %v, want dashboard (signals focused)", model.(Model).CurrentScreen)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeyDown}) // clamped at the last position
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := model.(Model); got.CurrentScreen != ScreenPosition || got.Detail.Mint != "MintBBB" {
+		t.Fatalf("screen = %v, detail = %q; want detail of MintBBB", got.CurrentScreen, got.Detail.Mint)
+	}
+	view := model.View()
+	for _, want := range []string{"MintBBB", "https://solscan.io/tx/SigBBB", "1.80x", "25% sold"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail view missing %q:\n%s", want, view)
+		}
+	}
+
+	// The position closing while open doesn't break the view
+	model, _ = model.Update(PositionMsg{Positions: []*trading.Position{{Mint: "MintAAA", TokenName: "AAA"}}})
+	if !strings.Contains(model.View(), "Position closed") {
+		t.Error("closed position not reported")
+	}
+	if got := model.(Model).Positions.Selected; got != 0 {
+		t.Errorf("selection = %d after the list shrank, want 0", got)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(Model).CurrentScreen != ScreenDashboard {
+		t.Errorf("screen = %v after Esc, want dashboard", model.(Model).CurrentScreen)
+	}
+}
+
+func TestLook_SavedAfterThemeChangeAndRestored(t *testing.T) {
+	t.Setenv("UI_MODE", "")
+	t.Cleanup(func() { SetTheme(Themes[0].Name) })
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("tui:\n  ui_mode: 2\nrpc:\n  shyft_url: http://127.0.0.1:0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(cfg)
+	if m.UIMode != 2 {
+		t.Fatalf("UI mode = %d, want 2 from tui.ui_mode", m.UIMode)
+	}
+	var model tea.Model = m
+	var cmd tea.Cmd
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")}) // next theme
+	if cmd == nil {
+		t.Fatal("theme change scheduled no save")
+	}
+	want := GetTheme().Name
+
+	// A superseded save is dropped; the latest one writes
+	model, _ = model.Update(saveLookMsg{seq: model.(Model).lookSeq - 1})
+	if cfg.Get().TUI.Theme != "" {
+		t.Fatal("stale save wrote the theme")
+	}
+	if _, cmd = model.Update(saveLookMsg{seq: model.(Model).lookSeq}); cmd == nil {
+		t.Fatal("latest save returned no write command")
+	}
+	if cfg.Get().TUI.Theme != "" {
+		t.Fatal("theme written on the UI goroutine, want it in the returned command")
+	}
+	model.Update(cmd())
+
+	SetTheme(Themes[0].Name)
+	reloaded, err := config.NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Get().TUI; got.Theme != want || got.UIMode != 2 {
+		t.Fatalf("saved look = %q / mode %d, want %q / 2", got.Theme, got.UIMode, want)
+	}
+	if NewModel(reloaded); GetTheme().Name != want {
+		t.Errorf("theme after restart = %q, want %q", GetTheme().Name, want)
+	}
+}
+
+func TestLook_UIModeSavedAfterToggleAndRestored(t *testing.T) {
+	t.Setenv("UI_MODE", "")
+	t.Cleanup(func() { SetTheme(Themes[0].Name) })
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("tui:\n  ui_mode: 2\nrpc:\n  shyft_url: http://127.0.0.1:0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var model tea.Model = NewModel(cfg)
+	var cmd tea.Cmd
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	if cmd == nil {
+		t.Fatal("mode change scheduled no save")
+	}
+	if got := model.(Model).UIMode; got != 3 {
+		t.Fatalf("UI mode = %d after M, want 3", got)
+	}
+	if _, cmd = model.Update(saveLookMsg{seq: model.(Model).lookSeq}); cmd == nil {
+		t.Fatal("save returned no write command")
+	}
+	model.Update(cmd())
+
+	reloaded, err := config.NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Get().TUI.UIMode; got != 3 {
+		t.Fatalf("saved UI mode = %d, want 3", got)
+	}
+	if m := NewModel(reloaded); m.UIMode != 3 {
+		t.Errorf("UI mode after restart = %d, want 3", m.UIMode)
+	}
+}
+
+func TestLook_SaveLeavesEnvUIModeUnsaved(t *testing.T) {
+	t.Setenv("UI_MODE", "1")
+	t.Cleanup(func() { SetTheme(Themes[0].Name) })
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("rpc:\n  shyft_url: http://127.0.0.1:0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(cfg)
+	CycleTheme()
+	if msg := m.saveLook()(); msg.(lookSavedMsg).err != nil {
+		t.Fatalf("saveLook: %v", msg.(lookSavedMsg).err)
+	}
+	reloaded, err := config.NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Get().TUI; got.UIMode != 0 || got.Theme != GetTheme().Name {
+		t.Errorf("saved look = %q / mode %d, want %q / 0 (UI_MODE not persisted)", got.Theme, got.UIMode, GetTheme().Name)
+	}
+}
+
+func TestHeader_ShowsWebSocketState(t *testing.T) {
+	var model tea.Model = NewModel(nil)
+	if got := model.(Model).Header.Render(200); strings.Contains(got, "WS ") {
+		t.Errorf("header shows a WS state before any status: %q", got)
+	}
+
+	model, _ = model.Update(WSStatusMsg{Connected: true})
+	if got := model.(Model).Header.Render(200); !strings.Contains(got, "WS ●") {
+		t.Errorf("connected header = %q, want WS ●", got)
+	}
+
+	model, _ = model.Update(WSStatusMsg{Connected: false, Err: errors.New("read: connection reset")})
+	if got := model.(Model).Header.Render(200); !strings.Contains(got, "WS ✗") || !strings.Contains(got, "connection reset") {
+		t.Errorf("disconnected header = %q, want WS ✗ and the reason", got)
+	}
+}
+
+func TestHealthDashboard_RendersDegradedInRed(t *testing.T) {
+	// Tests have no terminal; force colours so the indicators are observable
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+	red := func(s string) string { return lipgloss.NewStyle().Foreground(ColorLoss).Render(s) }
+	green := func(s string) string { return lipgloss.NewStyle().Foreground(ColorProfit).Render(s) }
+
+	var model tea.Model = NewModel(nil)
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	model, _ = model.Update(HealthMsg{Report: health.Report{
+		Components: []health.Status{
+			{Name: "RPC", Healthy: true, LatencyMs: 840},
+			{Name: "Jupiter", Healthy: false, LatencyMs: 5000, Error: "context deadline exceeded"},
+			{Name: "Database", Healthy: true, LatencyMs: 1},
+			{Name: "WebSocket", Healthy: false, Error: health.ErrWSDisconnected.Error()},
+		},
+		CheckedAt: time.Now(),
+	}})
+	primary := blockchain.EndpointStat{Host: "rpc.primary.test", Failures: 5, TotalFailures: 9, CircuitOpen: true, P50Ms: -1}
+	model, _ = model.Update(RPCEndpointMsg{Best: primary, Endpoints: []blockchain.EndpointStat{primary}})
+	model, _ = model.Update(BlockhashMsg{Stats: blockchain.BlockhashStats{Hits: 3, Misses: 7, HitRate: 30}})
+
+	got := model.(Model).renderFullHealth()
+	for _, want := range []string{"RPC", "Jupiter", "context deadline exceeded", "websocket not connected", "840ms - circuit open", "rpc.primary.test", "fails 5 (9 total)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("health screen is missing %q:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, red("✗")); n != 4 {
+		t.Errorf("red ✗ indicators = %d, want 4 (RPC breaker, Jupiter, WebSocket, open endpoint):\n%s", n, got)
+	}
+	if n := strings.Count(got, green("✓")); n != 1 {
+		t.Errorf("green ✓ indicators = %d, want 1 (Database):\n%s", n, got)
+	}
+	if !strings.Contains(got, red("30.0%")) {
+		t.Errorf("a 30%% blockhash hit rate is not rendered red:\n%s", got)
+	}
+}
+
+func TestFullMetrics_NoBlockhashReadsIsNotARate(t *testing.T) {
+	var model tea.Model = NewModel(nil)
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	model, _ = model.Update(BlockhashMsg{Stats: blockchain.BlockhashStats{Fetches: 2}})
+	got := model.(Model).renderFullMetrics()
+	if !strings.Contains(got, "no reads yet") || strings.Contains(got, "0.0%") {
+		t.Errorf("metrics before any blockhash read show a hit rate:\n%s", got)
+	}
+}
+
+func TestBlockhashSummary_FormatsHitRateAndAge(t *testing.T) {
+	st := blockchain.BlockhashStats{Hits: 95, Misses: 5, HitRate: 95, AgeMs: 420}
+	if got, want := blockhashSummary(st), "hit 95.0% (95 hits, 5 misses) | age 420ms"; got != want {
+		t.Errorf("blockhashSummary = %q, want %q", got, want)
+	}
+
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+	for _, tc := range []struct {
+		hits, misses int64
+		rate         float64
+		color        lipgloss.Color
+	}{
+		{95, 5, 95, ColorProfit},
+		{9, 1, LowBlockhashHitRate, ColorProfit},
+		{89, 11, 89, ColorLoss},
+	} {
+		st := blockchain.BlockhashStats{Hits: tc.hits, Misses: tc.misses, HitRate: tc.rate}
+		want := lipgloss.NewStyle().Foreground(tc.color).Render(fmt.Sprintf("%.1f%%", tc.rate))
+		if got := blockhashSummary(st); !strings.Contains(got, want) {
+			t.Errorf("%d hits / %d misses: %q, want the rate rendered %s", tc.hits, tc.misses, got, tc.color)
+		}
+	}
+}
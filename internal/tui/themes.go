@@ -1,6 +1,10 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Theme defines a color scheme for the TUI
 type Theme struct {
@@ -63,6 +67,18 @@ func GetTheme() Theme {
 	return Themes[CurrentThemeIndex]
 }
 
+// SetTheme applies the theme called name (case-insensitive); false if there is none
+func SetTheme(name string) bool {
+	for i, t := range Themes {
+		if strings.EqualFold(t.Name, name) {
+			CurrentThemeIndex = i
+			ApplyTheme(t)
+			return true
+		}
+	}
+	return false
+}
+
 // CycleTheme switches to the next theme
 func CycleTheme() {
 	CurrentThemeIndex = (CurrentThemeIndex + 1) % len(Themes)
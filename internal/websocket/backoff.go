@@ -0,0 +1,40 @@
+package websocket
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Reconnect backoff: the configured reconnect delay doubles with every
+// attempt up to MaxReconnectDelay, with +/-ReconnectJitter so many clients
+// dropped by the same outage don't retry in lockstep. A connection that
+// stays up for StableConnectionTime starts the next outage from the base
+// delay again.
+const (
+	MaxReconnectDelay    = 30 * time.Second
+	ReconnectJitter      = 0.2
+	StableConnectionTime = 30 * time.Second
+)
+
+// backoffDelay returns the delay before reconnect attempt number attempts
+// (0 = first), without jitter
+func backoffDelay(base time.Duration, attempts int) time.Duration {
+	if base <= 0 {
+		base = time.Second
+	}
+	d := base
+	for i := 0; i < attempts && d < MaxReconnectDelay; i++ {
+		d *= 2
+	}
+	return min(d, MaxReconnectDelay)
+}
+
+// withJitter spreads d by up to +/-ReconnectJitter; r is uniform in [0, 1)
+func withJitter(d time.Duration, r float64) time.Duration {
+	return time.Duration(float64(d) * (1 + ReconnectJitter*(2*r-1)))
+}
+
+// nextReconnectDelay is backoffDelay with random jitter
+func nextReconnectDelay(base time.Duration, attempts int) time.Duration {
+	return withJitter(backoffDelay(base, attempts), rand.Float64())
+}
@@ -0,0 +1,40 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	base := 500 * time.Millisecond
+	want := []time.Duration{
+		500 * time.Millisecond, time.Second, 2 * time.Second, 4 * time.Second,
+		8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second,
+	}
+	for attempts, w := range want {
+		if got := backoffDelay(base, attempts); got != w {
+			t.Errorf("attempt %d: delay = %v, want %v", attempts, got, w)
+		}
+	}
+	if got := backoffDelay(base, 1000); got != MaxReconnectDelay {
+		t.Errorf("after many failures: delay = %v, want cap %v", got, MaxReconnectDelay)
+	}
+	if got := backoffDelay(0, 0); got != time.Second {
+		t.Errorf("unset base: delay = %v, want 1s", got)
+	}
+}
+
+func TestReconnectJitter(t *testing.T) {
+	if got := withJitter(10*time.Second, 0); got != 8*time.Second {
+		t.Errorf("low jitter = %v, want 8s", got)
+	}
+	if got := withJitter(10*time.Second, 0.5); got != 10*time.Second {
+		t.Errorf("mid jitter = %v, want 10s", got)
+	}
+	for i := 0; i < 1000; i++ {
+		d := nextReconnectDelay(time.Second, 10)
+		if d < 24*time.Second || d > 36*time.Second {
+			t.Fatalf("jittered capped delay %v outside 30s +/-20%%", d)
+		}
+	}
+}
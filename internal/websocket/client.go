@@ -42,18 +42,13 @@ type WSParams struct {
 // SubscriptionHandler is called when a subscription receives data
 type SubscriptionHandler func(data json.RawMessage)
 
-// SubscriptionInfo stores subscription details for restoration after reconnect
-type SubscriptionInfo struct {
-	Method  string
-	Params  []interface{}
-	Handler SubscriptionHandler
-}
-
 // Client manages WebSocket connection to Solana RPC
 type Client struct {
 	url    string
 	conn   *websocket.Conn
 	connMu sync.RWMutex
+	// gorilla allows one concurrent writer per connection
+	writeMu sync.Mutex
 
 	// Request ID counter
 	requestID atomic.Uint64
@@ -62,22 +57,26 @@ type Client struct {
 	pending   map[uint64]chan WSResponse
 	pendingMu sync.RWMutex
 
-	// Active subscriptions
+	// Active subscriptions on the current connection. IDs don't survive a
+	// reconnect: owners (WalletMonitor, PriceFeed) re-subscribe from onConnect.
 	subscriptions map[uint64]SubscriptionHandler
 	subMu         sync.RWMutex
 
-	// FIX: Store subscription info for restoration after reconnect
-	subInfo   map[uint64]SubscriptionInfo
-	subInfoMu sync.RWMutex
-
 	// Reconnect settings
 	reconnectDelay time.Duration
 	pingInterval   time.Duration
 
+	// Reconnect backoff (see backoff.go)
+	reconnectAttempts atomic.Int32 // attempts since the last stable connection
+	connectedAt       atomic.Int64 // unix nanos of the last successful connect
+	backoff           atomic.Int64 // delay currently being waited, 0 when not reconnecting
+
 	// Control
 	ctx       context.Context
 	cancel    context.CancelFunc
 	connected atomic.Bool
+	errMu     sync.Mutex
+	lastErr   error // why the connection is down; nil while connected
 
 	// FIX: Goroutine control to prevent leaks
 	loopCtx      context.Context
@@ -96,7 +95,6 @@ func NewClient(url string, reconnectDelay, pingInterval time.Duration) *Client {
 		url:            url,
 		pending:        make(map[uint64]chan WSResponse),
 		subscriptions:  make(map[uint64]SubscriptionHandler),
-		subInfo:        make(map[uint64]SubscriptionInfo), // FIX: Initialize subscription info map
 		reconnectDelay: reconnectDelay,
 		pingInterval:   pingInterval,
 		ctx:            ctx,
@@ -132,11 +130,15 @@ func (c *Client) Connect() error {
 
 	conn, _, err := dialer.DialContext(c.ctx, c.url, nil)
 	if err != nil {
-		return fmt.Errorf("dial: %w", err)
+		err = fmt.Errorf("dial: %w", err)
+		c.setLastError(err)
+		return err
 	}
 
 	c.conn = conn
+	c.setLastError(nil)
 	c.connected.Store(true)
+	c.connectedAt.Store(time.Now().UnixNano())
 
 	// FIX: Create new context for this connection's goroutines
 	c.loopCtx, c.loopCancel = context.WithCancel(c.ctx)
@@ -147,6 +149,11 @@ func (c *Client) Connect() error {
 	}
 	log.Info().Str("url", urlDisplay).Msg("WebSocket connected")
 
+	// Subscriptions of a previous connection are gone server-side
+	c.subMu.Lock()
+	c.subscriptions = make(map[uint64]SubscriptionHandler)
+	c.subMu.Unlock()
+
 	// Start read loop with new context
 	go c.readLoop(c.loopCtx)
 
@@ -184,6 +191,25 @@ func (c *Client) IsConnected() bool {
 	return c.connected.Load()
 }
 
+// LastError returns why the connection was last lost or failed to connect,
+// nil while connected and before the first attempt
+func (c *Client) LastError() error {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	return c.lastErr
+}
+
+func (c *Client) setLastError(err error) {
+	c.errMu.Lock()
+	c.lastErr = err
+	c.errMu.Unlock()
+}
+
+// CurrentBackoff returns the delay before the next reconnect attempt, 0 when not reconnecting
+func (c *Client) CurrentBackoff() time.Duration {
+	return time.Duration(c.backoff.Load())
+}
+
 // Subscribe creates a new subscription
 func (c *Client) Subscribe(method string, params []interface{}, handler SubscriptionHandler) (uint64, error) {
 	resp, err := c.call(method, params)
@@ -200,15 +226,6 @@ func (c *Client) Subscribe(method string, params []interface{}, handler Subscrip
 	c.subscriptions[subID] = handler
 	c.subMu.Unlock()
 
-	// FIX: Store subscription info for restoration after reconnect
-	c.subInfoMu.Lock()
-	c.subInfo[subID] = SubscriptionInfo{
-		Method:  method,
-		Params:  params,
-		Handler: handler,
-	}
-	c.subInfoMu.Unlock()
-
 	log.Debug().Uint64("subID", subID).Str("method", method).Msg("subscription created")
 
 	return subID, nil
@@ -220,11 +237,6 @@ func (c *Client) Unsubscribe(method string, subID uint64) error {
 	delete(c.subscriptions, subID)
 	c.subMu.Unlock()
 
-	// FIX: Remove subscription info
-	c.subInfoMu.Lock()
-	delete(c.subInfo, subID)
-	c.subInfoMu.Unlock()
-
 	_, err := c.call(method, []interface{}{subID})
 	return err
 }
@@ -243,10 +255,15 @@ func (c *Client) AccountSubscribe(pubkey string, handler SubscriptionHandler) (u
 
 // SignatureSubscribe subscribes to transaction signature status
 func (c *Client) SignatureSubscribe(signature string, handler SubscriptionHandler) (uint64, error) {
+	return c.SignatureSubscribeWithCommitment(signature, "confirmed", handler)
+}
+
+// SignatureSubscribeWithCommitment subscribes to a signature at a specific commitment level
+func (c *Client) SignatureSubscribeWithCommitment(signature, commitment string, handler SubscriptionHandler) (uint64, error) {
 	params := []interface{}{
 		signature,
 		map[string]interface{}{
-			"commitment": "confirmed",
+			"commitment": commitment,
 		},
 	}
 	return c.Subscribe("signatureSubscribe", params, handler)
@@ -284,7 +301,10 @@ func (c *Client) call(method string, params []interface{}) (*WSResponse, error)
 		return nil, fmt.Errorf("not connected")
 	}
 
-	if err := conn.WriteJSON(req); err != nil {
+	c.writeMu.Lock()
+	err := conn.WriteJSON(req)
+	c.writeMu.Unlock()
+	if err != nil {
 		return nil, fmt.Errorf("write: %w", err)
 	}
 
@@ -377,7 +397,10 @@ func (c *Client) pingLoop(ctx context.Context) {
 			c.connMu.RUnlock()
 
 			if conn != nil {
-				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.writeMu.Lock()
+				err := conn.WriteMessage(websocket.PingMessage, nil)
+				c.writeMu.Unlock()
+				if err != nil {
 					log.Warn().Err(err).Msg("ping failed")
 				}
 			}
@@ -387,6 +410,7 @@ func (c *Client) pingLoop(ctx context.Context) {
 
 // handleDisconnect handles connection loss
 func (c *Client) handleDisconnect(err error) {
+	c.setLastError(err)
 	c.connected.Store(false)
 
 	if c.onDisconnect != nil {
@@ -398,73 +422,37 @@ func (c *Client) handleDisconnect(err error) {
 		return // Already reconnecting
 	}
 
+	// A connection that held up long enough ends the previous outage
+	if up := time.Since(time.Unix(0, c.connectedAt.Load())); up >= StableConnectionTime {
+		c.reconnectAttempts.Store(0)
+	}
+
 	// Auto-reconnect
 	go c.reconnectLoop()
 }
 
-// reconnectLoop attempts to reconnect
+// reconnectLoop attempts to reconnect with exponential backoff
 func (c *Client) reconnectLoop() {
 	defer c.reconnecting.Store(false)
+	defer c.backoff.Store(0)
 
 	for {
+		attempts := int(c.reconnectAttempts.Add(1)) - 1
+		delay := nextReconnectDelay(c.reconnectDelay, attempts)
+		c.backoff.Store(int64(delay))
+
 		select {
 		case <-c.ctx.Done():
 			return
-		case <-time.After(c.reconnectDelay):
-			log.Info().Msg("attempting WebSocket reconnect...")
+		case <-time.After(delay):
+			log.Info().Int("attempt", attempts+1).Dur("backoff", delay).Msg("attempting WebSocket reconnect...")
 			if err := c.Connect(); err != nil {
 				log.Error().Err(err).Msg("reconnect failed")
 				continue
 			}
 
-			// FIX: Re-subscribe existing subscriptions using stored info
-			c.resubscribe()
+			// Subscriptions are restored by their owners from onConnect
 			return
 		}
 	}
 }
-
-// resubscribe re-creates subscriptions after reconnect
-// FIX: Actually restore subscriptions instead of just logging
-func (c *Client) resubscribe() {
-	c.subInfoMu.RLock()
-	defer c.subInfoMu.RUnlock()
-
-	if len(c.subInfo) == 0 {
-		log.Debug().Msg("no subscriptions to restore")
-		return
-	}
-
-	log.Info().Int("count", len(c.subInfo)).Msg("restoring subscriptions after reconnect")
-
-	// Collect subscription info (we'll re-subscribe which will update the maps)
-	infos := make([]SubscriptionInfo, 0, len(c.subInfo))
-	for _, info := range c.subInfo {
-		infos = append(infos, info)
-	}
-
-	// Clear old subscription IDs (they're invalid after reconnect)
-	c.subMu.Lock()
-	c.subscriptions = make(map[uint64]SubscriptionHandler)
-	c.subMu.Unlock()
-
-	// Must release read lock before calling Subscribe (which needs write lock)
-	c.subInfoMu.RUnlock()
-	c.subInfoMu.Lock()
-	c.subInfo = make(map[uint64]SubscriptionInfo)
-	c.subInfoMu.Unlock()
-	c.subInfoMu.RLock() // Re-acquire for defer
-
-	// Re-subscribe each
-	restored := 0
-	for _, info := range infos {
-		_, err := c.Subscribe(info.Method, info.Params, info.Handler)
-		if err != nil {
-			log.Warn().Err(err).Str("method", info.Method).Msg("failed to restore subscription")
-		} else {
-			restored++
-		}
-	}
-
-	log.Info().Int("restored", restored).Int("total", len(infos)).Msg("subscriptions restored")
-}
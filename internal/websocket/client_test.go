@@ -0,0 +1,45 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClient_ConnectionState(t *testing.T) {
+	srv := newFakeRPCServer(t)
+	client := NewClient(srv.url(), 200*time.Millisecond, time.Minute)
+	defer client.Close()
+
+	// The 200ms reconnect delay leaves the down state observable
+	type state struct {
+		connected bool
+		err       error
+	}
+	down := make(chan state, 1)
+	client.SetCallbacks(nil, func(error) { down <- state{client.IsConnected(), client.LastError()} })
+
+	if client.IsConnected() || client.LastError() != nil {
+		t.Fatalf("before Connect: connected=%v err=%v, want false and nil", client.IsConnected(), client.LastError())
+	}
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if !client.IsConnected() || client.LastError() != nil {
+		t.Fatalf("after Connect: connected=%v err=%v, want true and nil", client.IsConnected(), client.LastError())
+	}
+
+	srv.drop()
+	select {
+	case s := <-down:
+		if s.connected || s.err == nil {
+			t.Errorf("after a drop: connected=%v err=%v, want false and the read error", s.connected, s.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no disconnect after the server dropped the connection")
+	}
+
+	waitUntil(t, "reconnect", client.IsConnected)
+	if err := client.LastError(); err != nil {
+		t.Errorf("LastError = %v after reconnecting, want nil", err)
+	}
+}
@@ -0,0 +1,174 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"solana-pump-bot/internal/blockchain"
+)
+
+// ErrPoolNotFound is returned when a token has no Raydium SOL pool
+var ErrPoolNotFound = errors.New("no raydium SOL pool for token")
+
+// PoolMissTTL is how long a token without a pool is remembered. Pump.fun
+// tokens trade on their bonding curve until they migrate, so a miss is
+// retried after a while rather than cached for good.
+const PoolMissTTL = time.Minute
+
+// PoolResolver finds the AMM pool account to track a token's price with:
+// the Raydium AMM v4 SOL pair holding the most SOL. Only Raydium v4 is
+// searched since that is the layout the price feed decodes.
+type PoolResolver struct {
+	rpc  *blockchain.RPCClient
+	hint func(mint string) (string, bool) // known pool, e.g. from symbol resolution (optional)
+
+	mu     sync.Mutex
+	pools  map[string]string    // mint -> pool address
+	misses map[string]time.Time // mint -> when no pool was found
+}
+
+// NewPoolResolver creates a pool resolver using rpc for getProgramAccounts
+func NewPoolResolver(rpc *blockchain.RPCClient) *PoolResolver {
+	return &PoolResolver{
+		rpc:    rpc,
+		pools:  make(map[string]string),
+		misses: make(map[string]time.Time),
+	}
+}
+
+// SetHint supplies pools already known for a mint (only Raydium AMM v4
+// ones, the layout the price feed decodes); a hinted pool whose reserves
+// check out skips getProgramAccounts, any other falls back to the scan
+func (r *PoolResolver) SetHint(hint func(mint string) (string, bool)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hint = hint
+}
+
+// Resolve returns the pool address for mint, from cache when known
+func (r *PoolResolver) Resolve(ctx context.Context, mint string) (string, error) {
+	r.mu.Lock()
+	if pool, ok := r.pools[mint]; ok {
+		r.mu.Unlock()
+		return pool, nil
+	}
+	hint := r.hint
+	if at, ok := r.misses[mint]; ok && time.Since(at) < PoolMissTTL {
+		r.mu.Unlock()
+		return "", ErrPoolNotFound
+	}
+	r.mu.Unlock()
+
+	if hint != nil {
+		if pool, ok := hint(mint); ok {
+			_, err := r.solReserve(ctx, mint, pool)
+			if err == nil {
+				r.mu.Lock()
+				r.pools[mint] = pool
+				r.mu.Unlock()
+				return pool, nil
+			}
+			log.Debug().Err(err).Str("pool", truncateStr(pool, 8)).Msg("hinted pool rejected, scanning")
+		}
+	}
+
+	pool, err := r.lookup(ctx, mint)
+	if err != nil && !errors.Is(err, ErrPoolNotFound) {
+		return "", err // RPC trouble: don't cache
+	}
+
+	r.mu.Lock()
+	if err != nil {
+		r.misses[mint] = time.Now()
+	} else {
+		r.pools[mint] = pool
+		delete(r.misses, mint)
+	}
+	r.mu.Unlock()
+	return pool, err
+}
+
+// lookup queries both pair orientations and picks the pool with the largest SOL reserve
+func (r *PoolResolver) lookup(ctx context.Context, mint string) (string, error) {
+	best, bestSol := "", uint64(0)
+	for _, filters := range raydiumPoolFilters(mint) {
+		accounts, err := r.rpc.GetProgramAccounts(ctx, RaydiumAMMProgramID, RaydiumAMMV4Size, filters)
+		if err != nil {
+			return "", err
+		}
+		for _, acc := range accounts {
+			amm, err := DecodeRaydiumAMMV4(acc.Data)
+			if err != nil {
+				continue
+			}
+			solVault := amm.QuoteVault
+			if amm.BaseMint == WSOLMint {
+				solVault = amm.BaseVault
+			}
+			lamports, _, err := r.rpc.GetTokenAccountBalance(ctx, solVault)
+			if err != nil || lamports == 0 {
+				log.Debug().Err(err).Str("pool", truncateStr(acc.Pubkey, 8)).Msg("pool vault balance unavailable")
+				continue
+			}
+			if best == "" || lamports > bestSol {
+				best, bestSol = acc.Pubkey, lamports
+			}
+		}
+	}
+	if best == "" {
+		return "", ErrPoolNotFound
+	}
+
+	log.Info().
+		Str("mint", truncateStr(mint, 8)).
+		Str("pool", truncateStr(best, 8)).
+		Float64("poolSol", float64(bestSol)/1e9).
+		Msg("🔎 pool resolved")
+	return best, nil
+}
+
+// solReserve reads pool and returns its SOL reserve, refusing anything but a
+// Raydium AMM v4 pair of mint and WSOL with SOL in it
+func (r *PoolResolver) solReserve(ctx context.Context, mint, pool string) (uint64, error) {
+	accounts, err := r.rpc.GetAccountsData(ctx, []string{pool})
+	if err != nil {
+		return 0, err
+	}
+	if len(accounts) != 1 || accounts[0] == nil {
+		return 0, errors.New("pool account not found")
+	}
+	amm, err := DecodeRaydiumAMMV4(accounts[0])
+	if err != nil {
+		return 0, err
+	}
+	solVault := amm.QuoteVault
+	switch {
+	case amm.BaseMint == mint && amm.QuoteMint == WSOLMint:
+	case amm.BaseMint == WSOLMint && amm.QuoteMint == mint:
+		solVault = amm.BaseVault
+	default:
+		return 0, errors.New("pool is not a mint/WSOL pair")
+	}
+	lamports, _, err := r.rpc.GetTokenAccountBalance(ctx, solVault)
+	if err != nil {
+		return 0, err
+	}
+	if lamports == 0 {
+		return 0, errors.New("pool has no SOL reserve")
+	}
+	return lamports, nil
+}
+
+// raydiumPoolFilters returns the getProgramAccounts memcmp filters for the
+// two orientations of a mint/SOL pair: mint as base with WSOL as quote,
+// and the reverse
+func raydiumPoolFilters(mint string) [][]blockchain.MemcmpFilter {
+	return [][]blockchain.MemcmpFilter{
+		{{Offset: ammBaseMintOffset, Bytes: mint}, {Offset: ammQuoteMintOffset, Bytes: WSOLMint}},
+		{{Offset: ammBaseMintOffset, Bytes: WSOLMint}, {Offset: ammQuoteMintOffset, Bytes: mint}},
+	}
+}
@@ -0,0 +1,177 @@
+package websocket
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mr-tron/base58"
+
+	"solana-pump-bot/internal/blockchain"
+)
+
+func TestRaydiumPoolFilters(t *testing.T) {
+	filters := raydiumPoolFilters(testTokenMint)
+	if len(filters) != 2 {
+		t.Fatalf("got %d filter sets, want 2 (both pair orientations)", len(filters))
+	}
+	want := [][]blockchain.MemcmpFilter{
+		{{Offset: 400, Bytes: testTokenMint}, {Offset: 432, Bytes: WSOLMint}},
+		{{Offset: 400, Bytes: WSOLMint}, {Offset: 432, Bytes: testTokenMint}},
+	}
+	for i := range want {
+		for j := range want[i] {
+			if filters[i][j] != want[i][j] {
+				t.Errorf("filters[%d][%d] = %+v, want %+v", i, j, filters[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestPoolResolver_PicksDeepestPoolAndCaches(t *testing.T) {
+	shallow := ammAccount(t)
+	deep := ammAccount(t)
+	deepVault := "9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin"
+	copy(deep[ammQuoteVaultOffset:], mustBase58(t, deepVault))
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var result interface{}
+		switch req.Method {
+		case "getProgramAccounts":
+			var opts struct {
+				Filters []struct {
+					Memcmp *struct {
+						Offset int    `json:"offset"`
+						Bytes  string `json:"bytes"`
+					} `json:"memcmp"`
+				} `json:"filters"`
+			}
+			json.Unmarshal(req.Params[1], &opts)
+			accounts := []interface{}{}
+			// Only the mint-as-base orientation has pools
+			if m := opts.Filters[1].Memcmp; m != nil && m.Offset == ammBaseMintOffset && m.Bytes == testTokenMint {
+				for pubkey, data := range map[string][]byte{"PoolShallow": shallow, "PoolDeep": deep} {
+					accounts = append(accounts, map[string]interface{}{
+						"pubkey":  pubkey,
+						"account": map[string]interface{}{"data": []string{base64.StdEncoding.EncodeToString(data), "base64"}},
+					})
+				}
+			}
+			result = accounts
+		case "getTokenAccountBalance":
+			var vault string
+			json.Unmarshal(req.Params[0], &vault)
+			amount := "5000000000"
+			if vault == deepVault {
+				amount = "80000000000"
+			}
+			result = map[string]interface{}{"value": map[string]interface{}{"amount": amount, "decimals": 9}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	defer srv.Close()
+
+	r := NewPoolResolver(blockchain.NewRPCClient(srv.URL, srv.URL, ""))
+	pool, err := r.Resolve(context.Background(), testTokenMint)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if pool != "PoolDeep" {
+		t.Errorf("pool = %s, want PoolDeep (80 SOL vs 5 SOL)", pool)
+	}
+
+	before := calls.Load()
+	if pool, err := r.Resolve(context.Background(), testTokenMint); err != nil || pool != "PoolDeep" {
+		t.Errorf("cached Resolve = %s, %v", pool, err)
+	}
+	if calls.Load() != before {
+		t.Error("cached Resolve hit the RPC")
+	}
+
+	// A token without a pool is remembered as a miss
+	if _, err := r.Resolve(context.Background(), WSOLMint); err != ErrPoolNotFound {
+		t.Fatalf("Resolve(no pool) err = %v, want ErrPoolNotFound", err)
+	}
+	before = calls.Load()
+	r.Resolve(context.Background(), WSOLMint)
+	if calls.Load() != before {
+		t.Error("recent miss hit the RPC again")
+	}
+}
+
+func TestPoolResolver_HintedPoolNeedsSOLReserves(t *testing.T) {
+	good := ammAccount(t)
+	usdcPair := ammAccount(t)
+	copy(usdcPair[ammQuoteMintOffset:], mustBase58(t, "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"))
+
+	var scans atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var result interface{}
+		switch req.Method {
+		case "getMultipleAccounts":
+			var pubkeys []string
+			json.Unmarshal(req.Params[0], &pubkeys)
+			data := map[string][]byte{"PoolGood": good, "PoolUSDC": usdcPair}[pubkeys[0]]
+			result = map[string]interface{}{"value": []interface{}{
+				map[string]interface{}{"data": []string{base64.StdEncoding.EncodeToString(data), "base64"}},
+			}}
+		case "getProgramAccounts":
+			scans.Add(1)
+			result = []interface{}{}
+		case "getTokenAccountBalance":
+			result = map[string]interface{}{"value": map[string]interface{}{"amount": "5000000000", "decimals": 9}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	defer srv.Close()
+
+	r := NewPoolResolver(blockchain.NewRPCClient(srv.URL, srv.URL, ""))
+	hint := "PoolUSDC"
+	r.SetHint(func(string) (string, bool) { return hint, true })
+
+	// Not a mint/WSOL pair: ignored (and not cached), the scan runs instead
+	if pool, err := r.Resolve(context.Background(), testTokenMint); err != ErrPoolNotFound {
+		t.Fatalf("Resolve with a USDC pool hint = %q, %v; want ErrPoolNotFound from the scan", pool, err)
+	}
+	if scans.Load() == 0 {
+		t.Error("rejected hint skipped the getProgramAccounts scan")
+	}
+
+	r = NewPoolResolver(blockchain.NewRPCClient(srv.URL, srv.URL, ""))
+	hint = "PoolGood"
+	r.SetHint(func(string) (string, bool) { return hint, true })
+	before := scans.Load()
+	if pool, err := r.Resolve(context.Background(), testTokenMint); err != nil || pool != "PoolGood" {
+		t.Fatalf("Resolve with a SOL pool hint = %q, %v; want PoolGood", pool, err)
+	}
+	if scans.Load() != before {
+		t.Error("valid hint still scanned")
+	}
+}
+
+func mustBase58(t *testing.T, key string) []byte {
+	t.Helper()
+	raw, err := base58.Decode(key)
+	if err != nil || len(raw) != 32 {
+		t.Fatalf("bad key %s: %v", key, err)
+	}
+	return raw
+}
@@ -18,6 +18,7 @@ type PriceUpdate struct {
 	Mint         string
 	PriceSOL     float64  // Price in SOL per token
 	TokenBalance uint64   // Your token balance
+	Decimals     int      // Token decimals (TokenBalance is in base units)
 	PoolReserves PoolReserves
 	Slot         uint64
 }
@@ -45,6 +46,12 @@ type PriceFeed struct {
 	
 	// Pool addresses cache: mint -> pool address
 	poolAddrs    map[string]string
+	// Token accounts: mint -> token account address
+	tokenAddrs   map[string]string
+	
+	// Decoded pools and their vault balances: mint -> pool
+	pools        map[string]*trackedPool
+	poolsMu      sync.Mutex
 	
 	// Price callbacks
 	handlers     []PriceHandler
@@ -65,6 +72,8 @@ func NewPriceFeed(client *Client, walletAddr string) *PriceFeed {
 		poolSubs:   make(map[string]uint64),
 		tokenSubs:  make(map[string]uint64),
 		poolAddrs:  make(map[string]string),
+		tokenAddrs: make(map[string]string),
+		pools:      make(map[string]*trackedPool),
 		prices:     make(map[string]float64),
 		walletAddr: walletAddr,
 	}
@@ -123,6 +132,7 @@ func (p *PriceFeed) TrackTokenAccount(mint string, tokenAccountAddr string) erro
 		return fmt.Errorf("subscribe to token account: %w", err)
 	}
 	p.tokenSubs[mint] = subID
+	p.tokenAddrs[mint] = tokenAccountAddr
 	
 	log.Debug().
 		Str("mint", truncateStr(mint, 8)).
@@ -150,13 +160,64 @@ func (p *PriceFeed) UntrackToken(mint string) error {
 	}
 	
 	delete(p.poolAddrs, mint)
+	delete(p.tokenAddrs, mint)
+	
+	// Unsubscribe pool vaults
+	p.poolsMu.Lock()
+	if pool, exists := p.pools[mint]; exists {
+		for _, subID := range pool.vaultSubs {
+			p.client.Unsubscribe("accountUnsubscribe", subID)
+		}
+		delete(p.pools, mint)
+	}
+	p.poolsMu.Unlock()
 	
 	return nil
 }
 
-// handlePoolUpdate processes AMM pool account changes
+// Resubscribe re-issues every pool and token account subscription on a new
+// connection (subscription IDs die with the old one). Vaults are
+// re-subscribed on each pool's first update, as when first tracked.
+func (p *PriceFeed) Resubscribe() {
+	p.subsMu.Lock()
+	pools := p.poolAddrs
+	tokens := p.tokenAddrs
+	p.poolSubs = make(map[string]uint64)
+	p.tokenSubs = make(map[string]uint64)
+	p.poolAddrs = make(map[string]string)
+	p.tokenAddrs = make(map[string]string)
+	p.subsMu.Unlock()
+	
+	p.poolsMu.Lock()
+	p.pools = make(map[string]*trackedPool)
+	p.poolsMu.Unlock()
+	
+	for mint, poolAddr := range pools {
+		if err := p.TrackToken(mint, poolAddr); err != nil {
+			log.Warn().Err(err).Str("mint", truncateStr(mint, 8)).Msg("failed to restore pool subscription")
+		}
+	}
+	for mint, addr := range tokens {
+		if err := p.TrackTokenAccount(mint, addr); err != nil {
+			log.Warn().Err(err).Str("mint", truncateStr(mint, 8)).Msg("failed to restore token account subscription")
+		}
+	}
+}
+
+// trackedPool is a decoded AMM pool with the latest balances of its vaults
+type trackedPool struct {
+	amm          *RaydiumAMMState
+	baseAmount   uint64
+	quoteAmount  uint64
+	haveBase     bool
+	haveQuote    bool
+	vaultSubs    []uint64
+}
+
+// handlePoolUpdate processes AMM pool account changes. The Raydium AMM v4
+// account names the vaults holding the reserves: the first update
+// subscribes to them, and every pool or vault change re-prices the pool.
 func (p *PriceFeed) handlePoolUpdate(mint string, data json.RawMessage) {
-	// Parse Raydium AMM pool data structure
 	var update struct {
 		Context struct {
 			Slot uint64 `json:"slot"`
@@ -171,29 +232,114 @@ func (p *PriceFeed) handlePoolUpdate(mint string, data json.RawMessage) {
 		log.Warn().Err(err).Msg("failed to parse pool update")
 		return
 	}
+	if len(update.Value.Data) == 0 {
+		log.Warn().Str("mint", truncateStr(mint, 8)).Msg("pool update without account data")
+		return
+	}
 	
-	// For Raydium pools, we need to decode the binary data
-	// Pool data layout contains base/quote reserves at specific offsets
-	// This is a simplified version - actual implementation needs proper decoding
+	amm, err := DecodeRaydiumAMMV4Base64(update.Value.Data[0])
+	if err != nil {
+		log.Warn().Err(err).Str("mint", truncateStr(mint, 8)).Msg("failed to decode pool account")
+		return
+	}
 	
-	// For now, emit a basic update to notify of pool activity
-	priceUpdate := PriceUpdate{
-		Mint: mint,
-		Slot: update.Context.Slot,
+	p.poolsMu.Lock()
+	pool, exists := p.pools[mint]
+	if !exists {
+		pool = &trackedPool{}
+		p.pools[mint] = pool
 	}
+	pool.amm = amm
+	p.poolsMu.Unlock()
 	
-	// Get cached price or calculate from reserves
-	p.pricesMu.RLock()
-	if price, ok := p.prices[mint]; ok {
-		priceUpdate.PriceSOL = price
+	if !exists {
+		p.subscribeVaults(mint, pool, amm)
 	}
-	p.pricesMu.RUnlock()
+	p.emitPoolPrice(mint, update.Context.Slot)
+}
+
+// subscribeVaults subscribes to a pool's base and quote vault token accounts
+func (p *PriceFeed) subscribeVaults(mint string, pool *trackedPool, amm *RaydiumAMMState) {
+	vaults := []struct {
+		addr string
+		base bool
+	}{{amm.BaseVault, true}, {amm.QuoteVault, false}}
 	
-	p.notifyHandlers(priceUpdate)
+	for _, v := range vaults {
+		isBase := v.base
+		subID, err := p.client.AccountSubscribe(v.addr, func(data json.RawMessage) {
+			p.handleVaultUpdate(mint, isBase, data)
+		})
+		if err != nil {
+			log.Warn().Err(err).Str("vault", truncateStr(v.addr, 8)).Msg("failed to subscribe to pool vault")
+			continue
+		}
+		p.poolsMu.Lock()
+		if p.pools[mint] == pool {
+			pool.vaultSubs = append(pool.vaultSubs, subID)
+		} else {
+			p.client.Unsubscribe("accountUnsubscribe", subID) // untracked meanwhile
+		}
+		p.poolsMu.Unlock()
+	}
 }
 
-// handleTokenAccountUpdate processes token account balance changes
-func (p *PriceFeed) handleTokenAccountUpdate(mint string, data json.RawMessage) {
+// handleVaultUpdate records a pool vault's token balance and re-prices the pool
+func (p *PriceFeed) handleVaultUpdate(mint string, isBase bool, data json.RawMessage) {
+	slot, amount, _, err := parseTokenAccount(data)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to parse pool vault update")
+		return
+	}
+	
+	p.poolsMu.Lock()
+	pool, exists := p.pools[mint]
+	if exists {
+		if isBase {
+			pool.baseAmount, pool.haveBase = amount, true
+		} else {
+			pool.quoteAmount, pool.haveQuote = amount, true
+		}
+	}
+	p.poolsMu.Unlock()
+	
+	if exists {
+		p.emitPoolPrice(mint, slot)
+	}
+}
+
+// emitPoolPrice prices the pool from its reserves once both vault balances
+// are known, caches the price and notifies handlers
+func (p *PriceFeed) emitPoolPrice(mint string, slot uint64) {
+	p.poolsMu.Lock()
+	pool, exists := p.pools[mint]
+	if !exists || !pool.haveBase || !pool.haveQuote {
+		p.poolsMu.Unlock()
+		return
+	}
+	reserves, ok := pool.amm.Reserves(mint, pool.baseAmount, pool.quoteAmount)
+	p.poolsMu.Unlock()
+	if !ok {
+		log.Debug().Str("mint", truncateStr(mint, 8)).Msg("pool is not a SOL pair of the token, no price")
+		return
+	}
+	
+	price := CalculatePriceFromReserves(reserves)
+	if price > 0 {
+		p.SetPrice(mint, price)
+	}
+	
+	p.notifyHandlers(PriceUpdate{
+		Mint:         mint,
+		PriceSOL:     price,
+		Decimals:     reserves.BaseDecimals,
+		PoolReserves: reserves,
+		Slot:         slot,
+	})
+}
+
+// parseTokenAccount extracts the balance from a jsonParsed token account notification
+func parseTokenAccount(data json.RawMessage) (slot, amount uint64, decimals int, err error) {
 	var update struct {
 		Context struct {
 			Slot uint64 `json:"slot"`
@@ -205,25 +351,36 @@ func (p *PriceFeed) handleTokenAccountUpdate(mint string, data json.RawMessage)
 						TokenAmount struct {
 							Amount   string  `json:"amount"`
 							Decimals int     `json:"decimals"`
-							UIAmount float64 `json:"uiAmount"`
 						} `json:"tokenAmount"`
 					} `json:"info"`
 				} `json:"parsed"`
 			} `json:"data"`
 		} `json:"value"`
 	}
-	
 	if err := json.Unmarshal(data, &update); err != nil {
+		return 0, 0, 0, err
+	}
+	tokenAmount := update.Value.Data.Parsed.Info.TokenAmount
+	amount, err = strconv.ParseUint(tokenAmount.Amount, 10, 64)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("token amount %q: %w", tokenAmount.Amount, err)
+	}
+	return update.Context.Slot, amount, tokenAmount.Decimals, nil
+}
+
+// handleTokenAccountUpdate processes token account balance changes
+func (p *PriceFeed) handleTokenAccountUpdate(mint string, data json.RawMessage) {
+	slot, balance, decimals, err := parseTokenAccount(data)
+	if err != nil {
 		log.Warn().Err(err).Msg("failed to parse token account update")
 		return
 	}
 	
-	balance, _ := strconv.ParseUint(update.Value.Data.Parsed.Info.TokenAmount.Amount, 10, 64)
-	
 	priceUpdate := PriceUpdate{
 		Mint:         mint,
 		TokenBalance: balance,
-		Slot:         update.Context.Slot,
+		Decimals:     decimals,
+		Slot:         slot,
 	}
 	
 	// Include cached price
@@ -0,0 +1,99 @@
+package websocket
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+const (
+	testTokenMint  = "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263"
+	testBaseVault  = "5eLRsN6qDQTQSBF8KdW4B8mVpeeAzHCCwaDptzMyszxH"
+	testQuoteVault = "ANLXYzCq6yd7gKxGHHBFHXo6rchTvDEvPvNDP9GLuLmN"
+)
+
+// ammAccount builds a pool account in the AMM v4 layout: 6-decimal token
+// as base, WSOL as quote
+func ammAccount(t *testing.T) []byte {
+	t.Helper()
+	data := make([]byte, RaydiumAMMV4Size)
+	binary.LittleEndian.PutUint64(data[ammBaseDecimalOffset:], 6)
+	binary.LittleEndian.PutUint64(data[ammQuoteDecimalOffset:], 9)
+	binary.LittleEndian.PutUint64(data[ammBaseNeedTakePnl:], 1_000_000)   // 1 token
+	binary.LittleEndian.PutUint64(data[ammQuoteNeedTakePnl:], 10_000_000) // 0.01 SOL
+	for off, key := range map[int]string{
+		ammBaseVaultOffset:  testBaseVault,
+		ammQuoteVaultOffset: testQuoteVault,
+		ammBaseMintOffset:   testTokenMint,
+		ammQuoteMintOffset:  WSOLMint,
+	} {
+		copy(data[off:], mustBase58(t, key))
+	}
+	return data
+}
+
+func TestDecodeRaydiumAMMV4(t *testing.T) {
+	amm, err := DecodeRaydiumAMMV4Base64(base64.StdEncoding.EncodeToString(ammAccount(t)))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if amm.BaseMint != testTokenMint || amm.QuoteMint != WSOLMint {
+		t.Errorf("mints = %s/%s", amm.BaseMint, amm.QuoteMint)
+	}
+	if amm.BaseVault != testBaseVault || amm.QuoteVault != testQuoteVault {
+		t.Errorf("vaults = %s/%s", amm.BaseVault, amm.QuoteVault)
+	}
+
+	// 1,000,001 tokens and 50.01 SOL in the vaults, less the untaken PnL
+	reserves, ok := amm.Reserves(testTokenMint, 1_000_001_000_000, 50_010_000_000)
+	if !ok {
+		t.Fatal("Reserves: not a SOL pair of the token")
+	}
+	want := PoolReserves{BaseReserve: 1_000_000_000_000, QuoteReserve: 50_000_000_000, BaseDecimals: 6, QuoteDecimals: 9}
+	if reserves != want {
+		t.Errorf("reserves = %+v, want %+v", reserves, want)
+	}
+	if price := CalculatePriceFromReserves(reserves); math.Abs(price-0.00005) > 1e-12 {
+		t.Errorf("price = %v SOL per token, want 0.00005", price)
+	}
+
+	if _, ok := amm.Reserves("OtherMint1111111111111111111111111111111111", 1, 1); ok {
+		t.Error("Reserves accepted a mint the pool doesn't trade")
+	}
+	if _, err := DecodeRaydiumAMMV4(make([]byte, 100)); err == nil {
+		t.Error("decoded a short account")
+	}
+}
+
+func TestPriceFeed_VaultUpdatesEmitPoolPrice(t *testing.T) {
+	amm, err := DecodeRaydiumAMMV4(ammAccount(t))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	feed := NewPriceFeed(nil, "")
+	feed.pools[testTokenMint] = &trackedPool{amm: amm}
+
+	updates := make(chan PriceUpdate, 1)
+	feed.OnPriceUpdate(func(u PriceUpdate) { updates <- u })
+
+	vault := func(amount string) json.RawMessage {
+		return json.RawMessage(`{"context":{"slot":7},"value":{"data":{"parsed":{"info":{"tokenAmount":{"amount":"` + amount + `","decimals":6}}}}}}`)
+	}
+	feed.handleVaultUpdate(testTokenMint, true, vault("1000001000000"))
+	feed.handleVaultUpdate(testTokenMint, false, vault("50010000000"))
+
+	select {
+	case u := <-updates:
+		if math.Abs(u.PriceSOL-0.00005) > 1e-12 || u.Decimals != 6 || u.PoolReserves.QuoteReserve != 50_000_000_000 {
+			t.Errorf("update = %+v", u)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no price update once both vaults were known")
+	}
+	if got := feed.GetPrice(testTokenMint); math.Abs(got-0.00005) > 1e-12 {
+		t.Errorf("cached price = %v, want 0.00005", got)
+	}
+}
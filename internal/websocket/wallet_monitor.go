@@ -3,6 +3,7 @@ package websocket
 import (
 	"encoding/json"
 	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog/log"
 )
@@ -29,9 +30,11 @@ type WalletMonitor struct {
 	// Wallet subscription
 	walletAddr string
 	walletSubID uint64
+	walletStarted atomic.Bool // StartWalletSubscription was called; restored on reconnect
 	
 	// TX confirmation callbacks: signature -> callback
 	txCallbacks   map[string]func(TxConfirmation)
+	txCommitments map[string]string // signature -> commitment awaited (pending confirmations)
 	txSubs        map[string]uint64 // signature -> subID
 	txMu          sync.RWMutex
 	
@@ -42,10 +45,11 @@ type WalletMonitor struct {
 // NewWalletMonitor creates a wallet monitor
 func NewWalletMonitor(client *Client, walletAddr string) *WalletMonitor {
 	return &WalletMonitor{
-		client:      client,
-		walletAddr:  walletAddr,
-		txCallbacks: make(map[string]func(TxConfirmation)),
-		txSubs:      make(map[string]uint64),
+		client:        client,
+		walletAddr:    walletAddr,
+		txCallbacks:   make(map[string]func(TxConfirmation)),
+		txCommitments: make(map[string]string),
+		txSubs:        make(map[string]uint64),
 	}
 }
 
@@ -59,6 +63,7 @@ func (w *WalletMonitor) StartWalletSubscription() error {
 	if w.walletAddr == "" {
 		return nil
 	}
+	w.walletStarted.Store(true)
 	
 	subID, err := w.client.AccountSubscribe(w.walletAddr, func(data json.RawMessage) {
 		w.handleBalanceUpdate(data)
@@ -110,18 +115,23 @@ func (w *WalletMonitor) handleBalanceUpdate(data json.RawMessage) {
 
 // WaitForConfirmation subscribes to a TX signature and calls callback on confirmation
 func (w *WalletMonitor) WaitForConfirmation(signature string, callback func(TxConfirmation)) error {
+	return w.WaitForCommitment(signature, "confirmed", callback)
+}
+
+// WaitForCommitment is WaitForConfirmation at an explicit commitment level
+// (processed/confirmed/finalized)
+func (w *WalletMonitor) WaitForCommitment(signature, commitment string, callback func(TxConfirmation)) error {
 	w.txMu.Lock()
 	defer w.txMu.Unlock()
 	
 	// Store callback
 	w.txCallbacks[signature] = callback
+	w.txCommitments[signature] = commitment
 	
-	// Subscribe to signature
-	subID, err := w.client.SignatureSubscribe(signature, func(data json.RawMessage) {
-		w.handleTxConfirmation(signature, data)
-	})
+	subID, err := w.subscribeSignature(signature, commitment)
 	if err != nil {
 		delete(w.txCallbacks, signature)
+		delete(w.txCommitments, signature)
 		return err
 	}
 	
@@ -135,6 +145,68 @@ func (w *WalletMonitor) WaitForConfirmation(signature string, callback func(TxCo
 	return nil
 }
 
+// subscribeSignature subscribes to a TX signature at commitment; caller holds txMu
+func (w *WalletMonitor) subscribeSignature(signature, commitment string) (uint64, error) {
+	return w.client.SignatureSubscribeWithCommitment(signature, commitment, func(data json.RawMessage) {
+		w.handleTxConfirmation(signature, data)
+	})
+}
+
+// Resubscribe re-issues the wallet subscription and the signature
+// subscriptions of every pending confirmation on a new connection (called
+// from the client's onConnect; subscription IDs die with the old one). A
+// signature that can't be re-subscribed stays pending, so its waiter
+// still gets its timeout and polling fallback rather than a lost callback.
+func (w *WalletMonitor) Resubscribe() {
+	if w.walletStarted.Load() {
+		if err := w.StartWalletSubscription(); err != nil {
+			log.Warn().Err(err).Msg("failed to restore wallet subscription")
+		}
+	}
+	
+	w.txMu.Lock()
+	defer w.txMu.Unlock()
+	
+	restored := 0
+	for sig, commitment := range w.txCommitments {
+		delete(w.txSubs, sig)
+		subID, err := w.subscribeSignature(sig, commitment)
+		if err != nil {
+			log.Warn().Err(err).Str("sig", truncateStr(sig, 12)).Msg("failed to restore TX confirmation subscription")
+			continue
+		}
+		w.txSubs[sig] = subID
+		restored++
+	}
+	
+	if len(w.txCommitments) > 0 {
+		log.Info().
+			Int("restored", restored).
+			Int("pending", len(w.txCommitments)).
+			Msg("TX confirmation subscriptions restored")
+	}
+}
+
+// CancelConfirmation stops waiting for a TX signature (the waiter gave up)
+func (w *WalletMonitor) CancelConfirmation(signature string) {
+	w.txMu.Lock()
+	defer w.txMu.Unlock()
+	
+	delete(w.txCallbacks, signature)
+	delete(w.txCommitments, signature)
+	if subID, ok := w.txSubs[signature]; ok {
+		delete(w.txSubs, signature)
+		go w.client.Unsubscribe("signatureUnsubscribe", subID)
+	}
+}
+
+// PendingConfirmations returns how many TX confirmations are still awaited
+func (w *WalletMonitor) PendingConfirmations() int {
+	w.txMu.RLock()
+	defer w.txMu.RUnlock()
+	return len(w.txCommitments)
+}
+
 // handleTxConfirmation processes signature confirmation notifications
 func (w *WalletMonitor) handleTxConfirmation(signature string, data json.RawMessage) {
 	var update struct {
@@ -173,6 +245,7 @@ func (w *WalletMonitor) handleTxConfirmation(signature string, data json.RawMess
 		// Cleanup
 		w.txMu.Lock()
 		delete(w.txCallbacks, signature)
+		delete(w.txCommitments, signature)
 		if subID, ok := w.txSubs[signature]; ok {
 			delete(w.txSubs, signature)
 			go w.client.Unsubscribe("signatureUnsubscribe", subID)
@@ -204,6 +277,7 @@ func (w *WalletMonitor) Stop() {
 		w.client.Unsubscribe("signatureUnsubscribe", subID)
 		delete(w.txSubs, sig)
 		delete(w.txCallbacks, sig)
+		delete(w.txCommitments, sig)
 	}
 	w.txMu.Unlock()
 }
@@ -0,0 +1,150 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeRPCServer is a minimal Solana WebSocket endpoint: it answers every
+// request with a fresh subscription ID and records what was subscribed
+type fakeRPCServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	conn   *websocket.Conn
+	nextID uint64
+	subs   []string          // "method param" per subscribe request, in order
+	subIDs map[string]uint64 // "method param" -> latest subscription ID
+}
+
+func newFakeRPCServer(t *testing.T) *fakeRPCServer {
+	s := &fakeRPCServer{subIDs: make(map[string]uint64)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conn = conn
+		s.mu.Unlock()
+		for {
+			var req struct {
+				ID     uint64            `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.nextID++
+			var result interface{} = true
+			if strings.HasSuffix(req.Method, "Subscribe") {
+				var param string
+				json.Unmarshal(req.Params[0], &param)
+				key := req.Method + " " + param
+				s.subs = append(s.subs, key)
+				s.subIDs[key] = s.nextID
+				result = s.nextID
+			}
+			conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+			s.mu.Unlock()
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeRPCServer) url() string { return "ws" + strings.TrimPrefix(s.URL, "http") }
+
+// drop kills the current connection as a provider outage would
+func (s *fakeRPCServer) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conn.Close()
+}
+
+func (s *fakeRPCServer) count(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, k := range s.subs {
+		if k == key {
+			n++
+		}
+	}
+	return n
+}
+
+// notify sends a notification on the latest subscription for key
+func (s *fakeRPCServer) notify(key string, result interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "signatureNotification",
+		"params":  map[string]interface{}{"subscription": s.subIDs[key], "result": result},
+	})
+}
+
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWalletMonitor_ResubscribesAfterReconnect(t *testing.T) {
+	const wallet = "Wallet1111111111111111111111111111111111111"
+	const sig = "SellSig1111111111111111111111111111111111111111111111111111111111111111111111111111111"
+	srv := newFakeRPCServer(t)
+
+	client := NewClient(srv.url(), 10*time.Millisecond, time.Minute)
+	defer client.Close()
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	// Set up after the first connect, as ExecutorFast.SetupWebSocket does
+	mon := NewWalletMonitor(client, wallet)
+	client.SetCallbacks(mon.Resubscribe, func(error) {})
+	if err := mon.StartWalletSubscription(); err != nil {
+		t.Fatalf("StartWalletSubscription: %v", err)
+	}
+	confirmed := make(chan TxConfirmation, 1)
+	if err := mon.WaitForCommitment(sig, "finalized", func(c TxConfirmation) { confirmed <- c }); err != nil {
+		t.Fatalf("WaitForCommitment: %v", err)
+	}
+
+	srv.drop()
+
+	waitUntil(t, "wallet and signature to be re-subscribed", func() bool {
+		return srv.count("accountSubscribe "+wallet) == 2 && srv.count("signatureSubscribe "+sig) == 2
+	})
+	if got := mon.PendingConfirmations(); got != 1 {
+		t.Errorf("pending confirmations = %d, want 1", got)
+	}
+
+	// The confirmation arrives on the new subscription
+	srv.notify("signatureSubscribe "+sig, map[string]interface{}{"context": map[string]interface{}{"slot": 9}, "value": map[string]interface{}{"err": nil}})
+	select {
+	case c := <-confirmed:
+		if !c.Confirmed || c.Signature != sig {
+			t.Errorf("confirmation = %+v", c)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("sell confirmation lost across the reconnect")
+	}
+	waitUntil(t, "confirmation to leave the pending set", func() bool { return mon.PendingConfirmations() == 0 })
+}
@@ -19,6 +19,7 @@ Environment Variables:
     TG_PHONE        - Your phone number (+1234567890)
     TG_CHANNEL_ID   - Target channel ID (e.g., -1001234567890)
     GO_BOT_ENDPOINT - Go bot URL (default: http://localhost:8080/signal)
+    WEBHOOK_SECRET  - Signs each signal (must match telegram.webhook_secret; optional)
 """
 
 import os
@@ -26,6 +27,9 @@ import sys
 import asyncio
 import time
 import re
+import hmac
+import hashlib
+import json
 
 # Handle missing aiohttp gracefully
 try:
@@ -48,6 +52,7 @@ API_HASH = os.getenv("TG_API_HASH")
 PHONE = os.getenv("TG_PHONE")
 CHANNEL_ID = os.getenv("TG_CHANNEL_ID")
 GO_BOT_ENDPOINT = os.getenv("GO_BOT_ENDPOINT", "http://localhost:8080/signal")
+WEBHOOK_SECRET = os.getenv("WEBHOOK_SECRET", "")
 
 # Session file location
 SESSION_FILE = "telegram_session"
@@ -120,7 +125,11 @@ async def send_to_bot(session: aiohttp.ClientSession, text: str, msg_id: int) ->
             "msg_id": msg_id,
             "timestamp": int(time.time())
         }
-        async with session.post(GO_BOT_ENDPOINT, json=payload, timeout=aiohttp.ClientTimeout(total=5)) as resp:
+        body = json.dumps(payload).encode()
+        headers = {"Content-Type": "application/json"}
+        if WEBHOOK_SECRET:
+            headers["X-Signature"] = hmac.new(WEBHOOK_SECRET.encode(), body, hashlib.sha256).hexdigest()
+        async with session.post(GO_BOT_ENDPOINT, data=body, headers=headers, timeout=aiohttp.ClientTimeout(total=5)) as resp:
             if resp.status == 200:
                 # Only show first 80 chars
                 preview = text.replace('\n', ' ')[:80]